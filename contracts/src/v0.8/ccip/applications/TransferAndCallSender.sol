@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.24;
+
+import {IERC677Receiver} from "../../shared/interfaces/IERC677Receiver.sol";
+import {ITypeAndVersion} from "../../shared/interfaces/ITypeAndVersion.sol";
+import {IRouterClient} from "../interfaces/IRouterClient.sol";
+
+import {Client} from "../libraries/Client.sol";
+
+import {IERC20} from "../../vendor/openzeppelin-solidity/v4.8.3/contracts/token/ERC20/IERC20.sol";
+import {SafeERC20} from "../../vendor/openzeppelin-solidity/v4.8.3/contracts/token/ERC20/utils/SafeERC20.sol";
+
+/// @notice A contract that sends a CCIP message paid for with an ERC677 fee token (i.e LINK) in a single
+/// transferAndCall of the fee token, without the sender approving the router.
+/// The data of the transferAndCall is the abi encoded (uint64 destinationChainSelector, Client.EVM2AnyMessage message).
+/// The fee is paid from the transferred amount and anything left over is returned to the sender.
+/// @dev Only data messages are supported, token transfers would require approvals which this contract is meant to avoid.
+/// @dev This contract is intentionally ownerless and permissionless. It never holds any funds when used correctly.
+contract TransferAndCallSender is IERC677Receiver, ITypeAndVersion {
+  using SafeERC20 for IERC20;
+
+  error OnlyFeeToken(address gotToken);
+  error InvalidFeeToken(address gotToken, address expectedToken);
+  error TokenTransfersNotSupported();
+  error InsufficientFee(uint256 gotFee, uint256 fee);
+
+  event MessageSent(bytes32 indexed messageId, address indexed sender, uint64 destinationChainSelector, uint256 fee);
+
+  string public constant override typeAndVersion = "TransferAndCallSender 1.5.0";
+
+  /// @notice The CCIP router contract
+  IRouterClient internal immutable i_ccipRouter;
+  /// @notice The ERC677 fee token the messages are paid with
+  address internal immutable i_feeToken;
+
+  constructor(address router, address feeToken) {
+    i_ccipRouter = IRouterClient(router);
+    i_feeToken = feeToken;
+    // Approve the router to spend an unlimited amount of fee tokens to reduce gas cost per tx.
+    IERC20(feeToken).safeIncreaseAllowance(router, type(uint256).max);
+  }
+
+  /// @notice Get the fee for sending a message to a destination chain through this contract.
+  /// @param destinationChainSelector The destination chainSelector
+  /// @param message The cross-chain CCIP message with data only
+  /// @return fee returns execution fee for the message delivery to destination chain, denominated in the fee token.
+  /// @dev Reverts with appropriate reason upon invalid message.
+  function getFee(
+    uint64 destinationChainSelector,
+    Client.EVM2AnyMessage calldata message
+  ) external view returns (uint256 fee) {
+    _validateMessage(message);
+    return i_ccipRouter.getFee(destinationChainSelector, message);
+  }
+
+  /// @notice Sends the message encoded in data, paying the fee from the fee tokens transferred to this contract.
+  /// @param sender The sender of the fee tokens, any fee tokens left after paying the fee are returned to it.
+  /// @param amount The amount of fee tokens transferred, must be at least the fee of the message.
+  /// @param data The abi encoded (uint64 destinationChainSelector, Client.EVM2AnyMessage message).
+  function onTokenTransfer(address sender, uint256 amount, bytes calldata data) external {
+    if (msg.sender != i_feeToken) revert OnlyFeeToken(msg.sender);
+
+    (uint64 destinationChainSelector, Client.EVM2AnyMessage memory message) =
+      abi.decode(data, (uint64, Client.EVM2AnyMessage));
+    _validateMessage(message);
+
+    uint256 fee = i_ccipRouter.getFee(destinationChainSelector, message);
+    if (amount < fee) revert InsufficientFee(amount, fee);
+
+    bytes32 messageId = i_ccipRouter.ccipSend(destinationChainSelector, message);
+    if (amount > fee) {
+      IERC20(i_feeToken).safeTransfer(sender, amount - fee);
+    }
+
+    emit MessageSent(messageId, sender, destinationChainSelector, fee);
+  }
+
+  /// @notice Validates that the message is a data message paid with the fee token.
+  function _validateMessage(Client.EVM2AnyMessage memory message) internal view {
+    if (message.feeToken != i_feeToken) revert InvalidFeeToken(message.feeToken, i_feeToken);
+    if (message.tokenAmounts.length > 0) revert TokenTransfersNotSupported();
+  }
+
+  /// @notice Returns the CCIP router contract.
+  function getRouter() external view returns (IRouterClient) {
+    return i_ccipRouter;
+  }
+
+  /// @notice Returns the fee token the messages are paid with.
+  function getFeeToken() external view returns (address) {
+    return i_feeToken;
+  }
+}
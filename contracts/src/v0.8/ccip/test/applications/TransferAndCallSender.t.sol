@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.24;
+
+import {BurnMintERC677} from "../../../shared/token/ERC677/BurnMintERC677.sol";
+import {TransferAndCallSender} from "../../applications/TransferAndCallSender.sol";
+import {IRouterClient} from "../../interfaces/IRouterClient.sol";
+import {Client} from "../../libraries/Client.sol";
+import {EVM2EVMOnRampSetup} from "../onRamp/EVM2EVMOnRampSetup.t.sol";
+
+contract TransferAndCallSenderSetup is EVM2EVMOnRampSetup {
+  TransferAndCallSender internal s_sender;
+  BurnMintERC677 internal s_feeToken;
+
+  function setUp() public virtual override {
+    EVM2EVMOnRampSetup.setUp();
+
+    s_feeToken = BurnMintERC677(s_sourceFeeToken);
+    s_sender = new TransferAndCallSender(address(s_sourceRouter), s_sourceFeeToken);
+  }
+}
+
+contract TransferAndCallSender_constructor is TransferAndCallSenderSetup {
+  function test_Constructor() public view {
+    assertEq(address(s_sender.getRouter()), address(s_sourceRouter));
+    assertEq(s_sender.getFeeToken(), s_sourceFeeToken);
+    assertEq(s_feeToken.allowance(address(s_sender), address(s_sourceRouter)), type(uint256).max);
+  }
+}
+
+contract TransferAndCallSender_getFee is TransferAndCallSenderSetup {
+  function test_GetFee_Success() public view {
+    Client.EVM2AnyMessage memory message = _generateEmptyMessage();
+
+    assertEq(s_sender.getFee(DEST_CHAIN_SELECTOR, message), s_sourceRouter.getFee(DEST_CHAIN_SELECTOR, message));
+  }
+
+  // Reverts
+
+  function test_GetFeeTokenTransfers_Revert() public {
+    Client.EVM2AnyMessage memory message = _generateTokenMessage();
+
+    vm.expectRevert(TransferAndCallSender.TokenTransfersNotSupported.selector);
+
+    s_sender.getFee(DEST_CHAIN_SELECTOR, message);
+  }
+}
+
+contract TransferAndCallSender_onTokenTransfer is TransferAndCallSenderSetup {
+  function test_TransferAndCall_Success() public {
+    Client.EVM2AnyMessage memory message = _generateEmptyMessage();
+    uint256 fee = s_sender.getFee(DEST_CHAIN_SELECTOR, message);
+    uint256 overpaid = 1e17;
+    uint256 senderBalanceBefore = s_feeToken.balanceOf(OWNER);
+    uint256 onRampBalanceBefore = s_feeToken.balanceOf(address(s_onRamp));
+
+    vm.expectEmit(false, true, false, true);
+    emit TransferAndCallSender.MessageSent(bytes32(0), OWNER, DEST_CHAIN_SELECTOR, fee);
+
+    s_feeToken.transferAndCall(address(s_sender), fee + overpaid, abi.encode(DEST_CHAIN_SELECTOR, message));
+
+    // only the fee is spent, the rest is returned to the sender
+    assertEq(s_feeToken.balanceOf(OWNER), senderBalanceBefore - fee);
+    assertEq(s_feeToken.balanceOf(address(s_onRamp)), onRampBalanceBefore + fee);
+    assertEq(s_feeToken.balanceOf(address(s_sender)), 0);
+  }
+
+  // Reverts
+
+  function test_OnlyFeeToken_Revert() public {
+    vm.expectRevert(abi.encodeWithSelector(TransferAndCallSender.OnlyFeeToken.selector, OWNER));
+
+    s_sender.onTokenTransfer(OWNER, 1e18, abi.encode(DEST_CHAIN_SELECTOR, _generateEmptyMessage()));
+  }
+
+  function test_InvalidFeeToken_Revert() public {
+    Client.EVM2AnyMessage memory message = _generateEmptyMessage();
+    message.feeToken = s_sourceRouter.getWrappedNative();
+
+    vm.expectRevert(
+      abi.encodeWithSelector(TransferAndCallSender.InvalidFeeToken.selector, message.feeToken, s_sourceFeeToken)
+    );
+
+    s_feeToken.transferAndCall(address(s_sender), 1e18, abi.encode(DEST_CHAIN_SELECTOR, message));
+  }
+
+  function test_InsufficientFee_Revert() public {
+    Client.EVM2AnyMessage memory message = _generateEmptyMessage();
+    uint256 fee = s_sender.getFee(DEST_CHAIN_SELECTOR, message);
+
+    vm.expectRevert(abi.encodeWithSelector(TransferAndCallSender.InsufficientFee.selector, fee - 1, fee));
+
+    s_feeToken.transferAndCall(address(s_sender), fee - 1, abi.encode(DEST_CHAIN_SELECTOR, message));
+  }
+
+  function test_UnsupportedDestination_Revert() public {
+    uint64 unsupportedDestination = DEST_CHAIN_SELECTOR + 1;
+
+    vm.expectRevert(abi.encodeWithSelector(IRouterClient.UnsupportedDestinationChain.selector, unsupportedDestination));
+
+    s_feeToken.transferAndCall(address(s_sender), 1e18, abi.encode(unsupportedDestination, _generateEmptyMessage()));
+  }
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity 0.8.24;
+
+import {Pool} from "../../libraries/Pool.sol";
+import {LockReleaseTokenPool} from "../../pools/LockReleaseTokenPool.sol";
+
+import {IERC20} from "../../../vendor/openzeppelin-solidity/v4.8.3/contracts/token/ERC20/IERC20.sol";
+import {SafeERC20} from "../../../vendor/openzeppelin-solidity/v4.8.3/contracts/token/ERC20/utils/SafeERC20.sol";
+
+/// @notice A lock release pool which reports the amount the receiver actually got instead of the amount released,
+/// so that the receiver is passed the right amount for tokens which charge a fee on transfer.
+contract BalanceDeltaTokenPoolHelper is LockReleaseTokenPool {
+  using SafeERC20 for IERC20;
+
+  constructor(
+    IERC20 token,
+    address rmnProxy,
+    address router
+  ) LockReleaseTokenPool(token, new address[](0), rmnProxy, true, router) {}
+
+  /// @notice Release tokens from the pool to the recipient, reporting the increase of the balance of the recipient.
+  function releaseOrMint(Pool.ReleaseOrMintInV1 calldata releaseOrMintIn)
+    external
+    virtual
+    override
+    whenNotCursed(releaseOrMintIn.remoteChainSelector)
+    returns (Pool.ReleaseOrMintOutV1 memory)
+  {
+    _onlyOffRamp(releaseOrMintIn.remoteChainSelector);
+    _validateSourceCaller(releaseOrMintIn.remoteChainSelector, releaseOrMintIn.sourcePoolAddress);
+    _consumeInboundRateLimit(releaseOrMintIn.remoteChainSelector, releaseOrMintIn.amount);
+
+    uint256 balanceBefore = getToken().balanceOf(releaseOrMintIn.receiver);
+    getToken().safeTransfer(releaseOrMintIn.receiver, releaseOrMintIn.amount);
+
+    emit Released(msg.sender, releaseOrMintIn.receiver, releaseOrMintIn.amount);
+
+    return Pool.ReleaseOrMintOutV1({
+      localToken: address(i_token),
+      destinationAmount: getToken().balanceOf(releaseOrMintIn.receiver) - balanceBefore
+    });
+  }
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity 0.8.24;
+
+import {BurnMintERC677} from "../../../shared/token/ERC677/BurnMintERC677.sol";
+
+/// @notice A burn/mint token which burns a fee from every transfer, so the recipient gets less than the
+/// transferred amount. Used to document the behavior of CCIP with fee-on-transfer tokens.
+contract FeeOnTransferTokenHelper is BurnMintERC677 {
+  /// @dev The fee taken from every transfer, in basis points of the transferred amount.
+  uint256 internal s_feeBps;
+
+  constructor(string memory name, string memory symbol, uint256 feeBps) BurnMintERC677(name, symbol, 18, 0) {
+    s_feeBps = feeBps;
+  }
+
+  /// @notice Returns the fee burnt from a transfer of amount.
+  function getTransferFee(uint256 amount) public view returns (uint256) {
+    return amount * s_feeBps / 10_000;
+  }
+
+  /// @dev Mints and burns are not charged, only the transfers between accounts are.
+  function _transfer(address from, address to, uint256 amount) internal virtual override {
+    uint256 fee = getTransferFee(amount);
+    super._transfer(from, to, amount - fee);
+    if (fee > 0) {
+      _burn(from, fee);
+    }
+  }
+}
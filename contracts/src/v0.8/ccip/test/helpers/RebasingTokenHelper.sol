@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity 0.8.24;
+
+import {BurnMintERC677} from "../../../shared/token/ERC677/BurnMintERC677.sol";
+
+/// @notice A burn/mint token whose balances are rebased by a multiplier, e.g. to pay out or slash yield. The balances
+/// are stored as shares and every amount is converted to shares at the current multiplier. Used to document the
+/// behavior of CCIP with rebasing tokens.
+/// @dev The Transfer events are emitted with the amounts in shares.
+contract RebasingTokenHelper is BurnMintERC677 {
+  uint256 internal constant MULTIPLIER_BASE = 1e18;
+
+  /// @dev The value of a share, scaled by MULTIPLIER_BASE.
+  uint256 internal s_multiplier = MULTIPLIER_BASE;
+
+  constructor(string memory name, string memory symbol) BurnMintERC677(name, symbol, 18, 0) {}
+
+  /// @notice Rebases all the balances, a multiplier of 1.1e18 increases every balance by 10%.
+  function rebase(uint256 multiplier) external onlyOwner {
+    s_multiplier = multiplier;
+  }
+
+  function balanceOf(address account) public view virtual override returns (uint256) {
+    return super.balanceOf(account) * s_multiplier / MULTIPLIER_BASE;
+  }
+
+  function totalSupply() public view virtual override returns (uint256) {
+    return super.totalSupply() * s_multiplier / MULTIPLIER_BASE;
+  }
+
+  function _toShares(uint256 amount) internal view returns (uint256) {
+    return amount * MULTIPLIER_BASE / s_multiplier;
+  }
+
+  function _transfer(address from, address to, uint256 amount) internal virtual override {
+    super._transfer(from, to, _toShares(amount));
+  }
+
+  function _mint(address account, uint256 amount) internal virtual override {
+    super._mint(account, _toShares(amount));
+  }
+
+  function _burn(address account, uint256 amount) internal virtual override {
+    super._burn(account, _toShares(amount));
+  }
+}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity 0.8.24;
+
+import {Router} from "../../Router.sol";
+import {Pool} from "../../libraries/Pool.sol";
+import {BurnMintTokenPool} from "../../pools/BurnMintTokenPool.sol";
+import {LockReleaseTokenPool} from "../../pools/LockReleaseTokenPool.sol";
+import {TokenPool} from "../../pools/TokenPool.sol";
+import {BalanceDeltaTokenPoolHelper} from "../helpers/BalanceDeltaTokenPoolHelper.sol";
+import {FeeOnTransferTokenHelper} from "../helpers/FeeOnTransferTokenHelper.sol";
+import {RebasingTokenHelper} from "../helpers/RebasingTokenHelper.sol";
+import {RouterSetup} from "../router/RouterSetup.t.sol";
+
+/// @notice Documents how the standard pools behave with tokens which don't move the exact amount transferred.
+/// The pools account in the amounts of the message, not in the balances, so such tokens either fail or
+/// leave the pools with a surplus or a shortfall.
+contract NonStandardTokensSetup is RouterSetup {
+  event Locked(address indexed sender, uint256 amount);
+
+  uint256 internal constant FEE_BPS = 100; // 1%
+  uint256 internal constant AMOUNT = 1e18;
+
+  FeeOnTransferTokenHelper internal s_feeOnTransferToken;
+  RebasingTokenHelper internal s_rebasingToken;
+
+  address internal s_onRamp = makeAddr("onRamp");
+  address internal s_offRamp = makeAddr("offRamp");
+  address internal s_remotePool = makeAddr("remote_pool");
+
+  function setUp() public virtual override {
+    RouterSetup.setUp();
+
+    s_feeOnTransferToken = new FeeOnTransferTokenHelper("Fee on transfer token", "FOT", FEE_BPS);
+    s_feeOnTransferToken.grantMintRole(OWNER);
+    s_feeOnTransferToken.mint(OWNER, 100 * AMOUNT);
+
+    s_rebasingToken = new RebasingTokenHelper("Rebasing token", "REB");
+    s_rebasingToken.grantMintRole(OWNER);
+    s_rebasingToken.mint(OWNER, 100 * AMOUNT);
+
+    Router.OnRamp[] memory onRampUpdates = new Router.OnRamp[](1);
+    onRampUpdates[0] = Router.OnRamp({destChainSelector: DEST_CHAIN_SELECTOR, onRamp: s_onRamp});
+    Router.OffRamp[] memory offRampUpdates = new Router.OffRamp[](1);
+    offRampUpdates[0] = Router.OffRamp({sourceChainSelector: DEST_CHAIN_SELECTOR, offRamp: s_offRamp});
+    s_sourceRouter.applyRampUpdates(onRampUpdates, new Router.OffRamp[](0), offRampUpdates);
+  }
+
+  function _applyChainUpdates(TokenPool pool) internal {
+    TokenPool.ChainUpdate[] memory chains = new TokenPool.ChainUpdate[](1);
+    chains[0] = TokenPool.ChainUpdate({
+      remoteChainSelector: DEST_CHAIN_SELECTOR,
+      remotePoolAddress: abi.encode(s_remotePool),
+      allowed: true,
+      outboundRateLimiterConfig: getOutboundRateLimiterConfig(),
+      inboundRateLimiterConfig: getInboundRateLimiterConfig()
+    });
+    pool.applyChainUpdates(chains);
+  }
+
+  /// @dev Transfers the tokens to the pool and locks or burns them the way the router and the onRamp do.
+  function _lockOrBurn(TokenPool pool, uint256 amount) internal {
+    pool.getToken().transfer(address(pool), amount);
+
+    vm.startPrank(s_onRamp);
+    pool.lockOrBurn(
+      Pool.LockOrBurnInV1({
+        originalSender: OWNER,
+        receiver: abi.encode(OWNER),
+        amount: amount,
+        remoteChainSelector: DEST_CHAIN_SELECTOR
+      })
+    );
+    vm.startPrank(OWNER);
+  }
+
+  function _releaseOrMint(
+    TokenPool pool,
+    address receiver,
+    uint256 amount
+  ) internal returns (Pool.ReleaseOrMintOutV1 memory releaseOrMintOut) {
+    vm.startPrank(s_offRamp);
+    releaseOrMintOut = pool.releaseOrMint(
+      Pool.ReleaseOrMintInV1({
+        originalSender: abi.encode(OWNER),
+        receiver: receiver,
+        amount: amount,
+        remoteChainSelector: DEST_CHAIN_SELECTOR,
+        sourcePoolAddress: abi.encode(s_remotePool),
+        sourcePoolData: "",
+        offchainTokenData: ""
+      })
+    );
+    vm.startPrank(OWNER);
+  }
+}
+
+contract NonStandardTokens_feeOnTransfer is NonStandardTokensSetup {
+  LockReleaseTokenPool internal s_lockReleasePool;
+  BurnMintTokenPool internal s_burnMintPool;
+  BalanceDeltaTokenPoolHelper internal s_balanceDeltaPool;
+
+  function setUp() public virtual override {
+    NonStandardTokensSetup.setUp();
+
+    s_lockReleasePool = new LockReleaseTokenPool(
+      s_feeOnTransferToken, new address[](0), address(s_mockRMN), true, address(s_sourceRouter)
+    );
+    s_burnMintPool =
+      new BurnMintTokenPool(s_feeOnTransferToken, new address[](0), address(s_mockRMN), address(s_sourceRouter));
+    s_balanceDeltaPool =
+      new BalanceDeltaTokenPoolHelper(s_feeOnTransferToken, address(s_mockRMN), address(s_sourceRouter));
+    s_feeOnTransferToken.grantMintAndBurnRoles(address(s_burnMintPool));
+
+    _applyChainUpdates(s_lockReleasePool);
+    _applyChainUpdates(s_burnMintPool);
+    _applyChainUpdates(s_balanceDeltaPool);
+  }
+
+  function test_LockReleaseLocksMoreThanReceived_Success() public {
+    uint256 fee = s_feeOnTransferToken.getTransferFee(AMOUNT);
+
+    s_feeOnTransferToken.transfer(address(s_lockReleasePool), AMOUNT);
+    vm.startPrank(s_onRamp);
+
+    // The full amount is locked and sent in the message, although the pool only got the amount minus the fee.
+    vm.expectEmit();
+    emit Locked(s_onRamp, AMOUNT);
+
+    s_lockReleasePool.lockOrBurn(
+      Pool.LockOrBurnInV1({
+        originalSender: OWNER,
+        receiver: abi.encode(OWNER),
+        amount: AMOUNT,
+        remoteChainSelector: DEST_CHAIN_SELECTOR
+      })
+    );
+
+    assertEq(s_feeOnTransferToken.balanceOf(address(s_lockReleasePool)), AMOUNT - fee);
+  }
+
+  function test_LockReleaseReportsReleasedAmount_Success() public {
+    s_feeOnTransferToken.mint(address(s_lockReleasePool), AMOUNT);
+    uint256 fee = s_feeOnTransferToken.getTransferFee(AMOUNT);
+
+    Pool.ReleaseOrMintOutV1 memory releaseOrMintOut = _releaseOrMint(s_lockReleasePool, STRANGER, AMOUNT);
+
+    // The receiver is told it got the full amount, while the fee is taken from the release.
+    assertEq(releaseOrMintOut.destinationAmount, AMOUNT);
+    assertEq(s_feeOnTransferToken.balanceOf(STRANGER), AMOUNT - fee);
+  }
+
+  function test_BalanceDeltaPoolReportsReceivedAmount_Success() public {
+    s_feeOnTransferToken.mint(address(s_balanceDeltaPool), AMOUNT);
+    uint256 fee = s_feeOnTransferToken.getTransferFee(AMOUNT);
+
+    Pool.ReleaseOrMintOutV1 memory releaseOrMintOut = _releaseOrMint(s_balanceDeltaPool, STRANGER, AMOUNT);
+
+    assertEq(releaseOrMintOut.destinationAmount, AMOUNT - fee);
+    assertEq(s_feeOnTransferToken.balanceOf(STRANGER), releaseOrMintOut.destinationAmount);
+  }
+
+  function test_BurnMintMintsFullAmount_Success() public {
+    Pool.ReleaseOrMintOutV1 memory releaseOrMintOut = _releaseOrMint(s_burnMintPool, STRANGER, AMOUNT);
+
+    // Minting isn't charged, so the receiver gets the full amount.
+    assertEq(releaseOrMintOut.destinationAmount, AMOUNT);
+    assertEq(s_feeOnTransferToken.balanceOf(STRANGER), AMOUNT);
+  }
+
+  // Reverts
+
+  function test_BurnMintBurnsMoreThanReceived_Revert() public {
+    // The pool only got the amount minus the fee, so burning the amount of the message fails.
+    s_feeOnTransferToken.transfer(address(s_burnMintPool), AMOUNT);
+
+    vm.startPrank(s_onRamp);
+    vm.expectRevert("ERC20: burn amount exceeds balance");
+
+    s_burnMintPool.lockOrBurn(
+      Pool.LockOrBurnInV1({
+        originalSender: OWNER,
+        receiver: abi.encode(OWNER),
+        amount: AMOUNT,
+        remoteChainSelector: DEST_CHAIN_SELECTOR
+      })
+    );
+  }
+
+  function test_LockReleaseDrainsPool_Revert() public {
+    // Every round trip through the pool leaves it short of the fee, until the last release fails.
+    _lockOrBurn(s_lockReleasePool, AMOUNT);
+    _releaseOrMint(s_lockReleasePool, STRANGER, AMOUNT / 2);
+
+    vm.startPrank(s_offRamp);
+    vm.expectRevert("ERC20: transfer amount exceeds balance");
+
+    s_lockReleasePool.releaseOrMint(
+      Pool.ReleaseOrMintInV1({
+        originalSender: abi.encode(OWNER),
+        receiver: STRANGER,
+        amount: AMOUNT / 2,
+        remoteChainSelector: DEST_CHAIN_SELECTOR,
+        sourcePoolAddress: abi.encode(s_remotePool),
+        sourcePoolData: "",
+        offchainTokenData: ""
+      })
+    );
+  }
+}
+
+contract NonStandardTokens_rebasing is NonStandardTokensSetup {
+  LockReleaseTokenPool internal s_lockReleasePool;
+
+  function setUp() public virtual override {
+    NonStandardTokensSetup.setUp();
+
+    s_lockReleasePool = new LockReleaseTokenPool(
+      s_rebasingToken, new address[](0), address(s_mockRMN), true, address(s_sourceRouter)
+    );
+    _applyChainUpdates(s_lockReleasePool);
+  }
+
+  function test_PositiveRebaseStaysInPool_Success() public {
+    _lockOrBurn(s_lockReleasePool, AMOUNT);
+    s_rebasingToken.rebase(2e18);
+
+    _releaseOrMint(s_lockReleasePool, STRANGER, AMOUNT);
+
+    // The yield accrued while locked is not bridged, it stays in the pool.
+    assertEq(s_rebasingToken.balanceOf(STRANGER), AMOUNT);
+    assertEq(s_rebasingToken.balanceOf(address(s_lockReleasePool)), AMOUNT);
+  }
+
+  // Reverts
+
+  function test_NegativeRebaseLeavesPoolShort_Revert() public {
+    _lockOrBurn(s_lockReleasePool, AMOUNT);
+    s_rebasingToken.rebase(0.5e18);
+
+    vm.startPrank(s_offRamp);
+    vm.expectRevert("ERC20: transfer amount exceeds balance");
+
+    s_lockReleasePool.releaseOrMint(
+      Pool.ReleaseOrMintInV1({
+        originalSender: abi.encode(OWNER),
+        receiver: STRANGER,
+        amount: AMOUNT,
+        remoteChainSelector: DEST_CHAIN_SELECTOR,
+        sourcePoolAddress: abi.encode(s_remotePool),
+        sourcePoolData: "",
+        offchainTokenData: ""
+      })
+    );
+  }
+}
@@ -0,0 +1,21 @@
+package testhelpers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/internal/ccipdata/v1_5_0"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/hashlib"
+)
+
+// LeafHasher hashes the CCIPSendRequested logs of an onRamp into the leaves of the merkle roots committed for them
+type LeafHasher interface {
+	HashLeaf(log types.Log) ([32]byte, error)
+}
+
+// NewLeafHasher returns the LeafHasher of the messages sent through the latest onRamp, to build the commit and
+// execution reports of the messages outside the plugins
+func NewLeafHasher(sourceChainSelector, destChainSelector uint64, onRampAddress common.Address, onRamp *evm_2_evm_onramp.EVM2EVMOnRamp) LeafHasher {
+	return v1_5_0.NewLeafHasher(sourceChainSelector, destChainSelector, onRampAddress, hashlib.NewKeccakCtx(), onRamp)
+}
@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+const (
+	DefaultAdaptiveTimeoutAlpha           = 0.2
+	DefaultAdaptiveTimeoutLatencyFactor   = 3.0
+	DefaultAdaptiveTimeoutMinObservations = 3
+)
+
+// AdaptiveTimeout adapts the phase timeouts of a lane to the phase latencies observed on it. It keeps an exponentially
+// weighted moving average (EWMA) of the latency of every phase and stretches the timeout of the phase to LatencyFactor
+// times the average, so that a congested chain slowing down all the requests doesn't fail them on a fixed timeout.
+// The timeouts are never shortened below the configured ones and never stretched beyond MaxTimeout.
+type AdaptiveTimeout struct {
+	Alpha           float64       // smoothing factor of the EWMA, the weight of the latest latency
+	LatencyFactor   float64       // the adapted timeout is LatencyFactor times the EWMA of the phase latency
+	MinObservations int           // number of latencies observed for a phase before its timeout is adapted
+	MaxTimeout      time.Duration // upper bound of the adapted timeouts, unbounded if zero
+	mu              sync.Mutex
+	latencies       map[testreporters.Phase]*phaseLatency
+}
+
+type phaseLatency struct {
+	ewma         float64 // in seconds
+	observations int
+}
+
+// NewAdaptiveTimeout returns an AdaptiveTimeout with the default params, bounded by maxTimeout
+func NewAdaptiveTimeout(maxTimeout time.Duration) *AdaptiveTimeout {
+	return &AdaptiveTimeout{
+		Alpha:           DefaultAdaptiveTimeoutAlpha,
+		LatencyFactor:   DefaultAdaptiveTimeoutLatencyFactor,
+		MinObservations: DefaultAdaptiveTimeoutMinObservations,
+		MaxTimeout:      maxTimeout,
+	}
+}
+
+// Observe records the latency of a request completing phase
+func (a *AdaptiveTimeout) Observe(phase testreporters.Phase, latency time.Duration) {
+	if latency <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.latencies == nil {
+		a.latencies = make(map[testreporters.Phase]*phaseLatency)
+	}
+	l, ok := a.latencies[phase]
+	if !ok {
+		a.latencies[phase] = &phaseLatency{ewma: latency.Seconds(), observations: 1}
+		return
+	}
+	l.ewma = a.Alpha*latency.Seconds() + (1-a.Alpha)*l.ewma
+	l.observations++
+}
+
+// Latency returns the EWMA of the observed latencies of phase, false if none is observed yet
+func (a *AdaptiveTimeout) Latency(phase testreporters.Phase) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	l, ok := a.latencies[phase]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(l.ewma * float64(time.Second)), true
+}
+
+// Timeout returns the timeout of phase adapted from timeout, the configured one
+func (a *AdaptiveTimeout) Timeout(phase testreporters.Phase, timeout time.Duration) time.Duration {
+	a.mu.Lock()
+	l, ok := a.latencies[phase]
+	if !ok || l.observations < a.MinObservations {
+		a.mu.Unlock()
+		return timeout
+	}
+	adapted := time.Duration(a.LatencyFactor * l.ewma * float64(time.Second))
+	a.mu.Unlock()
+	if a.MaxTimeout > 0 && adapted > a.MaxTimeout {
+		adapted = a.MaxTimeout
+	}
+	if adapted < timeout {
+		return timeout
+	}
+	return adapted
+}
+
+// phaseTimeout returns the timeout for validating phase of the requests of the lane. With adaptive timeouts set, the
+// timeout is adapted to the observed latencies of the phase and both the raw and the adapted timeout are recorded in
+// the lane report.
+func (lane *CCIPLane) phaseTimeout(phase testreporters.Phase) time.Duration {
+	if lane.AdaptiveTimeout == nil {
+		return lane.ValidationTimeout
+	}
+	timeout := lane.AdaptiveTimeout.Timeout(phase, lane.ValidationTimeout)
+	if timeout != lane.ValidationTimeout {
+		lane.Logger.Debug().
+			Str("Phase", string(phase)).
+			Str("Timeout", lane.ValidationTimeout.String()).
+			Str("Adapted Timeout", timeout.String()).
+			Msg("Phase timeout adapted to the observed latencies")
+	}
+	if lane.Reports != nil {
+		lane.Reports.RecordPhaseTimeout(phase, lane.ValidationTimeout, timeout)
+	}
+	return timeout
+}
+
+// observePhase records the latency of stat completing phase successfully, if adaptive timeouts are set
+func (lane *CCIPLane) observePhase(stat *testreporters.RequestStat, phase testreporters.Phase) {
+	if lane.AdaptiveTimeout == nil || stat == nil {
+		return
+	}
+	phaseStat, ok := stat.StatusByPhase[phase]
+	if !ok || phaseStat.Status != testreporters.Success {
+		return
+	}
+	lane.AdaptiveTimeout.Observe(phase, time.Duration(phaseStat.Duration*float64(time.Second)))
+}
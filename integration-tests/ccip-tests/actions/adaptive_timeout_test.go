@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+func TestAdaptiveTimeout(t *testing.T) {
+	t.Parallel()
+	adaptive := NewAdaptiveTimeout(30 * time.Minute)
+	adaptive.Alpha = 0.5
+	base := 10 * time.Minute
+
+	// not adapted until MinObservations latencies are observed
+	adaptive.Observe(testreporters.Commit, 4*time.Minute)
+	adaptive.Observe(testreporters.Commit, 6*time.Minute)
+	require.Equal(t, base, adaptive.Timeout(testreporters.Commit, base))
+	adaptive.Observe(testreporters.Commit, 6*time.Minute)
+	latency, ok := adaptive.Latency(testreporters.Commit)
+	require.True(t, ok)
+	// 4m, then 0.5*6m + 0.5*4m = 5m, then 0.5*6m + 0.5*5m = 5.5m
+	require.Equal(t, 330*time.Second, latency)
+	require.Equal(t, 990*time.Second, adaptive.Timeout(testreporters.Commit, base))
+
+	// never below the configured timeout
+	require.Equal(t, 20*time.Minute, adaptive.Timeout(testreporters.Commit, 20*time.Minute))
+	// other phases are not affected
+	require.Equal(t, base, adaptive.Timeout(testreporters.ExecStateChanged, base))
+	_, ok = adaptive.Latency(testreporters.ExecStateChanged)
+	require.False(t, ok)
+
+	// never beyond MaxTimeout
+	for i := 0; i < 10; i++ {
+		adaptive.Observe(testreporters.Commit, time.Hour)
+	}
+	require.Equal(t, 30*time.Minute, adaptive.Timeout(testreporters.Commit, base))
+}
+
+func TestLanePhaseTimeout(t *testing.T) {
+	t.Parallel()
+	reporter := testreporters.NewCCIPTestReporter(t, zerolog.Nop())
+	lane := &CCIPLane{
+		Logger:            zerolog.Nop(),
+		ValidationTimeout: time.Minute,
+		Reports:           reporter.AddNewLane("A To B", zerolog.Nop()),
+	}
+	require.Equal(t, time.Minute, lane.phaseTimeout(testreporters.Commit))
+
+	lane.AdaptiveTimeout = NewAdaptiveTimeout(0)
+	lane.AdaptiveTimeout.MinObservations = 1
+	stat := testreporters.NewCCIPRequestStats(1, "A", "B")
+	stat.UpdateState(zerolog.Nop(), 1, testreporters.Commit, 2*time.Minute, testreporters.Success)
+	lane.observePhase(stat, testreporters.Commit)
+	require.Equal(t, 6*time.Minute, lane.phaseTimeout(testreporters.Commit))
+
+	// failed phases are not observed
+	stat.UpdateState(zerolog.Nop(), 1, testreporters.ExecStateChanged, 10*time.Minute, testreporters.Failure)
+	lane.observePhase(stat, testreporters.ExecStateChanged)
+	require.Equal(t, time.Minute, lane.phaseTimeout(testreporters.ExecStateChanged))
+}
@@ -0,0 +1,143 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+)
+
+// DefaultPruningWindow is the number of latest blocks the state of which is kept by a pruned node, as with geth in its
+// default full sync mode
+const DefaultPruningWindow uint64 = 128
+
+// ArchiveReader serves the historical queries a pruned node fails on, it's implemented by ethclient.Client
+type ArchiveReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	Close()
+}
+
+// ArchiveFallbackClient is an EVMClient which sends the queries for the blocks older than the pruning window of the
+// primary node to an archive node, so that the validations of old requests in long soaks don't fail on pruned nodes.
+// The queries failing on the primary node are retried on the archive node as well, as the pruning window of a node
+// is not always known.
+type ArchiveFallbackClient struct {
+	blockchain.EVMClient
+	archive       ArchiveReader
+	pruningWindow uint64
+	lggr          zerolog.Logger
+}
+
+// NewArchiveFallbackClient connects to the archive node at archiveURL and wraps client with it. If pruningWindow is 0,
+// DefaultPruningWindow is used.
+func NewArchiveFallbackClient(
+	lggr zerolog.Logger,
+	client blockchain.EVMClient,
+	archiveURL string,
+	pruningWindow uint64,
+) (*ArchiveFallbackClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	archive, err := ethclient.DialContext(ctx, archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to archive node of %s: %w", client.GetNetworkName(), err)
+	}
+	chainID, err := archive.ChainID(ctx)
+	if err != nil {
+		archive.Close()
+		return nil, fmt.Errorf("failed to get chain id from archive node of %s: %w", client.GetNetworkName(), err)
+	}
+	if chainID.Cmp(client.GetChainID()) != 0 {
+		archive.Close()
+		return nil, fmt.Errorf("archive node of %s is on chain %s, expected chain %s",
+			client.GetNetworkName(), chainID, client.GetChainID())
+	}
+	return WithArchive(lggr, client, archive, pruningWindow), nil
+}
+
+// WithArchive wraps client with archive for the queries beyond pruningWindow, DefaultPruningWindow if it's 0
+func WithArchive(lggr zerolog.Logger, client blockchain.EVMClient, archive ArchiveReader, pruningWindow uint64) *ArchiveFallbackClient {
+	if pruningWindow == 0 {
+		pruningWindow = DefaultPruningWindow
+	}
+	return &ArchiveFallbackClient{
+		EVMClient:     client,
+		archive:       archive,
+		pruningWindow: pruningWindow,
+		lggr:          lggr.With().Str("Network", client.GetNetworkName()).Logger(),
+	}
+}
+
+// isPruned returns true if block is older than the pruning window of the primary node
+func (c *ArchiveFallbackClient) isPruned(ctx context.Context, block *big.Int) bool {
+	if block == nil || block.Sign() < 0 {
+		// latest, pending and the other block tags
+		return false
+	}
+	latest, err := c.EVMClient.LatestBlockNumber(ctx)
+	if err != nil {
+		return false
+	}
+	return latest > c.pruningWindow && block.Uint64() < latest-c.pruningWindow
+}
+
+func (c *ArchiveFallbackClient) HeaderByNumber(ctx context.Context, number *big.Int) (*blockchain.SafeEVMHeader, error) {
+	if !c.isPruned(ctx, number) {
+		hdr, err := c.EVMClient.HeaderByNumber(ctx, number)
+		if err == nil || number == nil {
+			return hdr, err
+		}
+		c.lggr.Debug().Err(err).Str("Block", number.String()).Msg("Header query failed on primary node, querying archive node")
+	}
+	hdr, err := c.archive.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header of block %s from archive node: %w", number, err)
+	}
+	return &blockchain.SafeEVMHeader{
+		Hash:      hdr.Hash(),
+		Number:    hdr.Number,
+		Timestamp: time.Unix(int64(hdr.Time), 0),
+		BaseFee:   hdr.BaseFee,
+	}, nil
+}
+
+// GetTxReceipt returns the receipt from the primary node, or from the archive node if the primary node has pruned it
+func (c *ArchiveFallbackClient) GetTxReceipt(txHash common.Hash) (*types.Receipt, error) {
+	rcpt, err := c.EVMClient.GetTxReceipt(txHash)
+	if err == nil && rcpt != nil {
+		return rcpt, nil
+	}
+	c.lggr.Debug().Err(err).Str("Tx", txHash.Hex()).Msg("Receipt query failed on primary node, querying archive node")
+	archived, archiveErr := c.archive.TransactionReceipt(context.Background(), txHash)
+	if archiveErr != nil {
+		if err == nil {
+			err = fmt.Errorf("receipt not found")
+		}
+		return nil, fmt.Errorf("failed to get receipt of tx %s from primary node: %w and from archive node: %w",
+			txHash.Hex(), err, archiveErr)
+	}
+	return archived, nil
+}
+
+func (c *ArchiveFallbackClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if query.BlockHash == nil && c.isPruned(ctx, query.FromBlock) {
+		return c.archive.FilterLogs(ctx, query)
+	}
+	return c.EVMClient.FilterLogs(ctx, query)
+}
+
+// Close closes the connections to both the primary and the archive node
+func (c *ArchiveFallbackClient) Close() error {
+	c.archive.Close()
+	return c.EVMClient.Close()
+}
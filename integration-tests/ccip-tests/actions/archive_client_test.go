@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+)
+
+// prunedChain is a synthetic chain which has pruned the headers and receipts of the blocks up to prunedTill
+type prunedChain struct {
+	*SyntheticChain
+	prunedTill uint64
+}
+
+func (c *prunedChain) LatestBlockNumber(_ context.Context) (uint64, error) {
+	hdr, err := c.SyntheticChain.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+	return hdr.Number.Uint64(), nil
+}
+
+func (c *prunedChain) HeaderByNumber(ctx context.Context, number *big.Int) (*blockchain.SafeEVMHeader, error) {
+	if number != nil && number.Uint64() <= c.prunedTill {
+		return nil, fmt.Errorf("header not found")
+	}
+	return c.SyntheticChain.HeaderByNumber(ctx, number)
+}
+
+func (c *prunedChain) GetTxReceipt(txHash common.Hash) (*types.Receipt, error) {
+	rcpt, err := c.SyntheticChain.GetTxReceipt(txHash)
+	if err != nil || rcpt.BlockNumber.Uint64() <= c.prunedTill {
+		return nil, fmt.Errorf("receipt not found")
+	}
+	return rcpt, nil
+}
+
+func (c *prunedChain) FilterLogs(_ context.Context, _ ethereum.FilterQuery) ([]types.Log, error) {
+	return []types.Log{{Index: 1}}, nil
+}
+
+type fakeArchive struct {
+	chain   *SyntheticChain
+	queried []string
+}
+
+func (a *fakeArchive) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	a.queried = append(a.queried, fmt.Sprintf("header %s", number))
+	hdr, err := a.chain.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Header{Number: hdr.Number, Time: uint64(hdr.Timestamp.Unix())}, nil
+}
+
+func (a *fakeArchive) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	a.queried = append(a.queried, "receipt")
+	return a.chain.GetTxReceipt(txHash)
+}
+
+func (a *fakeArchive) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	a.queried = append(a.queried, fmt.Sprintf("logs from %s", q.FromBlock))
+	return []types.Log{{Index: 2}}, nil
+}
+
+func (a *fakeArchive) Close() {}
+
+func TestArchiveFallbackClient(t *testing.T) {
+	t.Parallel()
+	synthetic := NewSyntheticChain("synthetic", 1337)
+	var receipts []*types.Receipt
+	for i := 0; i < 20; i++ {
+		receipts = append(receipts, synthetic.MineTx())
+	}
+	// the primary node has pruned the first 5 blocks and keeps the latest 10
+	archive := &fakeArchive{chain: synthetic}
+	client := WithArchive(zerolog.Nop(), &prunedChain{SyntheticChain: synthetic, prunedTill: 5}, archive, 10)
+
+	// blocks within the pruning window are served by the primary node
+	hdr, err := client.HeaderByNumber(context.Background(), big.NewInt(15))
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), hdr.Number.Uint64())
+	rcpt, err := client.GetTxReceipt(receipts[14].TxHash)
+	require.NoError(t, err)
+	require.Equal(t, receipts[14], rcpt)
+	logs, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(12)})
+	require.NoError(t, err)
+	require.Equal(t, uint(1), logs[0].Index)
+	require.Empty(t, archive.queried)
+
+	// blocks beyond the pruning window are served by the archive node
+	hdr, err = client.HeaderByNumber(context.Background(), big.NewInt(8))
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), hdr.Number.Uint64())
+	require.Equal(t, synthetic.blockTime(8).Unix(), hdr.Timestamp.Unix())
+	logs, err = client.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(2)})
+	require.NoError(t, err)
+	require.Equal(t, uint(2), logs[0].Index)
+	require.Equal(t, []string{"header 8", "logs from 2"}, archive.queried)
+
+	// receipts pruned by the primary node are served by the archive node
+	rcpt, err = client.GetTxReceipt(receipts[2].TxHash)
+	require.NoError(t, err)
+	require.Equal(t, receipts[2], rcpt)
+	require.Equal(t, "receipt", archive.queried[2])
+
+	// a tx not found on either node fails with both errors
+	_, err = client.GetTxReceipt(common.HexToHash("0x1"))
+	require.ErrorContains(t, err, "from primary node: receipt not found and from archive node")
+}
@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+// balanceReadBatchSize is the max number of balances read in a single multicall, so that the eth_call stays within
+// the gas cap of the rpcs
+const balanceReadBatchSize = 200
+
+// BalanceReader reads the ERC20 balances of a chain in batches with the Multicall contract deployed on it,
+// instead of one rpc call per balance
+type BalanceReader struct {
+	Chain     blockchain.EVMClient
+	Multicall common.Address
+}
+
+// balanceToken is an ERC20 token the asserted balances are read from
+type balanceToken interface {
+	Address() string
+	BalanceOf(ctx context.Context, addr string) (*big.Int, error)
+}
+
+// balanceReader returns the reader batching the balance reads of the chain, nil if there is no Multicall contract on it
+func (ccipModule *CCIPCommon) balanceReader() *BalanceReader {
+	if ccipModule.MulticallContract == (common.Address{}) {
+		return nil
+	}
+	return &BalanceReader{Chain: ccipModule.ChainClient, Multicall: ccipModule.MulticallContract}
+}
+
+// tokenBalance returns the balance item of holder in token, read in a batch with the other balances of the chain
+// if there is a Multicall contract on it
+func (ccipModule *CCIPCommon) tokenBalance(token balanceToken, holder common.Address) BalanceItem {
+	return BalanceItem{
+		Address: holder,
+		Getter:  GetterForLinkToken(token.BalanceOf, holder.Hex()),
+		Token:   common.HexToAddress(token.Address()),
+		Reader:  ccipModule.balanceReader(),
+	}
+}
+
+// balanceRequirements returns the balance requirements of items ordered by name
+func balanceRequirements(items map[string]BalanceItem) []testhelpers.BalanceReq {
+	var reqs []testhelpers.BalanceReq
+	for name, item := range items {
+		reqs = append(reqs, testhelpers.BalanceReq{
+			Name:   name,
+			Addr:   item.Address,
+			Getter: item.Getter,
+		})
+	}
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Name < reqs[j].Name
+	})
+	return reqs
+}
+
+// ReadBalances reads the balances of items by name. The balances of the items with a Reader are read with a
+// multicall per chain, the others with their Getter.
+func ReadBalances(t *testing.T, items map[string]BalanceItem) (map[string]*big.Int, error) {
+	type batch struct {
+		reader *BalanceReader
+		names  []string
+		calls  []contracts.BalanceOfCall
+	}
+	balances := make(map[string]*big.Int)
+	batches := make(map[string]*batch)
+	for name, item := range items {
+		if item.Reader == nil || item.Token == (common.Address{}) {
+			balances[name] = item.Getter(t, item.Address)
+			continue
+		}
+		key := fmt.Sprintf("%s-%s", item.Reader.Chain.GetChainID(), item.Reader.Multicall.Hex())
+		b, ok := batches[key]
+		if !ok {
+			b = &batch{reader: item.Reader}
+			batches[key] = b
+		}
+		b.names = append(b.names, name)
+		b.calls = append(b.calls, contracts.BalanceOfCall{Token: item.Token, Holder: item.Address})
+	}
+	for _, b := range batches {
+		for start := 0; start < len(b.calls); start += balanceReadBatchSize {
+			end := start + balanceReadBatchSize
+			if end > len(b.calls) {
+				end = len(b.calls)
+			}
+			read, err := contracts.BalancesOf(context.Background(), b.reader.Chain.Backend(), b.reader.Multicall, b.calls[start:end])
+			if err != nil {
+				return nil, fmt.Errorf("failed to read balances on %s with multicall: %w", b.reader.Chain.GetNetworkName(), err)
+			}
+			for i, balance := range read {
+				balances[b.names[start+i]] = balance
+			}
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,47 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func staticBalance(balance int64) func(*testing.T, common.Address) *big.Int {
+	return func(*testing.T, common.Address) *big.Int {
+		return big.NewInt(balance)
+	}
+}
+
+func TestReadBalances(t *testing.T) {
+	t.Parallel()
+	items := map[string]BalanceItem{
+		"b": {Address: common.HexToAddress("0x2"), Getter: staticBalance(20)},
+		// without a reader the getter is used even if the token is set
+		"a": {Address: common.HexToAddress("0x1"), Getter: staticBalance(10), Token: common.HexToAddress("0x10")},
+	}
+	balances, err := ReadBalances(t, items)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*big.Int{"a": big.NewInt(10), "b": big.NewInt(20)}, balances)
+
+	reqs := balanceRequirements(items)
+	require.Len(t, reqs, 2)
+	require.Equal(t, "a", reqs[0].Name)
+	require.Equal(t, common.HexToAddress("0x1"), reqs[0].Addr)
+	require.Equal(t, "b", reqs[1].Name)
+}
+
+func TestBalanceSheetKeepsReader(t *testing.T) {
+	t.Parallel()
+	sheet := NewBalanceSheet()
+	reader := &BalanceReader{Multicall: common.HexToAddress("0x99")}
+	item := BalanceItem{Address: common.HexToAddress("0x1"), Token: common.HexToAddress("0x10"), Reader: reader}
+	item.AmtToAdd = big.NewInt(5)
+	sheet.Update("a", item)
+	item.AmtToAdd = big.NewInt(7)
+	sheet.Update("a", item)
+	require.Equal(t, big.NewInt(12), sheet.Items["a"].AmtToAdd)
+	require.Equal(t, reader, sheet.Items["a"].Reader)
+	require.Equal(t, common.HexToAddress("0x10"), sheet.Items["a"].Token)
+}
@@ -1,13 +1,17 @@
 package actions
 
 import (
+	"bytes"
 	"context"
 	crypto_rand "crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
 	"sync"
@@ -20,13 +24,16 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"golang.org/x/exp/rand"
 	"golang.org/x/sync/errgroup"
 
@@ -42,6 +49,8 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/k8s/pkg/helm/reorg"
 	"github.com/smartcontractkit/chainlink-testing-framework/networks"
 
+	"github.com/smartcontractkit/ccip/integration-tests/wrappers"
+
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts/laneconfig"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
@@ -50,8 +59,6 @@ import (
 	"github.com/smartcontractkit/chainlink/integration-tests/client"
 	"github.com/smartcontractkit/chainlink/integration-tests/docker/test_env"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
-	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
-	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp_1_2_0"
@@ -150,6 +157,7 @@ type CCIPCommon struct {
 	PriceAggregators              map[common.Address]*contracts.MockAggregator
 	NoOfTokensNeedingDynamicPrice int
 	BridgeTokenPools              []*contracts.TokenPool
+	PoolAllowList                 []common.Address // if set, lock release pools are deployed with allowlist enabled with these senders allowed
 	RateLimiterConfig             contracts.RateLimiterConfig
 	ARMContract                   *common.Address
 	ARM                           *contracts.ARM // populate only if the ARM contracts is not a mock and can be used to verify various ARM events; keep this nil for mock ARM
@@ -167,6 +175,12 @@ type CCIPCommon struct {
 	gasUpdateWatcherMu            *sync.Mutex
 	gasUpdateWatcher              map[uint64]*big.Int // key - destchain id; value - timestamp of update
 	IsConnectionRestoredRecently  *atomic.Bool
+	PollingInterval               time.Duration // interval at which the Assert* loops poll the event watchers; DefaultPollingInterval if not set
+	AvgBlockTime                  time.Duration // average block time of the chain, used to stretch the phase timeouts for slow chains
+	InfiniteRouterApproval        bool          // if set, the router is approved for the max uint256 of the tokens instead of ApprovedAmountToRouter
+	FeeTokenDecimals              uint8         // decimals of the fee token deployed in place of LINK, LINK is deployed if 0 or 18
+	PoolTypes                     []string      // pool type of each bridge token, testconfig.LockReleasePoolType for the tokens not listed
+	ConcurrentDeployment          bool          // if set, DeployContracts deploys the contracts which don't depend on one another concurrently
 }
 
 // FreeUpUnusedSpace sets nil to various elements of ccipModule which are only used
@@ -365,7 +379,7 @@ func (ccipModule *CCIPCommon) ApproveTokens() error {
 			return fmt.Errorf("failed to get allowance for token %s: %w", token.ContractAddress.Hex(), err)
 		}
 		if allowance.Cmp(ApprovedAmountToRouter) < 0 {
-			err := token.Approve(ccipModule.Router.Address(), ApprovedAmountToRouter)
+			err := token.Approve(ccipModule.Router.Address(), ccipModule.routerApprovalAmount(ApprovedAmountToRouter))
 			if err != nil {
 				return fmt.Errorf("failed to approve token %s: %w", token.ContractAddress.Hex(), err)
 			}
@@ -384,7 +398,7 @@ func (ccipModule *CCIPCommon) ApproveTokens() error {
 			return fmt.Errorf("failed to get allowance for token %s: %w", ccipModule.FeeToken.Address(), err)
 		}
 		if allowance.Cmp(amount) < 0 {
-			err := ccipModule.FeeToken.Approve(ccipModule.Router.Address(), amount)
+			err := ccipModule.FeeToken.Approve(ccipModule.Router.Address(), ccipModule.routerApprovalAmount(amount))
 			if err != nil {
 				return fmt.Errorf("failed to approve fee token %s: %w", ccipModule.FeeToken.EthAddress.String(), err)
 			}
@@ -445,7 +459,7 @@ func (ccipModule *CCIPCommon) WaitForPriceUpdates(
 	}
 	// if not, wait for price update
 	lggr.Info().Msgf("Waiting for UsdPerUnitGas for dest chain %d Price Registry %s", destChainId, ccipModule.PriceRegistry.Address())
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(ccipModule.pollingInterval())
 	defer ticker.Stop()
 	localCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -469,7 +483,7 @@ func (ccipModule *CCIPCommon) WaitForPriceUpdates(
 	}
 }
 
-func (ccipModule *CCIPCommon) WatchForPriceUpdates(ctx context.Context) error {
+func (ccipModule *CCIPCommon) WatchForPriceUpdates(routines *Supervisor) error {
 	var sub event.Subscription
 	gasUpdateEventLatest := make(chan *price_registry.PriceRegistryUsdPerUnitGasUpdated)
 	sub = event.Resubscribe(2*time.Hour, func(_ context.Context) (event.Subscription, error) {
@@ -499,12 +513,12 @@ func (ccipModule *CCIPCommon) WatchForPriceUpdates(ctx context.Context) error {
 				destChain, ccipModule.ChainClient.GetNetworkName())
 		return nil
 	}
-	go func() {
-		defer func() {
-			sub.Unsubscribe()
-			ccipModule.gasUpdateWatcher = nil
-			ccipModule.gasUpdateWatcherMu = nil
-		}()
+	routines.OnStop(func() {
+		sub.Unsubscribe()
+		ccipModule.gasUpdateWatcher = nil
+		ccipModule.gasUpdateWatcherMu = nil
+	})
+	routines.Go("UsdPerUnitGasUpdated watcher", DefaultRestartPolicy, func(ctx context.Context) error {
 		for {
 			select {
 			case e := <-gasUpdateEventLatest:
@@ -513,17 +527,17 @@ func (ccipModule *CCIPCommon) WatchForPriceUpdates(ctx context.Context) error {
 					continue
 				}
 			case <-ctx.Done():
-				return
+				return nil
 			}
 		}
-	}()
+	})
 
 	return nil
 }
 
 // UpdateTokenPricesAtRegularInterval updates aggregator contract with updated answer at regular interval.
 // At each iteration of ticker it chooses one of the aggregator contracts and updates its round answer.
-func (ccipModule *CCIPCommon) UpdateTokenPricesAtRegularInterval(ctx context.Context, interval time.Duration, conf *laneconfig.LaneConfig) error {
+func (ccipModule *CCIPCommon) UpdateTokenPricesAtRegularInterval(routines *Supervisor, interval time.Duration, conf *laneconfig.LaneConfig) error {
 	if ccipModule.ExistingDeployment {
 		return nil
 	}
@@ -535,9 +549,10 @@ func (ccipModule *CCIPCommon) UpdateTokenPricesAtRegularInterval(ctx context.Con
 		}
 		aggregators = append(aggregators, contract)
 	}
-	go func() {
+	routines.Go("token price updater", DefaultRestartPolicy, func(ctx context.Context) error {
 		rand.NewSource(uint64(time.Now().UnixNano()))
 		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
@@ -548,10 +563,10 @@ func (ccipModule *CCIPCommon) UpdateTokenPricesAtRegularInterval(ctx context.Con
 					continue
 				}
 			case <-ctx.Done():
-				return
+				return nil
 			}
 		}
-	}()
+	})
 	return nil
 }
 
@@ -612,6 +627,30 @@ func (ccipModule *CCIPCommon) IsUSDCDeployment() bool {
 	return pointer.GetBool(ccipModule.USDCMockDeployment)
 }
 
+// PoolType returns the pool type of the bridge token at index i, the first token has a USDC pool in a USDC deployment
+func (ccipModule *CCIPCommon) PoolType(i int) string {
+	// if there is usdc token, the corresponding pool will always be added as first one in the slice
+	if ccipModule.IsUSDCDeployment() && i == 0 {
+		return testconfig.USDCPoolType
+	}
+	if i < len(ccipModule.PoolTypes) {
+		return ccipModule.PoolTypes[i]
+	}
+	return testconfig.LockReleasePoolType
+}
+
+// newBridgeTokenPool returns the wrapper of the deployed pool of the bridge token at index i as per its pool type
+func (ccipModule *CCIPCommon) newBridgeTokenPool(cd *contracts.CCIPContractsDeployer, i int, addr common.Address) (*contracts.TokenPool, error) {
+	switch ccipModule.PoolType(i) {
+	case testconfig.USDCPoolType:
+		return cd.NewUSDCTokenPoolContract(addr)
+	case testconfig.BurnMintPoolType:
+		return cd.NewBurnMintTokenPoolContract(addr)
+	default:
+		return cd.NewLockReleaseTokenPoolContract(addr)
+	}
+}
+
 func (ccipModule *CCIPCommon) WriteLaneConfig(conf *laneconfig.LaneConfig) {
 	var btAddresses, btpAddresses []string
 	priceAggrs := make(map[string]string)
@@ -683,13 +722,40 @@ func (ccipModule *CCIPCommon) NeedTokenAdminRegistry() bool {
 
 // DeployContracts deploys the contracts which are necessary in both source and dest chain
 // This reuses common contracts for bidirectional lanes
+// If ConcurrentDeployment is set, the contracts which don't depend on one another are deployed concurrently
 func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 	tokenDeployerFns []blockchain.ContractDeployer,
 	conf *laneconfig.LaneConfig) error {
+	ccipModule.LoadContractAddresses(conf, &noOfTokens)
+	// the deps of a step are the steps deploying the contracts it's deployed or set up with, the tokens are chained
+	// as well so that they get the dynamic price aggregators in the same order whether deployed concurrently or not
+	steps := []deployStep{
+		{name: "arm", run: ccipModule.deployARM},
+		{name: "wrapped native", run: ccipModule.deployWrappedNative},
+		{name: "router", deps: []string{"arm", "wrapped native"}, run: ccipModule.deployRouter},
+		{name: "usdc", run: ccipModule.deployUSDCContracts},
+		{name: "fee token", deps: []string{"wrapped native"}, run: ccipModule.setUpFeeToken},
+		{name: "bridge tokens", deps: []string{"usdc", "fee token"}, run: func() error {
+			return ccipModule.deployBridgeTokens(noOfTokens, tokenDeployerFns)
+		}},
+		{name: "bridge token pools", deps: []string{"arm", "router", "usdc", "bridge tokens"}, run: ccipModule.deployBridgeTokenPools},
+		{name: "price registry", deps: []string{"wrapped native", "fee token"}, run: ccipModule.deployPriceRegistry},
+		{name: "multicall", run: ccipModule.deployMulticall},
+		{name: "token admin registry", deps: []string{"bridge tokens", "bridge token pools"}, run: ccipModule.deployTokenAdminRegistry},
+	}
+	err := runDeploySteps(context.Background(), steps, ccipModule.ConcurrentDeployment)
+	if err != nil {
+		return err
+	}
+	log.Info().Bool("concurrent", ccipModule.ConcurrentDeployment).Msg("finished deploying common contracts")
+	// approve router to spend fee token
+	return ccipModule.ApproveTokens()
+}
+
+// deployARM deploys a mock ARM contract unless an ARM is provided in the lane config
+func (ccipModule *CCIPCommon) deployARM() error {
 	var err error
 	cd := ccipModule.Deployer
-
-	ccipModule.LoadContractAddresses(conf, &noOfTokens)
 	if ccipModule.ARM != nil {
 		arm, err := cd.NewARMContract(ccipModule.ARM.EthAddress)
 		if err != nil {
@@ -712,25 +778,37 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 			}
 		}
 	}
-	if ccipModule.WrappedNative == common.HexToAddress("0x0") {
-		if ccipModule.ExistingDeployment {
-			return fmt.Errorf("wrapped native contract address is not provided in lane config")
-		}
-		weth9addr, err := cd.DeployWrappedNative()
-		if err != nil {
-			return fmt.Errorf("deploying wrapped native shouldn't fail %w", err)
-		}
-		err = ccipModule.AddPriceAggregatorToken(*weth9addr, WrappedNativeToUSD)
-		if err != nil {
-			return fmt.Errorf("deploying mock aggregator contract shouldn't fail %w", err)
-		}
-		err = ccipModule.ChainClient.WaitForEvents()
-		if err != nil {
-			return fmt.Errorf("waiting for deploying wrapped native shouldn't fail %w", err)
-		}
-		ccipModule.WrappedNative = *weth9addr
+	return nil
+}
+
+// deployWrappedNative deploys the wrapped native unless it's provided in the lane config
+func (ccipModule *CCIPCommon) deployWrappedNative() error {
+	if ccipModule.WrappedNative != common.HexToAddress("0x0") {
+		return nil
+	}
+	if ccipModule.ExistingDeployment {
+		return fmt.Errorf("wrapped native contract address is not provided in lane config")
+	}
+	weth9addr, err := ccipModule.Deployer.DeployWrappedNative()
+	if err != nil {
+		return fmt.Errorf("deploying wrapped native shouldn't fail %w", err)
+	}
+	err = ccipModule.AddPriceAggregatorToken(*weth9addr, WrappedNativeToUSD)
+	if err != nil {
+		return fmt.Errorf("deploying mock aggregator contract shouldn't fail %w", err)
+	}
+	err = ccipModule.ChainClient.WaitForEvents()
+	if err != nil {
+		return fmt.Errorf("waiting for deploying wrapped native shouldn't fail %w", err)
 	}
+	ccipModule.WrappedNative = *weth9addr
+	return nil
+}
 
+// deployRouter deploys the router unless it's provided in the lane config
+func (ccipModule *CCIPCommon) deployRouter() error {
+	var err error
+	cd := ccipModule.Deployer
 	if ccipModule.Router == nil {
 		if ccipModule.ExistingDeployment {
 			return fmt.Errorf("router contract address is not provided in lane config")
@@ -750,63 +828,84 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 		}
 		ccipModule.Router = r
 	}
-	// if usdc deployment ,look for token transmitter and token messenger
-	if ccipModule.IsUSDCDeployment() {
-		// if existing deployment, no need to deploy new USDC contracts, it should be considered as a generic erc20 token
-		if ccipModule.ExistingDeployment {
-			return fmt.Errorf("existing deployment and new USDC deployment cannot be done together")
-		}
-		if ccipModule.TokenTransmitter == nil {
-			domain, err := GetUSDCDomain(ccipModule.ChainClient.GetNetworkName(), ccipModule.ChainClient.NetworkSimulated())
-			if err != nil {
-				return fmt.Errorf("error in getting USDC domain %w", err)
-			}
-			ccipModule.TokenTransmitter, err = cd.DeployTokenTransmitter(domain)
-			if err != nil {
-				return fmt.Errorf("deploying token transmitter shouldn't fail %w", err)
-			}
-		}
-		if ccipModule.TokenMessenger == nil {
-			if ccipModule.TokenTransmitter == nil {
-				return fmt.Errorf("TokenTransmitter contract address is not provided")
-			}
-			ccipModule.TokenMessenger, err = cd.DeployTokenMessenger(ccipModule.TokenTransmitter.ContractAddress)
-			if err != nil {
-				return fmt.Errorf("deploying token messenger shouldn't fail %w", err)
-			}
-			err = ccipModule.ChainClient.WaitForEvents()
-			if err != nil {
-				return fmt.Errorf("error in waiting for mock TokenMessenger and Transmitter deployment %w", err)
-			}
-		}
+	return nil
+}
+
+// deployUSDCContracts deploys the token transmitter and the token messenger if it's a USDC deployment
+func (ccipModule *CCIPCommon) deployUSDCContracts() error {
+	if !ccipModule.IsUSDCDeployment() {
+		return nil
 	}
-	if ccipModule.FeeToken == nil {
-		if ccipModule.ExistingDeployment {
-			return fmt.Errorf("FeeToken contract address is not provided in lane config")
+	var err error
+	cd := ccipModule.Deployer
+	// if existing deployment, no need to deploy new USDC contracts, it should be considered as a generic erc20 token
+	if ccipModule.ExistingDeployment {
+		return fmt.Errorf("existing deployment and new USDC deployment cannot be done together")
+	}
+	if ccipModule.TokenTransmitter == nil {
+		domain, err := GetUSDCDomain(ccipModule.ChainClient.GetNetworkName(), ccipModule.ChainClient.NetworkSimulated())
+		if err != nil {
+			return fmt.Errorf("error in getting USDC domain %w", err)
 		}
-		// deploy link token
-		token, err := cd.DeployLinkTokenContract()
+		ccipModule.TokenTransmitter, err = cd.DeployTokenTransmitter(domain)
 		if err != nil {
-			return fmt.Errorf("deploying fee token contract shouldn't fail %w", err)
+			return fmt.Errorf("deploying token transmitter shouldn't fail %w", err)
 		}
-
-		ccipModule.FeeToken = token
-		err = ccipModule.AddPriceAggregatorToken(ccipModule.FeeToken.EthAddress, LinkToUSD)
+	}
+	if ccipModule.TokenMessenger == nil {
+		if ccipModule.TokenTransmitter == nil {
+			return fmt.Errorf("TokenTransmitter contract address is not provided")
+		}
+		ccipModule.TokenMessenger, err = cd.DeployTokenMessenger(ccipModule.TokenTransmitter.ContractAddress)
 		if err != nil {
-			return fmt.Errorf("deploying mock aggregator contract shouldn't fail %w", err)
+			return fmt.Errorf("deploying token messenger shouldn't fail %w", err)
 		}
 		err = ccipModule.ChainClient.WaitForEvents()
 		if err != nil {
-			return fmt.Errorf("error in waiting for feetoken deployment %w", err)
+			return fmt.Errorf("error in waiting for mock TokenMessenger and Transmitter deployment %w", err)
 		}
-	} else {
-		token, err := cd.NewLinkTokenContract(common.HexToAddress(ccipModule.FeeToken.Address()))
+	}
+	return nil
+}
+
+// setUpFeeToken deploys the fee token with its price aggregator unless it's provided in the lane config
+func (ccipModule *CCIPCommon) setUpFeeToken() error {
+	if ccipModule.FeeToken != nil {
+		token, err := ccipModule.Deployer.NewLinkTokenContract(common.HexToAddress(ccipModule.FeeToken.Address()))
 		if err != nil {
 			return fmt.Errorf("getting fee token contract shouldn't fail %w", err)
 		}
 		ccipModule.FeeToken = token
+		return nil
+	}
+	if ccipModule.ExistingDeployment {
+		return fmt.Errorf("FeeToken contract address is not provided in lane config")
 	}
+	// deploy link token
+	token, err := ccipModule.deployFeeToken()
+	if err != nil {
+		return fmt.Errorf("deploying fee token contract shouldn't fail %w", err)
+	}
+
+	ccipModule.FeeToken = token
+	feeTokenPrice, err := ccipModule.FeeTokenUsdPrice()
+	if err != nil {
+		return fmt.Errorf("getting fee token price shouldn't fail %w", err)
+	}
+	err = ccipModule.AddPriceAggregatorToken(ccipModule.FeeToken.EthAddress, feeTokenPrice)
+	if err != nil {
+		return fmt.Errorf("deploying mock aggregator contract shouldn't fail %w", err)
+	}
+	err = ccipModule.ChainClient.WaitForEvents()
+	if err != nil {
+		return fmt.Errorf("error in waiting for feetoken deployment %w", err)
+	}
+	return nil
+}
 
+// deployBridgeTokens deploys the bridge tokens missing from the lane config up to noOfTokens
+func (ccipModule *CCIPCommon) deployBridgeTokens(noOfTokens int, tokenDeployerFns []blockchain.ContractDeployer) error {
+	cd := ccipModule.Deployer
 	// number of deployed bridge tokens does not match noOfTokens; deploy rest of the tokens in case ExistingDeployment is false
 	// In case of ExistingDeployment as true use whatever is provided in laneconfig
 	if len(ccipModule.BridgeTokens) < noOfTokens {
@@ -835,6 +934,21 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 						if err != nil {
 							return fmt.Errorf("granting minter role to token messenger shouldn't fail %w", err)
 						}
+					} else if ccipModule.PoolType(i) == testconfig.BurnMintPoolType {
+						// the pool of the token needs to be granted the mint and burn roles, which link token doesn't have
+						// as much as the 1e9 LINK minted to the owner of the link token
+						burnMintToken, err := cd.DeployBurnMintBridgeToken(new(big.Int).Mul(big.NewInt(1e9), big.NewInt(1e18)))
+						if err != nil {
+							return fmt.Errorf("deploying bridge burn mint token contract shouldn't fail %w", err)
+						}
+						token, err = cd.NewERC20TokenContract(burnMintToken.ContractAddress)
+						if err != nil {
+							return fmt.Errorf("getting new bridge burn mint token contract shouldn't fail %w", err)
+						}
+						err = ccipModule.AddPriceAggregatorToken(burnMintToken.ContractAddress, LinkToUSD)
+						if err != nil {
+							return fmt.Errorf("deploying mock aggregator contract shouldn't fail %w", err)
+						}
 					} else {
 						// otherwise we deploy link token and cast it to ERC20Token
 						linkToken, err := cd.DeployLinkTokenContract()
@@ -863,7 +977,7 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 				ccipModule.BridgeTokens = append(ccipModule.BridgeTokens, token)
 			}
 		}
-		err = ccipModule.ChainClient.WaitForEvents()
+		err := ccipModule.ChainClient.WaitForEvents()
 		if err != nil {
 			return fmt.Errorf("error in waiting for bridge token deployment %w", err)
 		}
@@ -878,6 +992,12 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 		tokens = append(tokens, newToken)
 	}
 	ccipModule.BridgeTokens = tokens
+	return nil
+}
+
+// deployBridgeTokenPools deploys the pools of the bridge tokens missing from the lane config
+func (ccipModule *CCIPCommon) deployBridgeTokenPools() error {
+	cd := ccipModule.Deployer
 	if len(ccipModule.BridgeTokenPools) != len(ccipModule.BridgeTokens) {
 		if ccipModule.ExistingDeployment {
 			return fmt.Errorf("bridge token pool contract address is not provided in lane config")
@@ -900,9 +1020,25 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 				}
 
 				ccipModule.BridgeTokenPools = append(ccipModule.BridgeTokenPools, usdcPool)
+			} else if ccipModule.PoolType(i) == testconfig.BurnMintPoolType {
+				btp, err := cd.DeployBurnMintTokenPoolContract(token.Address(), *ccipModule.ARMContract, ccipModule.Router.Instance.Address(), ccipModule.PoolAllowList)
+				if err != nil {
+					return fmt.Errorf("deploying bridge Token pool(burn&mint) shouldn't fail %w", err)
+				}
+				ccipModule.BridgeTokenPools = append(ccipModule.BridgeTokenPools, btp)
+
+				// the pool burns the tokens it receives and mints the tokens it releases, instead of holding liquidity
+				burnMintToken, err := cd.NewERC677TokenContract(token.ContractAddress)
+				if err != nil {
+					return fmt.Errorf("getting new burn mint token contract shouldn't fail %w", err)
+				}
+				err = burnMintToken.GrantMintAndBurn(btp.EthAddress)
+				if err != nil {
+					return fmt.Errorf("granting mint and burn roles to the burn mint pool shouldn't fail %w", err)
+				}
 			} else {
 				// deploy lock release token pool in case of non-usdc deployment
-				btp, err := cd.DeployLockReleaseTokenPoolContract(token.Address(), *ccipModule.ARMContract, ccipModule.Router.Instance.Address())
+				btp, err := cd.DeployLockReleaseTokenPoolContract(token.Address(), *ccipModule.ARMContract, ccipModule.Router.Instance.Address(), ccipModule.PoolAllowList)
 				if err != nil {
 					return fmt.Errorf("deploying bridge Token pool(lock&release) shouldn't fail %w", err)
 				}
@@ -916,8 +1052,8 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 		}
 	} else {
 		var pools []*contracts.TokenPool
-		for _, pool := range ccipModule.BridgeTokenPools {
-			newPool, err := cd.NewLockReleaseTokenPoolContract(pool.EthAddress)
+		for i, pool := range ccipModule.BridgeTokenPools {
+			newPool, err := ccipModule.newBridgeTokenPool(cd, i, pool.EthAddress)
 			if err != nil {
 				return fmt.Errorf("getting new bridge token pool contract shouldn't fail %w", err)
 			}
@@ -925,7 +1061,13 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 		}
 		ccipModule.BridgeTokenPools = pools
 	}
+	return nil
+}
 
+// deployPriceRegistry deploys the price registry unless it's provided in the lane config
+func (ccipModule *CCIPCommon) deployPriceRegistry() error {
+	var err error
+	cd := ccipModule.Deployer
 	if ccipModule.PriceRegistry == nil {
 		if ccipModule.ExistingDeployment {
 			return fmt.Errorf("price registry contract address is not provided in lane config")
@@ -949,13 +1091,27 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 			return fmt.Errorf("getting new PriceRegistry contract shouldn't fail %w", err)
 		}
 	}
+	return nil
+}
+
+// deployMulticall deploys the multicall contract if multicall is enabled and it's not provided in the lane config
+func (ccipModule *CCIPCommon) deployMulticall() error {
+	var err error
+	cd := ccipModule.Deployer
 	if ccipModule.MulticallContract == (common.Address{}) && ccipModule.MulticallEnabled {
 		ccipModule.MulticallContract, err = cd.DeployMultiCallContract()
 		if err != nil {
 			return fmt.Errorf("deploying multicall contract shouldn't fail %w", err)
 		}
 	}
+	return nil
+}
 
+// deployTokenAdminRegistry deploys the token admin registry and registers the pools in it, if the version needs it
+// and it's not provided in the lane config
+func (ccipModule *CCIPCommon) deployTokenAdminRegistry() error {
+	var err error
+	cd := ccipModule.Deployer
 	// if the version is after 1.4.0, we need to deploy TokenAdminRegistry
 	if ccipModule.NeedTokenAdminRegistry() {
 		if ccipModule.TokenAdminRegistry == nil {
@@ -994,9 +1150,7 @@ func (ccipModule *CCIPCommon) DeployContracts(noOfTokens int,
 			}
 		}
 	}
-	log.Info().Msg("finished deploying common contracts")
-	// approve router to spend fee token
-	return ccipModule.ApproveTokens()
+	return nil
 }
 
 // DynamicPriceGetterConfig specifies the configuration for the price getter in price pipeline.
@@ -1116,20 +1270,11 @@ func NewCCIPCommonFromConfig(
 	}
 	var pools []*contracts.TokenPool
 	for i := range newCCIPModule.BridgeTokenPools {
-		// if there is usdc token, the corresponding pool will always be added as first one in the slice
-		if newCCIPModule.IsUSDCDeployment() && i == 0 {
-			pool, err := newCD.NewUSDCTokenPoolContract(common.HexToAddress(newCCIPModule.BridgeTokenPools[i].Address()))
-			if err != nil {
-				return nil, err
-			}
-			pools = append(pools, pool)
-		} else {
-			pool, err := newCD.NewLockReleaseTokenPoolContract(common.HexToAddress(newCCIPModule.BridgeTokenPools[i].Address()))
-			if err != nil {
-				return nil, err
-			}
-			pools = append(pools, pool)
+		pool, err := newCCIPModule.newBridgeTokenPool(newCD, i, common.HexToAddress(newCCIPModule.BridgeTokenPools[i].Address()))
+		if err != nil {
+			return nil, err
 		}
+		pools = append(pools, pool)
 	}
 	newCCIPModule.BridgeTokenPools = pools
 	var tokens []*contracts.ERC20Token
@@ -1205,6 +1350,7 @@ type SourceCCIPModule struct {
 	OnRamp                     *contracts.OnRamp
 	SrcStartBlock              uint64
 	CCIPSendRequestedWatcher   *sync.Map // map[string]*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested
+	SentMsgs                   *sync.Map // key - tx hash; value - []router.ClientEVM2AnyMessage sent in the tx, see RecordSentMsgs
 	NewFinalizedBlockNum       atomic.Uint64
 	NewFinalizedBlockTimestamp atomic.Time
 }
@@ -1298,6 +1444,10 @@ func (sourceCCIP *SourceCCIPModule) DeployContracts(lane *laneconfig.LaneConfig)
 
 	// update prices for price registry. It might be omitted in future
 	if !sourceCCIP.Common.ExistingDeployment {
+		feeTokenPrice, err := sourceCCIP.Common.FeeTokenUsdPrice()
+		if err != nil {
+			return fmt.Errorf("getting fee token price shouldn't fail %w", err)
+		}
 		var tokenUpdates []contracts.InternalTokenPriceUpdate
 		for _, token := range sourceCCIP.Common.BridgeTokens {
 			tokenUpdates = append(tokenUpdates, contracts.InternalTokenPriceUpdate{
@@ -1310,9 +1460,9 @@ func (sourceCCIP *SourceCCIPModule) DeployContracts(lane *laneconfig.LaneConfig)
 			UsdPerToken: WrappedNativeToUSD,
 		}, contracts.InternalTokenPriceUpdate{
 			SourceToken: sourceCCIP.Common.FeeToken.EthAddress,
-			UsdPerToken: LinkToUSD,
+			UsdPerToken: feeTokenPrice,
 		})
-		err := sourceCCIP.Common.PriceRegistry.UpdatePrices(tokenUpdates,
+		err = sourceCCIP.Common.PriceRegistry.UpdatePrices(tokenUpdates,
 			[]contracts.InternalGasPriceUpdate{
 				{
 					DestChainSelector: sourceCCIP.DestChainSelector,
@@ -1396,45 +1546,32 @@ func (sourceCCIP *SourceCCIPModule) DeployContracts(lane *laneconfig.LaneConfig)
 }
 
 func (sourceCCIP *SourceCCIPModule) CollectBalanceRequirements() []testhelpers.BalanceReq {
-	var balancesReq []testhelpers.BalanceReq
+	return balanceRequirements(sourceCCIP.balanceItems())
+}
+
+// balanceItems returns the balances asserted before and after the transfers by name
+func (sourceCCIP *SourceCCIPModule) balanceItems() map[string]BalanceItem {
+	items := make(map[string]BalanceItem)
 	for _, token := range sourceCCIP.Common.BridgeTokens {
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), sourceCCIP.Sender.Hex()),
-			Addr:   sourceCCIP.Sender,
-			Getter: GetterForLinkToken(token.BalanceOf, sourceCCIP.Sender.Hex()),
-		})
+		name := fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), sourceCCIP.Sender.Hex())
+		items[name] = sourceCCIP.Common.tokenBalance(token, sourceCCIP.Sender)
 	}
 	for i, pool := range sourceCCIP.Common.BridgeTokenPools {
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("BridgeToken-%s-TokenPool-%s", sourceCCIP.Common.BridgeTokens[i].Address(), pool.Address()),
-			Addr:   pool.EthAddress,
-			Getter: GetterForLinkToken(sourceCCIP.Common.BridgeTokens[i].BalanceOf, pool.Address()),
-		})
+		name := fmt.Sprintf("BridgeToken-%s-TokenPool-%s", sourceCCIP.Common.BridgeTokens[i].Address(), pool.Address())
+		items[name] = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.BridgeTokens[i], pool.EthAddress)
 	}
 
 	if sourceCCIP.Common.FeeToken.Address() != common.HexToAddress("0x0").String() {
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-Address-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Sender.Hex()),
-			Addr:   sourceCCIP.Sender,
-			Getter: GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Sender.Hex()),
-		})
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-Router-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.Router.Address()),
-			Addr:   sourceCCIP.Common.Router.EthAddress,
-			Getter: GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Common.Router.Address()),
-		})
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-OnRamp-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.OnRamp.Address()),
-			Addr:   sourceCCIP.OnRamp.EthAddress,
-			Getter: GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.OnRamp.Address()),
-		})
-		balancesReq = append(balancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-Prices-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.PriceRegistry.Address()),
-			Addr:   sourceCCIP.Common.PriceRegistry.EthAddress,
-			Getter: GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Common.PriceRegistry.Address()),
-		})
+		name := fmt.Sprintf("FeeToken-%s-Address-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Sender.Hex())
+		items[name] = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Sender)
+		name = fmt.Sprintf("FeeToken-%s-Router-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.Router.Address())
+		items[name] = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Common.Router.EthAddress)
+		name = fmt.Sprintf("FeeToken-%s-OnRamp-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.OnRamp.Address())
+		items[name] = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.OnRamp.EthAddress)
+		name = fmt.Sprintf("FeeToken-%s-Prices-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.PriceRegistry.Address())
+		items[name] = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Common.PriceRegistry.EthAddress)
 	}
-	return balancesReq
+	return items
 }
 
 func (sourceCCIP *SourceCCIPModule) UpdateBalance(
@@ -1450,11 +1587,9 @@ func (sourceCCIP *SourceCCIPModule) UpdateBalance(
 				token = sourceCCIP.Common.BridgeTokens[i]
 			}
 			name := fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), sourceCCIP.Sender.Hex())
-			balances.Update(name, BalanceItem{
-				Address:  sourceCCIP.Sender,
-				Getter:   GetterForLinkToken(token.BalanceOf, sourceCCIP.Sender.Hex()),
-				AmtToSub: bigmath.Mul(big.NewInt(noOfReq), sourceCCIP.TransferAmount[i]),
-			})
+			item := sourceCCIP.Common.tokenBalance(token, sourceCCIP.Sender)
+			item.AmtToSub = bigmath.Mul(big.NewInt(noOfReq), sourceCCIP.TransferAmount[i])
+			balances.Update(name, item)
 		}
 		for i := range sourceCCIP.TransferAmount {
 			// if length of sourceCCIP.TransferAmount is more than available bridge token use first bridge token
@@ -1466,47 +1601,59 @@ func (sourceCCIP *SourceCCIPModule) UpdateBalance(
 			}
 
 			name := fmt.Sprintf("BridgeToken-%s-TokenPool-%s", sourceCCIP.Common.BridgeTokens[index].Address(), pool.Address())
-			balances.Update(name, BalanceItem{
-				Address:  pool.EthAddress,
-				Getter:   GetterForLinkToken(sourceCCIP.Common.BridgeTokens[index].BalanceOf, pool.Address()),
-				AmtToAdd: bigmath.Mul(big.NewInt(noOfReq), sourceCCIP.TransferAmount[i]),
-			})
+			item := sourceCCIP.Common.tokenBalance(sourceCCIP.Common.BridgeTokens[index], pool.EthAddress)
+			// a burn mint pool burns the tokens it receives
+			if !pool.IsBurnMint() {
+				item.AmtToAdd = bigmath.Mul(big.NewInt(noOfReq), sourceCCIP.TransferAmount[i])
+			}
+			balances.Update(name, item)
 		}
 	}
 	if sourceCCIP.Common.FeeToken.Address() != common.HexToAddress("0x0").String() {
 		name := fmt.Sprintf("FeeToken-%s-Address-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Sender.Hex())
-		balances.Update(name, BalanceItem{
-			Address:  sourceCCIP.Sender,
-			Getter:   GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Sender.Hex()),
-			AmtToSub: totalFee,
-		})
+		item := sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Sender)
+		item.AmtToSub = totalFee
+		balances.Update(name, item)
 		name = fmt.Sprintf("FeeToken-%s-Prices-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.PriceRegistry.Address())
-		balances.Update(name, BalanceItem{
-			Address: sourceCCIP.Common.PriceRegistry.EthAddress,
-			Getter:  GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Common.PriceRegistry.Address()),
-		})
+		balances.Update(name, sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Common.PriceRegistry.EthAddress))
 		name = fmt.Sprintf("FeeToken-%s-Router-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.Common.Router.Address())
-		balances.Update(name, BalanceItem{
-			Address: sourceCCIP.Common.Router.EthAddress,
-			Getter:  GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.Common.Router.Address()),
-		})
+		balances.Update(name, sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.Common.Router.EthAddress))
 		name = fmt.Sprintf("FeeToken-%s-OnRamp-%s", sourceCCIP.Common.FeeToken.Address(), sourceCCIP.OnRamp.Address())
-		balances.Update(name, BalanceItem{
-			Address:  sourceCCIP.OnRamp.EthAddress,
-			Getter:   GetterForLinkToken(sourceCCIP.Common.FeeToken.BalanceOf, sourceCCIP.OnRamp.Address()),
-			AmtToAdd: totalFee,
-		})
+		item = sourceCCIP.Common.tokenBalance(sourceCCIP.Common.FeeToken, sourceCCIP.OnRamp.EthAddress)
+		item.AmtToAdd = totalFee
+		balances.Update(name, item)
 	}
 }
 
+// AssertSendRequestedLogFinalized waits for the tx of the CCIPSendRequested event log to be finalized.
+// The wait is aborted once ctx is done, the finalizer of the chain client is left to time out on its own.
 func (sourceCCIP *SourceCCIPModule) AssertSendRequestedLogFinalized(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	txHash common.Hash,
 	prevEventAt time.Time,
 	reqStats []*testreporters.RequestStat,
 ) (time.Time, uint64, error) {
 	lggr.Info().Msg("Waiting for CCIPSendRequested event log to be finalized")
-	finalizedBlockNum, finalizedAt, err := sourceCCIP.Common.ChainClient.WaitForFinalizedTx(txHash)
+	type finalized struct {
+		blockNum *big.Int
+		at       time.Time
+		err      error
+	}
+	finalizedCh := make(chan finalized, 1)
+	go func() {
+		blockNum, at, err := sourceCCIP.Common.ChainClient.WaitForFinalizedTx(txHash)
+		finalizedCh <- finalized{blockNum: blockNum, at: at, err: err}
+	}()
+	var finalizedBlockNum *big.Int
+	var finalizedAt time.Time
+	var err error
+	select {
+	case f := <-finalizedCh:
+		finalizedBlockNum, finalizedAt, err = f.blockNum, f.at, f.err
+	case <-ctx.Done():
+		err = fmt.Errorf("validation cancelled: %w", ctx.Err())
+	}
 	if err != nil || finalizedBlockNum == nil {
 		for _, stat := range reqStats {
 			stat.UpdateState(lggr, stat.SeqNum, testreporters.SourceLogFinalized, time.Since(prevEventAt), testreporters.Failure)
@@ -1519,6 +1666,7 @@ func (sourceCCIP *SourceCCIPModule) AssertSendRequestedLogFinalized(
 				TxHash:           txHash.Hex(),
 				FinalizedByBlock: finalizedBlockNum.String(),
 				FinalizedAt:      finalizedAt.String(),
+				BlockNumber:      finalizedBlockNum.Uint64(),
 			})
 	}
 	return finalizedAt, finalizedBlockNum.Uint64(), nil
@@ -1549,14 +1697,16 @@ func (sourceCCIP *SourceCCIPModule) IsRequestTriggeredWithinTimeframe(timeframe
 }
 
 func (sourceCCIP *SourceCCIPModule) AssertEventCCIPSendRequested(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	txHash string,
 	timeout time.Duration,
 	prevEventAt time.Time,
 	reqStat []*testreporters.RequestStat,
 ) ([]*contracts.SendReqEventData, time.Time, error) {
+	timeout = sourceCCIP.Common.PhaseTimeout(timeout)
 	lggr.Info().Str("Timeout", timeout.String()).Msg("Waiting for CCIPSendRequested event")
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(sourceCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
@@ -1570,6 +1720,13 @@ func (sourceCCIP *SourceCCIPModule) AssertEventCCIPSendRequested(
 				if sendRequestedEvents, exists := value.([]*contracts.SendReqEventData); exists && len(sendRequestedEvents) == len(reqStat) {
 					// if the value is processed, delete it from the map
 					sourceCCIP.CCIPSendRequestedWatcher.Delete(txHash)
+					// the messages as emitted by the OnRamp should be the same as the ones sent
+					if err := sourceCCIP.validateSentMsgs(txHash, sendRequestedEvents); err != nil {
+						for _, stat := range reqStat {
+							stat.UpdateState(lggr, 0, testreporters.CCIPSendRe, time.Since(prevEventAt), testreporters.Failure)
+						}
+						return nil, time.Now(), fmt.Errorf("CCIPSendRequested event fields do not match the message sent: %w", err)
+					}
 					for i, sendRequestedEvent := range sendRequestedEvents {
 						seqNum := sendRequestedEvent.SequenceNumber
 						// prevEventAt is the time when the message was successful, this should be same as the time when the event was emitted
@@ -1579,6 +1736,7 @@ func (sourceCCIP *SourceCCIPModule) AssertEventCCIPSendRequested(
 								TxHash:             "",
 								NoOfTokensSent:     sendRequestedEvent.NoOfTokens,
 								MessageBytesLength: int64(sendRequestedEvent.DataLength),
+								BlockNumber:        sendRequestedEvent.Raw.BlockNumber,
 							})
 					}
 					var err error
@@ -1588,6 +1746,11 @@ func (sourceCCIP *SourceCCIPModule) AssertEventCCIPSendRequested(
 					return sendRequestedEvents, prevEventAt, err
 				}
 			}
+		case <-ctx.Done():
+			for _, stat := range reqStat {
+				stat.UpdateState(lggr, 0, testreporters.CCIPSendRe, time.Since(prevEventAt), testreporters.Failure)
+			}
+			return nil, time.Now(), fmt.Errorf("validation of CCIPSendRequested event for tx %s cancelled: %w", txHash, ctx.Err())
 		case <-timer.C:
 			// if there is connection issue reset the timer :
 			if sourceCCIP.Common.IsConnectionRestoredRecently != nil && !sourceCCIP.Common.IsConnectionRestoredRecently.Load() {
@@ -1691,6 +1854,10 @@ func (sourceCCIP *SourceCCIPModule) SendRequest(
 		return common.Hash{}, d, nil, fmt.Errorf("failed getting the fee: %w", err)
 	}
 	log.Info().Str("Fee", fee.String()).Msg("Calculated fee")
+	err = sourceCCIP.Common.EnsureRouterAllowance(log.Logger, msg, fee)
+	if err != nil {
+		return common.Hash{}, d, nil, fmt.Errorf("failed ensuring the router allowance: %w", err)
+	}
 
 	var sendTx *types.Transaction
 	timeNow := time.Now()
@@ -1717,6 +1884,7 @@ func (sourceCCIP *SourceCCIPModule) SendRequest(
 		}
 	}
 
+	sourceCCIP.RecordSentMsgs(sendTx.Hash(), msg)
 	log.Info().
 		Str("Network", sourceCCIP.Common.ChainClient.GetNetworkName()).
 		Str("Send token transaction", sendTx.Hash().String()).
@@ -1756,6 +1924,7 @@ func DefaultSourceCCIPModule(
 		DestNetworkName:          destChain,
 		Sender:                   common.HexToAddress(chainClient.GetDefaultWallet().Address()),
 		CCIPSendRequestedWatcher: &sync.Map{},
+		SentMsgs:                 &sync.Map{},
 	}
 
 	return source, nil
@@ -1775,6 +1944,7 @@ type DestCCIPModule struct {
 	ReportBlessedBySeqNum   *sync.Map
 	NextSeqNumToCommit      *atomic.Uint64
 	DestStartBlock          uint64
+	TimingOverride          *testconfig.LaneTimingConfig // overrides the package level timing params in the OCR2 config of the lane
 }
 
 func (destCCIP *DestCCIPModule) LoadContracts(conf *laneconfig.LaneConfig) {
@@ -2020,34 +2190,27 @@ func (destCCIP *DestCCIPModule) DeployContracts(
 }
 
 func (destCCIP *DestCCIPModule) CollectBalanceRequirements() []testhelpers.BalanceReq {
-	var destBalancesReq []testhelpers.BalanceReq
+	return balanceRequirements(destCCIP.balanceItems())
+}
+
+// balanceItems returns the balances asserted before and after the transfers by name
+func (destCCIP *DestCCIPModule) balanceItems() map[string]BalanceItem {
+	items := make(map[string]BalanceItem)
 	for _, token := range destCCIP.Common.BridgeTokens {
-		destBalancesReq = append(destBalancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), destCCIP.ReceiverDapp.Address()),
-			Addr:   destCCIP.ReceiverDapp.EthAddress,
-			Getter: GetterForLinkToken(token.BalanceOf, destCCIP.ReceiverDapp.Address()),
-		})
+		name := fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), destCCIP.ReceiverDapp.Address())
+		items[name] = destCCIP.Common.tokenBalance(token, destCCIP.ReceiverDapp.EthAddress)
 	}
 	for i, pool := range destCCIP.Common.BridgeTokenPools {
-		destBalancesReq = append(destBalancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("BridgeToken-%s-TokenPool-%s", destCCIP.Common.BridgeTokens[i].Address(), pool.Address()),
-			Addr:   pool.EthAddress,
-			Getter: GetterForLinkToken(destCCIP.Common.BridgeTokens[i].BalanceOf, pool.Address()),
-		})
+		name := fmt.Sprintf("BridgeToken-%s-TokenPool-%s", destCCIP.Common.BridgeTokens[i].Address(), pool.Address())
+		items[name] = destCCIP.Common.tokenBalance(destCCIP.Common.BridgeTokens[i], pool.EthAddress)
 	}
 	if destCCIP.Common.FeeToken.Address() != common.HexToAddress("0x0").String() {
-		destBalancesReq = append(destBalancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-Address-%s", destCCIP.Common.FeeToken.Address(), destCCIP.ReceiverDapp.Address()),
-			Addr:   destCCIP.ReceiverDapp.EthAddress,
-			Getter: GetterForLinkToken(destCCIP.Common.FeeToken.BalanceOf, destCCIP.ReceiverDapp.Address()),
-		})
-		destBalancesReq = append(destBalancesReq, testhelpers.BalanceReq{
-			Name:   fmt.Sprintf("FeeToken-%s-OffRamp-%s", destCCIP.Common.FeeToken.Address(), destCCIP.OffRamp.Address()),
-			Addr:   destCCIP.OffRamp.EthAddress,
-			Getter: GetterForLinkToken(destCCIP.Common.FeeToken.BalanceOf, destCCIP.OffRamp.Address()),
-		})
+		name := fmt.Sprintf("FeeToken-%s-Address-%s", destCCIP.Common.FeeToken.Address(), destCCIP.ReceiverDapp.Address())
+		items[name] = destCCIP.Common.tokenBalance(destCCIP.Common.FeeToken, destCCIP.ReceiverDapp.EthAddress)
+		name = fmt.Sprintf("FeeToken-%s-OffRamp-%s", destCCIP.Common.FeeToken.Address(), destCCIP.OffRamp.Address())
+		items[name] = destCCIP.Common.tokenBalance(destCCIP.Common.FeeToken, destCCIP.OffRamp.EthAddress)
 	}
-	return destBalancesReq
+	return items
 }
 
 func (destCCIP *DestCCIPModule) UpdateBalance(
@@ -2062,11 +2225,9 @@ func (destCCIP *DestCCIPModule) UpdateBalance(
 				token = destCCIP.Common.BridgeTokens[i]
 			}
 			name := fmt.Sprintf("BridgeToken-%s-Address-%s", token.Address(), destCCIP.ReceiverDapp.Address())
-			balance.Update(name, BalanceItem{
-				Address:  destCCIP.ReceiverDapp.EthAddress,
-				Getter:   GetterForLinkToken(token.BalanceOf, destCCIP.ReceiverDapp.Address()),
-				AmtToAdd: bigmath.Mul(big.NewInt(noOfReq), transferAmount[i]),
-			})
+			item := destCCIP.Common.tokenBalance(token, destCCIP.ReceiverDapp.EthAddress)
+			item.AmtToAdd = bigmath.Mul(big.NewInt(noOfReq), transferAmount[i])
+			balance.Update(name, item)
 		}
 		for i := range transferAmount {
 			pool := destCCIP.Common.BridgeTokenPools[0]
@@ -2076,33 +2237,33 @@ func (destCCIP *DestCCIPModule) UpdateBalance(
 				index = i
 			}
 			name := fmt.Sprintf("BridgeToken-%s-TokenPool-%s", destCCIP.Common.BridgeTokens[index].Address(), pool.Address())
-			balance.Update(name, BalanceItem{
-				Address:  pool.EthAddress,
-				Getter:   GetterForLinkToken(destCCIP.Common.BridgeTokens[index].BalanceOf, pool.Address()),
-				AmtToSub: bigmath.Mul(big.NewInt(noOfReq), transferAmount[i]),
-			})
+			item := destCCIP.Common.tokenBalance(destCCIP.Common.BridgeTokens[index], pool.EthAddress)
+			// a burn mint pool mints the tokens it releases
+			if !pool.IsBurnMint() {
+				item.AmtToSub = bigmath.Mul(big.NewInt(noOfReq), transferAmount[i])
+			}
+			balance.Update(name, item)
 		}
 	}
 	if destCCIP.Common.FeeToken.Address() != common.HexToAddress("0x0").String() {
 		name := fmt.Sprintf("FeeToken-%s-OffRamp-%s", destCCIP.Common.FeeToken.Address(), destCCIP.OffRamp.Address())
-		balance.Update(name, BalanceItem{
-			Address: destCCIP.OffRamp.EthAddress,
-			Getter:  GetterForLinkToken(destCCIP.Common.FeeToken.BalanceOf, destCCIP.OffRamp.Address()),
-		})
+		balance.Update(name, destCCIP.Common.tokenBalance(destCCIP.Common.FeeToken, destCCIP.OffRamp.EthAddress))
 
 		name = fmt.Sprintf("FeeToken-%s-Address-%s", destCCIP.Common.FeeToken.Address(), destCCIP.ReceiverDapp.Address())
-		balance.Update(name, BalanceItem{
-			Address: destCCIP.ReceiverDapp.EthAddress,
-			Getter:  GetterForLinkToken(destCCIP.Common.FeeToken.BalanceOf, destCCIP.ReceiverDapp.Address()),
-		})
+		balance.Update(name, destCCIP.Common.tokenBalance(destCCIP.Common.FeeToken, destCCIP.ReceiverDapp.EthAddress))
 	}
 }
 
 // AssertNoReportAcceptedEventReceived validates that no ExecutionStateChangedEvent is emitted for mentioned timeRange after lastSeenTimestamp
-func (destCCIP *DestCCIPModule) AssertNoReportAcceptedEventReceived(lggr zerolog.Logger, timeRange time.Duration, lastSeenTimestamp time.Time) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeRange)
+func (destCCIP *DestCCIPModule) AssertNoReportAcceptedEventReceived(
+	parent context.Context,
+	lggr zerolog.Logger,
+	timeRange time.Duration,
+	lastSeenTimestamp time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(parent, timeRange)
 	defer cancel()
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	for {
 		select {
@@ -2128,6 +2289,10 @@ func (destCCIP *DestCCIPModule) AssertNoReportAcceptedEventReceived(lggr zerolog
 				return fmt.Errorf("CommitReportAccepted Event detected at %s after %s", lastSeenTimestamp, eventFoundAfterCursing.String())
 			}
 		case <-ctx.Done():
+			// only the lapse of the time range validates the absence of the event
+			if parent.Err() != nil {
+				return fmt.Errorf("validation of no CommitReportAccepted event cancelled: %w", parent.Err())
+			}
 			lggr.Info().Msgf("successfully validated that no CommitReportAccepted detected after %s for %s", lastSeenTimestamp, timeRange)
 			return nil
 		}
@@ -2136,13 +2301,14 @@ func (destCCIP *DestCCIPModule) AssertNoReportAcceptedEventReceived(lggr zerolog
 
 // AssertNoExecutionStateChangedEventReceived validates that no ExecutionStateChangedEvent is emitted for mentioned timeRange after lastSeenTimestamp
 func (destCCIP *DestCCIPModule) AssertNoExecutionStateChangedEventReceived(
+	parent context.Context,
 	lggr zerolog.Logger,
 	timeRange time.Duration,
 	lastSeenTimestamp time.Time,
 ) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeRange)
+	ctx, cancel := context.WithTimeout(parent, timeRange)
 	defer cancel()
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	lggr.Info().Str("Wait Time", timeRange.String()).Time("Since", lastSeenTimestamp).Msg("Waiting to ensure no ExecutionStateChanged event")
 	for {
@@ -2169,6 +2335,10 @@ func (destCCIP *DestCCIPModule) AssertNoExecutionStateChangedEventReceived(
 				return fmt.Errorf("ExecutionStateChanged Event detected at %s after %s", lastSeenTimestamp, eventFoundAfterCursing.String())
 			}
 		case <-ctx.Done():
+			// only the lapse of the time range validates the absence of the event
+			if parent.Err() != nil {
+				return fmt.Errorf("validation of no ExecutionStateChanged event cancelled: %w", parent.Err())
+			}
 			lggr.Info().Msgf("Successfully validated that no ExecutionStateChanged detected after %s for %s", lastSeenTimestamp, timeRange)
 			return nil
 		}
@@ -2176,6 +2346,7 @@ func (destCCIP *DestCCIPModule) AssertNoExecutionStateChangedEventReceived(
 }
 
 func (destCCIP *DestCCIPModule) AssertEventExecutionStateChanged(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	seqNum uint64,
 	timeout time.Duration,
@@ -2183,10 +2354,11 @@ func (destCCIP *DestCCIPModule) AssertEventExecutionStateChanged(
 	reqStat *testreporters.RequestStat,
 	execState testhelpers.MessageExecutionState,
 ) (uint8, error) {
+	timeout = destCCIP.Common.PhaseTimeout(timeout)
 	lggr.Info().Int64("seqNum", int64(seqNum)).Str("Timeout", timeout.String()).Msg("Waiting for ExecutionStateChanged event")
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	resetTimer := 0
 	for {
@@ -2201,7 +2373,7 @@ func (destCCIP *DestCCIPModule) AssertEventExecutionStateChanged(
 					destCCIP.ExecStateChangedWatcher.Delete(seqNum)
 					vLogs := e.Raw
 					receivedAt := time.Now().UTC()
-					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(context.Background(), big.NewInt(int64(vLogs.BlockNumber)))
+					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(ctx, big.NewInt(int64(vLogs.BlockNumber)))
 					if err == nil {
 						receivedAt = hdr.Timestamp
 					}
@@ -2218,18 +2390,39 @@ func (destCCIP *DestCCIPModule) AssertEventExecutionStateChanged(
 						reqStat.UpdateState(lggr, seqNum, testreporters.ExecStateChanged, receivedAt.Sub(timeNow),
 							testreporters.Success,
 							testreporters.TransactionStats{
-								TxHash:  vLogs.TxHash.Hex(),
-								MsgID:   fmt.Sprintf("0x%x", e.MessageId[:]),
-								GasUsed: gasUsed,
+								TxHash:      vLogs.TxHash.Hex(),
+								MsgID:       fmt.Sprintf("0x%x", e.MessageId[:]),
+								GasUsed:     gasUsed,
+								BlockNumber: vLogs.BlockNumber,
 							},
 						)
 						return e.State, nil
 					}
-					reqStat.UpdateState(lggr, seqNum, testreporters.ExecStateChanged, time.Since(timeNow), testreporters.Failure)
-					return e.State, fmt.Errorf("ExecutionStateChanged event state - expected %d actual - %d with data %x for seq num %v for lane %d-->%d",
-						execState, testhelpers.MessageExecutionState(e.State), e.ReturnData, seqNum, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID())
+					failure := DecodeExecFailure(e.ReturnData)
+					lggr.Info().
+						Int64("seqNum", int64(seqNum)).
+						Uint8("ExecutionState", e.State).
+						Str("Failure Category", string(failure.Category)).
+						Str("Failure Reason", failure.Reason).
+						Msg("ExecutionStateChanged event received with unexpected state")
+					reqStat.UpdateState(lggr, seqNum, testreporters.ExecStateChanged, time.Since(timeNow), testreporters.Failure,
+						testreporters.TransactionStats{
+							TxHash:          vLogs.TxHash.Hex(),
+							MsgID:           fmt.Sprintf("0x%x", e.MessageId[:]),
+							GasUsed:         gasUsed,
+							FailureReason:   failure.Reason,
+							FailureCategory: string(failure.Category),
+							BlockNumber:     vLogs.BlockNumber,
+						},
+					)
+					return e.State, fmt.Errorf("ExecutionStateChanged event state - expected %d actual - %d with reason %s(%s) for seq num %v for lane %d-->%d",
+						execState, testhelpers.MessageExecutionState(e.State), failure.Category, failure.Reason, seqNum, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID())
 				}
 			}
+		case <-ctx.Done():
+			reqStat.UpdateState(lggr, seqNum, testreporters.ExecStateChanged, time.Since(timeNow), testreporters.Failure)
+			return 0, fmt.Errorf("validation of ExecutionStateChanged event for seq num %d for lane %d-->%d cancelled: %w",
+				seqNum, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID(), ctx.Err())
 		case <-timer.C:
 			// if there is connection issue reset the context :
 			if destCCIP.Common.IsConnectionRestoredRecently != nil && !destCCIP.Common.IsConnectionRestoredRecently.Load() {
@@ -2252,17 +2445,19 @@ func (destCCIP *DestCCIPModule) AssertEventExecutionStateChanged(
 }
 
 func (destCCIP *DestCCIPModule) AssertEventReportAccepted(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	seqNum uint64,
 	timeout time.Duration,
 	prevEventAt time.Time,
 	reqStat *testreporters.RequestStat,
 ) (*contracts.CommitStoreReportAccepted, time.Time, error) {
+	timeout = destCCIP.Common.PhaseTimeout(timeout)
 	lggr.Info().Int64("seqNum", int64(seqNum)).Str("Timeout", timeout.String()).Msg("Waiting for ReportAccepted event")
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	resetTimerCount := 0
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	for {
 		select {
@@ -2274,7 +2469,7 @@ func (destCCIP *DestCCIPModule) AssertEventReportAccepted(
 					// if the value is processed, delete it from the map
 					destCCIP.ReportAcceptedWatcher.Delete(seqNum)
 					receivedAt := time.Now().UTC()
-					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(context.Background(), big.NewInt(int64(reportAccepted.Raw.BlockNumber)))
+					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(ctx, big.NewInt(int64(reportAccepted.Raw.BlockNumber)))
 					if err == nil {
 						receivedAt = hdr.Timestamp
 					}
@@ -2303,13 +2498,18 @@ func (destCCIP *DestCCIPModule) AssertEventReportAccepted(
 					}
 					reqStat.UpdateState(lggr, seqNum, testreporters.Commit, totalTime, testreporters.Success,
 						testreporters.TransactionStats{
-							GasUsed:    gasUsed,
-							TxHash:     reportAccepted.Raw.TxHash.String(),
-							CommitRoot: fmt.Sprintf("%x", reportAccepted.MerkleRoot),
+							GasUsed:     gasUsed,
+							TxHash:      reportAccepted.Raw.TxHash.String(),
+							CommitRoot:  fmt.Sprintf("%x", reportAccepted.MerkleRoot),
+							BlockNumber: reportAccepted.Raw.BlockNumber,
 						})
 					return reportAccepted, receivedAt, nil
 				}
 			}
+		case <-ctx.Done():
+			reqStat.UpdateState(lggr, seqNum, testreporters.Commit, time.Since(prevEventAt), testreporters.Failure)
+			return nil, time.Now().UTC(), fmt.Errorf("validation of ReportAccepted for seq num %d lane %d-->%d cancelled: %w",
+				seqNum, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID(), ctx.Err())
 		case <-timer.C:
 			// if there is connection issue reset the context :
 			if destCCIP.Common.IsConnectionRestoredRecently != nil && !destCCIP.Common.IsConnectionRestoredRecently.Load() {
@@ -2331,6 +2531,7 @@ func (destCCIP *DestCCIPModule) AssertEventReportAccepted(
 }
 
 func (destCCIP *DestCCIPModule) AssertReportBlessed(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	seqNum uint64,
 	timeout time.Duration,
@@ -2338,6 +2539,7 @@ func (destCCIP *DestCCIPModule) AssertReportBlessed(
 	prevEventAt time.Time,
 	reqStat *testreporters.RequestStat,
 ) (time.Time, error) {
+	timeout = destCCIP.Common.PhaseTimeout(timeout)
 	if destCCIP.Common.ARM == nil {
 		lggr.Info().
 			Uint64("commit store interval Min", CommitReport.Min).
@@ -2354,7 +2556,7 @@ func (destCCIP *DestCCIPModule) AssertReportBlessed(
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	resetTimerCount := 0
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	for {
 		select {
@@ -2383,7 +2585,7 @@ func (destCCIP *DestCCIPModule) AssertReportBlessed(
 						// if the value is processed, delete it from the map
 						destCCIP.ReportBlessedBySeqNum.Delete(seqNum)
 					}
-					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(context.Background(), big.NewInt(int64(vLogs.BlockNumber)))
+					hdr, err := destCCIP.Common.ChainClient.HeaderByNumber(ctx, big.NewInt(int64(vLogs.BlockNumber)))
 					if err == nil {
 						receivedAt = hdr.Timestamp
 					}
@@ -2397,13 +2599,18 @@ func (destCCIP *DestCCIPModule) AssertReportBlessed(
 					}
 					reqStat.UpdateState(lggr, seqNum, testreporters.ReportBlessed, receivedAt.Sub(prevEventAt), testreporters.Success,
 						testreporters.TransactionStats{
-							GasUsed:    gasUsed,
-							TxHash:     vLogs.TxHash.String(),
-							CommitRoot: fmt.Sprintf("%x", CommitReport.MerkleRoot),
+							GasUsed:     gasUsed,
+							TxHash:      vLogs.TxHash.String(),
+							CommitRoot:  fmt.Sprintf("%x", CommitReport.MerkleRoot),
+							BlockNumber: vLogs.BlockNumber,
 						})
 					return receivedAt, nil
 				}
 			}
+		case <-ctx.Done():
+			reqStat.UpdateState(lggr, seqNum, testreporters.ReportBlessed, time.Since(prevEventAt), testreporters.Failure)
+			return time.Now().UTC(), fmt.Errorf("validation of ReportBlessed for interval min - %d max - %d lane %d-->%d cancelled: %w",
+				CommitReport.Min, CommitReport.Max, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID(), ctx.Err())
 		case <-timer.C:
 			// if there is connection issue reset the context :
 			if destCCIP.Common.IsConnectionRestoredRecently != nil && !destCCIP.Common.IsConnectionRestoredRecently.Load() {
@@ -2425,17 +2632,19 @@ func (destCCIP *DestCCIPModule) AssertReportBlessed(
 }
 
 func (destCCIP *DestCCIPModule) AssertSeqNumberExecuted(
+	ctx context.Context,
 	lggr zerolog.Logger,
 	seqNumberBefore uint64,
 	timeout time.Duration,
 	timeNow time.Time,
 	reqStat *testreporters.RequestStat,
 ) error {
+	timeout = destCCIP.Common.PhaseTimeout(timeout)
 	lggr.Info().Int64("seqNum", int64(seqNumberBefore)).Str("Timeout", timeout.String()).Msg("Waiting to be processed by commit store")
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	resetTimerCount := 0
-	ticker := time.NewTicker(time.Second)
+	ticker := time.NewTicker(destCCIP.Common.pollingInterval())
 	defer ticker.Stop()
 	for {
 		select {
@@ -2443,7 +2652,7 @@ func (destCCIP *DestCCIPModule) AssertSeqNumberExecuted(
 			if destCCIP.NextSeqNumToCommit.Load() > seqNumberBefore {
 				return nil
 			}
-			seqNumberAfter, err := destCCIP.CommitStore.Instance.GetExpectedNextSequenceNumber(nil)
+			seqNumberAfter, err := destCCIP.CommitStore.Instance.GetExpectedNextSequenceNumber(&bind.CallOpts{Context: ctx})
 			if err != nil {
 				// if we get error instead of returning error we continue, in case it's a temporary RPC failure .
 				continue
@@ -2452,6 +2661,10 @@ func (destCCIP *DestCCIPModule) AssertSeqNumberExecuted(
 				destCCIP.NextSeqNumToCommit.Store(seqNumberAfter)
 				return nil
 			}
+		case <-ctx.Done():
+			reqStat.UpdateState(lggr, seqNumberBefore, testreporters.Commit, time.Since(timeNow), testreporters.Failure)
+			return fmt.Errorf("validation of sequence number increase for seq num %d lane %d-->%d cancelled: %w",
+				seqNumberBefore, destCCIP.SourceChainId, destCCIP.Common.ChainClient.GetChainID(), ctx.Err())
 		case <-timer.C:
 			// if there is connection issue reset the context :
 			if destCCIP.Common.IsConnectionRestoredRecently != nil && !destCCIP.Common.IsConnectionRestoredRecently.Load() {
@@ -2549,6 +2762,34 @@ type CCIPLane struct {
 	Context           context.Context
 	SrcNetworkLaneCfg *laneconfig.LaneConfig
 	DstNetworkLaneCfg *laneconfig.LaneConfig
+	EventSource       LaneEventSource             // source of the events recorded by the event watchers; if nil, the events are watched on the lane contracts
+	MockDON           *MockDON                    // commits and executes the requests in place of the CL nodes if the lane is set up in mock DON mode
+	PhaseNotifier     testreporters.PhaseNotifier // notified of the phase transitions of the requests sent on the lane, if set
+	AdaptiveTimeout   *AdaptiveTimeout            // if set, the phase timeouts are adapted to the latencies observed on the lane
+	supervisor        *Supervisor
+	supervisorOnce    sync.Once
+}
+
+// Routines returns the supervisor of the background routines of the lane, created with the lane context on first use.
+// All the routines the lane starts are run with it, so that they are stopped in CleanUp.
+func (lane *CCIPLane) Routines() *Supervisor {
+	lane.supervisorOnce.Do(func() {
+		ctx := lane.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		lane.supervisor = NewSupervisor(ctx, lane.Logger)
+	})
+	return lane.supervisor
+}
+
+// NewRequestStat returns the stat of a request sent on the lane, notifying the phase notifier of the lane if any
+func (lane *CCIPLane) NewRequestStat(reqNo int64) *testreporters.RequestStat {
+	stat := testreporters.NewCCIPRequestStats(reqNo, lane.SourceNetworkName, lane.DestNetworkName)
+	if lane.PhaseNotifier != nil {
+		stat.SetNotifier(lane.PhaseNotifier)
+	}
+	return stat
 }
 
 func (lane *CCIPLane) TokenPricesConfig() (string, error) {
@@ -2564,7 +2805,11 @@ func (lane *CCIPLane) TokenPricesConfig() (string, error) {
 			return "", fmt.Errorf("error in adding PriceConfig for dest bridge token %s: %w", token.Address(), err)
 		}
 	}
-	err := d.AddPriceConfig(lane.Dest.Common.FeeToken.Address(), lane.Dest.Common.PriceAggregators, LinkToUSD, lane.DestChain.GetChainID().Uint64())
+	feeTokenPrice, err := lane.Dest.Common.FeeTokenUsdPrice()
+	if err != nil {
+		return "", fmt.Errorf("error getting price of dest Fee token %s: %w", lane.Dest.Common.FeeToken.Address(), err)
+	}
+	err = d.AddPriceConfig(lane.Dest.Common.FeeToken.Address(), lane.Dest.Common.PriceAggregators, feeTokenPrice, lane.DestChain.GetChainID().Uint64())
 	if err != nil {
 		return "", fmt.Errorf("error adding PriceConfig for dest Fee token %s: %w", lane.Dest.Common.FeeToken.Address(), err)
 	}
@@ -2638,13 +2883,42 @@ func (lane *CCIPLane) UpdateLaneConfig() {
 	lane.DstNetworkLaneCfg.DestContractsMu.Unlock()
 }
 
+// LaneBlocks are the block numbers of the source and the dest chain of a lane
+type LaneBlocks struct {
+	Source uint64
+	Dest   uint64
+}
+
+// LatestBlocks returns the latest blocks of the source and the dest chain. Taken before a downtime of the nodes,
+// these are the last known good blocks to replay the logs from once the nodes are back up.
+func (lane *CCIPLane) LatestBlocks() (LaneBlocks, error) {
+	source, err := lane.SourceChain.LatestBlockNumber(context.Background())
+	if err != nil {
+		return LaneBlocks{}, fmt.Errorf("failed to get latest block of %s: %w", lane.SourceNetworkName, err)
+	}
+	dest, err := lane.DestChain.LatestBlockNumber(context.Background())
+	if err != nil {
+		return LaneBlocks{}, fmt.Errorf("failed to get latest block of %s: %w", lane.DestNetworkName, err)
+	}
+	return LaneBlocks{Source: source, Dest: dest}, nil
+}
+
+// ReplayFromBlocks requests every CL node of env to replay the logs of the source and the dest chain of the lane
+// from blocks, so that the requests sent while the nodes were down are picked up.
+func (lane *CCIPLane) ReplayFromBlocks(env *CCIPTestEnv, blocks LaneBlocks) error {
+	if err := env.ReplayFromBlock(lane.Logger, lane.SourceChain, blocks.Source); err != nil {
+		return err
+	}
+	return env.ReplayFromBlock(lane.Logger, lane.DestChain, blocks.Dest)
+}
+
 func (lane *CCIPLane) RecordStateBeforeTransfer() {
 	// collect the balance assert.ment to verify balances after transfer
-	bal, err := testhelpers.GetBalances(lane.Test, lane.Source.CollectBalanceRequirements())
+	bal, err := ReadBalances(lane.Test, lane.Source.balanceItems())
 	require.NoError(lane.Test, err, "fetching source balance")
 	lane.Balance.RecordBalance(bal)
 
-	bal, err = testhelpers.GetBalances(lane.Test, lane.Dest.CollectBalanceRequirements())
+	bal, err = ReadBalances(lane.Test, lane.Dest.balanceItems())
 	require.NoError(lane.Test, err, "fetching dest balance")
 	lane.Balance.RecordBalance(bal)
 
@@ -2725,7 +2999,7 @@ func (lane *CCIPLane) Multicall(noOfRequests int, multiSendAddr common.Address)
 				return err
 			}
 		}
-		stat := testreporters.NewCCIPRequestStats(int64(lane.NumberOfReq+i), lane.SourceNetworkName, lane.DestNetworkName)
+		stat := lane.NewRequestStat(int64(lane.NumberOfReq + i))
 		txstats = append(txstats, testreporters.TransactionStats{
 			Fee:                fee.String(),
 			NoOfTokensSent:     len(msg.TokenAmounts),
@@ -2751,18 +3025,25 @@ func (lane *CCIPLane) Multicall(noOfRequests int, multiSendAddr common.Address)
 		}
 		return fmt.Errorf("failed to send the multicall: %w", err)
 	}
+	var sentMsgs []router.ClientEVM2AnyMessage
+	for _, sendData := range ccipMultipleMsg {
+		sentMsgs = append(sentMsgs, sendData.Msg)
+	}
+	lane.Source.RecordSentMsgs(tx.Hash(), sentMsgs...)
 	rcpt, err := lane.AddToSentReqs(tx.Hash(), reqStats)
 	if err != nil {
 		return err
 	}
-	var gasUsed uint64
+	var gasUsed, blockNum uint64
 	if rcpt != nil {
 		gasUsed = rcpt.GasUsed
+		blockNum = rcpt.BlockNumber.Uint64()
 	}
 	// update the stats for all the requests in the multicall tx
 	for i, stat := range reqStats {
 		txstats[i].GasUsed = gasUsed
 		txstats[i].TxHash = tx.Hash().Hex()
+		txstats[i].BlockNumber = blockNum
 		stat.UpdateState(lane.Logger, 0, testreporters.TX, 0, testreporters.Success, txstats[i])
 	}
 	return nil
@@ -2772,7 +3053,7 @@ func (lane *CCIPLane) Multicall(noOfRequests int, multiSendAddr common.Address)
 // It will create noOfRequests transactions
 func (lane *CCIPLane) SendRequests(noOfRequests int, gasLimit *big.Int) error {
 	for i := 1; i <= noOfRequests; i++ {
-		stat := testreporters.NewCCIPRequestStats(int64(lane.NumberOfReq+i), lane.SourceNetworkName, lane.DestNetworkName)
+		stat := lane.NewRequestStat(int64(lane.NumberOfReq + i))
 		txHash, txConfirmationDur, fee, err := lane.Source.SendRequest(
 			lane.Dest.ReceiverDapp.EthAddress,
 			gasLimit,
@@ -2808,6 +3089,7 @@ func (lane *CCIPLane) SendRequests(noOfRequests int, gasLimit *big.Int) error {
 				TxHash:             rcpt.TxHash.Hex(),
 				NoOfTokensSent:     noOfTokens,
 				MessageBytesLength: lane.Source.MsgDataLength,
+				BlockNumber:        rcpt.BlockNumber.Uint64(),
 			})
 		lane.TotalFee = bigmath.Add(lane.TotalFee, fee)
 	}
@@ -2815,9 +3097,131 @@ func (lane *CCIPLane) SendRequests(noOfRequests int, gasLimit *big.Int) error {
 	return nil
 }
 
+// SendRequestExpectingRevert sends a ccip-send request which is expected to be reverted on source chain with the error
+// expectedErr, decoded against the provided contract abi. As the request never reaches the OnRamp it is not added to
+// the SentReqs, instead it is recorded in the lane report as failed at TX phase along with the revert reason.
+func (lane *CCIPLane) SendRequestExpectingRevert(gasLimit *big.Int, expectedErr string, contractABI string) error {
+	stat := lane.NewRequestStat(int64(lane.NumberOfReq + 1))
+	defer lane.Reports.UpdatePhaseStatsForReq(stat)
+	txHash, txConfirmationDur, _, err := lane.Source.SendRequest(
+		lane.Dest.ReceiverDapp.EthAddress,
+		gasLimit,
+	)
+	if err != nil {
+		stat.UpdateState(lane.Logger, 0, testreporters.TX, txConfirmationDur, testreporters.Failure)
+		return fmt.Errorf("could not send request: %w", err)
+	}
+	if err = lane.Source.Common.ChainClient.WaitForEvents(); err == nil {
+		stat.UpdateState(lane.Logger, 0, testreporters.TX, txConfirmationDur, testreporters.Failure, testreporters.TransactionStats{
+			TxHash: txHash.Hex(),
+		})
+		return fmt.Errorf("expected request %s to revert with %s, but it succeeded", txHash.Hex(), expectedErr)
+	}
+	errReason, v, err := lane.Source.Common.ChainClient.RevertReasonFromTx(txHash, contractABI)
+	if err != nil {
+		return fmt.Errorf("could not get revert reason for tx %s: %w", txHash.Hex(), err)
+	}
+	lane.Logger.Info().
+		Str("Revert Reason", errReason).
+		Interface("Args", v).
+		Str("FailedTx", txHash.Hex()).
+		Msg("Request reverted on source")
+	stat.UpdateState(lane.Logger, 0, testreporters.TX, txConfirmationDur, testreporters.Failure, testreporters.TransactionStats{
+		TxHash:          txHash.Hex(),
+		FailureReason:   errReason,
+		FailureCategory: "SourceRevert",
+	})
+	if errReason != expectedErr {
+		return fmt.Errorf("expected request %s to revert with %s, got %s", txHash.Hex(), expectedErr, errReason)
+	}
+	return nil
+}
+
+// SendRequestToUnsupportedDestination validates that both the fee quote and the ccip-send of the lane msg revert with
+// UnsupportedDestinationChain on the source router. It expects the onRamp for the lane destination to be unset in the
+// router, e.g. before a new destination is onboarded or after it's disabled with Router.SetOnRamp to zero address.
+func (lane *CCIPLane) SendRequestToUnsupportedDestination(gasLimit *big.Int) error {
+	const expectedErr = "UnsupportedDestinationChain"
+	src := lane.Source
+	msg, err := src.CCIPMsg(lane.Dest.ReceiverDapp.EthAddress, gasLimit)
+	if err != nil {
+		return fmt.Errorf("failed forming the ccip msg: %w", err)
+	}
+	_, err = src.Common.Router.GetFee(src.DestChainSelector, msg)
+	if err == nil {
+		return fmt.Errorf("expected fee quote for unsupported destination %d to revert, but it succeeded", src.DestChainSelector)
+	}
+	errReason, err := revertErrorFromCall(err, router.RouterABI)
+	if err != nil {
+		return fmt.Errorf("could not get revert reason for fee quote: %w", err)
+	}
+	if errReason != expectedErr {
+		return fmt.Errorf("expected fee quote to revert with %s, got %s", expectedErr, errReason)
+	}
+
+	// the router reverts before checking the fee, so the msg is sent without any fee
+	sendTx, err := src.Common.Router.CCIPSendAndProcessTx(src.DestChainSelector, msg, nil)
+	if sendTx == nil {
+		return fmt.Errorf("could not send request: %w", err)
+	}
+	if err == nil {
+		err = src.Common.ChainClient.WaitForEvents()
+	}
+	if err == nil {
+		return fmt.Errorf("expected request %s to unsupported destination %d to revert, but it succeeded", sendTx.Hash().Hex(), src.DestChainSelector)
+	}
+	errReason, _, err = src.Common.ChainClient.RevertReasonFromTx(sendTx.Hash(), router.RouterABI)
+	if err != nil {
+		return fmt.Errorf("could not get revert reason for tx %s: %w", sendTx.Hash().Hex(), err)
+	}
+	if errReason != expectedErr {
+		return fmt.Errorf("expected request %s to revert with %s, got %s", sendTx.Hash().Hex(), expectedErr, errReason)
+	}
+	lane.Logger.Info().
+		Str("Revert Reason", errReason).
+		Str("FailedTx", sendTx.Hash().Hex()).
+		Uint64("Dest Chain Selector", src.DestChainSelector).
+		Msg("Fee quote and request to unsupported destination reverted on source")
+	return nil
+}
+
+// revertErrorFromCall returns the name of the custom error from contractABI which the failed eth_call reverted with
+func revertErrorFromCall(callErr error, contractABI string) (string, error) {
+	var dataErr rpc.DataError
+	if !errors.As(callErr, &dataErr) {
+		return "", fmt.Errorf("no revert data in error: %w", callErr)
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected revert data %v in error: %w", dataErr.ErrorData(), callErr)
+	}
+	// some nodes prepend "Reverted " to the revert data
+	data, err := hexutil.Decode(strings.TrimPrefix(hexData, "Reverted "))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode revert data %s: %w", hexData, err)
+	}
+	if len(data) < 4 {
+		return "", fmt.Errorf("revert data %s is too short", hexData)
+	}
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse abi: %w", err)
+	}
+	for name, abiErr := range parsed.Errors {
+		if bytes.Equal(data[:4], abiErr.ID.Bytes()[:4]) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("revert data %s does not match any error in the abi", hexData)
+}
+
+// DefaultManualExecGasLimit is the gas limit used to manually execute a message if no override is set for the message
+var DefaultManualExecGasLimit = big.NewInt(600_000)
+
 // manualExecutionOpts modify how ExecuteManually behaves
 type manualExecutionOpts struct {
-	timeout time.Duration
+	timeout           time.Duration
+	gasLimitOverrides map[[32]byte]*big.Int // key - message id; value - gas limit for manual execution of the message
 }
 
 // ManualExecutionOption is a function that modifies ExecuteManually behavior
@@ -2830,6 +3234,15 @@ func WithConfirmationTimeout(timeout time.Duration) ManualExecutionOption {
 	}
 }
 
+// WithGasLimitOverrides sets the gas limit for manual execution of the messages by message id.
+// This is useful to re-execute messages which failed due to low gas limit with a corrected gas limit.
+// Messages which are not in gasLimits are executed with DefaultManualExecGasLimit.
+func WithGasLimitOverrides(gasLimits map[[32]byte]*big.Int) ManualExecutionOption {
+	return func(opts *manualExecutionOpts) {
+		opts.gasLimitOverrides = gasLimits
+	}
+}
+
 // ExecuteManually attempts to execute pending CCIP transactions manually.
 // This is necessary in situations where Smart Execution window for that message is over and Offchain plugin
 // will not attempt to execute the message.In such situation any further message from same sender will not be executed until
@@ -2872,15 +3285,19 @@ func (lane *CCIPLane) ExecuteManually(options ...ManualExecutionOption) error {
 				return err
 			}
 			var logIndex uint
+			gasLimit := DefaultManualExecGasLimit
 			// find the send request log index sendReqReceipt
 			for _, sendReqLog := range sendReqReceipt.Logs {
 				if sendReqLog.Topics[0] == sendReqTopic {
-					logSeqNum, err := lane.Source.OnRamp.Instance.ParseCCIPSendRequested(*sendReqLog)
+					sendReq, err := lane.Source.OnRamp.Instance.ParseCCIPSendRequested(*sendReqLog)
 					if err != nil {
 						return err
 					}
-					if logSeqNum == seqNum {
+					if sendReq.SequenceNumber == seqNum {
 						logIndex = sendReqLog.Index
+						if override, ok := opts.gasLimitOverrides[sendReq.MessageId]; ok && override != nil {
+							gasLimit = override
+						}
 					}
 				}
 			}
@@ -2910,15 +3327,16 @@ func (lane *CCIPLane) ExecuteManually(options ...ManualExecutionOption) error {
 				OnRamp:           lane.Source.OnRamp.Address(),
 				OffRamp:          lane.Dest.OffRamp.Address(),
 				SendReqLogIndex:  logIndex,
-				GasLimit:         big.NewInt(600_000),
+				GasLimit:         gasLimit,
 			}
+			lane.Logger.Info().Uint64("seqNum", seqNum).Str("GasLimit", gasLimit.String()).Msg("Executing manually")
 			timeNow := time.Now().UTC()
 			tx, err := args.ExecuteManually()
 			if err != nil {
 				return fmt.Errorf("could not execute manually: %w seqNum %d", err, seqNum)
 			}
 
-			ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+			ctx, cancel := context.WithTimeout(lane.Context, opts.timeout)
 			rec, err := bind.WaitMined(ctx, lane.DestChain.DeployBackend(), tx)
 			if err != nil {
 				cancel()
@@ -2932,7 +3350,7 @@ func (lane *CCIPLane) ExecuteManually(options ...ManualExecutionOption) error {
 				)
 			}
 			lane.Logger.Info().Uint64("seqNum", seqNum).Msg("Manual Execution completed")
-			_, err = lane.Dest.AssertEventExecutionStateChanged(lane.Logger, seqNum, opts.timeout,
+			_, err = lane.Dest.AssertEventExecutionStateChanged(lane.Context, lane.Logger, seqNum, opts.timeout,
 				timeNow, ccipReq.RequestStat, testhelpers.ExecutionStateSuccess,
 			)
 			if err != nil {
@@ -3038,7 +3456,6 @@ func (lane *CCIPLane) ValidateRequests(validationOptionFuncs ...ValidationOption
 func (lane *CCIPLane) ValidateRequestByTxHash(txHash common.Hash, opts validationOptions) error {
 	var (
 		reqStats       []*testreporters.RequestStat
-		timeout        = lane.ValidationTimeout
 		ccipRequests   = lane.SentReqs[txHash]
 		txConfirmation = ccipRequests[0].txConfirmationTimestamp
 	)
@@ -3053,17 +3470,22 @@ func (lane *CCIPLane) ValidateRequestByTxHash(txHash common.Hash, opts validatio
 		reqStats = append(reqStats, req.RequestStat)
 	}
 
-	if opts.phaseExpectedToFail == testreporters.CCIPSendRe && opts.timeout != 0 {
-		timeout = opts.timeout
+	// the timeout of the phase expected to fail is set by the validation options, the others are the lane timeouts
+	timeoutFor := func(phase testreporters.Phase) time.Duration {
+		if opts.phaseExpectedToFail == phase && opts.timeout != 0 {
+			return opts.timeout
+		}
+		return lane.phaseTimeout(phase)
 	}
 	msgLogs, ccipSendReqGenAt, err := lane.Source.AssertEventCCIPSendRequested(
-		lane.Logger, txHash.Hex(), timeout, txConfirmation, reqStats,
+		lane.Context, lane.Logger, txHash.Hex(), timeoutFor(testreporters.CCIPSendRe), txConfirmation, reqStats,
 	)
 	if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.CCIPSendRe, opts, err); shouldReturn {
 		return phaseErr
 	}
+	lane.observePhase(reqStats[0], testreporters.CCIPSendRe)
 
-	sourceLogFinalizedAt, _, err := lane.Source.AssertSendRequestedLogFinalized(lane.Logger, txHash, ccipSendReqGenAt, reqStats)
+	sourceLogFinalizedAt, _, err := lane.Source.AssertSendRequestedLogFinalized(lane.Context, lane.Logger, txHash, ccipSendReqGenAt, reqStats)
 	if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.SourceLogFinalized, opts, err); shouldReturn {
 		return phaseErr
 	}
@@ -3080,37 +3502,33 @@ func (lane *CCIPLane) ValidateRequestByTxHash(txHash common.Hash, opts validatio
 			return fmt.Errorf("could not find request stat for seq number %d", seqNumber)
 		}
 
-		if opts.phaseExpectedToFail == testreporters.Commit && opts.timeout != 0 {
-			timeout = opts.timeout
-		}
-		err = lane.Dest.AssertSeqNumberExecuted(lane.Logger, seqNumber, timeout, sourceLogFinalizedAt, reqStat)
+		timeout := timeoutFor(testreporters.Commit)
+		err = lane.Dest.AssertSeqNumberExecuted(lane.Context, lane.Logger, seqNumber, timeout, sourceLogFinalizedAt, reqStat)
 		if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.Commit, opts, err); shouldReturn {
 			return phaseErr
 		}
 
 		// Verify whether commitStore has accepted the report
 		commitReport, reportAcceptedAt, err := lane.Dest.AssertEventReportAccepted(
-			lane.Logger, seqNumber, timeout, sourceLogFinalizedAt, reqStat,
+			lane.Context, lane.Logger, seqNumber, timeout, sourceLogFinalizedAt, reqStat,
 		)
 		if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.Commit, opts, err); shouldReturn {
 			return phaseErr
 		}
+		lane.observePhase(reqStat, testreporters.Commit)
 
-		if opts.phaseExpectedToFail == testreporters.ReportBlessed && opts.timeout != 0 {
-			timeout = opts.timeout
-		}
-		reportBlessedAt, err := lane.Dest.AssertReportBlessed(lane.Logger, seqNumber, timeout, *commitReport, reportAcceptedAt, reqStat)
+		reportBlessedAt, err := lane.Dest.AssertReportBlessed(
+			lane.Context, lane.Logger, seqNumber, timeoutFor(testreporters.ReportBlessed), *commitReport, reportAcceptedAt, reqStat,
+		)
 		if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.ReportBlessed, opts, err); shouldReturn {
 			return phaseErr
 		}
+		lane.observePhase(reqStat, testreporters.ReportBlessed)
 
-		if opts.phaseExpectedToFail == testreporters.ExecStateChanged && opts.timeout != 0 {
-			timeout = opts.timeout
-		}
 		// Verify whether the execution state is changed and the transfer is successful
 		_, err = lane.Dest.AssertEventExecutionStateChanged(
-			lane.Logger, seqNumber,
-			timeout,
+			lane.Context, lane.Logger, seqNumber,
+			timeoutFor(testreporters.ExecStateChanged),
 			reportBlessedAt,
 			reqStat,
 			testhelpers.ExecutionStateSuccess,
@@ -3118,6 +3536,7 @@ func (lane *CCIPLane) ValidateRequestByTxHash(txHash common.Hash, opts validatio
 		if shouldReturn, phaseErr := isPhaseValid(lane.Logger, testreporters.ExecStateChanged, opts, err); shouldReturn {
 			return phaseErr
 		}
+		lane.observePhase(reqStat, testreporters.ExecStateChanged)
 	}
 	return nil
 }
@@ -3214,6 +3633,12 @@ func (lane *CCIPLane) DisableAllRateLimiting() error {
 
 func (lane *CCIPLane) StartEventWatchers() error {
 	lane.Logger.Info().Msg("Starting event watchers")
+	// the events are only verified against the deployed contracts, injected event sources don't have any
+	if lane.EventSource == nil {
+		if err := lane.VerifyEventABIs(); err != nil {
+			return err
+		}
+	}
 	if lane.Source.Common.ChainClient.GetNetworkConfig().FinalityDepth == 0 {
 		err := lane.Source.Common.ChainClient.PollFinality()
 		if err != nil {
@@ -3221,151 +3646,93 @@ func (lane *CCIPLane) StartEventWatchers() error {
 		}
 	}
 
-	go lane.Source.Common.PollRPCConnection(lane.Context, lane.Logger)
-	go lane.Dest.Common.PollRPCConnection(lane.Context, lane.Logger)
-
-	sendReqEventLatest := make(chan *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested)
-	senReqSub := event.Resubscribe(3*time.Hour, func(_ context.Context) (event.Subscription, error) {
-		sub, err := lane.Source.OnRamp.WatchCCIPSendRequested(nil, sendReqEventLatest)
-		if err != nil {
-			log.Error().Err(err).Msg("error in subscribing to CCIPSendRequested event")
-		}
-		return sub, err
+	lane.Routines().Go("source RPC connection poller", DefaultRestartPolicy, func(ctx context.Context) error {
+		lane.Source.Common.PollRPCConnection(ctx, lane.Logger)
+		return nil
+	})
+	lane.Routines().Go("dest RPC connection poller", DefaultRestartPolicy, func(ctx context.Context) error {
+		lane.Dest.Common.PollRPCConnection(ctx, lane.Logger)
+		return nil
 	})
-	if senReqSub == nil {
-		return fmt.Errorf("failed to subscribe to CCIPSendRequested event")
-	}
-	go func(sub event.Subscription) {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case e := <-sendReqEventLatest:
-				lane.Logger.Info().Msgf("CCIPSendRequested event received for seq number %d", e.Message.SequenceNumber)
-				eventsForTx, ok := lane.Source.CCIPSendRequestedWatcher.Load(e.Raw.TxHash.Hex())
-				if ok {
-					lane.Source.CCIPSendRequestedWatcher.Store(e.Raw.TxHash.Hex(), append(eventsForTx.([]*contracts.SendReqEventData),
-						&contracts.SendReqEventData{
-							MessageId:      e.Message.MessageId,
-							SequenceNumber: e.Message.SequenceNumber,
-							DataLength:     len(e.Message.Data),
-							NoOfTokens:     len(e.Message.TokenAmounts),
-							Raw:            e.Raw,
-						}))
-				} else {
-					lane.Source.CCIPSendRequestedWatcher.Store(e.Raw.TxHash.Hex(), []*contracts.SendReqEventData{
-						{
-							MessageId:      e.Message.MessageId,
-							SequenceNumber: e.Message.SequenceNumber,
-							DataLength:     len(e.Message.Data),
-							NoOfTokens:     len(e.Message.TokenAmounts),
-							Raw:            e.Raw,
-						},
-					})
-				}
 
-				lane.Source.CCIPSendRequestedWatcher = testutils.DeleteNilEntriesFromMap(lane.Source.CCIPSendRequestedWatcher)
-			case <-lane.Context.Done():
-				return
-			}
-		}
-	}(senReqSub)
+	return lane.startEventWatchers(lane.eventSource(), DefaultEventResubscribeBackoff)
+}
 
-	reportAcceptedEvent := make(chan *commit_store.CommitStoreReportAccepted)
-	reportAccSub := event.Resubscribe(3*time.Hour, func(_ context.Context) (event.Subscription, error) {
-		sub, err := lane.Dest.CommitStore.WatchReportAccepted(nil, reportAcceptedEvent)
-		if err != nil {
-			log.Error().Err(err).Msg("error in subscribing to ReportAccepted event")
-		}
-		return sub, err
-	})
-	if reportAccSub == nil {
-		return fmt.Errorf("failed to subscribe to ReportAccepted event")
+// NewLaneReader returns a read-only reader of the lane state which queries the chains directly instead of the event watchers
+func (lane *CCIPLane) NewLaneReader() (*contracts.LaneReader, error) {
+	return contracts.NewLaneReader(
+		wrappers.MustNewWrappedContractBackend(lane.Source.Common.ChainClient, nil),
+		wrappers.MustNewWrappedContractBackend(lane.Dest.Common.ChainClient, nil),
+		lane.Source.OnRamp.EthAddress,
+		lane.Dest.CommitStore.EthAddress,
+		lane.Dest.OffRamp.EthAddress,
+	)
+}
+
+// execBackfillAttempts is the number of times an interrupted backfill of the ExecutionStateChanged events is resumed
+const execBackfillAttempts = 3
+
+// VerifyExecuted verifies post-run that every request recorded in the lane report with a sequence number is executed
+// successfully, backfilling the ExecutionStateChanged events from the cursor till the latest dest block instead of
+// relying on the event watchers. The cursor can be shared with the other components querying the dest logs, e.g.
+// NewRangeCursor(blocks.Dest) for the LaneBlocks the nodes replayed from, and is left where the backfill stopped.
+func (lane *CCIPLane) VerifyExecuted(ctx context.Context, cursor *contracts.RangeCursor) error {
+	reader, err := lane.NewLaneReader()
+	if err != nil {
+		return err
 	}
-	go func(sub event.Subscription) {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case e := <-reportAcceptedEvent:
-				lane.Logger.Info().Interface("Interval", e.Report.Interval).Msgf("ReportAccepted event received")
-				for i := e.Report.Interval.Min; i <= e.Report.Interval.Max; i++ {
-					lane.Dest.ReportAcceptedWatcher.Store(i, &contracts.CommitStoreReportAccepted{
-						Min:        e.Report.Interval.Min,
-						Max:        e.Report.Interval.Max,
-						MerkleRoot: e.Report.MerkleRoot,
-						Raw:        e.Raw,
-					})
-				}
-				lane.Dest.ReportAcceptedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ReportAcceptedWatcher)
-			case <-lane.Context.Done():
-				return
-			}
+	var seqNums []uint64
+	for _, stat := range lane.Reports.RequestStats() {
+		if stat.SeqNum != 0 {
+			seqNums = append(seqNums, stat.SeqNum)
 		}
-	}(reportAccSub)
-
-	if lane.Dest.Common.ARM != nil {
-		reportBlessedEvent := make(chan *arm_contract.ARMContractTaggedRootBlessed)
-		blessedSub := event.Resubscribe(3*time.Hour, func(_ context.Context) (event.Subscription, error) {
-			sub, err := lane.Dest.Common.ARM.Instance.WatchTaggedRootBlessed(nil, reportBlessedEvent, nil)
-			if err != nil {
-				log.Error().Err(err).Msg("error in subscribing to TaggedRootBlessed event")
-			}
-			return sub, err
-		})
-		if blessedSub == nil {
-			return fmt.Errorf("failed to subscribe to TaggedRootBlessed event")
-		}
-		go func(sub event.Subscription) {
-			defer sub.Unsubscribe()
-			for {
-				select {
-				case e := <-reportBlessedEvent:
-					lane.Logger.Info().Msgf("TaggedRootBlessed event received for root %x", e.TaggedRoot.Root)
-					if e.TaggedRoot.CommitStore == lane.Dest.CommitStore.EthAddress {
-						lane.Dest.ReportBlessedWatcher.Store(e.TaggedRoot.Root, &e.Raw)
-					}
-					lane.Dest.ReportBlessedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ReportBlessedWatcher)
-				case <-lane.Context.Done():
-					return
-				}
-			}
-		}(blessedSub)
 	}
-
-	execStateChangedEventLatest := make(chan *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged)
-	execSub := event.Resubscribe(3*time.Hour, func(_ context.Context) (event.Subscription, error) {
-		sub, err := lane.Dest.OffRamp.WatchExecutionStateChanged(nil, execStateChangedEventLatest, nil, nil)
-		if err != nil {
-			log.Error().Err(err).Msg("error in subscribing to ExecutionStateChanged event")
+	latest, err := lane.DestChain.LatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block of %s: %w", lane.DestNetworkName, err)
+	}
+	executed := make(map[uint64]*contracts.EVM2EVMOffRampExecutionStateChanged)
+	for attempt := 1; ; attempt++ {
+		events, err := reader.BackfillExecutionStates(ctx, seqNums, cursor, latest)
+		// the ranges of a resumed backfill are later than the ones already queried, so are their events
+		for seqNum, event := range events {
+			executed[seqNum] = event
 		}
-		return sub, err
-	})
-	if execSub == nil {
-		return fmt.Errorf("failed to subscribe to ExecutionStateChanged event")
+		if err == nil {
+			break
+		}
+		if attempt == execBackfillAttempts || ctx.Err() != nil {
+			return err
+		}
+		lane.Logger.Warn().Err(err).Uint64("Resume From Block", cursor.Next()).Msg("Resuming backfill of execution states")
 	}
-	go func(sub event.Subscription) {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case e := <-execStateChangedEventLatest:
-				lane.Logger.Info().Msgf("Execution state changed event received for seq number %d", e.SequenceNumber)
-				lane.Dest.ExecStateChangedWatcher.Store(e.SequenceNumber, &contracts.EVM2EVMOffRampExecutionStateChanged{
-					SequenceNumber: e.SequenceNumber,
-					MessageId:      e.MessageId,
-					State:          e.State,
-					ReturnData:     e.ReturnData,
-					Raw:            e.Raw,
-				})
-				lane.Dest.ExecStateChangedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ExecStateChangedWatcher)
-			case <-lane.Context.Done():
-				return
-			}
+	var notExecuted, failed []uint64
+	for _, seqNum := range seqNums {
+		event, ok := executed[seqNum]
+		switch {
+		case !ok:
+			notExecuted = append(notExecuted, seqNum)
+		case event.State != uint8(testhelpers.ExecutionStateSuccess):
+			failed = append(failed, seqNum)
 		}
-	}(execSub)
+	}
+	lane.Logger.Info().
+		Int("Requests", len(seqNums)).
+		Int("Not Executed", len(notExecuted)).
+		Int("Failed", len(failed)).
+		Uint64("To Block", latest).
+		Msg("Verified execution states")
+	if len(notExecuted) > 0 || len(failed) > 0 {
+		return fmt.Errorf("requests with seq nums %v are not executed and %v failed to execute on %s",
+			notExecuted, failed, lane.DestNetworkName)
+	}
 	return nil
 }
 
 func (lane *CCIPLane) CleanUp(clearFees bool) error {
 	lane.Logger.Info().Msg("Cleaning up lane")
+	// the background routines are stopped before the clients they use are closed
+	routinesErr := lane.Routines().Stop(DefaultSupervisorStopTimeout)
 	if lane.Source.Common.ChainClient.GetNetworkConfig().FinalityDepth == 0 {
 		lane.Source.Common.ChainClient.CancelFinalityPolling()
 	}
@@ -3373,14 +3740,14 @@ func (lane *CCIPLane) CleanUp(clearFees bool) error {
 	if clearFees && !lane.Source.Common.ChainClient.NetworkSimulated() {
 		err := lane.Source.PayCCIPFeeToOwnerAddress()
 		if err != nil {
-			return err
+			return multierr.Append(routinesErr, err)
 		}
 	}
 	err := lane.Dest.Common.ChainClient.Close()
 	if err != nil {
-		return err
+		return multierr.Append(routinesErr, err)
 	}
-	return lane.Source.Common.ChainClient.Close()
+	return multierr.Append(routinesErr, lane.Source.Common.ChainClient.Close())
 }
 
 // DeployNewCCIPLane sets up a lane and initiates lane.Source and lane.Destination
@@ -3430,6 +3797,18 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 		return fmt.Errorf("failed to create destination module: %w", err)
 	}
 
+	lane.Source.Common.SetPollingInterval(setUpCtx, lane.Logger, testConf.PollingIntervalFor(sourceChainClient.GetNetworkName()))
+	lane.Dest.Common.SetPollingInterval(setUpCtx, lane.Logger, testConf.PollingIntervalFor(destChainClient.GetNetworkName()))
+	lane.Source.Common.InfiniteRouterApproval = pointer.GetBool(testConf.InfiniteRouterApproval)
+	lane.Dest.Common.InfiniteRouterApproval = pointer.GetBool(testConf.InfiniteRouterApproval)
+	lane.Source.Common.FeeTokenDecimals = testConf.TokenConfig.FeeTokenDecimalsOrLink()
+	lane.Dest.Common.FeeTokenDecimals = testConf.TokenConfig.FeeTokenDecimalsOrLink()
+	lane.Source.Common.PoolTypes = testConf.TokenConfig.PoolTypes
+	lane.Dest.Common.PoolTypes = testConf.TokenConfig.PoolTypes
+	lane.Source.Common.ConcurrentDeployment = pointer.GetBool(testConf.ConcurrentDeployment)
+	lane.Dest.Common.ConcurrentDeployment = pointer.GetBool(testConf.ConcurrentDeployment)
+	lane.Dest.TimingOverride = testConf.LaneTimingFor(sourceChainClient.GetNetworkName(), destChainClient.GetNetworkName())
+
 	// deploy all source contracts
 	err = lane.Source.DeployContracts(srcConf)
 	if err != nil {
@@ -3454,6 +3833,11 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 		return fmt.Errorf("failed to sync USDC domain: %w", err)
 	}
 
+	err = lane.VerifyWiring()
+	if err != nil {
+		return err
+	}
+
 	lane.UpdateLaneConfig()
 
 	// if lane is being set up for already configured CL nodes and contracts
@@ -3461,7 +3845,20 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 	if !configureCLNodes {
 		return nil
 	}
-	err = lane.Source.Common.WatchForPriceUpdates(setUpCtx)
+	// in mock DON mode there are no CL nodes to set up, the requests are committed and executed in-process
+	if pointer.GetBool(testConf.MockDON) {
+		lane.MockDON, err = NewMockDON(lane)
+		if err != nil {
+			return fmt.Errorf("failed to create mock DON: %w", err)
+		}
+		err = lane.MockDON.SetOCR2Config()
+		if err != nil {
+			return fmt.Errorf("failed to set ocr2 config for mock DON: %w", err)
+		}
+		lane.MockDON.Start(lane.Routines())
+		return nil
+	}
+	err = lane.Source.Common.WatchForPriceUpdates(lane.Routines())
 	if err != nil {
 		return fmt.Errorf("error in starting price update watch %w", err)
 	}
@@ -3545,6 +3942,15 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 		if lane.Source.Common.TokenTransmitter == nil {
 			return fmt.Errorf("token transmitter address not set")
 		}
+		// if the attestation service is running, the attestations are served per message by the service
+		// instead of the canned response from mock server
+		if env.USDCAttestationService != nil {
+			api = env.USDCAttestationService.ExternalURL
+			err = env.USDCAttestationService.WatchMessageSent(lane.Routines().Context(), lane.Source.Common.TokenTransmitter)
+			if err != nil {
+				return fmt.Errorf("failed to watch USDC messages for attestation: %w", err)
+			}
+		}
 		// Only one USDC allowed per chain
 		jobParams.USDCConfig = &config.USDCConfig{
 			SourceTokenAddress:              common.HexToAddress(lane.Source.Common.BridgeTokens[0].Address()),
@@ -3562,22 +3968,26 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 	}
 
 	bootstrapCommitP2PId := bootstrapCommit.KeysBundle.P2PKeys.Data[0].Attributes.PeerID
+	// the IPv6 addresses of the bootstrappers are enclosed in brackets for the nodes to split them from the port
 	var p2pBootstrappersExec, p2pBootstrappersCommit *client.P2PData
 	if bootstrapExec != nil {
 		p2pBootstrappersExec = &client.P2PData{
-			InternalIP: bootstrapExec.Node.InternalIP(),
+			InternalIP: testutils.HostForURL(bootstrapExec.Node.InternalIP()),
 			PeerID:     bootstrapExec.KeysBundle.P2PKeys.Data[0].Attributes.PeerID,
 		}
 	}
 
 	p2pBootstrappersCommit = &client.P2PData{
-		InternalIP: bootstrapCommit.Node.InternalIP(),
+		InternalIP: testutils.HostForURL(bootstrapCommit.Node.InternalIP()),
 		PeerID:     bootstrapCommitP2PId,
 	}
 
 	jobParams.P2PV2Bootstrappers = []string{p2pBootstrappersCommit.P2PV2Bootstrapper()}
 
-	err = SetOCR2Config(commitNodes, execNodes, *lane.Dest)
+	// the ocr2 config is set by the deployer wallet which is shared with other processes targeting the same environment
+	err = WithResourceLock(setUpCtx, env.ResourceLocker, "ocr2config-"+lane.Dest.CommitStore.Address(), func() error {
+		return SetOCR2Config(commitNodes, execNodes, *lane.Dest)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to set ocr2 config: %w", err)
 	}
@@ -3607,14 +4017,56 @@ func (lane *CCIPLane) DeployNewCCIPLane(
 	return nil
 }
 
+// laneTiming is the timing params set in the OCR2 config of the CommitStore and the OffRamp of a lane
+type laneTiming struct {
+	permissionlessExecThreshold time.Duration
+	rootSnooze                  time.Duration
+	inflightExpiryExec          time.Duration
+	inflightExpiryCommit        time.Duration
+}
+
+// laneTiming returns the package level timing params overridden with the ones set for the lane
+func (destCCIP *DestCCIPModule) laneTiming() laneTiming {
+	timing := laneTiming{
+		permissionlessExecThreshold: DefaultPermissionlessExecThreshold,
+		rootSnooze:                  RootSnoozeTime,
+		inflightExpiryExec:          InflightExpiryExec,
+		inflightExpiryCommit:        InflightExpiryCommit,
+	}
+	override := destCCIP.TimingOverride
+	if override == nil {
+		return timing
+	}
+	if override.PermissionlessExecThreshold != nil {
+		timing.permissionlessExecThreshold = override.PermissionlessExecThreshold.Duration()
+	}
+	if override.RootSnooze != nil {
+		timing.rootSnooze = override.RootSnooze.Duration()
+	}
+	if override.InflightExpiry != nil {
+		timing.inflightExpiryExec = override.InflightExpiry.Duration()
+	}
+	if override.CommitInflightExpiry != nil {
+		timing.inflightExpiryCommit = override.CommitInflightExpiry.Duration()
+	}
+	return timing
+}
+
+// PermissionlessExecThreshold returns the window in which the DON executes the messages of the lane,
+// the messages are to be executed manually after that
+func (destCCIP *DestCCIPModule) PermissionlessExecThreshold() time.Duration {
+	return destCCIP.laneTiming().permissionlessExecThreshold
+}
+
 // SetOCR2Config sets the oracle config in ocr2 contracts. If execNodes is nil, commit and execution jobs are set up in same DON
 func SetOCR2Config(
 	commitNodes,
 	execNodes []*client.CLNodesWithKeys,
 	destCCIP DestCCIPModule,
 ) error {
-	inflightExpiryExec := commonconfig.MustNewDuration(InflightExpiryExec)
-	inflightExpiryCommit := commonconfig.MustNewDuration(InflightExpiryCommit)
+	timing := destCCIP.laneTiming()
+	inflightExpiryExec := commonconfig.MustNewDuration(timing.inflightExpiryExec)
+	inflightExpiryCommit := commonconfig.MustNewDuration(timing.inflightExpiryCommit)
 
 	signers, transmitters, f, onchainConfig, offchainConfigVersion, offchainConfig, err := contracts.NewOffChainAggregatorV2ConfigForCCIPPlugin(
 		commitNodes, testhelpers.NewCommitOffchainConfig(
@@ -3648,9 +4100,9 @@ func SetOCR2Config(
 				BatchGasLimit,
 				0.7,
 				*inflightExpiryExec,
-				*commonconfig.MustNewDuration(RootSnoozeTime),
+				*commonconfig.MustNewDuration(timing.rootSnooze),
 			), testhelpers.NewExecOnchainConfig(
-				uint32(DefaultPermissionlessExecThreshold.Seconds()),
+				uint32(timing.permissionlessExecThreshold.Seconds()),
 				destCCIP.Common.Router.EthAddress,
 				destCCIP.Common.PriceRegistry.EthAddress,
 				DefaultMaxNoOfTokensInMsg,
@@ -3800,7 +4252,11 @@ type CCIPTestEnv struct {
 	NumOfCommitNodes         int
 	NumOfExecNodes           int
 	K8Env                    *environment.Environment
-	CLNodeWithKeyReady       *errgroup.Group // denotes if keys are created in chainlink node and ready to be used for job creation
+	K8Shards                 []*environment.Environment // namespaces the networks and the nodes are sharded across in addition to K8Env
+	CLNodeWithKeyReady       *errgroup.Group            // denotes if keys are created in chainlink node and ready to be used for job creation
+	USDCAttestationService   *USDCAttestationService
+	ResourceLocker           ResourceLocker     // guards the resources shared with other test processes, nil if not required
+	Faucets                  map[string]*Faucet // key - network name, tops up the default wallet before funding the nodes
 }
 
 func (c *CCIPTestEnv) ChaosLabelForGeth(t *testing.T, srcChain, destChain string) {
@@ -3909,15 +4365,32 @@ func (c *CCIPTestEnv) ConnectToDeployedNodes() error {
 	} else {
 		// in case of k8s, we need to connect to the chainlink nodes
 		log.Info().Msg("Connecting to launched resources")
-		chainlinkK8sNodes, err := client.ConnectChainlinkNodes(c.K8Env)
-		if err != nil {
-			return fmt.Errorf("failed to connect to chainlink nodes: %w", err)
+		// the nodes of all the namespaces are connected in the order of the namespaces, so that the first node
+		// of the first namespace remains the bootstrapper
+		var chainlinkK8sNodes []*client.ChainlinkK8sClient
+		for _, k8Env := range c.K8Envs() {
+			nodes, err := client.ConnectChainlinkNodes(k8Env)
+			if err != nil {
+				return fmt.Errorf("failed to connect to chainlink nodes in namespace %s: %w", k8Env.Cfg.Namespace, err)
+			}
+			if c.Sharded() {
+				// the nodes in other namespaces reach the node by the cluster wide name of its service
+				for _, node := range nodes {
+					node.Config.InternalIP = ClusterServiceHost(node.Config.InternalIP, k8Env.Cfg.Namespace)
+				}
+			}
+			chainlinkK8sNodes = append(chainlinkK8sNodes, nodes...)
 		}
 		if len(chainlinkK8sNodes) == 0 {
 			return fmt.Errorf("no CL node found")
 		}
 
 		for i := range chainlinkK8sNodes {
+			// the urls are built from the addresses of the services, which fail to parse later on if they are
+			// IPv6 addresses without brackets
+			if _, err := testutils.NormalizeURL(chainlinkK8sNodes[i].URL()); err != nil {
+				return fmt.Errorf("chainlink node %d is not reachable: %w", i+1, err)
+			}
 			chainlinkK8sNodes[i].ChainlinkClient.WithRetryCount(3)
 			c.nodeMutexes = append(c.nodeMutexes, &sync.Mutex{})
 		}
@@ -3927,12 +4400,88 @@ func (c *CCIPTestEnv) ConnectToDeployedNodes() error {
 			if err != nil {
 				return fmt.Errorf("failed to connect to mock server: %w", err)
 			}
+			if c.Sharded() {
+				mockServer.Config.ClusterURL = ClusterServiceURL(mockServer.Config.ClusterURL, c.K8Env.Cfg.Namespace)
+			}
+			for _, mockserverURL := range []*string{&mockServer.Config.LocalURL, &mockServer.Config.ClusterURL} {
+				if *mockserverURL, err = testutils.NormalizeURL(*mockserverURL); err != nil {
+					return fmt.Errorf("mock server is not reachable: %w", err)
+				}
+			}
 			c.MockServer = mockServer
 		}
 	}
 	return nil
 }
 
+// K8Envs returns K8Env followed by the namespaces it's sharded across, nil if there is no k8s env
+func (c *CCIPTestEnv) K8Envs() []*environment.Environment {
+	if c.K8Env == nil {
+		return nil
+	}
+	return append([]*environment.Environment{c.K8Env}, c.K8Shards...)
+}
+
+// Sharded returns true if the k8s env is sharded across namespaces
+func (c *CCIPTestEnv) Sharded() bool {
+	return len(c.K8Shards) > 0
+}
+
+// K8EnvForNetwork returns the namespace the simulated network is deployed in, K8Env if it's not found in any
+func (c *CCIPTestEnv) K8EnvForNetwork(networkName string) *environment.Environment {
+	for _, k8Env := range c.K8Envs() {
+		if _, ok := k8Env.URLs[networkName]; ok {
+			return k8Env
+		}
+	}
+	return c.K8Env
+}
+
+// ClusterServiceHost returns the cluster wide dns name of the service with the host name in namespace. Host names which
+// are already qualified with a domain and ip addresses are returned as is.
+func ClusterServiceHost(host, namespace string) string {
+	if host == "" || namespace == "" || strings.Contains(host, ".") || testutils.IsIPv6(host) {
+		return host
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace)
+}
+
+// ClusterServiceURL returns rawURL with its host replaced by the cluster wide dns name of the service in namespace
+func ClusterServiceURL(rawURL, namespace string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return rawURL
+	}
+	host := ClusterServiceHost(u.Hostname(), namespace)
+	if port := u.Port(); port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else {
+		u.Host = testutils.HostForURL(host)
+	}
+	return u.String()
+}
+
+// ReplayFromBlock requests every CL node to replay the logs of the chain from fromBlock. This is the documented
+// recovery procedure for the nodes which missed logs while they were down.
+func (c *CCIPTestEnv) ReplayFromBlock(lggr zerolog.Logger, chain blockchain.EVMClient, fromBlock uint64) error {
+	for i, node := range c.CLNodes {
+		resp, httpResp, err := node.ReplayLogPollerFromBlock(int64(fromBlock), chain.GetChainID().Int64())
+		if err != nil {
+			return fmt.Errorf("failed to replay %s from block %d on node %d: %w", chain.GetNetworkName(), fromBlock, i, err)
+		}
+		if httpResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to replay %s from block %d on node %d: status %d", chain.GetNetworkName(), fromBlock, i, httpResp.StatusCode)
+		}
+		lggr.Info().
+			Str("Network", chain.GetNetworkName()).
+			Uint64("From Block", fromBlock).
+			Str("Node", node.URL()).
+			Str("Response", resp.Data.Attributes.Message).
+			Msg("Replay requested")
+	}
+	return nil
+}
+
 // SetUpNodeKeysAndFund creates node keys and funds the nodes
 func (c *CCIPTestEnv) SetUpNodeKeysAndFund(
 	logger zerolog.Logger,
@@ -3976,6 +4525,12 @@ func (c *CCIPTestEnv) SetUpNodeKeysAndFund(
 				c1.Close()
 			}
 		}()
+		if faucet, ok := c.Faucets[ec.GetNetworkName()]; ok {
+			err = faucet.TopUp(context.Background(), c1, common.HexToAddress(c1.GetDefaultWallet().Address()))
+			if err != nil {
+				return err
+			}
+		}
 		log.Info().Str("chain id", c1.GetChainID().String()).Msg("Funding Chainlink nodes for chain")
 		for i := 1; i < len(chainlinkNodes); i++ {
 			cl := chainlinkNodes[i]
@@ -4012,7 +4567,10 @@ func (c *CCIPTestEnv) SetUpNodeKeysAndFund(
 	for _, chain := range chains {
 		chain := chain
 		grp.Go(func() error {
-			return fund(chain)
+			// the funding wallet might be used by other processes targeting the same environment
+			return WithResourceLock(context.Background(), c.ResourceLocker, "fund-"+chain.GetChainID().String(), func() error {
+				return fund(chain)
+			})
 		})
 	}
 	err := grp.Wait()
@@ -4075,6 +4633,8 @@ type BalanceItem struct {
 	PreviousBalance *big.Int
 	AmtToAdd        *big.Int
 	AmtToSub        *big.Int
+	Token           common.Address // token the balance of Address is in, read with Reader instead of Getter if both are set
+	Reader          *BalanceReader
 }
 
 type BalanceSheet struct {
@@ -4110,6 +4670,8 @@ func (b *BalanceSheet) Update(key string, item BalanceItem) {
 		Getter:   item.Getter,
 		AmtToAdd: amtToAdd,
 		AmtToSub: amtToSub,
+		Token:    item.Token,
+		Reader:   item.Reader,
 	}
 }
 
@@ -4124,6 +4686,9 @@ func (b *BalanceSheet) RecordBalance(bal map[string]*big.Int) {
 }
 
 func (b *BalanceSheet) Verify(t *testing.T) {
+	// read all the balances upfront, batched per chain where possible
+	actual, err := ReadBalances(t, b.Items)
+	require.NoError(t, err, "reading balances shouldn't fail")
 	var balAssertions []testhelpers.BalanceAssertion
 	for key, item := range b.Items {
 		prevBalance, ok := b.PrevBalance[key]
@@ -4135,10 +4700,13 @@ func (b *BalanceSheet) Verify(t *testing.T) {
 		if item.AmtToSub != nil {
 			exp = new(big.Int).Sub(exp, item.AmtToSub)
 		}
+		balance := actual[key]
 		balAssertions = append(balAssertions, testhelpers.BalanceAssertion{
-			Name:     key,
-			Address:  item.Address,
-			Getter:   item.Getter,
+			Name:    key,
+			Address: item.Address,
+			Getter: func(_ *testing.T, _ common.Address) *big.Int {
+				return balance
+			},
 			Expected: exp.String(),
 		})
 	}
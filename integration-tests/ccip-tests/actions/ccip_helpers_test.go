@@ -2,11 +2,21 @@ package actions
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
 )
 
@@ -103,3 +113,117 @@ func TestIsPhaseValid(t *testing.T) {
 		})
 	}
 }
+
+func TestAdaptivePollingInterval(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		avgBlockTime time.Duration
+		expected     time.Duration
+	}{
+		{name: "unknown block time", avgBlockTime: 0, expected: DefaultPollingInterval},
+		{name: "sub-second block time", avgBlockTime: 50 * time.Millisecond, expected: MinPollingInterval},
+		{name: "regular block time", avgBlockTime: 2 * time.Second, expected: time.Second},
+		{name: "slow block time", avgBlockTime: 30 * time.Second, expected: MaxPollingInterval},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, AdaptivePollingInterval(tc.avgBlockTime))
+		})
+	}
+}
+
+func TestPhaseTimeout(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, 10*time.Minute, (&CCIPCommon{AvgBlockTime: 2 * time.Second}).PhaseTimeout(10*time.Minute))
+	require.Equal(t, 20*time.Minute, (&CCIPCommon{AvgBlockTime: time.Minute}).PhaseTimeout(10*time.Minute))
+	require.Equal(t, 10*time.Minute, (&CCIPCommon{}).PhaseTimeout(10*time.Minute))
+}
+
+func TestPoolType(t *testing.T) {
+	t.Parallel()
+	ccipModule := &CCIPCommon{PoolTypes: []string{testconfig.BurnMintPoolType, testconfig.LockReleasePoolType, testconfig.BurnMintPoolType}}
+	require.Equal(t, testconfig.BurnMintPoolType, ccipModule.PoolType(0))
+	require.Equal(t, testconfig.LockReleasePoolType, ccipModule.PoolType(1))
+	require.Equal(t, testconfig.BurnMintPoolType, ccipModule.PoolType(2))
+	// not listed
+	require.Equal(t, testconfig.LockReleasePoolType, ccipModule.PoolType(3))
+
+	// the first pool of a USDC deployment is the USDC pool whatever the pool types
+	usdc := true
+	ccipModule.USDCMockDeployment = &usdc
+	require.Equal(t, testconfig.USDCPoolType, ccipModule.PoolType(0))
+	require.Equal(t, testconfig.BurnMintPoolType, ccipModule.PoolType(2))
+}
+
+func TestLaneTimingOverride(t *testing.T) {
+	t.Parallel()
+	destCCIP := &DestCCIPModule{}
+	require.Equal(t, DefaultPermissionlessExecThreshold, destCCIP.PermissionlessExecThreshold())
+	require.Equal(t, RootSnoozeTime, destCCIP.laneTiming().rootSnooze)
+
+	destCCIP.TimingOverride = &testconfig.LaneTimingConfig{
+		PermissionlessExecThreshold: config.MustNewDuration(2 * time.Minute),
+	}
+	timing := destCCIP.laneTiming()
+	require.Equal(t, 2*time.Minute, destCCIP.PermissionlessExecThreshold())
+	require.Equal(t, RootSnoozeTime, timing.rootSnooze, "params not overridden should be left as is")
+	require.Equal(t, InflightExpiryExec, timing.inflightExpiryExec)
+	require.Equal(t, InflightExpiryCommit, timing.inflightExpiryCommit)
+}
+
+// callError is an eth_call error with revert data, as returned by the rpc client
+type callError struct {
+	data interface{}
+}
+
+func (c callError) Error() string {
+	return "execution reverted"
+}
+
+func (c callError) ErrorData() interface{} {
+	return c.data
+}
+
+func TestRevertErrorFromCall(t *testing.T) {
+	t.Parallel()
+	routerABI, err := abi.JSON(strings.NewReader(router.RouterABI))
+	require.NoError(t, err)
+	revertData, err := routerABI.Errors["UnsupportedDestinationChain"].Inputs.Pack(uint64(5009297550715157269))
+	require.NoError(t, err)
+	revertData = append(routerABI.Errors["UnsupportedDestinationChain"].ID.Bytes()[:4], revertData...)
+
+	name, err := revertErrorFromCall(callError{data: hexutil.Encode(revertData)}, router.RouterABI)
+	require.NoError(t, err)
+	require.Equal(t, "UnsupportedDestinationChain", name)
+
+	name, err = revertErrorFromCall(fmt.Errorf("failed getting the fee: %w", callError{data: "Reverted " + hexutil.Encode(revertData)}), router.RouterABI)
+	require.NoError(t, err, "wrapped error with prefixed revert data should be decoded")
+	require.Equal(t, "UnsupportedDestinationChain", name)
+
+	_, err = revertErrorFromCall(errors.New("connection refused"), router.RouterABI)
+	require.Error(t, err)
+	_, err = revertErrorFromCall(callError{data: "0xdeadbeef"}, router.RouterABI)
+	require.ErrorContains(t, err, "does not match any error")
+}
+
+func TestClusterServiceURL(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "geth.ns-1.svc.cluster.local", ClusterServiceHost("geth", "ns-1"))
+	require.Equal(t, "geth.ns-2.svc.cluster.local", ClusterServiceHost("geth.ns-2.svc.cluster.local", "ns-1"),
+		"qualified host should be left as is")
+	require.Equal(t, "geth", ClusterServiceHost("geth", ""))
+	require.Equal(t, "", ClusterServiceHost("", "ns-1"))
+
+	require.Equal(t, "ws://simulated-1-ethereum-geth.ns-1.svc.cluster.local:8546",
+		ClusterServiceURL("ws://simulated-1-ethereum-geth:8546", "ns-1"))
+	require.Equal(t, "http://mockserver.ns-1.svc.cluster.local:1080/path",
+		ClusterServiceURL("http://mockserver:1080/path", "ns-1"))
+	require.Equal(t, "not a url", ClusterServiceURL("not a url", "ns-1"))
+
+	require.Equal(t, "fd00::1", ClusterServiceHost("fd00::1", "ns-1"), "ip should be left as is")
+	require.Equal(t, "http://[fd00::1]:1080/path", ClusterServiceURL("http://[fd00::1]:1080/path", "ns-1"))
+	require.Equal(t, "http://[fd00::1]/path", ClusterServiceURL("http://[fd00::1]/path", "ns-1"))
+}
@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTimeControlUnsupported is returned by the ChainClock of a chain the node of which has no time control methods,
+// e.g. geth in dev mode
+var ErrTimeControlUnsupported = errors.New("the node doesn't support time control")
+
+// rpcCaller is the part of rpc.Client used by ChainClock
+type rpcCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// ChainClock moves the time of a simulated chain with the evm_setNextBlockTimestamp and evm_mine methods of the dev
+// nodes like anvil and hardhat. The time of a chain can only be moved forward.
+type ChainClock struct {
+	client rpcCaller
+	close  func()
+}
+
+// NewChainClock connects to the node at rpcURL, the ChainClock should be closed once done
+func NewChainClock(ctx context.Context, rpcURL string) (*ChainClock, error) {
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	return &ChainClock{client: client, close: client.Close}, nil
+}
+
+// Close closes the connection to the node
+func (c *ChainClock) Close() {
+	if c.close != nil {
+		c.close()
+	}
+}
+
+// Now returns the timestamp and the number of the latest block
+func (c *ChainClock) Now(ctx context.Context) (timestamp uint64, number uint64, err error) {
+	var head struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := c.client.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return 0, 0, fmt.Errorf("failed to get the latest block: %w", err)
+	}
+	return uint64(head.Timestamp), uint64(head.Number), nil
+}
+
+// MineBlockAt mines an empty block with the timestamp ts and returns its number, ts should be after the timestamp of the
+// latest block. It returns ErrTimeControlUnsupported if the node has no time control methods.
+func (c *ChainClock) MineBlockAt(ctx context.Context, ts uint64) (uint64, error) {
+	if err := c.client.CallContext(ctx, nil, "evm_setNextBlockTimestamp", hexutil.Uint64(ts)); err != nil {
+		if isMethodNotFound(err) {
+			return 0, fmt.Errorf("%w: %s", ErrTimeControlUnsupported, err.Error())
+		}
+		return 0, fmt.Errorf("failed to set the timestamp of the next block to %d: %w", ts, err)
+	}
+	if err := c.client.CallContext(ctx, nil, "evm_mine"); err != nil {
+		return 0, fmt.Errorf("failed to mine a block: %w", err)
+	}
+	minedAt, number, err := c.Now(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if minedAt != ts {
+		return 0, fmt.Errorf("block %d was mined at %d instead of %d", number, minedAt, ts)
+	}
+	return number, nil
+}
+
+// isMethodNotFound returns true if err is the json-rpc error of a method the node doesn't have
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	// -32601 is the json-rpc code of a method not found
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
+}
@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevNode is a dev node with time control, or geth in dev mode if timeControl is false
+type fakeDevNode struct {
+	timeControl bool
+	number      uint64
+	timestamp   uint64
+	next        uint64
+}
+
+type methodNotFoundError struct{ method string }
+
+func (e methodNotFoundError) Error() string {
+	return fmt.Sprintf("the method %s does not exist/is not available", e.method)
+}
+
+func (e methodNotFoundError) ErrorCode() int { return -32601 }
+
+func (n *fakeDevNode) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	switch method {
+	case "evm_setNextBlockTimestamp":
+		if !n.timeControl {
+			return methodNotFoundError{method}
+		}
+		n.next = uint64(args[0].(hexutil.Uint64))
+	case "evm_mine":
+		n.number++
+		n.timestamp = n.next
+	case "eth_getBlockByNumber":
+		b, err := json.Marshal(map[string]hexutil.Uint64{"number": hexutil.Uint64(n.number), "timestamp": hexutil.Uint64(n.timestamp)})
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, result)
+	default:
+		return methodNotFoundError{method}
+	}
+	return nil
+}
+
+func TestChainClockMineBlockAt(t *testing.T) {
+	node := &fakeDevNode{timeControl: true, number: 10, timestamp: 1_000}
+	clock := &ChainClock{client: node}
+	now, head, err := clock.Now(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000), now)
+	require.Equal(t, uint64(10), head)
+
+	block, err := clock.MineBlockAt(context.Background(), 1_000+14*24*60*60)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), block)
+	now, _, err = clock.Now(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000+14*24*60*60), now)
+}
+
+func TestChainClockUnsupported(t *testing.T) {
+	clock := &ChainClock{client: &fakeDevNode{number: 10, timestamp: 1_000}}
+	_, err := clock.MineBlockAt(context.Background(), 2_000)
+	require.True(t, errors.Is(err, ErrTimeControlUnsupported), "unexpected error %v", err)
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/merklemulti"
+)
+
+// MaxCommitIntervalSize is the max number of messages the commit plugin includes in a single commit report.
+// Larger intervals are truncated and the rest of the messages are committed in the following reports.
+const MaxCommitIntervalSize = uint64(merklemulti.MaxNumberTreeLeaves)
+
+// SentSeqNums returns the sequence numbers of the requests sent since the last RecordStateBeforeTransfer in ascending
+// order. The sequence numbers are known only once the CCIPSendRequested events of the requests are validated.
+func (lane *CCIPLane) SentSeqNums() []uint64 {
+	var seqNums []uint64
+	for _, reqs := range lane.SentReqs {
+		for _, req := range reqs {
+			if req.RequestStat != nil && req.RequestStat.SeqNum != 0 {
+				seqNums = append(seqNums, req.RequestStat.SeqNum)
+			}
+		}
+	}
+	sort.Slice(seqNums, func(i, j int) bool {
+		return seqNums[i] < seqNums[j]
+	})
+	return seqNums
+}
+
+// AssertCommitBatching validates the intervals of the accepted commit reports against the batching expected for
+// a single request committed on its own followed by a burst of requests sent in one transaction.
+// The single request should be committed alone. The burst is finalized at once, so it should be committed in
+// reports of at most maxIntervalSize messages which cover it without gaps and as few of them as maxIntervalSize allows.
+func AssertCommitBatching(intervals []testreporters.CommitInterval, single uint64, burst []uint64, maxIntervalSize uint64) error {
+	if len(burst) == 0 {
+		return fmt.Errorf("no burst request to validate")
+	}
+	if maxIntervalSize == 0 {
+		return fmt.Errorf("max interval size should be greater than 0")
+	}
+	first, last := burst[0], burst[len(burst)-1]
+	if last-first+1 != uint64(len(burst)) {
+		return fmt.Errorf("burst seq nums should be consecutive, got %d requests in range %d-%d", len(burst), first, last)
+	}
+	if single >= first {
+		return fmt.Errorf("single request seq num %d should be before the burst starting at %d", single, first)
+	}
+	singleCommitted := false
+	next := first
+	var burstReports uint64
+	for _, interval := range intervals {
+		if interval.Min <= single && single <= interval.Max {
+			if interval.Size() != 1 {
+				return fmt.Errorf("single request seq num %d should be committed alone, got interval %d-%d",
+					single, interval.Min, interval.Max)
+			}
+			singleCommitted = true
+			continue
+		}
+		if interval.Max < first || interval.Min > last {
+			continue
+		}
+		if interval.Min != next || interval.Max > last {
+			return fmt.Errorf("interval %d-%d does not follow seq num %d of the burst %d-%d", interval.Min, interval.Max, next, first, last)
+		}
+		if interval.Size() > maxIntervalSize {
+			return fmt.Errorf("interval %d-%d is larger than the max interval size %d", interval.Min, interval.Max, maxIntervalSize)
+		}
+		burstReports++
+		next = interval.Max + 1
+	}
+	if !singleCommitted {
+		return fmt.Errorf("no commit report found for single request seq num %d", single)
+	}
+	if next != last+1 {
+		return fmt.Errorf("burst %d-%d is committed only up to seq num %d", first, last, next-1)
+	}
+	if expected := (uint64(len(burst)) + maxIntervalSize - 1) / maxIntervalSize; burstReports != expected {
+		return fmt.Errorf("burst of %d requests should be committed in %d reports with max interval size %d, got %d",
+			len(burst), expected, maxIntervalSize, burstReports)
+	}
+	return nil
+}
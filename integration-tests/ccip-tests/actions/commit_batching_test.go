@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+func TestAssertCommitBatching(t *testing.T) {
+	t.Parallel()
+	burst := []uint64{2, 3, 4, 5, 6}
+	for _, tc := range []struct {
+		name            string
+		intervals       []testreporters.CommitInterval
+		maxIntervalSize uint64
+		expectedErr     string
+	}{
+		{
+			name:            "burst in one report",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 6}},
+			maxIntervalSize: MaxCommitIntervalSize,
+		},
+		{
+			name:            "burst split by max interval size",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 3}, {Min: 4, Max: 5}, {Min: 6, Max: 6}},
+			maxIntervalSize: 2,
+		},
+		{
+			name:            "reports of other requests are ignored",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 6}, {Min: 7, Max: 9}},
+			maxIntervalSize: MaxCommitIntervalSize,
+		},
+		{
+			name:            "single request batched",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 6}},
+			maxIntervalSize: MaxCommitIntervalSize,
+			expectedErr:     "should be committed alone",
+		},
+		{
+			name:            "single request not committed",
+			intervals:       []testreporters.CommitInterval{{Min: 2, Max: 6}},
+			maxIntervalSize: MaxCommitIntervalSize,
+			expectedErr:     "no commit report found for single request",
+		},
+		{
+			name:            "burst split below max interval size",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 4}, {Min: 5, Max: 6}},
+			maxIntervalSize: MaxCommitIntervalSize,
+			expectedErr:     "should be committed in 1 reports",
+		},
+		{
+			name:            "interval larger than max",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 6}},
+			maxIntervalSize: 4,
+			expectedErr:     "larger than the max interval size",
+		},
+		{
+			name:            "burst partially committed",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 4}},
+			maxIntervalSize: 3,
+			expectedErr:     "committed only up to seq num 4",
+		},
+		{
+			name:            "gap in burst",
+			intervals:       []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 3}, {Min: 5, Max: 6}},
+			maxIntervalSize: 2,
+			expectedErr:     "does not follow seq num 4",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := AssertCommitBatching(tc.intervals, 1, burst, tc.maxIntervalSize)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+	require.ErrorContains(t, AssertCommitBatching(nil, 1, []uint64{2, 4}, 2), "should be consecutive")
+	require.ErrorContains(t, AssertCommitBatching(nil, 3, burst, 2), "should be before the burst")
+}
+
+func TestCommitBatchingOnSyntheticLane(t *testing.T) {
+	t.Parallel()
+	s, err := NewSyntheticLane(t, zerolog.Nop(), false)
+	require.NoError(t, err)
+
+	_, _, err = s.SendRequests(1)
+	require.NoError(t, err)
+	s.Deliver()
+	s.Lane.ValidateRequests()
+	single := s.Lane.SentSeqNums()
+	require.Equal(t, []uint64{1}, single)
+
+	s.Lane.SentReqs = make(map[common.Hash][]CCIPRequest)
+	_, seqNums, err := s.SendRequests(5)
+	require.NoError(t, err)
+	s.Commit(2, 4)
+	s.Commit(5, 6)
+	s.Execute(testhelpers.ExecutionStateSuccess, seqNums...)
+	s.Lane.ValidateRequests()
+	burst := s.Lane.SentSeqNums()
+	require.Equal(t, seqNums, burst)
+
+	intervals := s.Lane.Reports.CommitIntervals()
+	require.Equal(t, []testreporters.CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 4}, {Min: 5, Max: 6}}, intervals)
+	require.NoError(t, AssertCommitBatching(intervals, single[0], burst, 3))
+	require.ErrorContains(t, AssertCommitBatching(intervals, single[0], burst, MaxCommitIntervalSize), "should be committed in 1 reports")
+}
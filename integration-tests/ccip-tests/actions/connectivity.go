@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HappyEyeballsDelay is the delay before the next address of a host is dialed while the previous ones are still
+// connecting, as recommended by RFC 8305
+const HappyEyeballsDelay = 250 * time.Millisecond
+
+// happyEyeballsClient is the http client of the connectivity checks, it dials with DialHappyEyeballs
+var happyEyeballsClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         DialHappyEyeballs,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// lookupIPAddr resolves the addresses of a host, it's replaced in the tests
+var lookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+// dialAddress dials a single address, it's replaced in the tests
+var dialAddress = func(ctx context.Context, network, address string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, network, address)
+}
+
+// sortAddrsForHappyEyeballs orders the addresses IPv6 first, alternating between IPv6 and IPv4 after that, so that a
+// dual-stack host is reached over IPv6 if it can be and an IPv6-only or IPv4-only host is reached without waiting for
+// all the addresses of the other family to time out
+func sortAddrsForHappyEyeballs(addrs []net.IPAddr) []net.IPAddr {
+	var v6, v4 []net.IPAddr
+	for _, addr := range addrs {
+		if addr.IP.To4() == nil {
+			v6 = append(v6, addr)
+		} else {
+			v4 = append(v4, addr)
+		}
+	}
+	sorted := make([]net.IPAddr, 0, len(addrs))
+	for i := 0; i < len(v6) || i < len(v4); i++ {
+		if i < len(v6) {
+			sorted = append(sorted, v6[i])
+		}
+		if i < len(v4) {
+			sorted = append(sorted, v4[i])
+		}
+	}
+	return sorted
+}
+
+// DialHappyEyeballs connects to address, a host:port, racing all the addresses the host resolves to as per RFC 8305.
+// The addresses are dialed IPv6 first, every next one HappyEyeballsDelay after the previous one unless the previous
+// one has failed already, and the first connection established wins. If none of them connects, the error lists the
+// failure of every address, instead of the last one only, so that an IPv6-only cluster reached over IPv4 is told apart
+// from a host which is down.
+func DialHappyEyeballs(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+	var addrs []net.IPAddr
+	if ip := net.ParseIP(host); ip != nil {
+		addrs = []net.IPAddr{{IP: ip}}
+	} else {
+		addrs, err = lookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no address found for %s", host)
+		}
+	}
+	addrs = sortAddrsForHappyEyeballs(addrs)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type attempt struct {
+		addr string
+		conn net.Conn
+		err  error
+	}
+	results := make(chan attempt, len(addrs))
+	dial := dialAddress
+	next, pending := 0, 0
+	dialNext := func() {
+		target := net.JoinHostPort(addrs[next].String(), port)
+		next++
+		pending++
+		go func() {
+			conn, err := dial(ctx, network, target)
+			results <- attempt{addr: target, conn: conn, err: err}
+		}()
+	}
+	// closeLate closes the connections of the attempts still running once they return
+	closeLate := func(n int) {
+		go func() {
+			for ; n > 0; n-- {
+				if r := <-results; r.conn != nil {
+					_ = r.conn.Close()
+				}
+			}
+		}()
+	}
+	var errs []string
+	dialNext()
+	for pending > 0 {
+		var delay <-chan time.Time
+		timer := time.NewTimer(HappyEyeballsDelay)
+		if next < len(addrs) {
+			delay = timer.C
+		}
+		select {
+		case r := <-results:
+			timer.Stop()
+			pending--
+			if r.err == nil {
+				cancel()
+				closeLate(pending)
+				return r.conn, nil
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", r.addr, r.err))
+			if next < len(addrs) {
+				// the previous attempt has failed already, dial the next address right away
+				dialNext()
+			}
+		case <-delay:
+			dialNext()
+		case <-ctx.Done():
+			timer.Stop()
+			closeLate(pending)
+			return nil, fmt.Errorf("failed to connect to %s: %w", address, ctx.Err())
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	return nil, fmt.Errorf("failed to connect to %s on any of its addresses: %s", address, strings.Join(errs, "; "))
+}
@@ -0,0 +1,143 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNetwork resolves every host to addrs and connects to the addresses in up, the dials of the addresses in hang
+// block till their context is done and the others are refused
+type fakeNetwork struct {
+	addrs []net.IPAddr
+	up    map[string]bool
+	hang  map[string]bool
+
+	mu     sync.Mutex
+	dialed []string
+}
+
+func (f *fakeNetwork) install(t *testing.T) {
+	lookup, dial := lookupIPAddr, dialAddress
+	t.Cleanup(func() {
+		lookupIPAddr, dialAddress = lookup, dial
+	})
+	lookupIPAddr = func(context.Context, string) ([]net.IPAddr, error) {
+		return f.addrs, nil
+	}
+	dialAddress = func(ctx context.Context, _, address string) (net.Conn, error) {
+		f.mu.Lock()
+		f.dialed = append(f.dialed, address)
+		f.mu.Unlock()
+		switch {
+		case f.up[address]:
+			conn, _ := net.Pipe()
+			return conn, nil
+		case f.hang[address]:
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("connection refused")
+	}
+}
+
+func (f *fakeNetwork) dialedAddrs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.dialed...)
+}
+
+func ipAddrs(ips ...string) []net.IPAddr {
+	var addrs []net.IPAddr
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs
+}
+
+func TestSortAddrsForHappyEyeballs(t *testing.T) {
+	sorted := sortAddrsForHappyEyeballs(ipAddrs("10.0.0.1", "10.0.0.2", "fd00::1", "fd00::2", "fd00::3"))
+	var got []string
+	for _, addr := range sorted {
+		got = append(got, addr.String())
+	}
+	require.Equal(t, []string{"fd00::1", "10.0.0.1", "fd00::2", "10.0.0.2", "fd00::3"}, got)
+}
+
+func TestDialHappyEyeballs(t *testing.T) {
+	t.Run("prefers IPv6 on dual-stack", func(t *testing.T) {
+		f := &fakeNetwork{
+			addrs: ipAddrs("10.0.0.1", "fd00::1"),
+			up:    map[string]bool{"10.0.0.1:1080": true, "[fd00::1]:1080": true},
+		}
+		f.install(t)
+		conn, err := DialHappyEyeballs(context.Background(), "tcp", "mockserver:1080")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		require.Equal(t, []string{"[fd00::1]:1080"}, f.dialedAddrs())
+	})
+
+	t.Run("falls back to IPv4 right away if IPv6 is refused", func(t *testing.T) {
+		f := &fakeNetwork{
+			addrs: ipAddrs("10.0.0.1", "fd00::1"),
+			up:    map[string]bool{"10.0.0.1:1080": true},
+		}
+		f.install(t)
+		start := time.Now()
+		conn, err := DialHappyEyeballs(context.Background(), "tcp", "mockserver:1080")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		require.Less(t, time.Since(start), HappyEyeballsDelay)
+		require.Equal(t, []string{"[fd00::1]:1080", "10.0.0.1:1080"}, f.dialedAddrs())
+	})
+
+	t.Run("races IPv4 after the delay if IPv6 hangs", func(t *testing.T) {
+		f := &fakeNetwork{
+			addrs: ipAddrs("10.0.0.1", "fd00::1"),
+			up:    map[string]bool{"10.0.0.1:1080": true},
+			hang:  map[string]bool{"[fd00::1]:1080": true},
+		}
+		f.install(t)
+		start := time.Now()
+		conn, err := DialHappyEyeballs(context.Background(), "tcp", "mockserver:1080")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		require.GreaterOrEqual(t, time.Since(start), HappyEyeballsDelay)
+	})
+
+	t.Run("lists the failure of every address", func(t *testing.T) {
+		f := &fakeNetwork{addrs: ipAddrs("10.0.0.1", "fd00::1")}
+		f.install(t)
+		_, err := DialHappyEyeballs(context.Background(), "tcp", "mockserver:1080")
+		require.ErrorContains(t, err, "[fd00::1]:1080: connection refused")
+		require.ErrorContains(t, err, "10.0.0.1:1080: connection refused")
+	})
+
+	t.Run("dials an ip without resolving it", func(t *testing.T) {
+		f := &fakeNetwork{up: map[string]bool{"[fd00::1]:6688": true}}
+		f.install(t)
+		conn, err := DialHappyEyeballs(context.Background(), "tcp", "[fd00::1]:6688")
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("times out with the context", func(t *testing.T) {
+		f := &fakeNetwork{
+			addrs: ipAddrs("fd00::1"),
+			hang:  map[string]bool{"[fd00::1]:1080": true},
+		}
+		f.install(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := DialHappyEyeballs(ctx, "tcp", "mockserver:1080")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	_, err := DialHappyEyeballs(context.Background(), "tcp", "fd00::1:1080")
+	require.ErrorContains(t, err, "invalid address")
+}
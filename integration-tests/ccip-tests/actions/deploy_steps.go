@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// deployStep is a step of the deployment of the common contracts of a chain
+type deployStep struct {
+	name string
+	deps []string // names of the steps deploying the contracts the step needs, they must come before it in the steps
+	run  func() error
+}
+
+// runDeploySteps runs the steps one after the other in their order if concurrent is false. Otherwise each step runs as
+// soon as all its deps have succeeded, concurrently with the others, and no new step is started once one has failed.
+// Either way, the error is that of the first step failing.
+func runDeploySteps(ctx context.Context, steps []deployStep, concurrent bool) error {
+	done := make(map[string]chan struct{}, len(steps))
+	for _, step := range steps {
+		if _, ok := done[step.name]; ok {
+			return fmt.Errorf("deploy step %s is listed twice", step.name)
+		}
+		for _, dep := range step.deps {
+			// the deps coming first make the order of the steps a valid sequential order and rule out cycles
+			if _, ok := done[dep]; !ok {
+				return fmt.Errorf("deploy step %s depends on %s, which is not listed before it", step.name, dep)
+			}
+		}
+		done[step.name] = make(chan struct{})
+	}
+	if !concurrent {
+		for _, step := range steps {
+			if err := step.run(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	grp, ctx := errgroup.WithContext(ctx)
+	for _, step := range steps {
+		step := step
+		grp.Go(func() error {
+			for _, dep := range step.deps {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			if err := step.run(); err != nil {
+				return err
+			}
+			close(done[step.name])
+			return nil
+		})
+	}
+	return grp.Wait()
+}
@@ -0,0 +1,106 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the order the steps are run in
+type recorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (r *recorder) step(name string, deps ...string) deployStep {
+	return deployStep{name: name, deps: deps, run: func() error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.ran = append(r.ran, name)
+		return nil
+	}}
+}
+
+func (r *recorder) index(name string) int {
+	for i, n := range r.ran {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRunDeployStepsSequential(t *testing.T) {
+	r := &recorder{}
+	steps := []deployStep{r.step("arm"), r.step("wrapped native"), r.step("router", "arm", "wrapped native"), r.step("multicall")}
+	require.NoError(t, runDeploySteps(context.Background(), steps, false))
+	require.Equal(t, []string{"arm", "wrapped native", "router", "multicall"}, r.ran)
+}
+
+func TestRunDeployStepsConcurrentOrder(t *testing.T) {
+	r := &recorder{}
+	steps := []deployStep{
+		r.step("arm"),
+		r.step("wrapped native"),
+		r.step("router", "arm", "wrapped native"),
+		r.step("fee token", "wrapped native"),
+		r.step("bridge tokens", "fee token"),
+		r.step("pools", "arm", "router", "bridge tokens"),
+		r.step("multicall"),
+	}
+	require.NoError(t, runDeploySteps(context.Background(), steps, true))
+	require.Len(t, r.ran, len(steps))
+	for _, step := range steps {
+		for _, dep := range step.deps {
+			require.Less(t, r.index(dep), r.index(step.name), "%s ran before its dep %s", step.name, dep)
+		}
+	}
+}
+
+func TestRunDeployStepsConcurrentOverlap(t *testing.T) {
+	// the independent steps wait for one another, which only succeeds if they run concurrently
+	var wg sync.WaitGroup
+	wg.Add(3)
+	independent := func(name string) deployStep {
+		return deployStep{name: name, run: func() error {
+			wg.Done()
+			wg.Wait()
+			return nil
+		}}
+	}
+	steps := []deployStep{independent("arm"), independent("wrapped native"), independent("multicall")}
+	errCh := make(chan error, 1)
+	go func() { errCh <- runDeploySteps(context.Background(), steps, true) }()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("independent steps were not run concurrently")
+	}
+}
+
+func TestRunDeployStepsConcurrentFailure(t *testing.T) {
+	var dependentsRan atomic.Int32
+	steps := []deployStep{
+		{name: "arm", run: func() error { return fmt.Errorf("deploying mock ARM contract shouldn't fail") }},
+		{name: "router", deps: []string{"arm"}, run: func() error { dependentsRan.Add(1); return nil }},
+		{name: "pools", deps: []string{"router"}, run: func() error { dependentsRan.Add(1); return nil }},
+	}
+	err := runDeploySteps(context.Background(), steps, true)
+	require.EqualError(t, err, "deploying mock ARM contract shouldn't fail")
+	require.Zero(t, dependentsRan.Load())
+}
+
+func TestRunDeployStepsInvalidDeps(t *testing.T) {
+	r := &recorder{}
+	err := runDeploySteps(context.Background(), []deployStep{r.step("router", "arm"), r.step("arm")}, true)
+	require.ErrorContains(t, err, "deploy step router depends on arm, which is not listed before it")
+	err = runDeploySteps(context.Background(), []deployStep{r.step("arm"), r.step("arm")}, false)
+	require.ErrorContains(t, err, "deploy step arm is listed twice")
+	require.Empty(t, r.ran)
+}
@@ -0,0 +1,269 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts/laneconfig"
+	testutils "github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/utils"
+)
+
+// DoctorTimeout is the timeout of every single precondition checked by the doctor
+const DoctorTimeout = 30 * time.Second
+
+// DoctorCheck is the outcome of a single precondition checked before the test environment is deployed
+type DoctorCheck struct {
+	Name string
+	Err  error
+}
+
+func (c DoctorCheck) Passed() bool {
+	return c.Err == nil
+}
+
+// DoctorReport is the pass/fail checklist of all the preconditions checked by the doctor
+type DoctorReport struct {
+	Checks []DoctorCheck
+}
+
+// Run runs check and records its outcome under name
+func (r *DoctorReport) Run(ctx context.Context, name string, check func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(ctx, DoctorTimeout)
+	defer cancel()
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Err: check(ctx)})
+}
+
+// Failed returns the checks which did not pass
+func (r *DoctorReport) Failed() []DoctorCheck {
+	var failed []DoctorCheck
+	for _, c := range r.Checks {
+		if !c.Passed() {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// String renders the checklist, one check per line in the order they are run
+func (r *DoctorReport) String() string {
+	var sb strings.Builder
+	for _, c := range r.Checks {
+		if c.Passed() {
+			fmt.Fprintf(&sb, "[PASS] %s\n", c.Name)
+		} else {
+			fmt.Fprintf(&sb, "[FAIL] %s: %v\n", c.Name, c.Err)
+		}
+	}
+	fmt.Fprintf(&sb, "%d/%d checks passed", len(r.Checks)-len(r.Failed()), len(r.Checks))
+	return sb.String()
+}
+
+// Print logs the checklist and returns an error listing the failed checks, if any
+func (r *DoctorReport) Print(lggr zerolog.Logger) error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		lggr.Info().Msgf("Environment doctor checklist:\n%s", r.String())
+		return nil
+	}
+	lggr.Error().Msgf("Environment doctor checklist:\n%s", r.String())
+	names := make([]string, 0, len(failed))
+	for _, c := range failed {
+		names = append(names, c.Name)
+	}
+	return fmt.Errorf("environment doctor found %d failed checks: %s", len(failed), strings.Join(names, ", "))
+}
+
+// CheckRPC validates that the rpc at url, either http or ws, is reachable and serves the chain with chainID.
+// For ws urls it also validates that new heads can be subscribed to, as the event watchers need subscriptions.
+func CheckRPC(ctx context.Context, url string, chainID int64) error {
+	url, err := testutils.NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %w", url, err)
+	}
+	defer client.Close()
+	id, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain id from %s: %w", url, err)
+	}
+	if id.Int64() != chainID {
+		return fmt.Errorf("rpc %s serves chain id %d, expected %d", url, id.Int64(), chainID)
+	}
+	if !strings.HasPrefix(url, "ws") {
+		return nil
+	}
+	heads := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return fmt.Errorf("rpc %s does not support subscriptions: %w", url, err)
+	}
+	sub.Unsubscribe()
+	return nil
+}
+
+// CheckFunding validates that the address of the hex private key holds at least minBalance wei, or any balance if
+// minBalance is nil
+func CheckFunding(ctx context.Context, url string, privateKey string, minBalance *big.Int) error {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	url, err = testutils.NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %w", url, err)
+	}
+	defer client.Close()
+	balance, err := client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", address.Hex(), err)
+	}
+	if minBalance == nil || minBalance.Sign() == 0 {
+		minBalance = big.NewInt(1)
+	}
+	if balance.Cmp(minBalance) < 0 {
+		return fmt.Errorf("balance of %s is %s wei, expected at least %s wei", address.Hex(), balance, minBalance)
+	}
+	return nil
+}
+
+// CheckNodeAuth validates that a session can be created in the API of the CL node at url with the credentials
+func CheckNodeAuth(ctx context.Context, url, email, password string) error {
+	url, err := testutils.NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"email": email, "password": password})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(url, "/")+"/sessions", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create session request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := happyEyeballsClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach node %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("login to node %s failed with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// CheckReachable validates that the server at url responds to a GET request with any status other than a server error
+func CheckReachable(ctx context.Context, url string) error {
+	url, err := testutils.NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := happyEyeballsClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// CheckLaneConfigConsistency validates the contract addresses set for every network in lanes, and that every lane in
+// them is between networks with contracts in lanes, with matching src and dest contracts on both ends. The contracts of
+// every one of expectedLanes, as source and dest network names, should be present too, which is the case for existing
+// deployments where nothing is going to be deployed.
+func CheckLaneConfigConsistency(lanes *laneconfig.Lanes, expectedLanes [][2]string) error {
+	var errs []string
+	networks := make([]string, 0, len(lanes.LaneConfigs))
+	for network := range lanes.LaneConfigs {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		cfg := lanes.LaneConfigs[network]
+		if cfg == nil {
+			errs = append(errs, fmt.Sprintf("%s: empty lane config", network))
+			continue
+		}
+		for name, address := range map[string]string{
+			"arm":            cfg.ARM,
+			"router":         cfg.Router,
+			"price registry": cfg.PriceRegistry,
+			"fee token":      cfg.FeeToken,
+			"wrapped native": cfg.WrappedNative,
+			"multicall":      cfg.Multicall,
+		} {
+			if address != "" && !common.IsHexAddress(address) {
+				errs = append(errs, fmt.Sprintf("%s: invalid %s address", network, name))
+			}
+		}
+		for dest, src := range cfg.SrcContracts {
+			destCfg, ok := lanes.LaneConfigs[dest]
+			if !ok || destCfg == nil {
+				errs = append(errs, fmt.Sprintf("%s: on ramp to %s, which has no lane config", network, dest))
+				continue
+			}
+			if !common.IsHexAddress(src.OnRamp) {
+				errs = append(errs, fmt.Sprintf("%s: invalid on ramp address to %s", network, dest))
+			}
+			if _, ok := destCfg.DestContracts[network]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: on ramp to %s, which has no off ramp from %s", network, dest, network))
+			}
+		}
+		for source, dest := range cfg.DestContracts {
+			if _, ok := lanes.LaneConfigs[source]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: off ramp from %s, which has no lane config", network, source))
+			}
+			for name, address := range map[string]string{
+				"off ramp":      dest.OffRamp,
+				"commit store":  dest.CommitStore,
+				"receiver dapp": dest.ReceiverDapp,
+			} {
+				if !common.IsHexAddress(address) {
+					errs = append(errs, fmt.Sprintf("%s: invalid %s address from %s", network, name, source))
+				}
+			}
+		}
+	}
+	for _, lane := range expectedLanes {
+		src, ok := lanes.LaneConfigs[lane[0]]
+		if !ok || src == nil {
+			errs = append(errs, fmt.Sprintf("no lane config for %s", lane[0]))
+			continue
+		}
+		if _, ok := src.SrcContracts[lane[1]]; !ok {
+			errs = append(errs, fmt.Sprintf("no lane from %s to %s", lane[0], lane[1]))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("inconsistent lane config: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
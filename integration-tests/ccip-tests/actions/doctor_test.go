@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts/laneconfig"
+)
+
+func TestDoctorReport(t *testing.T) {
+	t.Parallel()
+	report := &DoctorReport{}
+	report.Run(context.Background(), "passing", func(context.Context) error { return nil })
+	report.Run(context.Background(), "failing", func(context.Context) error { return errors.New("boom") })
+	require.Len(t, report.Failed(), 1)
+	require.Equal(t, "[PASS] passing\n[FAIL] failing: boom\n1/2 checks passed", report.String())
+}
+
+func TestCheckNodeAuth(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var session map[string]string
+		if r.URL.Path != "/sessions" || json.NewDecoder(r.Body).Decode(&session) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if session["email"] != "admin@chain.link" || session["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	require.NoError(t, CheckNodeAuth(context.Background(), srv.URL+"/", "admin@chain.link", "secret"))
+	require.ErrorContains(t, CheckNodeAuth(context.Background(), srv.URL, "admin@chain.link", "wrong"), "failed with status 401")
+}
+
+func TestCheckReachable(t *testing.T) {
+	t.Parallel()
+	var status atomic.Int64
+	status.Store(http.StatusNotFound)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, CheckReachable(context.Background(), srv.URL), "any response other than a server error means reachable")
+	status.Store(http.StatusBadGateway)
+	require.ErrorContains(t, CheckReachable(context.Background(), srv.URL), "responded with status 502")
+}
+
+func TestCheckLaneConfigConsistency(t *testing.T) {
+	t.Parallel()
+	addr := "0x0000000000000000000000000000000000000001"
+	lanes := func() *laneconfig.Lanes {
+		return &laneconfig.Lanes{LaneConfigs: map[string]*laneconfig.LaneConfig{
+			"A": {
+				CommonContracts: laneconfig.CommonContracts{ARM: addr, Router: addr},
+				SrcContracts:    map[string]laneconfig.SourceContracts{"B": {OnRamp: addr}},
+			},
+			"B": {
+				CommonContracts: laneconfig.CommonContracts{ARM: addr, Router: addr},
+				DestContracts:   map[string]laneconfig.DestContracts{"A": {OffRamp: addr, CommitStore: addr, ReceiverDapp: addr}},
+			},
+		}}
+	}
+	require.NoError(t, CheckLaneConfigConsistency(lanes(), [][2]string{{"A", "B"}}))
+	require.ErrorContains(t, CheckLaneConfigConsistency(lanes(), [][2]string{{"B", "A"}}), "no lane from B to A")
+
+	invalid := lanes()
+	invalid.LaneConfigs["A"].Router = "not-an-address"
+	require.ErrorContains(t, CheckLaneConfigConsistency(invalid, nil), "A: invalid router address")
+
+	missingOffRamp := lanes()
+	missingOffRamp.LaneConfigs["B"].DestContracts = nil
+	require.ErrorContains(t, CheckLaneConfigConsistency(missingOffRamp, nil), "A: on ramp to B, which has no off ramp from A")
+
+	missingNetwork := lanes()
+	delete(missingNetwork.LaneConfigs, "A")
+	require.ErrorContains(t, CheckLaneConfigConsistency(missingNetwork, nil), "B: off ramp from A, which has no lane config")
+}
@@ -0,0 +1,317 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	testutils "github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store_1_2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp_1_2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp_1_2_0"
+)
+
+// DefaultEventResubscribeBackoff is the max backoff between two attempts to resubscribe to an event once the subscription fails
+const DefaultEventResubscribeBackoff = 3 * time.Hour
+
+// latestWrapperVersion is the version of the contracts the latest gethwrappers, which the watchers subscribe with, are generated from
+const latestWrapperVersion = "1.5.0-dev"
+
+// watchedEvent is an event the event watchers subscribe to with the latest gethwrapper of the contract emitting it
+type watchedEvent struct {
+	name          string
+	contractTypes []string          // types of the contract emitting the event as reported by its typeAndVersion
+	abiByVersion  map[string]string // ABIs of the gethwrappers of the contract by the version they are generated from
+}
+
+var (
+	ccipSendRequestedEvent = watchedEvent{
+		name:          "CCIPSendRequested",
+		contractTypes: []string{"EVM2EVMOnRamp"},
+		abiByVersion: map[string]string{
+			latestWrapperVersion: evm_2_evm_onramp.EVM2EVMOnRampABI,
+			"1.2.0":              evm_2_evm_onramp_1_2_0.EVM2EVMOnRampABI,
+		},
+	}
+	reportAcceptedEvent = watchedEvent{
+		name:          "ReportAccepted",
+		contractTypes: []string{"CommitStore"},
+		abiByVersion: map[string]string{
+			latestWrapperVersion: commit_store.CommitStoreABI,
+			"1.2.0":              commit_store_1_2_0.CommitStoreABI,
+		},
+	}
+	executionStateChangedEvent = watchedEvent{
+		name:          "ExecutionStateChanged",
+		contractTypes: []string{"EVM2EVMOffRamp"},
+		abiByVersion: map[string]string{
+			latestWrapperVersion: evm_2_evm_offramp.EVM2EVMOffRampABI,
+			"1.2.0":              evm_2_evm_offramp_1_2_0.EVM2EVMOffRampABI,
+		},
+	}
+	taggedRootBlessedEvent = watchedEvent{
+		name:          "TaggedRootBlessed",
+		contractTypes: []string{"RMN", "ARM"},
+		abiByVersion: map[string]string{
+			latestWrapperVersion: arm_contract.ARMContractABI,
+		},
+	}
+)
+
+// eventID returns the id of the event in the ABI, false if the ABI has no such event
+func eventID(contractABI, eventName string) (common.Hash, bool, error) {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	e, ok := parsed.Events[eventName]
+	return e.ID, ok, nil
+}
+
+// verifyEventABI verifies that the contract of contractType and version with the deployed code emits the event with
+// the same signature as the latest gethwrapper the watchers subscribe with. The event is looked up in the gethwrapper
+// generated from the deployed version, if there is none the deployed code is checked for the event id instead.
+func verifyEventABI(e watchedEvent, contractType, version string, code []byte) error {
+	knownType := false
+	for _, t := range e.contractTypes {
+		knownType = knownType || t == contractType
+	}
+	if !knownType {
+		return fmt.Errorf("contract emitting %s is of type %s, expected one of %v", e.name, contractType, e.contractTypes)
+	}
+	watchedID, ok, err := eventID(e.abiByVersion[latestWrapperVersion], e.name)
+	if err != nil || !ok {
+		return fmt.Errorf("event %s not found in the latest gethwrapper of %s: %w", e.name, contractType, err)
+	}
+	deployedABI, ok := e.abiByVersion[version]
+	if !ok {
+		if !bytes.Contains(code, watchedID.Bytes()) {
+			return fmt.Errorf("no gethwrapper for %s %s and the deployed code does not emit %s with id %s",
+				contractType, version, e.name, watchedID.Hex())
+		}
+		return nil
+	}
+	deployedID, ok, err := eventID(deployedABI, e.name)
+	if err != nil {
+		return fmt.Errorf("failed to parse the gethwrapper ABI of %s %s: %w", contractType, version, err)
+	}
+	if !ok {
+		return fmt.Errorf("event %s not found in the gethwrapper of %s %s", e.name, contractType, version)
+	}
+	if deployedID != watchedID {
+		return fmt.Errorf("event %s of %s %s has id %s, the watchers subscribe with id %s",
+			e.name, contractType, version, deployedID.Hex(), watchedID.Hex())
+	}
+	return nil
+}
+
+// VerifyEventABIs verifies that the deployed lane contracts emit the events the event watchers subscribe to with the
+// latest gethwrappers, so that the setup fails early instead of the watchers missing the events when the gethwrappers
+// and the deployed versions diverge. The version of every contract is resolved with its typeAndVersion.
+func (lane *CCIPLane) VerifyEventABIs() error {
+	type deployedContract struct {
+		event    watchedEvent
+		deployer *contracts.CCIPContractsDeployer
+		client   bind.ContractCaller
+		address  common.Address
+	}
+	deployed := []deployedContract{
+		{ccipSendRequestedEvent, lane.Source.Common.Deployer, lane.Source.Common.ChainClient.Backend(), lane.Source.OnRamp.EthAddress},
+		{reportAcceptedEvent, lane.Dest.Common.Deployer, lane.Dest.Common.ChainClient.Backend(), lane.Dest.CommitStore.EthAddress},
+		{executionStateChangedEvent, lane.Dest.Common.Deployer, lane.Dest.Common.ChainClient.Backend(), lane.Dest.OffRamp.EthAddress},
+	}
+	// the blessing is only watched on a real ARM, the mock ARM does not report its typeAndVersion
+	if lane.Dest.Common.ARM != nil {
+		deployed = append(deployed, deployedContract{
+			taggedRootBlessedEvent, lane.Dest.Common.Deployer, lane.Dest.Common.ChainClient.Backend(), lane.Dest.Common.ARM.EthAddress,
+		})
+	}
+	for _, c := range deployed {
+		contractType, version, err := c.deployer.ContractTypeAndVersion(c.address)
+		if err != nil {
+			return fmt.Errorf("failed to get the type and version of the contract emitting %s: %w", c.event.name, err)
+		}
+		code, err := c.client.CodeAt(lane.Context, c.address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get the code of %s at %s: %w", contractType, c.address.Hex(), err)
+		}
+		if err := verifyEventABI(c.event, contractType, version, code); err != nil {
+			return fmt.Errorf("event ABI drift for %s at %s: %w", contractType, c.address.Hex(), err)
+		}
+		lane.Logger.Debug().
+			Str("Event", c.event.name).
+			Str("Contract", contractType).
+			Str("Version", version).
+			Msg("Verified event ABI")
+	}
+	return nil
+}
+
+// LaneEventSource provides the subscriptions to the lane events consumed by the event watchers started with
+// CCIPLane.StartEventWatchers. The events are delivered on sink till the subscription is unsubscribed or fails,
+// on failure the watchers resubscribe with the same sink.
+type LaneEventSource interface {
+	WatchCCIPSendRequested(sink chan *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) (event.Subscription, error)
+	WatchReportAccepted(sink chan *commit_store.CommitStoreReportAccepted) (event.Subscription, error)
+	WatchTaggedRootBlessed(sink chan *arm_contract.ARMContractTaggedRootBlessed) (event.Subscription, error)
+	WatchExecutionStateChanged(sink chan *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged) (event.Subscription, error)
+}
+
+// contractEventSource watches the events on the deployed lane contracts
+type contractEventSource struct {
+	lane *CCIPLane
+}
+
+func (s contractEventSource) WatchCCIPSendRequested(sink chan *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) (event.Subscription, error) {
+	return s.lane.Source.OnRamp.WatchCCIPSendRequested(nil, sink)
+}
+
+func (s contractEventSource) WatchReportAccepted(sink chan *commit_store.CommitStoreReportAccepted) (event.Subscription, error) {
+	return s.lane.Dest.CommitStore.WatchReportAccepted(nil, sink)
+}
+
+func (s contractEventSource) WatchTaggedRootBlessed(sink chan *arm_contract.ARMContractTaggedRootBlessed) (event.Subscription, error) {
+	return s.lane.Dest.Common.ARM.Instance.WatchTaggedRootBlessed(nil, sink, nil)
+}
+
+func (s contractEventSource) WatchExecutionStateChanged(sink chan *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged) (event.Subscription, error) {
+	return s.lane.Dest.OffRamp.WatchExecutionStateChanged(nil, sink, nil, nil)
+}
+
+// eventSource returns the injected event source of the lane, or the lane contracts if none is injected
+func (lane *CCIPLane) eventSource() LaneEventSource {
+	if lane.EventSource != nil {
+		return lane.EventSource
+	}
+	return contractEventSource{lane: lane}
+}
+
+// startEventWatchers starts a watcher per lane event which records the events from source in the lane watchers
+// till the lane routines are stopped
+func (lane *CCIPLane) startEventWatchers(source LaneEventSource, resubscribeBackoff time.Duration) error {
+	err := watchEvents(lane.Routines(), lane.Logger, "CCIPSendRequested", resubscribeBackoff,
+		source.WatchCCIPSendRequested, lane.onCCIPSendRequested)
+	if err != nil {
+		return err
+	}
+	err = watchEvents(lane.Routines(), lane.Logger, "ReportAccepted", resubscribeBackoff,
+		source.WatchReportAccepted, lane.onReportAccepted)
+	if err != nil {
+		return err
+	}
+	if lane.Dest.Common.ARM != nil {
+		err = watchEvents(lane.Routines(), lane.Logger, "TaggedRootBlessed", resubscribeBackoff,
+			source.WatchTaggedRootBlessed, lane.onTaggedRootBlessed)
+		if err != nil {
+			return err
+		}
+	}
+	return watchEvents(lane.Routines(), lane.Logger, "ExecutionStateChanged", resubscribeBackoff,
+		source.WatchExecutionStateChanged, lane.onExecutionStateChanged)
+}
+
+// watchEvents subscribes to the events with subscribe and calls handle for every event received till routines are
+// stopped. If the subscription fails, it's resubscribed with a backoff of at most resubscribeBackoff. A panic in handle
+// restarts the watcher on the same subscription.
+func watchEvents[T any](
+	routines *Supervisor,
+	lggr zerolog.Logger,
+	eventName string,
+	resubscribeBackoff time.Duration,
+	subscribe func(sink chan T) (event.Subscription, error),
+	handle func(T),
+) error {
+	sink := make(chan T)
+	sub := event.Resubscribe(resubscribeBackoff, func(_ context.Context) (event.Subscription, error) {
+		sub, err := subscribe(sink)
+		if err != nil {
+			lggr.Error().Err(err).Msgf("error in subscribing to %s event", eventName)
+		}
+		return sub, err
+	})
+	if sub == nil {
+		return fmt.Errorf("failed to subscribe to %s event", eventName)
+	}
+	routines.OnStop(sub.Unsubscribe)
+	routines.Go(eventName+" watcher", DefaultRestartPolicy, func(ctx context.Context) error {
+		for {
+			select {
+			case e := <-sink:
+				handle(e)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+	return nil
+}
+
+// onCCIPSendRequested records the message against the hash of the tx it's sent in, a tx can send multiple messages.
+// An event received again, e.g. after a resubscription, is ignored.
+func (lane *CCIPLane) onCCIPSendRequested(e *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) {
+	lane.Logger.Info().Msgf("CCIPSendRequested event received for seq number %d", e.Message.SequenceNumber)
+	txHash := e.Raw.TxHash.Hex()
+	var eventsForTx []*contracts.SendReqEventData
+	if value, ok := lane.Source.CCIPSendRequestedWatcher.Load(txHash); ok && value != nil {
+		eventsForTx = value.([]*contracts.SendReqEventData)
+	}
+	for _, recorded := range eventsForTx {
+		if recorded.MessageId == e.Message.MessageId {
+			return
+		}
+	}
+	lane.Source.CCIPSendRequestedWatcher.Store(txHash, append(eventsForTx, contracts.NewSendReqEventData(e)))
+	lane.Source.CCIPSendRequestedWatcher = testutils.DeleteNilEntriesFromMap(lane.Source.CCIPSendRequestedWatcher)
+}
+
+// onReportAccepted records the commit report against every sequence number in its interval
+func (lane *CCIPLane) onReportAccepted(e *commit_store.CommitStoreReportAccepted) {
+	lane.Logger.Info().Interface("Interval", e.Report.Interval).Msgf("ReportAccepted event received")
+	if lane.Reports != nil {
+		lane.Reports.RecordCommitInterval(e.Report.Interval.Min, e.Report.Interval.Max)
+	}
+	for i := e.Report.Interval.Min; i <= e.Report.Interval.Max; i++ {
+		lane.Dest.ReportAcceptedWatcher.Store(i, &contracts.CommitStoreReportAccepted{
+			Min:        e.Report.Interval.Min,
+			Max:        e.Report.Interval.Max,
+			MerkleRoot: e.Report.MerkleRoot,
+			Raw:        e.Raw,
+		})
+	}
+	lane.Dest.ReportAcceptedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ReportAcceptedWatcher)
+}
+
+// onTaggedRootBlessed records the blessed root if it's committed by the CommitStore of the lane
+func (lane *CCIPLane) onTaggedRootBlessed(e *arm_contract.ARMContractTaggedRootBlessed) {
+	lane.Logger.Info().Msgf("TaggedRootBlessed event received for root %x", e.TaggedRoot.Root)
+	if e.TaggedRoot.CommitStore == lane.Dest.CommitStore.EthAddress {
+		lane.Dest.ReportBlessedWatcher.Store(e.TaggedRoot.Root, &e.Raw)
+	}
+	lane.Dest.ReportBlessedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ReportBlessedWatcher)
+}
+
+// onExecutionStateChanged records the execution state change against the sequence number of the message
+func (lane *CCIPLane) onExecutionStateChanged(e *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged) {
+	lane.Logger.Info().Msgf("Execution state changed event received for seq number %d", e.SequenceNumber)
+	lane.Dest.ExecStateChangedWatcher.Store(e.SequenceNumber, &contracts.EVM2EVMOffRampExecutionStateChanged{
+		SequenceNumber: e.SequenceNumber,
+		MessageId:      e.MessageId,
+		State:          e.State,
+		ReturnData:     e.ReturnData,
+		Raw:            e.Raw,
+	})
+	lane.Dest.ExecStateChangedWatcher = testutils.DeleteNilEntriesFromMap(lane.Dest.ExecStateChangedWatcher)
+}
@@ -0,0 +1,307 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+)
+
+// syntheticStream is an injectable stream of events of a single type
+type syntheticStream[T any] struct {
+	events        chan T
+	fail          chan error
+	subscribeErrs chan error // if an error is queued, the next subscription attempt fails with it
+	subscriptions atomic.Int32
+}
+
+func newSyntheticStream[T any]() *syntheticStream[T] {
+	return &syntheticStream[T]{
+		events:        make(chan T),
+		fail:          make(chan error),
+		subscribeErrs: make(chan error, 1),
+	}
+}
+
+func (s *syntheticStream[T]) subscribe(sink chan T) (event.Subscription, error) {
+	select {
+	case err := <-s.subscribeErrs:
+		return nil, err
+	default:
+	}
+	s.subscriptions.Add(1)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			select {
+			case e := <-s.events:
+				select {
+				case sink <- e:
+				case <-quit:
+					return nil
+				}
+			case err := <-s.fail:
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+type syntheticEventSource struct {
+	sendReq  *syntheticStream[*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested]
+	accepted *syntheticStream[*commit_store.CommitStoreReportAccepted]
+	blessed  *syntheticStream[*arm_contract.ARMContractTaggedRootBlessed]
+	exec     *syntheticStream[*evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged]
+}
+
+func newSyntheticEventSource() *syntheticEventSource {
+	return &syntheticEventSource{
+		sendReq:  newSyntheticStream[*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested](),
+		accepted: newSyntheticStream[*commit_store.CommitStoreReportAccepted](),
+		blessed:  newSyntheticStream[*arm_contract.ARMContractTaggedRootBlessed](),
+		exec:     newSyntheticStream[*evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged](),
+	}
+}
+
+func (s *syntheticEventSource) WatchCCIPSendRequested(sink chan *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) (event.Subscription, error) {
+	return s.sendReq.subscribe(sink)
+}
+
+func (s *syntheticEventSource) WatchReportAccepted(sink chan *commit_store.CommitStoreReportAccepted) (event.Subscription, error) {
+	return s.accepted.subscribe(sink)
+}
+
+func (s *syntheticEventSource) WatchTaggedRootBlessed(sink chan *arm_contract.ARMContractTaggedRootBlessed) (event.Subscription, error) {
+	return s.blessed.subscribe(sink)
+}
+
+func (s *syntheticEventSource) WatchExecutionStateChanged(sink chan *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged) (event.Subscription, error) {
+	return s.exec.subscribe(sink)
+}
+
+var syntheticCommitStore = common.HexToAddress("0x1")
+
+// newSyntheticLane returns a lane with only the state required by the event watchers, watching source.
+// The watchers are stopped with the returned func or at the end of the test.
+func newSyntheticLane(t *testing.T, source LaneEventSource, withARM bool) (*CCIPLane, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	lane := &CCIPLane{
+		Logger:  zerolog.Nop(),
+		Context: ctx,
+		Source: &SourceCCIPModule{
+			CCIPSendRequestedWatcher: &sync.Map{},
+		},
+		Dest: &DestCCIPModule{
+			Common:                  &CCIPCommon{},
+			CommitStore:             &contracts.CommitStore{EthAddress: syntheticCommitStore},
+			ReportAcceptedWatcher:   &sync.Map{},
+			ExecStateChangedWatcher: &sync.Map{},
+			ReportBlessedWatcher:    &sync.Map{},
+		},
+		EventSource: source,
+	}
+	if withARM {
+		lane.Dest.Common.ARM = &contracts.ARM{}
+	}
+	require.NoError(t, lane.startEventWatchers(lane.eventSource(), 10*time.Millisecond))
+	return lane, cancel
+}
+
+func sendRequested(txHash common.Hash, seqNum uint64, messageId byte) *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested {
+	return &evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested{
+		Message: evm_2_evm_onramp.InternalEVM2EVMMessage{
+			SequenceNumber: seqNum,
+			MessageId:      [32]byte{messageId},
+			Data:           []byte("hello"),
+		},
+		Raw: types.Log{TxHash: txHash},
+	}
+}
+
+func sendReqEventsForTx(lane *CCIPLane, txHash common.Hash) []*contracts.SendReqEventData {
+	value, ok := lane.Source.CCIPSendRequestedWatcher.Load(txHash.Hex())
+	if !ok {
+		return nil
+	}
+	return value.([]*contracts.SendReqEventData)
+}
+
+func TestEventWatchersSendRequested(t *testing.T) {
+	t.Parallel()
+	source := newSyntheticEventSource()
+	lane, _ := newSyntheticLane(t, source, false)
+	txHash := common.HexToHash("0xabc")
+
+	// messages sent in the same tx are recorded together
+	source.sendReq.events <- sendRequested(txHash, 1, 1)
+	source.sendReq.events <- sendRequested(txHash, 2, 2)
+	require.Eventually(t, func() bool {
+		return len(sendReqEventsForTx(lane, txHash)) == 2
+	}, time.Second, 5*time.Millisecond)
+	events := sendReqEventsForTx(lane, txHash)
+	require.Equal(t, uint64(1), events[0].SequenceNumber)
+	require.Equal(t, uint64(2), events[1].SequenceNumber)
+	require.Equal(t, 5, events[0].DataLength)
+
+	// an event delivered again is not recorded twice
+	source.sendReq.events <- sendRequested(txHash, 2, 2)
+	otherTx := common.HexToHash("0xdef")
+	source.sendReq.events <- sendRequested(otherTx, 3, 3)
+	require.Eventually(t, func() bool {
+		return len(sendReqEventsForTx(lane, otherTx)) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Len(t, sendReqEventsForTx(lane, txHash), 2)
+}
+
+func TestEventWatchersDeleteNilEntries(t *testing.T) {
+	t.Parallel()
+	source := newSyntheticEventSource()
+	lane, _ := newSyntheticLane(t, source, false)
+	// the validations mark the processed entries as nil, they are dropped with the next event
+	lane.Dest.ReportAcceptedWatcher.Store(uint64(1), nil)
+	lane.Dest.ExecStateChangedWatcher.Store(uint64(1), nil)
+
+	source.accepted.events <- &commit_store.CommitStoreReportAccepted{
+		Report: commit_store.CommitStoreCommitReport{
+			Interval:   commit_store.CommitStoreInterval{Min: 2, Max: 4},
+			MerkleRoot: [32]byte{1},
+		},
+	}
+	source.exec.events <- &evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged{SequenceNumber: 2, State: 2}
+	require.Eventually(t, func() bool {
+		_, execRecorded := lane.Dest.ExecStateChangedWatcher.Load(uint64(2))
+		_, nilExecEntry := lane.Dest.ExecStateChangedWatcher.Load(uint64(1))
+		return execRecorded && !nilExecEntry
+	}, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, nilEntry := lane.Dest.ReportAcceptedWatcher.Load(uint64(1))
+		return !nilEntry
+	}, time.Second, 5*time.Millisecond)
+	for seqNum := uint64(2); seqNum <= 4; seqNum++ {
+		value, ok := lane.Dest.ReportAcceptedWatcher.Load(seqNum)
+		require.True(t, ok, "report should be recorded for seq num %d", seqNum)
+		require.Equal(t, [32]byte{1}, value.(*contracts.CommitStoreReportAccepted).MerkleRoot)
+	}
+}
+
+func TestEventWatchersResubscribe(t *testing.T) {
+	t.Parallel()
+	source := newSyntheticEventSource()
+	// the first attempt to subscribe fails, the watcher keeps trying
+	source.exec.subscribeErrs <- errors.New("connection refused")
+	lane, _ := newSyntheticLane(t, source, false)
+	require.Eventually(t, func() bool {
+		return source.exec.subscriptions.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// the subscription drops, the events after resubscribing are still recorded
+	source.exec.fail <- errors.New("websocket closed")
+	require.Eventually(t, func() bool {
+		return source.exec.subscriptions.Load() == 2
+	}, time.Second, 5*time.Millisecond)
+	source.exec.events <- &evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged{SequenceNumber: 7, State: 2}
+	require.Eventually(t, func() bool {
+		_, ok := lane.Dest.ExecStateChangedWatcher.Load(uint64(7))
+		return ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestEventWatchersBlessed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without ARM", func(t *testing.T) {
+		t.Parallel()
+		source := newSyntheticEventSource()
+		_, _ = newSyntheticLane(t, source, false)
+		require.Eventually(t, func() bool {
+			return source.exec.subscriptions.Load() == 1
+		}, time.Second, 5*time.Millisecond)
+		require.Zero(t, source.blessed.subscriptions.Load(), "blessed events should not be watched without ARM")
+	})
+
+	t.Run("with ARM", func(t *testing.T) {
+		t.Parallel()
+		source := newSyntheticEventSource()
+		lane, _ := newSyntheticLane(t, source, true)
+		source.blessed.events <- &arm_contract.ARMContractTaggedRootBlessed{
+			TaggedRoot: arm_contract.IRMNTaggedRoot{CommitStore: common.HexToAddress("0x2"), Root: [32]byte{2}},
+		}
+		source.blessed.events <- &arm_contract.ARMContractTaggedRootBlessed{
+			TaggedRoot: arm_contract.IRMNTaggedRoot{CommitStore: syntheticCommitStore, Root: [32]byte{3}},
+		}
+		require.Eventually(t, func() bool {
+			_, ok := lane.Dest.ReportBlessedWatcher.Load([32]byte{3})
+			return ok
+		}, time.Second, 5*time.Millisecond)
+		_, ok := lane.Dest.ReportBlessedWatcher.Load([32]byte{2})
+		require.False(t, ok, "roots of other commit stores should be ignored")
+	})
+}
+
+func TestEventWatchersStopWithLaneContext(t *testing.T) {
+	t.Parallel()
+	source := newSyntheticEventSource()
+	_, stop := newSyntheticLane(t, source, false)
+	require.Eventually(t, func() bool {
+		return source.exec.subscriptions.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+	stop()
+	// once the lane context is done the subscription is dropped and no more events are consumed
+	require.Eventually(t, func() bool {
+		select {
+		case source.exec.events <- &evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged{SequenceNumber: 1}:
+			return false
+		case <-time.After(20 * time.Millisecond):
+			return true
+		}
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, int32(1), source.exec.subscriptions.Load(), "watcher should not resubscribe once stopped")
+}
+
+func TestVerifyEventABI(t *testing.T) {
+	t.Parallel()
+	for _, e := range []watchedEvent{ccipSendRequestedEvent, reportAcceptedEvent, executionStateChangedEvent, taggedRootBlessedEvent} {
+		for version := range e.abiByVersion {
+			require.NoError(t, verifyEventABI(e, e.contractTypes[0], version, nil), "%s %s", e.name, version)
+		}
+	}
+
+	err := verifyEventABI(executionStateChangedEvent, "CommitStore", latestWrapperVersion, nil)
+	require.ErrorContains(t, err, "expected one of [EVM2EVMOffRamp]")
+
+	// without a gethwrapper for the deployed version, the deployed code is looked up for the event id
+	watchedID, ok, err := eventID(evm_2_evm_offramp.EVM2EVMOffRampABI, "ExecutionStateChanged")
+	require.NoError(t, err)
+	require.True(t, ok)
+	code := append([]byte{0x60, 0x80, 0x7f}, watchedID.Bytes()...)
+	require.NoError(t, verifyEventABI(executionStateChangedEvent, "EVM2EVMOffRamp", "1.6.0", code))
+	err = verifyEventABI(executionStateChangedEvent, "EVM2EVMOffRamp", "1.6.0", []byte{0x60, 0x80})
+	require.ErrorContains(t, err, "no gethwrapper for EVM2EVMOffRamp 1.6.0")
+
+	drifted := executionStateChangedEvent
+	drifted.abiByVersion = map[string]string{
+		latestWrapperVersion: evm_2_evm_offramp.EVM2EVMOffRampABI,
+		"1.6.0":              `[{"type":"event","name":"ExecutionStateChanged","inputs":[{"name":"sequenceNumber","type":"uint64","indexed":true}]}]`,
+		"1.7.0":              `[{"type":"event","name":"Transmitted","inputs":[]}]`,
+	}
+	err = verifyEventABI(drifted, "EVM2EVMOffRamp", "1.6.0", code)
+	require.ErrorContains(t, err, "the watchers subscribe with id "+watchedID.Hex())
+	err = verifyEventABI(drifted, "EVM2EVMOffRamp", "1.7.0", code)
+	require.ErrorContains(t, err, "event ExecutionStateChanged not found in the gethwrapper of EVM2EVMOffRamp 1.7.0")
+}
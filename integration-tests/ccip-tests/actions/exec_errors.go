@@ -0,0 +1,134 @@
+package actions
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp_1_2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/usdc_token_pool"
+)
+
+// ExecFailureCategory denotes where the failure of a message execution originated from
+type ExecFailureCategory string
+
+const (
+	ExecFailureReceiver      ExecFailureCategory = "ReceiverRevert" // ccipReceive of the receiver reverted
+	ExecFailureTokenHandling ExecFailureCategory = "TokenHandling"  // release/mint of tokens in the destination pool failed
+	ExecFailureOffRamp       ExecFailureCategory = "OffRamp"        // offRamp rejected the message
+	ExecFailureUnknown       ExecFailureCategory = "Unknown"        // return data could not be decoded against known error ABIs
+)
+
+var (
+	// execErrorABIs are the ABIs of all the contracts which can produce the return data of a failed execution
+	execErrorABIs = []string{
+		evm_2_evm_offramp.EVM2EVMOffRampABI,
+		evm_2_evm_offramp_1_2_0.EVM2EVMOffRampABI,
+		maybe_revert_message_receiver.MaybeRevertMessageReceiverABI,
+		lock_release_token_pool.LockReleaseTokenPoolABI,
+		usdc_token_pool.USDCTokenPoolABI,
+	}
+	execErrorsBySelector map[[4]byte]abi.Error
+	loadExecErrors       sync.Once
+	revertSelector       = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector        = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// ExecFailure is the decoded form of the ReturnData in a failed ExecutionStateChanged event
+type ExecFailure struct {
+	Category ExecFailureCategory
+	Reason   string
+}
+
+func execErrors() map[[4]byte]abi.Error {
+	loadExecErrors.Do(func() {
+		execErrorsBySelector = make(map[[4]byte]abi.Error)
+		for _, abiStr := range execErrorABIs {
+			parsed, err := abi.JSON(strings.NewReader(abiStr))
+			if err != nil {
+				continue
+			}
+			for _, e := range parsed.Errors {
+				var selector [4]byte
+				copy(selector[:], e.ID[:4])
+				execErrorsBySelector[selector] = e
+			}
+		}
+	})
+	return execErrorsBySelector
+}
+
+// DecodeExecFailure decodes the ReturnData of a failed ExecutionStateChanged event against the known
+// offRamp, receiver and token pool error ABIs and returns a human-readable reason along with the category of failure.
+func DecodeExecFailure(returnData []byte) ExecFailure {
+	if len(returnData) == 0 {
+		return ExecFailure{
+			Category: ExecFailureUnknown,
+			Reason:   "no return data",
+		}
+	}
+	name, reason, ok := decodeRevertData(returnData)
+	if !ok {
+		return ExecFailure{
+			Category: ExecFailureUnknown,
+			Reason:   reason,
+		}
+	}
+	switch name {
+	case "ReceiverError":
+		return ExecFailure{Category: ExecFailureReceiver, Reason: reason}
+	case "TokenHandlingError":
+		return ExecFailure{Category: ExecFailureTokenHandling, Reason: reason}
+	default:
+		return ExecFailure{Category: ExecFailureOffRamp, Reason: reason}
+	}
+}
+
+// decodeRevertData returns the name of the error and the human-readable form of it.
+// Errors wrapping the revert data of the callee (e.g. ReceiverError(bytes)) are decoded recursively.
+func decodeRevertData(data []byte) (string, string, bool) {
+	if len(data) < 4 {
+		return "", fmt.Sprintf("0x%x", data), false
+	}
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return "", fmt.Sprintf("0x%x", data), false
+		}
+		return "Error", fmt.Sprintf("Error(%s)", reason), true
+	case bytes.Equal(data[:4], panicSelector):
+		return "Panic", fmt.Sprintf("Panic(0x%x)", data[4:]), true
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	e, ok := execErrors()[selector]
+	if !ok {
+		return "", fmt.Sprintf("0x%x", data), false
+	}
+	unpacked, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return e.Name, fmt.Sprintf("%s(0x%x)", e.Name, data[4:]), true
+	}
+	var args []string
+	for _, arg := range unpacked {
+		if b, isBytes := arg.([]byte); isBytes {
+			if len(b) == 0 {
+				// callee reverted without any data, most commonly caused by running out of gas
+				args = append(args, "empty revert data")
+				continue
+			}
+			_, inner, _ := decodeRevertData(b)
+			args = append(args, inner)
+			continue
+		}
+		args = append(args, fmt.Sprintf("%v", arg))
+	}
+	return e.Name, fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", ")), true
+}
@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+)
+
+func TestDecodeExecFailure(t *testing.T) {
+	t.Parallel()
+	offRampABI, err := abi.JSON(strings.NewReader(evm_2_evm_offramp.EVM2EVMOffRampABI))
+	require.NoError(t, err)
+	receiverABI, err := abi.JSON(strings.NewReader(maybe_revert_message_receiver.MaybeRevertMessageReceiverABI))
+	require.NoError(t, err)
+	packError := func(parsed abi.ABI, name string, args ...interface{}) []byte {
+		e := parsed.Errors[name]
+		data, err := e.Inputs.Pack(args...)
+		require.NoError(t, err)
+		return append(e.ID[:4], data...)
+	}
+
+	testCases := []struct {
+		name             string
+		returnData       []byte
+		expectedCategory ExecFailureCategory
+		expectedReason   string
+	}{
+		{
+			name:             "empty return data",
+			returnData:       nil,
+			expectedCategory: ExecFailureUnknown,
+			expectedReason:   "no return data",
+		},
+		{
+			name:             "receiver reverted with custom error",
+			returnData:       packError(offRampABI, "ReceiverError", packError(receiverABI, "ReceiveRevert")),
+			expectedCategory: ExecFailureReceiver,
+			expectedReason:   "ReceiverError(ReceiveRevert())",
+		},
+		{
+			name:             "receiver reverted without data",
+			returnData:       packError(offRampABI, "ReceiverError", []byte{}),
+			expectedCategory: ExecFailureReceiver,
+			expectedReason:   "ReceiverError(empty revert data)",
+		},
+		{
+			name:             "offRamp error",
+			returnData:       packError(offRampABI, "CursedByRMN"),
+			expectedCategory: ExecFailureOffRamp,
+			expectedReason:   "CursedByRMN()",
+		},
+		{
+			name:             "unknown error",
+			returnData:       []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+			expectedCategory: ExecFailureUnknown,
+			expectedReason:   "0x0102030405",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			failure := DecodeExecFailure(tc.returnData)
+			require.Equal(t, tc.expectedCategory, failure.Category)
+			require.Equal(t, tc.expectedReason, failure.Reason)
+		})
+	}
+}
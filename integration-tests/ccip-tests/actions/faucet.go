@@ -0,0 +1,174 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+	"github.com/smartcontractkit/chainlink-testing-framework/utils/conversions"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+const (
+	faucetAddressPlaceholder = "{address}"
+	defaultFaucetMaxRetries  = 3
+	defaultFaucetRetryDelay  = 10 * time.Second
+	defaultFaucetFundsWait   = 5 * time.Minute
+	faucetBalancePollPeriod  = 5 * time.Second
+)
+
+// Faucet requests native funds from the HTTP faucet of a public testnet
+type Faucet struct {
+	cfg    *testconfig.FaucetConfig
+	client *http.Client
+	logger zerolog.Logger
+}
+
+func NewFaucet(lggr zerolog.Logger, cfg *testconfig.FaucetConfig) *Faucet {
+	return &Faucet{
+		cfg:    cfg,
+		client: &http.Client{Timeout: time.Minute},
+		logger: lggr.With().Str("Component", "Faucet").Logger(),
+	}
+}
+
+// expand replaces the address placeholder and the env vars in s
+func (f *Faucet) expand(s string, address common.Address) string {
+	return os.ExpandEnv(strings.ReplaceAll(s, faucetAddressPlaceholder, address.Hex()))
+}
+
+// MinBalance returns the balance in wei below which the faucet is requested
+func (f *Faucet) MinBalance() *big.Int {
+	return conversions.EtherToWei(big.NewFloat(pointer.GetFloat64(f.cfg.MinBalance)))
+}
+
+// faucetRetryAfter returns the delay requested by the faucet in the Retry-After header in seconds, if any
+func faucetRetryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// Request requests funds for address. A request failed with a server error or rate limited is retried up to MaxRetries
+// times, after the delay set by the faucet in Retry-After if any, otherwise after RetryDelay doubled on every retry.
+func (f *Faucet) Request(ctx context.Context, address common.Address) error {
+	maxRetries := defaultFaucetMaxRetries
+	if f.cfg.MaxRetries != nil {
+		maxRetries = *f.cfg.MaxRetries
+	}
+	delay := defaultFaucetRetryDelay
+	if f.cfg.RetryDelay != nil {
+		delay = f.cfg.RetryDelay.Duration()
+	}
+	method := pointer.GetString(f.cfg.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if f.cfg.Body != nil {
+			body = strings.NewReader(f.expand(*f.cfg.Body, address))
+		}
+		req, err := http.NewRequestWithContext(ctx, method, f.expand(pointer.GetString(f.cfg.URL), address), body)
+		if err != nil {
+			return fmt.Errorf("failed to create faucet request: %w", err)
+		}
+		for key, value := range f.cfg.Headers {
+			req.Header.Set(key, f.expand(value, address))
+		}
+		if f.cfg.Body != nil && req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		wait := delay
+		resp, err := f.client.Do(req)
+		if err == nil {
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				f.logger.Info().Str("Address", address.Hex()).Msg("Faucet request accepted")
+				return nil
+			}
+			err = fmt.Errorf("faucet responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+			// other client errors are not going to be fixed by a retry
+			if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+				return err
+			}
+			if retryAfter, ok := faucetRetryAfter(resp); ok {
+				wait = retryAfter
+			}
+		}
+		if attempt >= maxRetries {
+			return fmt.Errorf("faucet request failed after %d attempts: %w", attempt+1, err)
+		}
+		f.logger.Warn().Err(err).Str("Retry In", wait.String()).Int("Attempt", attempt+1).Msg("Faucet request failed, retrying")
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("faucet request cancelled: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// TopUp requests funds for address if its balance on chain is below the min balance of the faucet and waits for the
+// balance to increase. Simulated networks are never topped up.
+func (f *Faucet) TopUp(ctx context.Context, chain blockchain.EVMClient, address common.Address) error {
+	if chain.NetworkSimulated() {
+		return nil
+	}
+	balance, err := chain.BalanceAt(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s on %s: %w", address.Hex(), chain.GetNetworkName(), err)
+	}
+	if balance.Cmp(f.MinBalance()) >= 0 {
+		return nil
+	}
+	f.logger.Info().
+		Str("Network", chain.GetNetworkName()).
+		Str("Address", address.Hex()).
+		Str("Balance", balance.String()).
+		Str("Min Balance", f.MinBalance().String()).
+		Msg("Balance is low, requesting funds from faucet")
+	if err := f.Request(ctx, address); err != nil {
+		return fmt.Errorf("failed to top up %s on %s: %w", address.Hex(), chain.GetNetworkName(), err)
+	}
+	fundsWait := defaultFaucetFundsWait
+	if f.cfg.FundsWait != nil {
+		fundsWait = f.cfg.FundsWait.Duration()
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, fundsWait)
+	defer cancel()
+	ticker := time.NewTicker(faucetBalancePollPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("funds from faucet not received by %s on %s within %s", address.Hex(), chain.GetNetworkName(), fundsWait)
+		case <-ticker.C:
+			newBalance, err := chain.BalanceAt(waitCtx, address)
+			if err != nil {
+				// temporary RPC failures are retried with the next tick
+				continue
+			}
+			// the faucet might send less than the min balance, the increase is what's waited for
+			if newBalance.Cmp(balance) > 0 {
+				f.logger.Info().Str("Network", chain.GetNetworkName()).Str("Balance", newBalance.String()).Msg("Funds received from faucet")
+				return nil
+			}
+		}
+	}
+}
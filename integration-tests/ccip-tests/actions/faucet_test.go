@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+func TestFaucetRequest(t *testing.T) {
+	t.Setenv("FAUCET_API_KEY", "secret")
+	address := common.HexToAddress("0x1")
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/claim/"+address.Hex(), r.URL.Path)
+		assert.Equal(t, `{"address":"`+address.Hex()+`"}`, string(body))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		switch r.URL.Query().Get("status") {
+		case "bad":
+			w.WriteHeader(http.StatusBadRequest)
+		case "down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			// rate limited on the first call
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	newFaucet := func(query string) *Faucet {
+		return NewFaucet(zerolog.Nop(), &testconfig.FaucetConfig{
+			URL:        pointer.ToString(srv.URL + "/claim/{address}" + query),
+			Body:       pointer.ToString(`{"address":"{address}"}`),
+			Headers:    map[string]string{"Authorization": "Bearer ${FAUCET_API_KEY}"},
+			MinBalance: pointer.ToFloat64(1),
+			MaxRetries: pointer.ToInt(2),
+			RetryDelay: commonconfig.MustNewDuration(time.Millisecond),
+		})
+	}
+	ctx := context.Background()
+	require.NoError(t, newFaucet("").Request(ctx, address))
+	require.Equal(t, int32(2), calls.Load())
+
+	err := newFaucet("?status=bad").Request(ctx, address)
+	require.ErrorContains(t, err, "status 400")
+	require.NotContains(t, err.Error(), "attempts")
+
+	err = newFaucet("?status=down").Request(ctx, address)
+	require.ErrorContains(t, err, "failed after 3 attempts")
+	require.ErrorContains(t, err, "status 503")
+}
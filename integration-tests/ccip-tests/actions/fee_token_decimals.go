@@ -0,0 +1,150 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+// FeeQuoteTolerancePPM is the difference allowed between the USD values of the fee quoted in the fee token and in the
+// wrapped native, in parts per million, to account for the rounding of the fee to the smallest unit of each token
+const FeeQuoteTolerancePPM = 1_000
+
+var oneEth = big.NewInt(1e18)
+
+// UsdPerToken returns the price the PriceRegistry expects for a token with decimals, which is the USD value with 18
+// decimals of 1e18 of its smallest unit, given the USD price with 18 decimals of a whole token.
+// For a token with 18 decimals both are the same, for a 6-decimal token priced at 20 USD it's 20e30.
+func UsdPerToken(usdPerWholeToken *big.Int, decimals uint8) *big.Int {
+	if decimals >= 18 {
+		return new(big.Int).Div(usdPerWholeToken, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-18)), nil))
+	}
+	return new(big.Int).Mul(usdPerWholeToken, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(18-decimals)), nil))
+}
+
+// FeeValueUSD returns the USD value with 18 decimals of amount of the smallest unit of a token priced at usdPerToken,
+// as returned by UsdPerToken
+func FeeValueUSD(amount, usdPerToken *big.Int) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(amount, usdPerToken), oneEth)
+}
+
+// withinPPM returns true if a and b differ by at most tolerancePPM parts per million of the larger of the two
+func withinPPM(a, b *big.Int, tolerancePPM int64) bool {
+	larger := a
+	if b.Cmp(a) > 0 {
+		larger = b
+	}
+	if larger.Sign() == 0 {
+		return true
+	}
+	diff := new(big.Int).Abs(new(big.Int).Sub(a, b))
+	return new(big.Int).Mul(diff, big.NewInt(1e6)).Cmp(new(big.Int).Mul(larger, big.NewInt(tolerancePPM))) <= 0
+}
+
+// FeeTokenUsdPrice returns the price of the fee token set in the PriceRegistry, scaled by the decimals of the fee token
+func (ccipModule *CCIPCommon) FeeTokenUsdPrice() (*big.Int, error) {
+	decimals, err := ccipModule.FeeToken.Decimals()
+	if err != nil {
+		return nil, err
+	}
+	return UsdPerToken(LinkToUSD, decimals), nil
+}
+
+// deployFeeToken deploys the fee token, LINK unless FeeTokenDecimals is set to something other than its 18 decimals
+func (ccipModule *CCIPCommon) deployFeeToken() (*contracts.LinkToken, error) {
+	if ccipModule.FeeTokenDecimals == 0 || ccipModule.FeeTokenDecimals == 18 {
+		return ccipModule.Deployer.DeployLinkTokenContract()
+	}
+	// as much as the 1e9 LINK minted to the owner of the LINK token
+	mintAmount := new(big.Int).Mul(big.NewInt(1e9), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(ccipModule.FeeTokenDecimals)), nil))
+	return ccipModule.Deployer.DeployFeeTokenWithDecimals(ccipModule.FeeTokenDecimals, mintAmount)
+}
+
+// ValidateFeeQuote verifies the fee of the lane's message quoted in the fee token is worth as much in USD as the fee
+// quoted in the wrapped native, which has 18 decimals. If the decimals of the fee token are not accounted for in its
+// price, the fee in the fee token is off by orders of magnitude.
+func (lane *CCIPLane) ValidateFeeQuote(gasLimit *big.Int) error {
+	src := lane.Source.Common
+	destChainSelector, err := chainselectors.SelectorFromChainId(lane.Source.DestinationChainId)
+	if err != nil {
+		return fmt.Errorf("failed getting the chain selector: %w", err)
+	}
+	msg, err := lane.Source.CCIPMsg(lane.Dest.ReceiverDapp.EthAddress, gasLimit)
+	if err != nil {
+		return fmt.Errorf("failed to form the ccip message: %w", err)
+	}
+	feeInFeeToken, err := src.Router.GetFee(destChainSelector, msg)
+	if err != nil {
+		return fmt.Errorf("failed getting the fee in fee token: %w", err)
+	}
+	msg.FeeToken = src.WrappedNative
+	feeInNative, err := src.Router.GetFee(destChainSelector, msg)
+	if err != nil {
+		return fmt.Errorf("failed getting the fee in wrapped native: %w", err)
+	}
+	feeTokenPrice, err := src.FeeTokenUsdPrice()
+	if err != nil {
+		return err
+	}
+	feeTokenUSD := FeeValueUSD(feeInFeeToken, feeTokenPrice)
+	nativeUSD := FeeValueUSD(feeInNative, WrappedNativeToUSD)
+	lane.Logger.Info().
+		Str("Fee in fee token", feeInFeeToken.String()).
+		Str("Fee in native", feeInNative.String()).
+		Str("Fee token USD", feeTokenUSD.String()).
+		Str("Native USD", nativeUSD.String()).
+		Msg("Fee quotes")
+	if !withinPPM(feeTokenUSD, nativeUSD, FeeQuoteTolerancePPM) {
+		return fmt.Errorf("fee of %s in fee token %s is worth %s USD, the fee of %s in wrapped native is worth %s USD",
+			feeInFeeToken, src.FeeToken.Address(), feeTokenUSD, feeInNative, nativeUSD)
+	}
+	return nil
+}
+
+// ValidateNopPayout verifies the OnRamp has collected the total fee of the lane's requests on top of nopFeesBefore, then
+// pays the nops, the default wallet being the only nop, and verifies the default wallet receives all the collected fees
+func (lane *CCIPLane) ValidateNopPayout(nopFeesBefore *big.Int) error {
+	src := lane.Source
+	nopFees, err := src.OnRamp.NopFeesJuels()
+	if err != nil {
+		return err
+	}
+	collected := new(big.Int).Sub(nopFees, nopFeesBefore)
+	if collected.Cmp(lane.TotalFee) != 0 {
+		return fmt.Errorf("onRamp collected %s of fee token for the nops, expected the total fee %s of %d requests",
+			collected, lane.TotalFee, lane.NumberOfReq)
+	}
+	nop := src.Common.ChainClient.GetDefaultWallet().Address()
+	balanceBefore, err := src.Common.FeeToken.BalanceOf(lane.Context, nop)
+	if err != nil {
+		return err
+	}
+	if err := src.OnRamp.SetNops(); err != nil {
+		return err
+	}
+	if err := src.OnRamp.PayNops(); err != nil {
+		return err
+	}
+	if err := src.Common.ChainClient.WaitForEvents(); err != nil {
+		return fmt.Errorf("error in waiting for paying nops: %w", err)
+	}
+	balanceAfter, err := src.Common.FeeToken.BalanceOf(lane.Context, nop)
+	if err != nil {
+		return err
+	}
+	paid := new(big.Int).Sub(balanceAfter, balanceBefore)
+	if paid.Cmp(nopFees) != 0 {
+		return fmt.Errorf("nop %s was paid %s of fee token %s, expected %s", nop, paid, src.Common.FeeToken.Address(), nopFees)
+	}
+	remaining, err := src.OnRamp.NopFeesJuels()
+	if err != nil {
+		return err
+	}
+	if remaining.Sign() != 0 {
+		return fmt.Errorf("onRamp has %s of nop fees left after paying the nops", remaining)
+	}
+	return nil
+}
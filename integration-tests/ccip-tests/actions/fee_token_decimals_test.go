@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsdPerToken(t *testing.T) {
+	twenty := new(big.Int).Mul(big.NewInt(20), big.NewInt(1e18))
+	require.Equal(t, twenty, UsdPerToken(twenty, 18))
+	sixDecimals, _ := new(big.Int).SetString("20000000000000000000000000000000", 10)
+	require.Equal(t, sixDecimals, UsdPerToken(twenty, 6))
+
+	// 1 whole token of 6 decimals is worth 20 USD whatever its decimals
+	require.Equal(t, twenty, FeeValueUSD(big.NewInt(1e6), UsdPerToken(twenty, 6)))
+	require.Equal(t, twenty, FeeValueUSD(big.NewInt(1e18), UsdPerToken(twenty, 18)))
+	// the smallest unit of a 6-decimal token is worth 20e-6 USD
+	require.Equal(t, big.NewInt(20e12), FeeValueUSD(big.NewInt(1), UsdPerToken(twenty, 6)))
+}
+
+func TestFeeQuoteTolerance(t *testing.T) {
+	feeTokenPrice := UsdPerToken(LinkToUSD, 6)
+	// a fee of 2.55 USD is 0.1275 of the 6-decimal fee token and 0.0015 of the native
+	feeTokenUSD := FeeValueUSD(big.NewInt(127_500), feeTokenPrice)
+	nativeUSD := FeeValueUSD(big.NewInt(1.5e15), WrappedNativeToUSD)
+	require.Equal(t, feeTokenUSD, nativeUSD)
+	require.True(t, withinPPM(feeTokenUSD, nativeUSD, FeeQuoteTolerancePPM))
+
+	// rounded to the smallest unit of the fee token
+	require.True(t, withinPPM(FeeValueUSD(big.NewInt(127_501), feeTokenPrice), nativeUSD, FeeQuoteTolerancePPM))
+	// priced as if it had 18 decimals
+	require.False(t, withinPPM(FeeValueUSD(big.NewInt(127_500), LinkToUSD), nativeUSD, FeeQuoteTolerancePPM))
+	require.True(t, withinPPM(big.NewInt(0), big.NewInt(0), FeeQuoteTolerancePPM))
+}
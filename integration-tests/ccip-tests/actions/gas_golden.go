@@ -0,0 +1,196 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+// GasSample is the gas used by a tx of a canonical operation of a lane. The operation name captures the shape of the
+// tx which the gas depends on, e.g. the number of tokens in a ccipSend or the number of messages in an exec batch.
+type GasSample struct {
+	Operation string
+	TxHash    string
+	GasUsed   uint64
+}
+
+func ccipSendOperation(noOfTokens int, dataLength int64) string {
+	return fmt.Sprintf("ccipSend/tokens=%d,data=%d", noOfTokens, dataLength)
+}
+
+func commitOperation(noOfMsgs int) string {
+	return fmt.Sprintf("commit/msgs=%d", noOfMsgs)
+}
+
+func execOperation(noOfMsgs int) string {
+	return fmt.Sprintf("exec/msgs=%d", noOfMsgs)
+}
+
+// gasSamples returns the gas samples of the send, commit and exec txs of stats. The requests sharing a tx are counted
+// once, as a commit report of all of them or an exec batch of all of them. Send txs carrying more than one request,
+// i.e. sent with multicall, are not sampled as they are not a plain ccipSend.
+func gasSamples(stats []*testreporters.RequestStat) []GasSample {
+	type txGas struct {
+		gasUsed uint64
+		noOfReq int
+		send    testreporters.TransactionStats
+	}
+	byPhase := map[testreporters.Phase]map[string]*txGas{
+		testreporters.TX:               {},
+		testreporters.Commit:           {},
+		testreporters.ExecStateChanged: {},
+	}
+	for _, stat := range stats {
+		for phase, txs := range byPhase {
+			phaseStat, ok := stat.StatusByPhase[phase]
+			if !ok || phaseStat.Status != testreporters.Success || phaseStat.SendTransactionStats.TxHash == "" {
+				continue
+			}
+			txStats := phaseStat.SendTransactionStats
+			tx, ok := txs[txStats.TxHash]
+			if !ok {
+				tx = &txGas{gasUsed: txStats.GasUsed, send: txStats}
+				txs[txStats.TxHash] = tx
+			}
+			tx.noOfReq++
+		}
+	}
+	var samples []GasSample
+	for phase, txs := range byPhase {
+		for txHash, tx := range txs {
+			if tx.gasUsed == 0 {
+				continue
+			}
+			var op string
+			switch phase {
+			case testreporters.TX:
+				if tx.noOfReq > 1 {
+					continue
+				}
+				op = ccipSendOperation(tx.send.NoOfTokensSent, tx.send.MessageBytesLength)
+			case testreporters.Commit:
+				op = commitOperation(tx.noOfReq)
+			case testreporters.ExecStateChanged:
+				op = execOperation(tx.noOfReq)
+			}
+			samples = append(samples, GasSample{Operation: op, TxHash: txHash, GasUsed: tx.gasUsed})
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].Operation != samples[j].Operation {
+			return samples[i].Operation < samples[j].Operation
+		}
+		return samples[i].TxHash < samples[j].TxHash
+	})
+	return samples
+}
+
+// GasSamples returns the gas samples of the validated requests sent since the last RecordStateBeforeTransfer
+func (lane *CCIPLane) GasSamples() []GasSample {
+	var stats []*testreporters.RequestStat
+	for _, reqs := range lane.SentReqs {
+		for _, req := range reqs {
+			stats = append(stats, req.RequestStat)
+		}
+	}
+	return gasSamples(stats)
+}
+
+// GasGoldenEntry is the expected gas of an operation, samples deviating from it by more than Tolerance, as a fraction
+// of GasUsed, fail the comparison. If Tolerance is not set, the default tolerance of the comparison is used.
+type GasGoldenEntry struct {
+	GasUsed   uint64   `json:"gas_used"`
+	Tolerance *float64 `json:"tolerance,omitempty"`
+}
+
+// GasGolden is the expected gas of the canonical operations keyed by operation name
+type GasGolden map[string]GasGoldenEntry
+
+// ReadGasGolden reads the golden file at path, it returns an error satisfying os.IsNotExist if there is none
+func ReadGasGolden(path string) (GasGolden, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var golden GasGolden
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("failed to parse gas golden file %s: %w", path, err)
+	}
+	for op, entry := range golden {
+		if entry.GasUsed == 0 {
+			return nil, fmt.Errorf("gas used of %s in gas golden file %s should be greater than 0", op, path)
+		}
+	}
+	return golden, nil
+}
+
+// NewGasGolden records the median gas of every operation in samples as its expected gas
+func NewGasGolden(samples []GasSample) GasGolden {
+	byOp := make(map[string][]uint64)
+	for _, s := range samples {
+		byOp[s.Operation] = append(byOp[s.Operation], s.GasUsed)
+	}
+	golden := make(GasGolden)
+	for op, gas := range byOp {
+		sort.Slice(gas, func(i, j int) bool { return gas[i] < gas[j] })
+		golden[op] = GasGoldenEntry{GasUsed: gas[len(gas)/2]}
+	}
+	return golden
+}
+
+// Merge adds the entries of other to g, replacing the existing ones for the same operations
+func (g GasGolden) Merge(other GasGolden) {
+	for op, entry := range other {
+		g[op] = entry
+	}
+}
+
+// Write writes the golden file to path
+func (g GasGolden) Write(path string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create dir for gas golden file %s: %w", path, err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Compare validates samples against the expected gas of their operations. It returns an error listing every sample
+// deviating beyond tolerance, or if none of the samples has a recorded operation, as then nothing is compared.
+// Samples of operations which are not recorded are returned as unmatched.
+func (g GasGolden) Compare(samples []GasSample, defaultTolerance float64) (unmatched []GasSample, err error) {
+	var deviations []string
+	compared := 0
+	for _, s := range samples {
+		entry, ok := g[s.Operation]
+		if !ok {
+			unmatched = append(unmatched, s)
+			continue
+		}
+		compared++
+		tolerance := defaultTolerance
+		if entry.Tolerance != nil {
+			tolerance = *entry.Tolerance
+		}
+		deviation := math.Abs(float64(s.GasUsed)-float64(entry.GasUsed)) / float64(entry.GasUsed)
+		if deviation > tolerance {
+			deviations = append(deviations, fmt.Sprintf("%s used %d gas in tx %s, expected %d ±%.1f%% (deviation %.1f%%)",
+				s.Operation, s.GasUsed, s.TxHash, entry.GasUsed, tolerance*100, deviation*100))
+		}
+	}
+	if len(deviations) > 0 {
+		return unmatched, fmt.Errorf("gas usage deviates from golden file:\n%s", strings.Join(deviations, "\n"))
+	}
+	if compared == 0 && len(samples) > 0 {
+		return unmatched, fmt.Errorf("none of the %d gas samples is of an operation recorded in the golden file", len(samples))
+	}
+	return unmatched, nil
+}
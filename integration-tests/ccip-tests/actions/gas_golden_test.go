@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+func gasStat(send, commit, exec testreporters.TransactionStats) *testreporters.RequestStat {
+	return &testreporters.RequestStat{StatusByPhase: map[testreporters.Phase]testreporters.PhaseStat{
+		testreporters.TX:               {Status: testreporters.Success, SendTransactionStats: send},
+		testreporters.Commit:           {Status: testreporters.Success, SendTransactionStats: commit},
+		testreporters.ExecStateChanged: {Status: testreporters.Success, SendTransactionStats: exec},
+	}}
+}
+
+func TestGasSamples(t *testing.T) {
+	t.Parallel()
+	commit := testreporters.TransactionStats{TxHash: "0xc1", GasUsed: 90_000}
+	stats := []*testreporters.RequestStat{
+		gasStat(testreporters.TransactionStats{TxHash: "0xs1", GasUsed: 150_000, NoOfTokensSent: 1, MessageBytesLength: 0},
+			commit, testreporters.TransactionStats{TxHash: "0xe1", GasUsed: 200_000}),
+		// sent with multicall along with the next one, so the send is not sampled
+		gasStat(testreporters.TransactionStats{TxHash: "0xs2", GasUsed: 400_000, NoOfTokensSent: 1},
+			commit, testreporters.TransactionStats{TxHash: "0xe2", GasUsed: 350_000}),
+		gasStat(testreporters.TransactionStats{TxHash: "0xs2", GasUsed: 400_000, NoOfTokensSent: 1},
+			commit, testreporters.TransactionStats{TxHash: "0xe2", GasUsed: 350_000}),
+	}
+	require.Equal(t, []GasSample{
+		{Operation: "ccipSend/tokens=1,data=0", TxHash: "0xs1", GasUsed: 150_000},
+		{Operation: "commit/msgs=3", TxHash: "0xc1", GasUsed: 90_000},
+		{Operation: "exec/msgs=1", TxHash: "0xe1", GasUsed: 200_000},
+		{Operation: "exec/msgs=2", TxHash: "0xe2", GasUsed: 350_000},
+	}, gasSamples(stats))
+}
+
+func TestGasGolden(t *testing.T) {
+	t.Parallel()
+	samples := []GasSample{
+		{Operation: "exec/msgs=1", TxHash: "0x1", GasUsed: 100},
+		{Operation: "exec/msgs=1", TxHash: "0x2", GasUsed: 300},
+		{Operation: "exec/msgs=1", TxHash: "0x3", GasUsed: 200},
+	}
+	golden := NewGasGolden(samples)
+	require.Equal(t, GasGolden{"exec/msgs=1": {GasUsed: 200}}, golden)
+
+	path := filepath.Join(t.TempDir(), "gas", "golden.json")
+	_, err := ReadGasGolden(path)
+	require.True(t, os.IsNotExist(err))
+	golden.Merge(GasGolden{"commit/msgs=1": {GasUsed: 1000, Tolerance: pointer.ToFloat64(0.2)}})
+	require.NoError(t, golden.Write(path))
+	read, err := ReadGasGolden(path)
+	require.NoError(t, err)
+	require.Equal(t, golden, read)
+
+	unmatched, err := read.Compare([]GasSample{
+		{Operation: "exec/msgs=1", GasUsed: 205},
+		{Operation: "commit/msgs=1", GasUsed: 1150},
+		{Operation: "exec/msgs=5", GasUsed: 1},
+	}, 0.05)
+	require.NoError(t, err)
+	require.Equal(t, []GasSample{{Operation: "exec/msgs=5", GasUsed: 1}}, unmatched)
+
+	_, err = read.Compare([]GasSample{{Operation: "exec/msgs=1", TxHash: "0x4", GasUsed: 220}}, 0.05)
+	require.ErrorContains(t, err, "exec/msgs=1 used 220 gas in tx 0x4, expected 200")
+
+	_, err = read.Compare([]GasSample{{Operation: "exec/msgs=5", GasUsed: 1}}, 0.05)
+	require.ErrorContains(t, err, "none of the 1 gas samples")
+}
@@ -0,0 +1,112 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+// LaneWiring is what the ramps and the commit store of a lane are expected to be configured with on chain, as per the
+// contracts of the lane in the lane config
+type LaneWiring struct {
+	SourceChainSelector uint64
+	DestChainSelector   uint64
+	OnRamp              common.Address
+	CommitStore         common.Address
+	OffRamp             common.Address
+	SourceRouter        common.Address
+	DestRouter          common.Address
+	SourcePriceRegistry common.Address
+	DestPriceRegistry   common.Address
+	SourceARM           common.Address // zero if not known, in which case the ARM of the source contracts is not checked
+	DestARM             common.Address // zero if not known, in which case the ARM of the dest contracts is not checked
+	FeeToken            common.Address
+}
+
+// ExpectedWiring returns the wiring of the lane as per its contracts
+func (lane *CCIPLane) ExpectedWiring() LaneWiring {
+	src, dest := lane.Source, lane.Dest
+	w := LaneWiring{
+		SourceChainSelector: dest.SourceChainSelector,
+		DestChainSelector:   src.DestChainSelector,
+		OnRamp:              src.OnRamp.EthAddress,
+		CommitStore:         dest.CommitStore.EthAddress,
+		OffRamp:             dest.OffRamp.EthAddress,
+		SourceRouter:        src.Common.Router.EthAddress,
+		DestRouter:          dest.Common.Router.EthAddress,
+		SourcePriceRegistry: src.Common.PriceRegistry.EthAddress,
+		DestPriceRegistry:   dest.Common.PriceRegistry.EthAddress,
+		FeeToken:            src.Common.FeeToken.EthAddress,
+	}
+	if src.Common.ARMContract != nil {
+		w.SourceARM = *src.Common.ARMContract
+	}
+	if dest.Common.ARMContract != nil {
+		w.DestARM = *dest.Common.ARMContract
+	}
+	return w
+}
+
+// Check returns an error listing every field of the on chain configs of the OnRamp, the CommitStore and the OffRamp
+// which is not wired as expected, nil if all of them are
+func (w LaneWiring) Check(onRamp contracts.OnRampConfig, commitStore contracts.CommitStoreConfig, offRamp contracts.OffRampConfig) error {
+	var errs error
+	check := func(contract, field string, got, expected any) {
+		if got != expected {
+			errs = multierr.Append(errs, fmt.Errorf("%s %s is %v, expected %v", contract, field, got, expected))
+		}
+	}
+	checkARM := func(contract string, got, expected common.Address) {
+		if expected != (common.Address{}) {
+			check(contract, "ARM proxy", got, expected)
+		}
+	}
+
+	check("onRamp", "chain selector", onRamp.ChainSelector, w.SourceChainSelector)
+	check("onRamp", "dest chain selector", onRamp.DestChainSelector, w.DestChainSelector)
+	check("onRamp", "router", onRamp.Router, w.SourceRouter)
+	check("onRamp", "price registry", onRamp.PriceRegistry, w.SourcePriceRegistry)
+	check("onRamp", "link token", onRamp.LinkToken, w.FeeToken)
+	checkARM("onRamp", onRamp.ARMProxy, w.SourceARM)
+
+	check("commit store", "chain selector", commitStore.ChainSelector, w.DestChainSelector)
+	check("commit store", "source chain selector", commitStore.SourceChainSelector, w.SourceChainSelector)
+	check("commit store", "onRamp", commitStore.OnRamp, w.OnRamp)
+	check("commit store", "price registry", commitStore.PriceRegistry, w.DestPriceRegistry)
+	checkARM("commit store", commitStore.ARMProxy, w.DestARM)
+
+	check("offRamp", "chain selector", offRamp.ChainSelector, w.DestChainSelector)
+	check("offRamp", "source chain selector", offRamp.SourceChainSelector, w.SourceChainSelector)
+	check("offRamp", "commit store", offRamp.CommitStore, w.CommitStore)
+	check("offRamp", "onRamp", offRamp.OnRamp, w.OnRamp)
+	check("offRamp", "router", offRamp.Router, w.DestRouter)
+	check("offRamp", "price registry", offRamp.PriceRegistry, w.DestPriceRegistry)
+	checkARM("offRamp", offRamp.ARMProxy, w.DestARM)
+	return errs
+}
+
+// VerifyWiring reads the configs of the OnRamp, the CommitStore and the OffRamp of the lane from the chains and
+// verifies they are wired with the selectors, the ramps, the routers, the price registries and the ARMs of the lane,
+// so that a cross-wired lane config is caught before any request is sent on the lane
+func (lane *CCIPLane) VerifyWiring() error {
+	onRamp, err := lane.Source.OnRamp.Config()
+	if err != nil {
+		return err
+	}
+	commitStore, err := lane.Dest.CommitStore.Config()
+	if err != nil {
+		return err
+	}
+	offRamp, err := lane.Dest.OffRamp.Config()
+	if err != nil {
+		return err
+	}
+	if err := lane.ExpectedWiring().Check(onRamp, commitStore, offRamp); err != nil {
+		return fmt.Errorf("lane %s -> %s is not wired as per the lane config: %w", lane.SourceNetworkName, lane.DestNetworkName, err)
+	}
+	lane.Logger.Info().Msg("Lane contracts are wired as per the lane config")
+	return nil
+}
@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+func testWiring() (LaneWiring, contracts.OnRampConfig, contracts.CommitStoreConfig, contracts.OffRampConfig) {
+	w := LaneWiring{
+		SourceChainSelector: 1,
+		DestChainSelector:   2,
+		OnRamp:              common.HexToAddress("0x01"),
+		CommitStore:         common.HexToAddress("0x02"),
+		OffRamp:             common.HexToAddress("0x03"),
+		SourceRouter:        common.HexToAddress("0x04"),
+		DestRouter:          common.HexToAddress("0x05"),
+		SourcePriceRegistry: common.HexToAddress("0x06"),
+		DestPriceRegistry:   common.HexToAddress("0x07"),
+		SourceARM:           common.HexToAddress("0x08"),
+		DestARM:             common.HexToAddress("0x09"),
+		FeeToken:            common.HexToAddress("0x0a"),
+	}
+	onRamp := contracts.OnRampConfig{
+		ChainSelector:     w.SourceChainSelector,
+		DestChainSelector: w.DestChainSelector,
+		LinkToken:         w.FeeToken,
+		ARMProxy:          w.SourceARM,
+		Router:            w.SourceRouter,
+		PriceRegistry:     w.SourcePriceRegistry,
+	}
+	commitStore := contracts.CommitStoreConfig{
+		ChainSelector:       w.DestChainSelector,
+		SourceChainSelector: w.SourceChainSelector,
+		OnRamp:              w.OnRamp,
+		ARMProxy:            w.DestARM,
+		PriceRegistry:       w.DestPriceRegistry,
+	}
+	offRamp := contracts.OffRampConfig{
+		ChainSelector:       w.DestChainSelector,
+		SourceChainSelector: w.SourceChainSelector,
+		CommitStore:         w.CommitStore,
+		OnRamp:              w.OnRamp,
+		ARMProxy:            w.DestARM,
+		Router:              w.DestRouter,
+		PriceRegistry:       w.DestPriceRegistry,
+	}
+	return w, onRamp, commitStore, offRamp
+}
+
+func TestLaneWiringCheck(t *testing.T) {
+	w, onRamp, commitStore, offRamp := testWiring()
+	require.NoError(t, w.Check(onRamp, commitStore, offRamp))
+
+	// a commit store and an offRamp of the reverse lane
+	commitStore.SourceChainSelector, commitStore.ChainSelector = w.DestChainSelector, w.SourceChainSelector
+	offRamp.OnRamp = common.HexToAddress("0xff")
+	err := w.Check(onRamp, commitStore, offRamp)
+	require.Error(t, err)
+	require.Len(t, multierr.Errors(err), 3)
+	require.ErrorContains(t, err, "commit store source chain selector is 2, expected 1")
+	require.ErrorContains(t, err, "offRamp onRamp is")
+}
+
+func TestLaneWiringCheckUnknownARM(t *testing.T) {
+	w, onRamp, commitStore, offRamp := testWiring()
+	w.SourceARM, w.DestARM = common.Address{}, common.Address{}
+	onRamp.ARMProxy = common.HexToAddress("0xaa")
+	commitStore.ARMProxy = common.HexToAddress("0xbb")
+	require.NoError(t, w.Check(onRamp, commitStore, offRamp))
+
+	w.DestARM = offRamp.ARMProxy
+	require.ErrorContains(t, w.Check(onRamp, commitStore, offRamp), "commit store ARM proxy")
+}
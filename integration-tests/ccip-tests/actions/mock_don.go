@@ -0,0 +1,413 @@
+package actions
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/abihelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/hashlib"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/merklemulti"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+const (
+	// MockDONSize is the number of oracles set in the OCR2 config by the mock DON, the smallest DON with f=1
+	MockDONSize = 4
+	// MockDONRoundInterval is the interval at which the mock DON commits and executes the sent messages
+	MockDONRoundInterval = 2 * time.Second
+)
+
+var (
+	mockDONCommitReportArgs = abihelpers.MustGetEventInputs("ReportAccepted", abihelpers.MustParseABI(commit_store.CommitStoreABI))
+	mockDONExecReportArgs   = abihelpers.MustGetMethodInputs("manuallyExecute", abihelpers.MustParseABI(evm_2_evm_offramp.EVM2EVMOffRampABI))[:1]
+)
+
+// mockDONRoot is a root committed by the mock DON with the messages it's built from, to be executed
+type mockDONRoot struct {
+	msgs []*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested
+	tree *merklemulti.Tree[[32]byte]
+}
+
+// MockDON commits and executes the messages of a lane in-process, in place of the commit and exec DONs, so that the
+// whole lifecycle of the messages can be tested without CL nodes. It sets the OCR2 config of the CommitStore and the
+// OffRamp with its own signer keys and the default wallet of the dest chain as the transmitter, then builds the
+// reports from the CCIPSendRequested logs of the OnRamp and transmits them.
+// Unlike the DONs, it doesn't wait for the logs to be finalized and it doesn't update the prices, the fees are paid
+// with the prices set at deployment. Only the latest contract versions are supported.
+type MockDON struct {
+	lane          *CCIPLane
+	logger        zerolog.Logger
+	signers       []*ecdsa.PrivateKey
+	transmitters  []common.Address
+	f             uint8
+	leafHasher    testhelpers.LeafHasher
+	epochAndRound uint64
+	nextBlock     uint64                                             // source block from which the CCIPSendRequested logs are to be read
+	pending       []*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested // messages sent and not committed yet, ordered by seq num
+	roots         []mockDONRoot                                      // roots committed and not executed yet
+	mu            sync.Mutex
+}
+
+// NewMockDON creates the mock DON of the lane with new signer keys. The OCR2 config is to be set with SetOCR2Config.
+func NewMockDON(lane *CCIPLane) (*MockDON, error) {
+	if lane.Source.OnRamp.Instance.Latest == nil || lane.Dest.CommitStore.Instance.Latest == nil ||
+		lane.Dest.OffRamp.Instance.Latest == nil {
+		return nil, fmt.Errorf("mock DON only supports the latest OnRamp, CommitStore and OffRamp")
+	}
+	sourceChainSelector, err := chainselectors.SelectorFromChainId(lane.Source.Common.ChainClient.GetChainID().Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("failed getting the source chain selector: %w", err)
+	}
+	d := &MockDON{
+		lane:   lane,
+		logger: lane.Logger.With().Str("Component", "MockDON").Logger(),
+		f:      1,
+		leafHasher: testhelpers.NewLeafHasher(
+			sourceChainSelector, lane.Source.DestChainSelector, lane.Source.OnRamp.EthAddress, lane.Source.OnRamp.Instance.Latest,
+		),
+		nextBlock: lane.Source.SrcStartBlock,
+	}
+	// the reports are transmitted by the default wallet, the other transmitters are only there to fill the DON
+	d.transmitters = append(d.transmitters, common.HexToAddress(lane.Dest.Common.ChainClient.GetDefaultWallet().Address()))
+	for i := 0; i < MockDONSize; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed generating signer key: %w", err)
+		}
+		d.signers = append(d.signers, key)
+		if i > 0 {
+			// the signer addresses are never used to send txs
+			d.transmitters = append(d.transmitters, crypto.PubkeyToAddress(key.PublicKey))
+		}
+	}
+	return d, nil
+}
+
+// SignerAddresses returns the addresses of the signer keys of the mock DON
+func (d *MockDON) SignerAddresses() []common.Address {
+	var addresses []common.Address
+	for _, key := range d.signers {
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return addresses
+}
+
+// SetOCR2Config sets the mock DON as the oracles of the CommitStore and the OffRamp
+func (d *MockDON) SetOCR2Config() error {
+	destCCIP := d.lane.Dest
+	timing := destCCIP.laneTiming()
+	commitOnchainConfig, err := abihelpers.EncodeAbiStruct(testhelpers.NewCommitOnchainConfig(
+		destCCIP.Common.PriceRegistry.EthAddress,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to encode commit onchain config: %w", err)
+	}
+	// the offchain config is only read by the plugins
+	err = destCCIP.CommitStore.SetOCR2Config(d.SignerAddresses(), d.transmitters, d.f, commitOnchainConfig, 0, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set ocr2 config for commit: %w", err)
+	}
+	execOnchainConfig, err := abihelpers.EncodeAbiStruct(testhelpers.NewExecOnchainConfig(
+		uint32(timing.permissionlessExecThreshold.Seconds()),
+		destCCIP.Common.Router.EthAddress,
+		destCCIP.Common.PriceRegistry.EthAddress,
+		DefaultMaxNoOfTokensInMsg,
+		MaxDataBytes,
+		200_000,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to encode exec onchain config: %w", err)
+	}
+	err = destCCIP.OffRamp.SetOCR2Config(d.SignerAddresses(), d.transmitters, d.f, execOnchainConfig, 0, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set ocr2 config for exec: %w", err)
+	}
+	return destCCIP.Common.ChainClient.WaitForEvents()
+}
+
+// Start runs the rounds of the mock DON every MockDONRoundInterval with routines until they are stopped. The failed
+// rounds are logged and retried with the next round.
+func (d *MockDON) Start(routines *Supervisor) {
+	routines.Go("mock DON", DefaultRestartPolicy, func(ctx context.Context) error {
+		ticker := time.NewTicker(MockDONRoundInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				d.logger.Info().Msg("Stopping mock DON")
+				return nil
+			case <-ticker.C:
+				if err := d.Round(ctx); err != nil {
+					d.logger.Error().Err(err).Msg("Mock DON round failed")
+				}
+			}
+		}
+	})
+}
+
+// Round commits the messages sent since the last round and executes the committed ones
+func (d *MockDON) Round(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.readSentMsgs(ctx); err != nil {
+		return err
+	}
+	if err := d.commit(); err != nil {
+		return err
+	}
+	return d.execute()
+}
+
+// readSentMsgs adds the messages sent since the last read to the pending ones
+func (d *MockDON) readSentMsgs(ctx context.Context) error {
+	latest, err := d.lane.Source.Common.ChainClient.LatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest source block: %w", err)
+	}
+	if latest < d.nextBlock {
+		return nil
+	}
+	end := latest
+	it, err := d.lane.Source.OnRamp.Instance.Latest.FilterCCIPSendRequested(&bind.FilterOpts{Start: d.nextBlock, End: &end, Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to filter CCIPSendRequested logs: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		d.pending = append(d.pending, it.Event)
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to read CCIPSendRequested logs: %w", err)
+	}
+	d.nextBlock = latest + 1
+	return nil
+}
+
+// commit commits the pending messages in a single root, up to MaxCommitIntervalSize of them
+func (d *MockDON) commit() error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+	expected, err := d.lane.Dest.CommitStore.Instance.GetExpectedNextSequenceNumber(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get expected next seq num: %w", err)
+	}
+	// drop the messages committed already, e.g. by a previous config of the CommitStore
+	for len(d.pending) > 0 && d.pending[0].Message.SequenceNumber < expected {
+		d.pending = d.pending[1:]
+	}
+	if len(d.pending) == 0 {
+		return nil
+	}
+	msgs := d.pending[:min(uint64(len(d.pending)), MaxCommitIntervalSize)]
+	report, tree, err := mockDONCommitReport(d.leafHasher, msgs)
+	if err != nil {
+		return err
+	}
+	digest, err := d.lane.Dest.CommitStore.Instance.LatestConfigDigest(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get commit config digest: %w", err)
+	}
+	d.epochAndRound++
+	reportContext := mockDONReportContext(digest, d.epochAndRound)
+	rs, ss, rawVs, err := signMockDONReport(d.signers[:d.f+1], reportContext, report)
+	if err != nil {
+		return err
+	}
+	txHash, err := d.lane.Dest.CommitStore.Transmit(reportContext, report, rs, ss, rawVs)
+	if err != nil {
+		return err
+	}
+	if err := d.confirm(txHash); err != nil {
+		return fmt.Errorf("commit report for seq nums %d-%d: %w",
+			msgs[0].Message.SequenceNumber, msgs[len(msgs)-1].Message.SequenceNumber, err)
+	}
+	d.logger.Info().
+		Uint64("Min", msgs[0].Message.SequenceNumber).
+		Uint64("Max", msgs[len(msgs)-1].Message.SequenceNumber).
+		Str("TxHash", txHash.Hex()).
+		Msg("Mock DON committed messages")
+	d.roots = append(d.roots, mockDONRoot{msgs: msgs, tree: tree})
+	d.pending = d.pending[len(msgs):]
+	return nil
+}
+
+// execute executes the messages of every committed root in a single report
+func (d *MockDON) execute() error {
+	for len(d.roots) > 0 {
+		root := d.roots[0]
+		report, err := mockDONExecReport(root)
+		if err != nil {
+			return err
+		}
+		digest, err := d.lane.Dest.OffRamp.Instance.LatestConfigDigest(nil)
+		if err != nil {
+			return fmt.Errorf("failed to get exec config digest: %w", err)
+		}
+		d.epochAndRound++
+		txHash, err := d.lane.Dest.OffRamp.Transmit(mockDONReportContext(digest, d.epochAndRound), report)
+		if err != nil {
+			return err
+		}
+		if err := d.confirm(txHash); err != nil {
+			return fmt.Errorf("execution report for root %x: %w", root.tree.Root(), err)
+		}
+		d.logger.Info().
+			Int("Messages", len(root.msgs)).
+			Str("Root", fmt.Sprintf("%x", root.tree.Root())).
+			Str("TxHash", txHash.Hex()).
+			Msg("Mock DON executed messages")
+		d.roots = d.roots[1:]
+	}
+	return nil
+}
+
+// confirm waits for the tx to be mined on the dest chain and checks that it didn't revert
+func (d *MockDON) confirm(txHash common.Hash) error {
+	return confirmTx(d.lane.Dest.Common.ChainClient, txHash)
+}
+
+func confirmTx(client blockchain.EVMClient, txHash common.Hash) error {
+	if err := client.WaitForEvents(); err != nil {
+		return fmt.Errorf("failed waiting for tx %s: %w", txHash.Hex(), err)
+	}
+	rcpt, err := client.GetTxReceipt(txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt of tx %s: %w", txHash.Hex(), err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("tx %s reverted", txHash.Hex())
+	}
+	return nil
+}
+
+// mockDONReportContext returns the OCR2 report context of the config digest, with the epoch and round in the last
+// 5 bytes of the second word
+func mockDONReportContext(configDigest [32]byte, epochAndRound uint64) [3][32]byte {
+	var epochAndRoundWord [32]byte
+	binary.BigEndian.PutUint64(epochAndRoundWord[24:], epochAndRound)
+	return [3][32]byte{configDigest, epochAndRoundWord, {}}
+}
+
+// signMockDONReport signs the report in the report context with every signer, the way the OCR2 contracts verify it
+func signMockDONReport(signers []*ecdsa.PrivateKey, reportContext [3][32]byte, report []byte) (rs, ss [][32]byte, rawVs [32]byte, err error) {
+	reportHash := crypto.Keccak256(report)
+	h := crypto.Keccak256(reportHash, reportContext[0][:], reportContext[1][:], reportContext[2][:])
+	for i, key := range signers {
+		sig, err := crypto.Sign(h, key)
+		if err != nil {
+			return nil, nil, [32]byte{}, fmt.Errorf("failed signing report: %w", err)
+		}
+		var r, s [32]byte
+		copy(r[:], sig[:32])
+		copy(s[:], sig[32:64])
+		rs = append(rs, r)
+		ss = append(ss, s)
+		rawVs[i] = sig[64]
+	}
+	return rs, ss, rawVs, nil
+}
+
+// mockDONCommitReport returns the encoded commit report of the messages along with the merkle tree of its root
+func mockDONCommitReport(
+	leafHasher testhelpers.LeafHasher,
+	msgs []*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested,
+) ([]byte, *merklemulti.Tree[[32]byte], error) {
+	var leaves [][32]byte
+	for _, msg := range msgs {
+		leaf, err := leafHasher.HashLeaf(msg.Raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed hashing message %d: %w", msg.Message.SequenceNumber, err)
+		}
+		leaves = append(leaves, leaf)
+	}
+	tree, err := merklemulti.NewTree(hashlib.NewKeccakCtx(), leaves)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed building merkle tree: %w", err)
+	}
+	report, err := mockDONCommitReportArgs.PackValues([]interface{}{commit_store.CommitStoreCommitReport{
+		PriceUpdates: commit_store.InternalPriceUpdates{
+			TokenPriceUpdates: []commit_store.InternalTokenPriceUpdate{},
+			GasPriceUpdates:   []commit_store.InternalGasPriceUpdate{},
+		},
+		Interval: commit_store.CommitStoreInterval{
+			Min: msgs[0].Message.SequenceNumber,
+			Max: msgs[len(msgs)-1].Message.SequenceNumber,
+		},
+		MerkleRoot: tree.Root(),
+	}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed encoding commit report: %w", err)
+	}
+	return report, tree, nil
+}
+
+// mockDONExecReport returns the encoded execution report of all the messages of the root
+func mockDONExecReport(root mockDONRoot) ([]byte, error) {
+	var indices []int
+	var msgs []evm_2_evm_offramp.InternalEVM2EVMMessage
+	var offchainTokenData [][][]byte
+	for i, e := range root.msgs {
+		indices = append(indices, i)
+		msg := e.Message
+		var tokenAmounts []evm_2_evm_offramp.ClientEVMTokenAmount
+		for _, tokenAmount := range msg.TokenAmounts {
+			tokenAmounts = append(tokenAmounts, evm_2_evm_offramp.ClientEVMTokenAmount{
+				Token:  tokenAmount.Token,
+				Amount: tokenAmount.Amount,
+			})
+		}
+		msgs = append(msgs, evm_2_evm_offramp.InternalEVM2EVMMessage{
+			SourceChainSelector: msg.SourceChainSelector,
+			Sender:              msg.Sender,
+			Receiver:            msg.Receiver,
+			SequenceNumber:      msg.SequenceNumber,
+			GasLimit:            msg.GasLimit,
+			Strict:              msg.Strict,
+			Nonce:               msg.Nonce,
+			FeeToken:            msg.FeeToken,
+			FeeTokenAmount:      msg.FeeTokenAmount,
+			Data:                msg.Data,
+			TokenAmounts:        tokenAmounts,
+			SourceTokenData:     msg.SourceTokenData,
+			MessageId:           msg.MessageId,
+		})
+		// the mock DON has no attestation to provide, which is only required by the USDC pools
+		offchainTokenData = append(offchainTokenData, make([][]byte, len(msg.TokenAmounts)))
+	}
+	proof, err := root.tree.Prove(indices)
+	if err != nil {
+		return nil, fmt.Errorf("failed proving messages: %w", err)
+	}
+	proofFlagBits := abihelpers.ProofFlagsToBits(proof.SourceFlags)
+	if proofFlagBits == nil {
+		proofFlagBits = big.NewInt(0)
+	}
+	report, err := mockDONExecReportArgs.PackValues([]interface{}{evm_2_evm_offramp.InternalExecutionReport{
+		Messages:          msgs,
+		OffchainTokenData: offchainTokenData,
+		Proofs:            proof.Hashes,
+		ProofFlagBits:     proofFlagBits,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding execution report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,113 @@
+package actions
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/hashlib"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/pkg/merklemulti"
+)
+
+// keccakLeafHasher hashes the data of the logs, in place of the onRamp leaf hasher which needs a deployed onRamp
+type keccakLeafHasher struct{}
+
+func (keccakLeafHasher) HashLeaf(log types.Log) ([32]byte, error) {
+	return crypto.Keccak256Hash(log.Data), nil
+}
+
+func mockDONSentMsgs(seqNums ...uint64) []*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested {
+	var msgs []*evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested
+	for _, seqNum := range seqNums {
+		msgs = append(msgs, &evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested{
+			Message: evm_2_evm_onramp.InternalEVM2EVMMessage{
+				SequenceNumber: seqNum,
+				GasLimit:       big.NewInt(200_000),
+				FeeTokenAmount: big.NewInt(1),
+				Data:           []byte("hello"),
+				TokenAmounts: []evm_2_evm_onramp.ClientEVMTokenAmount{
+					{Token: common.HexToAddress("0x1"), Amount: big.NewInt(10)},
+				},
+				SourceTokenData: [][]byte{{}},
+			},
+			Raw: types.Log{Data: new(big.Int).SetUint64(seqNum).Bytes()},
+		})
+	}
+	return msgs
+}
+
+func TestSignMockDONReport(t *testing.T) {
+	t.Parallel()
+	var signers []*ecdsa.PrivateKey
+	for i := 0; i < 2; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers = append(signers, key)
+	}
+	report := []byte("report")
+	reportContext := mockDONReportContext([32]byte{1}, 5)
+	require.Equal(t, byte(5), reportContext[1][31])
+
+	rs, ss, rawVs, err := signMockDONReport(signers, reportContext, report)
+	require.NoError(t, err)
+	require.Len(t, rs, len(signers))
+	require.Len(t, ss, len(signers))
+	h := crypto.Keccak256(crypto.Keccak256(report), reportContext[0][:], reportContext[1][:], reportContext[2][:])
+	for i, key := range signers {
+		sig := append(append(rs[i][:], ss[i][:]...), rawVs[i])
+		pub, err := crypto.SigToPub(h, sig)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+	}
+}
+
+func TestMockDONReports(t *testing.T) {
+	t.Parallel()
+	msgs := mockDONSentMsgs(3, 4, 5)
+	report, tree, err := mockDONCommitReport(keccakLeafHasher{}, msgs)
+	require.NoError(t, err)
+
+	unpacked, err := mockDONCommitReportArgs.Unpack(report)
+	require.NoError(t, err)
+	require.Len(t, unpacked, 1)
+	commitReport := *abi.ConvertType(unpacked[0], new(commit_store.CommitStoreCommitReport)).(*commit_store.CommitStoreCommitReport)
+	require.Equal(t, commit_store.CommitStoreInterval{Min: 3, Max: 5}, commitReport.Interval)
+	require.Equal(t, tree.Root(), commitReport.MerkleRoot)
+
+	report, err = mockDONExecReport(mockDONRoot{msgs: msgs, tree: tree})
+	require.NoError(t, err)
+	unpacked, err = mockDONExecReportArgs.Unpack(report)
+	require.NoError(t, err)
+	require.Len(t, unpacked, 1)
+	execReport := *abi.ConvertType(unpacked[0], new(evm_2_evm_offramp.InternalExecutionReport)).(*evm_2_evm_offramp.InternalExecutionReport)
+	require.Len(t, execReport.Messages, len(msgs))
+	require.Equal(t, [][][]byte{{{}}, {{}}, {{}}}, execReport.OffchainTokenData)
+
+	var leaves [][32]byte
+	var proofFlags []bool
+	for i, msg := range execReport.Messages {
+		require.Equal(t, msgs[i].Message.SequenceNumber, msg.SequenceNumber)
+		require.Equal(t, msgs[i].Message.TokenAmounts[0].Amount, msg.TokenAmounts[0].Amount)
+		leaf, err := keccakLeafHasher{}.HashLeaf(msgs[i].Raw)
+		require.NoError(t, err)
+		leaves = append(leaves, leaf)
+	}
+	for i := 0; i < len(leaves)+len(execReport.Proofs)-1; i++ {
+		proofFlags = append(proofFlags, execReport.ProofFlagBits.Bit(i) == 1)
+	}
+	root, err := merklemulti.VerifyComputeRoot(hashlib.NewKeccakCtx(), leaves, merklemulti.Proof[[32]byte]{
+		Hashes:      execReport.Proofs,
+		SourceFlags: proofFlags,
+	})
+	require.NoError(t, err)
+	require.Equal(t, commitReport.MerkleRoot, root)
+}
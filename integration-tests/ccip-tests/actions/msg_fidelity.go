@@ -0,0 +1,132 @@
+package actions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+var (
+	evmExtraArgsV1Tag = []byte{0x97, 0xa6, 0x57, 0xc9}
+	evmExtraArgsV2Tag = []byte{0x18, 0x1d, 0xcf, 0x10}
+)
+
+// RecordSentMsgs records the messages sent to the router in txHash, in the order they are sent, so that they
+// can be compared with the fields of the CCIPSendRequested events emitted for them
+func (sourceCCIP *SourceCCIPModule) RecordSentMsgs(txHash common.Hash, msgs ...router.ClientEVM2AnyMessage) {
+	if sourceCCIP.SentMsgs == nil {
+		return
+	}
+	sourceCCIP.SentMsgs.Store(txHash.Hex(), msgs)
+}
+
+// validateSentMsgs compares the messages recorded for txHash with the CCIPSendRequested events emitted in the tx.
+// It's a no-op if no message is recorded for the tx.
+func (sourceCCIP *SourceCCIPModule) validateSentMsgs(txHash string, events []*contracts.SendReqEventData) error {
+	if sourceCCIP.SentMsgs == nil {
+		return nil
+	}
+	value, ok := sourceCCIP.SentMsgs.LoadAndDelete(txHash)
+	if !ok {
+		return nil
+	}
+	sent := value.([]router.ClientEVM2AnyMessage)
+	if len(sent) != len(events) {
+		return fmt.Errorf("%d messages sent in tx %s, found %d CCIPSendRequested events", len(sent), txHash, len(events))
+	}
+	// the events are emitted in the order the messages are sent
+	emitted := append([]*contracts.SendReqEventData{}, events...)
+	sort.Slice(emitted, func(i, j int) bool {
+		return emitted[i].Raw.Index < emitted[j].Raw.Index
+	})
+	var err error
+	for i, msg := range sent {
+		if msgErr := ValidateSendRequestedFields(msg, sourceCCIP.Common.WrappedNative, emitted[i]); msgErr != nil {
+			err = multierr.Append(err, fmt.Errorf("message %d of tx %s: %w", i+1, txHash, msgErr))
+		}
+	}
+	return err
+}
+
+// ValidateSendRequestedFields compares the message sent to the router with the fields of the CCIPSendRequested
+// event emitted for it, to catch any encoding drift between the client and the OnRamp. As the OnRamp charges
+// the fee in wrapped native for the messages sent with native fee, wrappedNative is expected as the fee token
+// in the event for such messages.
+func ValidateSendRequestedFields(sent router.ClientEVM2AnyMessage, wrappedNative common.Address, emitted *contracts.SendReqEventData) error {
+	var err error
+	if len(sent.Receiver) != 32 {
+		err = multierr.Append(err, fmt.Errorf("receiver %x sent is not an abi encoded address", sent.Receiver))
+	} else if receiver := common.BytesToAddress(sent.Receiver); receiver != emitted.Receiver {
+		err = multierr.Append(err, fmt.Errorf("receiver mismatch: sent %s, emitted %s", receiver.Hex(), emitted.Receiver.Hex()))
+	}
+	if !bytes.Equal(sent.Data, emitted.Data) {
+		err = multierr.Append(err, fmt.Errorf("data mismatch: sent %x, emitted %x", sent.Data, emitted.Data))
+	}
+	if len(sent.TokenAmounts) != len(emitted.TokenAmounts) {
+		err = multierr.Append(err, fmt.Errorf("token amounts mismatch: sent %d tokens, emitted %d tokens",
+			len(sent.TokenAmounts), len(emitted.TokenAmounts)))
+	} else {
+		for i, tokenAmount := range sent.TokenAmounts {
+			if tokenAmount.Token != emitted.TokenAmounts[i].Token || !bigEqual(tokenAmount.Amount, emitted.TokenAmounts[i].Amount) {
+				err = multierr.Append(err, fmt.Errorf("token amount %d mismatch: sent %s %s, emitted %s %s", i,
+					tokenAmount.Amount, tokenAmount.Token.Hex(), emitted.TokenAmounts[i].Amount, emitted.TokenAmounts[i].Token.Hex()))
+			}
+		}
+	}
+	expectedFeeToken := sent.FeeToken
+	if expectedFeeToken == (common.Address{}) {
+		expectedFeeToken = wrappedNative
+	}
+	if expectedFeeToken != emitted.FeeToken {
+		err = multierr.Append(err, fmt.Errorf("fee token mismatch: expected %s, emitted %s", expectedFeeToken.Hex(), emitted.FeeToken.Hex()))
+	}
+	// with no extra args the OnRamp applies the default gas limit, there is nothing to compare with
+	if len(sent.ExtraArgs) > 0 {
+		gasLimit, strict, decodeErr := decodeEVMExtraArgs(sent.ExtraArgs)
+		if decodeErr != nil {
+			err = multierr.Append(err, decodeErr)
+		} else {
+			if !bigEqual(gasLimit, emitted.GasLimit) {
+				err = multierr.Append(err, fmt.Errorf("gas limit mismatch: sent %s, emitted %s", gasLimit, emitted.GasLimit))
+			}
+			if strict != emitted.Strict {
+				err = multierr.Append(err, fmt.Errorf("strict mismatch: sent %t, emitted %t", strict, emitted.Strict))
+			}
+		}
+	}
+	return err
+}
+
+// decodeEVMExtraArgs decodes the gas limit and the strict flag from EVMExtraArgsV1, or the gas limit from EVMExtraArgsV2.
+// Both are abi encoded as a uint256 followed by a bool after the tag.
+func decodeEVMExtraArgs(extraArgs []byte) (*big.Int, bool, error) {
+	if len(extraArgs) != 4+64 {
+		return nil, false, fmt.Errorf("extra args %x are not EVMExtraArgs", extraArgs)
+	}
+	tag, args := extraArgs[:4], extraArgs[4:]
+	gasLimit := new(big.Int).SetBytes(args[:32])
+	flag := new(big.Int).SetBytes(args[32:]).Sign() != 0
+	switch {
+	case bytes.Equal(tag, evmExtraArgsV1Tag):
+		return gasLimit, flag, nil
+	case bytes.Equal(tag, evmExtraArgsV2Tag):
+		// the second field of V2 is allowOutOfOrderExecution, not strict
+		return gasLimit, false, nil
+	default:
+		return nil, false, fmt.Errorf("unknown extra args tag %x", tag)
+	}
+}
+
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Cmp(b) == 0
+}
@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+var (
+	fidelityReceiver      = common.HexToAddress("0x1111")
+	fidelityToken         = common.HexToAddress("0x2222")
+	fidelityFeeToken      = common.HexToAddress("0x3333")
+	fidelityWrappedNative = common.HexToAddress("0x4444")
+)
+
+func sentMsgForFidelity(t *testing.T, feeToken common.Address) router.ClientEVM2AnyMessage {
+	receiver, err := utils.ABIEncode(`[{"type":"address"}]`, fidelityReceiver)
+	require.NoError(t, err)
+	extraArgs, err := testhelpers.GetEVMExtraArgsV1(big.NewInt(200_000), false)
+	require.NoError(t, err)
+	return router.ClientEVM2AnyMessage{
+		Receiver:     receiver,
+		Data:         []byte("hello"),
+		TokenAmounts: []router.ClientEVMTokenAmount{{Token: fidelityToken, Amount: big.NewInt(10)}},
+		FeeToken:     feeToken,
+		ExtraArgs:    extraArgs,
+	}
+}
+
+func emittedForFidelity(feeToken common.Address) *contracts.SendReqEventData {
+	return &contracts.SendReqEventData{
+		Receiver:     fidelityReceiver,
+		Data:         []byte("hello"),
+		TokenAmounts: []router.ClientEVMTokenAmount{{Token: fidelityToken, Amount: big.NewInt(10)}},
+		FeeToken:     feeToken,
+		GasLimit:     big.NewInt(200_000),
+	}
+}
+
+func TestValidateSendRequestedFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matching fields", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, ValidateSendRequestedFields(sentMsgForFidelity(t, fidelityFeeToken), fidelityWrappedNative, emittedForFidelity(fidelityFeeToken)))
+	})
+
+	t.Run("native fee is charged in wrapped native", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, ValidateSendRequestedFields(sentMsgForFidelity(t, common.Address{}), fidelityWrappedNative, emittedForFidelity(fidelityWrappedNative)))
+		require.Error(t, ValidateSendRequestedFields(sentMsgForFidelity(t, common.Address{}), fidelityWrappedNative, emittedForFidelity(common.Address{})))
+	})
+
+	t.Run("no extra args", func(t *testing.T) {
+		t.Parallel()
+		sent := sentMsgForFidelity(t, fidelityFeeToken)
+		sent.ExtraArgs = nil
+		// the OnRamp applies its default gas limit
+		emitted := emittedForFidelity(fidelityFeeToken)
+		emitted.GasLimit = big.NewInt(123_456)
+		require.NoError(t, ValidateSendRequestedFields(sent, fidelityWrappedNative, emitted))
+	})
+
+	mismatches := []struct {
+		name   string
+		mutate func(*contracts.SendReqEventData)
+		errMsg string
+	}{
+		{"receiver", func(e *contracts.SendReqEventData) { e.Receiver = common.HexToAddress("0x5555") }, "receiver mismatch"},
+		{"data", func(e *contracts.SendReqEventData) { e.Data = []byte("hell") }, "data mismatch"},
+		{"token count", func(e *contracts.SendReqEventData) { e.TokenAmounts = nil }, "token amounts mismatch"},
+		{"token amount", func(e *contracts.SendReqEventData) { e.TokenAmounts[0].Amount = big.NewInt(11) }, "token amount 0 mismatch"},
+		{"fee token", func(e *contracts.SendReqEventData) { e.FeeToken = fidelityWrappedNative }, "fee token mismatch"},
+		{"gas limit", func(e *contracts.SendReqEventData) { e.GasLimit = big.NewInt(100_000) }, "gas limit mismatch"},
+		{"strict", func(e *contracts.SendReqEventData) { e.Strict = true }, "strict mismatch"},
+	}
+	for _, tc := range mismatches {
+		tc := tc
+		t.Run(tc.name+" mismatch", func(t *testing.T) {
+			t.Parallel()
+			emitted := emittedForFidelity(fidelityFeeToken)
+			tc.mutate(emitted)
+			err := ValidateSendRequestedFields(sentMsgForFidelity(t, fidelityFeeToken), fidelityWrappedNative, emitted)
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestValidateSentMsgs(t *testing.T) {
+	t.Parallel()
+	source := &SourceCCIPModule{
+		Common:   &CCIPCommon{WrappedNative: fidelityWrappedNative},
+		SentMsgs: &sync.Map{},
+	}
+	txHash := common.HexToHash("0xabc")
+	first, second := sentMsgForFidelity(t, fidelityFeeToken), sentMsgForFidelity(t, fidelityFeeToken)
+	second.Data = []byte("world")
+	source.RecordSentMsgs(txHash, first, second)
+
+	// the events are compared in the order they are emitted in the tx
+	firstEmitted, secondEmitted := emittedForFidelity(fidelityFeeToken), emittedForFidelity(fidelityFeeToken)
+	firstEmitted.Raw = types.Log{Index: 1}
+	secondEmitted.Raw = types.Log{Index: 2}
+	secondEmitted.Data = []byte("world")
+	require.NoError(t, source.validateSentMsgs(txHash.Hex(), []*contracts.SendReqEventData{secondEmitted, firstEmitted}))
+
+	// the recorded messages are validated only once
+	require.NoError(t, source.validateSentMsgs(txHash.Hex(), nil))
+
+	source.RecordSentMsgs(txHash, first, second)
+	require.ErrorContains(t, source.validateSentMsgs(txHash.Hex(), []*contracts.SendReqEventData{firstEmitted}), "2 messages sent")
+}
@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultPollingInterval = time.Second
+	MinPollingInterval     = 100 * time.Millisecond
+	MaxPollingInterval     = 5 * time.Second
+	// MinBlocksPerPhase is the min number of blocks a phase timeout should cover,
+	// on chains with 30s+ block time the configured phase timeouts might not be enough to see a single event
+	MinBlocksPerPhase = 20
+)
+
+// AdaptivePollingInterval returns the polling interval for a chain with the given average block time.
+// It polls twice per block so that an event is picked up within half a block of being emitted,
+// bounded by MinPollingInterval for sub-second block chains and MaxPollingInterval for slow chains.
+func AdaptivePollingInterval(avgBlockTime time.Duration) time.Duration {
+	if avgBlockTime <= 0 {
+		return DefaultPollingInterval
+	}
+	interval := avgBlockTime / 2
+	if interval < MinPollingInterval {
+		return MinPollingInterval
+	}
+	if interval > MaxPollingInterval {
+		return MaxPollingInterval
+	}
+	return interval
+}
+
+// SetPollingInterval sets the interval at which the Assert* loops poll the event watchers of the chain.
+// If interval is zero, it's adapted to the average block time of the chain.
+// The average block time is also recorded to adjust the phase timeouts, see PhaseTimeout.
+func (ccipModule *CCIPCommon) SetPollingInterval(ctx context.Context, lggr zerolog.Logger, interval time.Duration) {
+	avgBlockTime, err := ccipModule.ChainClient.AvgBlockTime(ctx)
+	if err != nil {
+		lggr.Warn().Err(err).
+			Str("Network", ccipModule.ChainClient.GetNetworkName()).
+			Msg("Failed to get average block time, using default polling interval")
+	} else {
+		ccipModule.AvgBlockTime = avgBlockTime
+	}
+	if interval <= 0 {
+		interval = AdaptivePollingInterval(ccipModule.AvgBlockTime)
+	}
+	ccipModule.PollingInterval = interval
+	lggr.Info().
+		Str("Network", ccipModule.ChainClient.GetNetworkName()).
+		Str("Avg Block Time", ccipModule.AvgBlockTime.String()).
+		Str("Polling Interval", interval.String()).
+		Msg("Set event polling interval")
+}
+
+// pollingInterval returns the interval at which the event watchers of the chain are polled
+func (ccipModule *CCIPCommon) pollingInterval() time.Duration {
+	if ccipModule.PollingInterval <= 0 {
+		return DefaultPollingInterval
+	}
+	return ccipModule.PollingInterval
+}
+
+// PhaseTimeout returns timeout stretched to cover at least MinBlocksPerPhase blocks of the chain
+func (ccipModule *CCIPCommon) PhaseTimeout(timeout time.Duration) time.Duration {
+	if minTimeout := MinBlocksPerPhase * ccipModule.AvgBlockTime; timeout < minTimeout {
+		return minTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,207 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+// erc20TransferTopic is the topic of the ERC20 Transfer(address,address,uint256) event
+var erc20TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// PoolSwitch is the switch of the dest pool of a bridge token while requests are in flight
+type PoolSwitch struct {
+	TokenIndex  int
+	OldPool     common.Address
+	NewPool     common.Address
+	BlockBefore uint64 // latest dest block before the switch, requests executed till this block are served by the old pool
+	BlockAfter  uint64 // latest dest block after the switch, requests executed from this block are served by the new pool
+}
+
+// ExpectedPool returns the pool which should release the tokens of a request executed at execBlock. Both pools are
+// accepted for the requests executed in the blocks the switch could be included in.
+func (s PoolSwitch) ExpectedPool(execBlock uint64) []common.Address {
+	switch {
+	case execBlock <= s.BlockBefore:
+		return []common.Address{s.OldPool}
+	case execBlock >= s.BlockAfter:
+		return []common.Address{s.NewPool}
+	default:
+		return []common.Address{s.OldPool, s.NewPool}
+	}
+}
+
+// SwitchTokenPool deploys a new lock release pool for the dest bridge token at tokenIndex, funds it with the same
+// liquidity as the pools deployed with the lane, and switches the pool of the token to it, in the TokenAdminRegistry
+// or in the OffRamp for v1.2.0 ramps. The old pool keeps its liquidity, so that the requests executed before the switch
+// can be told apart from the ones executed after it.
+func (lane *CCIPLane) SwitchTokenPool(tokenIndex int) (PoolSwitch, error) {
+	src, dest := lane.Source, lane.Dest
+	if tokenIndex >= len(dest.Common.BridgeTokens) || tokenIndex >= len(src.Common.BridgeTokens) {
+		return PoolSwitch{}, fmt.Errorf("no bridge token at index %d", tokenIndex)
+	}
+	if dest.Common.ExistingDeployment {
+		return PoolSwitch{}, fmt.Errorf("pools cannot be switched for existing deployments")
+	}
+	oldPool := dest.Common.BridgeTokenPools[tokenIndex]
+	if !oldPool.IsLockRelease() {
+		return PoolSwitch{}, fmt.Errorf("only lock release pools can be switched, pool %s is not one", oldPool.Address())
+	}
+	token := dest.Common.BridgeTokens[tokenIndex]
+	newPool, err := dest.Common.Deployer.DeployLockReleaseTokenPoolContract(
+		token.Address(), *dest.Common.ARMContract, dest.Common.Router.EthAddress, dest.Common.PoolAllowList)
+	if err != nil {
+		return PoolSwitch{}, fmt.Errorf("deploying new pool for token %s shouldn't fail %w", token.Address(), err)
+	}
+	if err = dest.Common.ChainClient.WaitForEvents(); err != nil {
+		return PoolSwitch{}, fmt.Errorf("waiting for new pool deployment shouldn't fail %w", err)
+	}
+	if err = newPool.AddLiquidity(token.Approve, token.Address(), dest.Common.poolFunds); err != nil {
+		return PoolSwitch{}, fmt.Errorf("adding liquidity to new pool shouldn't fail %w", err)
+	}
+	if err = newPool.SetRemoteChainOnPool(dest.SourceChainSelector, src.Common.BridgeTokenPools[tokenIndex].EthAddress); err != nil {
+		return PoolSwitch{}, fmt.Errorf("setting remote chain on new pool shouldn't fail %w", err)
+	}
+	if err = dest.Common.ChainClient.WaitForEvents(); err != nil {
+		return PoolSwitch{}, fmt.Errorf("waiting for new pool set up shouldn't fail %w", err)
+	}
+
+	blockBefore, err := dest.Common.ChainClient.LatestBlockNumber(context.Background())
+	if err != nil {
+		return PoolSwitch{}, fmt.Errorf("getting latest block number shouldn't fail %w", err)
+	}
+	if dest.OffRamp.Instance.V1_2_0 != nil {
+		err = dest.OffRamp.SwitchPool(src.Common.BridgeTokens[tokenIndex].ContractAddress, oldPool.EthAddress, newPool.EthAddress)
+	} else {
+		if dest.Common.TokenAdminRegistry == nil {
+			return PoolSwitch{}, fmt.Errorf("token admin registry is not set to switch the pool")
+		}
+		err = dest.Common.TokenAdminRegistry.SetPool(token.ContractAddress, newPool.EthAddress)
+	}
+	if err != nil {
+		return PoolSwitch{}, err
+	}
+	if err = dest.Common.ChainClient.WaitForEvents(); err != nil {
+		return PoolSwitch{}, fmt.Errorf("waiting for pool switch shouldn't fail %w", err)
+	}
+	blockAfter, err := dest.Common.ChainClient.LatestBlockNumber(context.Background())
+	if err != nil {
+		return PoolSwitch{}, fmt.Errorf("getting latest block number shouldn't fail %w", err)
+	}
+	dest.Common.BridgeTokenPools[tokenIndex] = newPool
+	lane.Logger.Info().
+		Str("Token", token.Address()).
+		Str("Old Pool", oldPool.Address()).
+		Str("New Pool", newPool.Address()).
+		Uint64("Block Before", blockBefore).
+		Uint64("Block After", blockAfter).
+		Msg("Dest token pool switched")
+	return PoolSwitch{
+		TokenIndex:  tokenIndex,
+		OldPool:     oldPool.EthAddress,
+		NewPool:     newPool.EthAddress,
+		BlockBefore: blockBefore,
+		BlockAfter:  blockAfter,
+	}, nil
+}
+
+// ServedTransfer is the release of the tokens of a request by a dest pool
+type ServedTransfer struct {
+	SeqNum    uint64
+	ExecTx    common.Hash
+	ExecBlock uint64
+	Pool      common.Address
+	Amount    *big.Int
+}
+
+// TransfersServedByPools returns the transfers of token to the receiver of the lane released by any of pools in the
+// exec txs of the sent requests, one per request which transferred the token. The requests should be validated before,
+// so that the exec txs are recorded in their stats.
+func (lane *CCIPLane) TransfersServedByPools(token common.Address, pools []common.Address) ([]ServedTransfer, error) {
+	var served []ServedTransfer
+	for _, reqs := range lane.SentReqs {
+		for _, req := range reqs {
+			execStat, ok := req.RequestStat.StatusByPhase[testreporters.ExecStateChanged]
+			if !ok || execStat.Status != testreporters.Success {
+				return nil, fmt.Errorf("request %d is not executed", req.RequestStat.ReqNo)
+			}
+			execTx := common.HexToHash(execStat.SendTransactionStats.TxHash)
+			rcpt, err := lane.DestChain.GetTxReceipt(execTx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get receipt of exec tx %s: %w", execTx.Hex(), err)
+			}
+			pool, amount, found := poolServingTransfer(rcpt.Logs, token, lane.Dest.ReceiverDapp.EthAddress, pools)
+			if !found {
+				continue
+			}
+			transfer := ServedTransfer{
+				SeqNum:    req.RequestStat.SeqNum,
+				ExecTx:    execTx,
+				ExecBlock: rcpt.BlockNumber.Uint64(),
+				Pool:      pool,
+				Amount:    amount,
+			}
+			lane.Logger.Info().
+				Uint64("seqNum", transfer.SeqNum).
+				Str("Exec Tx", execTx.Hex()).
+				Uint64("Exec Block", transfer.ExecBlock).
+				Str("Pool", pool.Hex()).
+				Str("Amount", amount.String()).
+				Msg("Request served by dest pool")
+			served = append(served, transfer)
+		}
+	}
+	return served, nil
+}
+
+// poolServingTransfer returns the pool out of pools which transferred token to receiver in logs, along with the
+// amount transferred. The pool of the receiver's transfer is found in the ERC20 Transfer events of the exec tx.
+func poolServingTransfer(logs []*types.Log, token, receiver common.Address, pools []common.Address) (common.Address, *big.Int, bool) {
+	for _, l := range logs {
+		if l.Address != token || len(l.Topics) != 3 || l.Topics[0] != erc20TransferTopic {
+			continue
+		}
+		from := common.BytesToAddress(l.Topics[1].Bytes())
+		to := common.BytesToAddress(l.Topics[2].Bytes())
+		if to != receiver {
+			continue
+		}
+		for _, pool := range pools {
+			if from == pool {
+				return pool, new(big.Int).SetBytes(l.Data), true
+			}
+		}
+	}
+	return common.Address{}, nil, false
+}
+
+// AssertServedByPools validates that the token transfers of the requests are released by the pool expected at their
+// exec block and that the released amounts add up to expectedTotal, i.e. no funds are left stranded in either pool
+func AssertServedByPools(transfers []ServedTransfer, poolSwitch PoolSwitch, expectedTotal *big.Int) error {
+	total := big.NewInt(0)
+	for _, transfer := range transfers {
+		expected := poolSwitch.ExpectedPool(transfer.ExecBlock)
+		isExpected := false
+		for _, pool := range expected {
+			if transfer.Pool == pool {
+				isExpected = true
+				break
+			}
+		}
+		if !isExpected {
+			return fmt.Errorf("request with seq num %d executed at block %d is served by pool %s, expected %v",
+				transfer.SeqNum, transfer.ExecBlock, transfer.Pool.Hex(), expected)
+		}
+		total.Add(total, transfer.Amount)
+	}
+	if total.Cmp(expectedTotal) != 0 {
+		return fmt.Errorf("pools released %s in total, expected %s", total, expectedTotal)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func transferLog(token, from, to common.Address, amount int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{erc20TransferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(big.NewInt(amount)).Bytes(),
+	}
+}
+
+func TestPoolServingTransfer(t *testing.T) {
+	t.Parallel()
+	token := common.HexToAddress("0x1")
+	otherToken := common.HexToAddress("0x2")
+	receiver := common.HexToAddress("0x3")
+	oldPool := common.HexToAddress("0x4")
+	newPool := common.HexToAddress("0x5")
+	pools := []common.Address{oldPool, newPool}
+
+	logs := []*types.Log{
+		transferLog(otherToken, newPool, receiver, 7),
+		transferLog(token, newPool, common.HexToAddress("0x6"), 8),
+		transferLog(token, newPool, receiver, 9),
+	}
+	pool, amount, found := poolServingTransfer(logs, token, receiver, pools)
+	require.True(t, found)
+	require.Equal(t, newPool, pool)
+	require.Equal(t, big.NewInt(9), amount)
+
+	_, _, found = poolServingTransfer(logs[:2], token, receiver, pools)
+	require.False(t, found)
+	_, _, found = poolServingTransfer([]*types.Log{transferLog(token, common.HexToAddress("0x7"), receiver, 1)}, token, receiver, pools)
+	require.False(t, found, "transfer from an address other than the pools should be ignored")
+}
+
+func TestAssertServedByPools(t *testing.T) {
+	t.Parallel()
+	oldPool := common.HexToAddress("0x4")
+	newPool := common.HexToAddress("0x5")
+	poolSwitch := PoolSwitch{OldPool: oldPool, NewPool: newPool, BlockBefore: 10, BlockAfter: 12}
+	require.Equal(t, []common.Address{oldPool}, poolSwitch.ExpectedPool(10))
+	require.Equal(t, []common.Address{oldPool, newPool}, poolSwitch.ExpectedPool(11))
+	require.Equal(t, []common.Address{newPool}, poolSwitch.ExpectedPool(12))
+
+	transfers := []ServedTransfer{
+		{SeqNum: 1, ExecBlock: 9, Pool: oldPool, Amount: big.NewInt(1)},
+		{SeqNum: 2, ExecBlock: 11, Pool: newPool, Amount: big.NewInt(1)},
+		{SeqNum: 3, ExecBlock: 13, Pool: newPool, Amount: big.NewInt(1)},
+	}
+	require.NoError(t, AssertServedByPools(transfers, poolSwitch, big.NewInt(3)))
+	require.ErrorContains(t, AssertServedByPools(transfers, poolSwitch, big.NewInt(4)), "pools released 3 in total, expected 4")
+
+	transfers[2].Pool = oldPool
+	require.ErrorContains(t, AssertServedByPools(transfers, poolSwitch, big.NewInt(3)), "request with seq num 3 executed at block 13 is served by pool")
+}
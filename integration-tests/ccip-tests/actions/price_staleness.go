@@ -0,0 +1,202 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/price_registry"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+// StalePriceError returns the error the PriceRegistry reverts the fee quote of a data-only message with at blockTime,
+// "" if the quote succeeds. As in PriceRegistry.getTokenAndGasPrices, the gas price of the dest chain is checked before
+// the price of the fee token, and a price is stale once more than threshold seconds have passed since its update; a
+// price updated exactly threshold seconds before blockTime is still fresh.
+func StalePriceError(blockTime uint64, gasPriceUpdatedAt, feeTokenPriceUpdatedAt uint32, threshold uint64) string {
+	if blockTime-uint64(gasPriceUpdatedAt) > threshold {
+		return "StaleGasPrice"
+	}
+	if blockTime-uint64(feeTokenPriceUpdatedAt) > threshold {
+		return "StaleTokenPrice"
+	}
+	return ""
+}
+
+// priceTimestamps returns the update times of the dest gas price and of the fee token price at block
+func (lane *CCIPLane) priceTimestamps(block uint64) (gasPriceUpdatedAt uint32, feeTokenPriceUpdatedAt uint32, err error) {
+	src := lane.Source
+	opts := &bind.CallOpts{Context: lane.Context, BlockNumber: new(big.Int).SetUint64(block)}
+	gasPrice, err := src.Common.PriceRegistry.Instance.GetDestinationChainGasPrice(opts, src.DestChainSelector)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the gas price of dest chain %d: %w", src.DestChainSelector, err)
+	}
+	feeTokenPrice, err := src.Common.PriceRegistry.Instance.GetTimestampedTokenPrice(opts, src.Common.FeeToken.EthAddress)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the price of fee token %s: %w", src.Common.FeeToken.Address(), err)
+	}
+	return gasPrice.Timestamp, feeTokenPrice.Timestamp, nil
+}
+
+// ValidatePriceStaleness moves the time of the source chain with clock till the source PriceRegistry prices used for
+// the fee quotes of the lane are stale, and validates the staleness is enforced at the exact boundary:
+//   - the fee quote succeeds in the block mined threshold seconds after the earliest of the dest gas price and fee token
+//     price updates, and reverts with StalePriceError in the block mined a second later
+//   - ccipSend reverts with the same error once the prices are stale
+//
+// The prices are updated with their latest values afterwards, so that the lane can be quoted again. The time of the
+// chain can't be moved back, so the lanes sharing the source chain see it moved forward by up to the staleness
+// threshold, 14 days for the PriceRegistry deployed by the tests.
+func (lane *CCIPLane) ValidatePriceStaleness(clock *ChainClock, gasLimit *big.Int) error {
+	ctx := lane.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	src := lane.Source
+	threshold, err := src.Common.PriceRegistry.Instance.GetStalenessThreshold(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to get the staleness threshold: %w", err)
+	}
+	msg, err := src.CCIPMsg(lane.Dest.ReceiverDapp.EthAddress, gasLimit)
+	if err != nil {
+		return fmt.Errorf("failed forming the ccip msg: %w", err)
+	}
+	// the prices of the bridge tokens are checked by the token transfer fees, keep to the gas and fee token prices
+	msg.TokenAmounts = nil
+
+	now, head, err := clock.Now(ctx)
+	if err != nil {
+		return err
+	}
+	gasPriceUpdatedAt, feeTokenPriceUpdatedAt, err := lane.priceTimestamps(head)
+	if err != nil {
+		return err
+	}
+	boundary := uint64(min(gasPriceUpdatedAt, feeTokenPriceUpdatedAt)) + threshold
+	if now >= boundary {
+		return fmt.Errorf("prices are already stale at block %d, %d seconds after their update with a threshold of %d seconds",
+			head, now-(boundary-threshold), threshold)
+	}
+	lane.Logger.Info().
+		Uint64("Staleness Threshold", threshold).
+		Uint32("Gas Price Updated At", gasPriceUpdatedAt).
+		Uint32("Fee Token Price Updated At", feeTokenPriceUpdatedAt).
+		Uint64("Boundary", boundary).
+		Msg("Moving the source chain time to the price staleness boundary")
+
+	var lastFee *big.Int
+	for _, ts := range []uint64{boundary, boundary + 1} {
+		block, err := clock.MineBlockAt(ctx, ts)
+		if err != nil {
+			return err
+		}
+		// the prices can be updated by the commit DON while the time is moved, take them as of the block
+		gasPriceUpdatedAt, feeTokenPriceUpdatedAt, err := lane.priceTimestamps(block)
+		if err != nil {
+			return err
+		}
+		expectedErr := StalePriceError(ts, gasPriceUpdatedAt, feeTokenPriceUpdatedAt, threshold)
+		if (ts == boundary) != (expectedErr == "") {
+			return fmt.Errorf("prices were updated at %d and %d while the time was moved, the boundary %d was not probed",
+				gasPriceUpdatedAt, feeTokenPriceUpdatedAt, boundary)
+		}
+		fee, err := src.Common.Router.Instance.GetFee(
+			&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(block)}, src.DestChainSelector, msg)
+		if expectedErr == "" {
+			if err != nil {
+				return fmt.Errorf("fee quote at %d, %d seconds after the price update, should succeed: %w", ts, threshold, err)
+			}
+			lastFee = fee
+			continue
+		}
+		if err == nil {
+			return fmt.Errorf("fee quote at %d, %d seconds after the price update, should revert with %s, it succeeded",
+				ts, threshold+1, expectedErr)
+		}
+		errReason, err := revertErrorFromCall(err, price_registry.PriceRegistryABI)
+		if err != nil {
+			return fmt.Errorf("could not get revert reason for fee quote: %w", err)
+		}
+		if errReason != expectedErr {
+			return fmt.Errorf("expected fee quote at %d to revert with %s, got %s", ts, expectedErr, errReason)
+		}
+		lane.Logger.Info().Uint64("Block", block).Uint64("Timestamp", ts).Str("Revert Reason", errReason).Msg("Fee quote reverted on stale prices")
+	}
+
+	// the fee quoted on fresh prices is paid, the revert comes from the price check regardless
+	var valueForNative *big.Int
+	if msg.FeeToken == (common.Address{}) {
+		valueForNative = lastFee
+	}
+	sendTx, err := src.Common.Router.CCIPSendAndProcessTx(src.DestChainSelector, msg, valueForNative)
+	var errReason string
+	if sendTx == nil {
+		// the gas estimation of the tx reverted
+		errReason, err = revertErrorFromCall(err, price_registry.PriceRegistryABI)
+		if err != nil {
+			return fmt.Errorf("could not get revert reason for request on stale prices: %w", err)
+		}
+	} else {
+		if err == nil {
+			err = src.Common.ChainClient.WaitForEvents()
+		}
+		if err == nil {
+			return fmt.Errorf("expected request %s on stale prices to revert, but it succeeded", sendTx.Hash().Hex())
+		}
+		errReason, _, err = src.Common.ChainClient.RevertReasonFromTx(sendTx.Hash(), price_registry.PriceRegistryABI)
+		if err != nil {
+			return fmt.Errorf("could not get revert reason for tx %s: %w", sendTx.Hash().Hex(), err)
+		}
+	}
+	if errReason != "StaleGasPrice" && errReason != "StaleTokenPrice" {
+		return fmt.Errorf("expected request on stale prices to revert with StaleGasPrice or StaleTokenPrice, got %s", errReason)
+	}
+	lane.Logger.Info().Str("Revert Reason", errReason).Msg("Request reverted on stale prices")
+
+	if err := lane.RefreshPrices(); err != nil {
+		return err
+	}
+	if _, err := src.Common.Router.GetFee(src.DestChainSelector, msg); err != nil {
+		return fmt.Errorf("fee quote should succeed after the prices are refreshed: %w", err)
+	}
+	return nil
+}
+
+// RefreshPrices updates the dest gas price and the prices of the fee token, the wrapped native and the bridge tokens in
+// the source PriceRegistry with their current values, so that they are fresh again
+func (lane *CCIPLane) RefreshPrices() error {
+	src := lane.Source
+	pr := src.Common.PriceRegistry.Instance
+	opts := &bind.CallOpts{Context: lane.Context}
+	gasPrice, err := pr.GetDestinationChainGasPrice(opts, src.DestChainSelector)
+	if err != nil {
+		return fmt.Errorf("failed to get the gas price of dest chain %d: %w", src.DestChainSelector, err)
+	}
+	tokens := []common.Address{src.Common.FeeToken.EthAddress, src.Common.WrappedNative}
+	for _, token := range src.Common.BridgeTokens {
+		tokens = append(tokens, token.ContractAddress)
+	}
+	var tokenUpdates []contracts.InternalTokenPriceUpdate
+	for _, token := range tokens {
+		price, err := pr.GetTimestampedTokenPrice(opts, token)
+		if err != nil {
+			return fmt.Errorf("failed to get the price of token %s: %w", token.Hex(), err)
+		}
+		// a token without price is not supported, it has no price to be refreshed
+		if price.Value == nil || price.Value.Sign() == 0 {
+			continue
+		}
+		tokenUpdates = append(tokenUpdates, contracts.InternalTokenPriceUpdate{SourceToken: token, UsdPerToken: price.Value})
+	}
+	err = src.Common.PriceRegistry.UpdatePrices(tokenUpdates, []contracts.InternalGasPriceUpdate{
+		{DestChainSelector: src.DestChainSelector, UsdPerUnitGas: gasPrice.Value},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh the prices: %w", err)
+	}
+	return src.Common.ChainClient.WaitForEvents()
+}
@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStalePriceError(t *testing.T) {
+	const threshold = 60 * 60 * 24 * 14
+	for _, tc := range []struct {
+		name        string
+		blockTime   uint64
+		gasAt       uint32
+		feeTokenAt  uint32
+		expectedErr string
+	}{
+		{name: "fresh", blockTime: 1_000, gasAt: 1_000, feeTokenAt: 1_000},
+		{name: "exactly at the threshold", blockTime: 1_000 + threshold, gasAt: 1_000, feeTokenAt: 1_000},
+		{name: "a second past the threshold", blockTime: 1_000 + threshold + 1, gasAt: 1_000, feeTokenAt: 1_000, expectedErr: "StaleGasPrice"},
+		{name: "gas price checked first", blockTime: 2 * threshold, gasAt: 1, feeTokenAt: 1, expectedErr: "StaleGasPrice"},
+		{name: "stale fee token price", blockTime: 1_000 + threshold + 1, gasAt: 2_000, feeTokenAt: 1_000, expectedErr: "StaleTokenPrice"},
+		{name: "fee token price at the threshold", blockTime: 1_000 + threshold, gasAt: 2_000, feeTokenAt: 1_000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, StalePriceError(tc.blockTime, tc.gasAt, tc.feeTokenAt, threshold))
+		})
+	}
+}
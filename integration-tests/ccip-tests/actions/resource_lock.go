@@ -0,0 +1,148 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/gofrs/flock"
+	_ "github.com/lib/pq" // postgres driver for advisory locks
+	"github.com/rs/zerolog"
+)
+
+const (
+	ResourceLockFile     = "file"
+	ResourceLockPostgres = "postgres"
+
+	fileLockRetryDelay = 500 * time.Millisecond
+)
+
+var lockKeySanitizer = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// ResourceLocker provides mutual exclusion on shared resources of a persistent test environment
+// (e.g. funding wallets, OCR configs and lane config files) across independent test processes.
+// Lock blocks until the lock for the resource is acquired or ctx is done and returns a function to release the lock.
+type ResourceLocker interface {
+	Lock(ctx context.Context, resource string) (func() error, error)
+	Close() error
+}
+
+// WithResourceLock runs fn while holding the lock on resource. If locker is nil, fn is run without any lock.
+func WithResourceLock(ctx context.Context, locker ResourceLocker, resource string, fn func() error) (err error) {
+	if locker == nil {
+		return fn()
+	}
+	unlock, err := locker.Lock(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock on %s: %w", resource, err)
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("failed to release lock on %s: %w", resource, unlockErr)
+		}
+	}()
+	return fn()
+}
+
+// NewResourceLocker returns the ResourceLocker for the lock type.
+// For ResourceLockFile the target is the directory shared by all the processes to create the lock files in,
+// for ResourceLockPostgres it's the connection url of the database used for advisory locks.
+func NewResourceLocker(lggr zerolog.Logger, lockType, target string) (ResourceLocker, error) {
+	switch lockType {
+	case ResourceLockFile:
+		return NewFileResourceLocker(lggr, target)
+	case ResourceLockPostgres:
+		return NewPostgresResourceLocker(lggr, target)
+	default:
+		return nil, fmt.Errorf("unsupported resource lock type %s", lockType)
+	}
+}
+
+// FileResourceLocker uses advisory file locks, it can be used when all the test processes share a file system
+type FileResourceLocker struct {
+	logger zerolog.Logger
+	dir    string
+}
+
+func NewFileResourceLocker(lggr zerolog.Logger, dir string) (*FileResourceLocker, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory %s: %w", dir, err)
+	}
+	return &FileResourceLocker{
+		logger: lggr,
+		dir:    dir,
+	}, nil
+}
+
+func (f *FileResourceLocker) Lock(ctx context.Context, resource string) (func() error, error) {
+	path := filepath.Join(f.dir, lockKeySanitizer.ReplaceAllString(resource, "_")+".lock")
+	fileLock := flock.New(path)
+	locked, err := fileLock.TryLockContext(ctx, fileLockRetryDelay)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, fmt.Errorf("could not lock %s", path)
+	}
+	f.logger.Debug().Str("Resource", resource).Str("Lock File", path).Msg("Acquired resource lock")
+	return func() error {
+		f.logger.Debug().Str("Resource", resource).Str("Lock File", path).Msg("Releasing resource lock")
+		return fileLock.Unlock()
+	}, nil
+}
+
+func (f *FileResourceLocker) Close() error {
+	return nil
+}
+
+// PostgresResourceLocker uses session level postgres advisory locks, it can be used when the test processes
+// run on different hosts
+type PostgresResourceLocker struct {
+	logger zerolog.Logger
+	db     *sql.DB
+}
+
+func NewPostgresResourceLocker(lggr zerolog.Logger, url string) (*PostgresResourceLocker, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	return &PostgresResourceLocker{
+		logger: lggr,
+		db:     db,
+	}, nil
+}
+
+func (p *PostgresResourceLocker) Lock(ctx context.Context, resource string) (func() error, error) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(resource))
+	key := int64(h.Sum64())
+	// advisory locks are held by the session, the same connection needs to be used for lock and unlock
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postgres connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	p.logger.Debug().Str("Resource", resource).Int64("Key", key).Msg("Acquired resource lock")
+	return func() error {
+		defer conn.Close()
+		p.logger.Debug().Str("Resource", resource).Int64("Key", key).Msg("Releasing resource lock")
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		return err
+	}, nil
+}
+
+func (p *PostgresResourceLocker) Close() error {
+	return p.db.Close()
+}
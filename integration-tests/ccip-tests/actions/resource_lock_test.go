@@ -0,0 +1,47 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileResourceLocker(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	// two lockers on the same directory simulate two independent test processes
+	lockerA, err := NewResourceLocker(zerolog.New(zerolog.Nop()), ResourceLockFile, dir)
+	require.NoError(t, err)
+	lockerB, err := NewResourceLocker(zerolog.New(zerolog.Nop()), ResourceLockFile, dir)
+	require.NoError(t, err)
+
+	unlock, err := lockerA.Lock(context.Background(), "fund-1337")
+	require.NoError(t, err)
+
+	// the resource is held by lockerA, lockerB should not be able to acquire it
+	ctx, cancel := context.WithTimeout(context.Background(), 2*fileLockRetryDelay)
+	defer cancel()
+	_, err = lockerB.Lock(ctx, "fund-1337")
+	require.Error(t, err)
+
+	// other resources are not affected
+	require.NoError(t, WithResourceLock(context.Background(), lockerB, "ocr2config-0xabc", func() error {
+		return nil
+	}))
+
+	require.NoError(t, unlock())
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	called := false
+	require.NoError(t, WithResourceLock(ctx, lockerB, "fund-1337", func() error {
+		called = true
+		return nil
+	}))
+	require.True(t, called)
+
+	_, err = NewResourceLocker(zerolog.New(zerolog.Nop()), "redis", dir)
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// routerAllowances tracks the allowance left for the router to spend the tokens of a wallet.
+// It's shared by all the lanes sending from the same wallet through the same router.
+var routerAllowances sync.Map // key - routerAllowanceKey; value - *trackedAllowance
+
+type routerAllowanceKey struct {
+	chainID uint64
+	owner   common.Address
+	router  common.Address
+	token   common.Address
+}
+
+// routerToken is the subset of the token wrappers required to manage the allowance of the router
+type routerToken interface {
+	Address() string
+	Allowance(owner, spender string) (*big.Int, error)
+	Approve(to string, amount *big.Int) error
+}
+
+type trackedAllowance struct {
+	mu        sync.Mutex
+	remaining *big.Int // nil till the allowance is read from the chain
+}
+
+// consume deducts amount from the allowance left. If the allowance left is not enough, the allowance is read again
+// from the chain in case it's approved elsewhere, and if that's not enough either, topUp is approved.
+// It returns true if the allowance is topped up.
+func (a *trackedAllowance) consume(token routerToken, owner, spender string, amount, topUp *big.Int) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	toppedUp := false
+	if a.remaining == nil || a.remaining.Cmp(amount) < 0 {
+		allowance, err := token.Allowance(owner, spender)
+		if err != nil {
+			return false, fmt.Errorf("failed to get allowance for token %s: %w", token.Address(), err)
+		}
+		a.remaining = allowance
+		if allowance.Cmp(amount) < 0 {
+			if topUp.Cmp(amount) < 0 {
+				topUp = amount
+			}
+			if err := token.Approve(spender, topUp); err != nil {
+				// the allowance is not known anymore, it's read again with the next spend
+				a.remaining = nil
+				return false, fmt.Errorf("failed to approve token %s: %w", token.Address(), err)
+			}
+			a.remaining = new(big.Int).Set(topUp)
+			toppedUp = true
+		}
+	}
+	a.remaining = new(big.Int).Sub(a.remaining, amount)
+	return toppedUp, nil
+}
+
+// routerApprovalAmount returns the amount approved for the router to spend a token,
+// which is the max uint256 if InfiniteRouterApproval is set
+func (ccipModule *CCIPCommon) routerApprovalAmount(amount *big.Int) *big.Int {
+	if ccipModule.InfiniteRouterApproval {
+		return new(big.Int).Set(math.MaxBig256)
+	}
+	return amount
+}
+
+// EnsureRouterAllowance deducts the tokens and the fee to be spent by the router for msg from the allowance of the
+// router. If the allowance left is not enough for it, the router is approved again for ApprovedAmountToRouter on top of
+// the amount to be spent, so that the long-running tests don't fail once the initial approval is used up.
+// The fee is deducted only if it's paid in the fee token.
+func (ccipModule *CCIPCommon) EnsureRouterAllowance(lggr zerolog.Logger, msg router.ClientEVM2AnyMessage, fee *big.Int) error {
+	spends := make(map[common.Address]*big.Int)
+	var tokens []common.Address
+	addSpend := func(token common.Address, amount *big.Int) {
+		if amount == nil || amount.Sign() <= 0 {
+			return
+		}
+		if _, ok := spends[token]; !ok {
+			spends[token] = big.NewInt(0)
+			tokens = append(tokens, token)
+		}
+		spends[token].Add(spends[token], amount)
+	}
+	for _, tokenAmount := range msg.TokenAmounts {
+		addSpend(tokenAmount.Token, tokenAmount.Amount)
+	}
+	if msg.FeeToken != (common.Address{}) {
+		addSpend(msg.FeeToken, fee)
+	}
+
+	owner := ccipModule.ChainClient.GetDefaultWallet().Address()
+	spender := ccipModule.Router.Address()
+	for _, tokenAddr := range tokens {
+		token := ccipModule.routerTokenFor(tokenAddr)
+		if token == nil {
+			return fmt.Errorf("token %s is neither a bridge token nor the fee token of %s", tokenAddr.Hex(), ccipModule.ChainClient.GetNetworkName())
+		}
+		key := routerAllowanceKey{
+			chainID: ccipModule.ChainClient.GetChainID().Uint64(),
+			owner:   common.HexToAddress(owner),
+			router:  ccipModule.Router.EthAddress,
+			token:   tokenAddr,
+		}
+		value, _ := routerAllowances.LoadOrStore(key, &trackedAllowance{})
+		amount := spends[tokenAddr]
+		topUp := ccipModule.routerApprovalAmount(new(big.Int).Add(ApprovedAmountToRouter, amount))
+		toppedUp, err := value.(*trackedAllowance).consume(token, owner, spender, amount, topUp)
+		if err != nil {
+			return err
+		}
+		if toppedUp {
+			lggr.Info().
+				Str("Network", ccipModule.ChainClient.GetNetworkName()).
+				Str("Token", tokenAddr.Hex()).
+				Str("Router", spender).
+				Str("Planned Spend", amount.String()).
+				Str("Approved", topUp.String()).
+				Msg("Router allowance is used up, topped up the approval")
+		}
+	}
+	return nil
+}
+
+// routerTokenFor returns the bridge token or the fee token with the address, nil if there is none
+func (ccipModule *CCIPCommon) routerTokenFor(tokenAddr common.Address) routerToken {
+	for _, token := range ccipModule.BridgeTokens {
+		if token.ContractAddress == tokenAddr {
+			return token
+		}
+	}
+	if ccipModule.FeeToken != nil && ccipModule.FeeToken.EthAddress == tokenAddr {
+		return ccipModule.FeeToken
+	}
+	return nil
+}
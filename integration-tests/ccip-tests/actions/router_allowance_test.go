@@ -0,0 +1,109 @@
+package actions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// allowanceToken is a token with an on-chain allowance which is only changed by Approve
+type allowanceToken struct {
+	allowance  *big.Int
+	approvals  []*big.Int
+	reads      int
+	approveErr error
+}
+
+func (a *allowanceToken) Address() string {
+	return "0x1"
+}
+
+func (a *allowanceToken) Allowance(_, _ string) (*big.Int, error) {
+	a.reads++
+	return new(big.Int).Set(a.allowance), nil
+}
+
+func (a *allowanceToken) Approve(_ string, amount *big.Int) error {
+	if a.approveErr != nil {
+		return a.approveErr
+	}
+	a.approvals = append(a.approvals, amount)
+	a.allowance = new(big.Int).Set(amount)
+	return nil
+}
+
+// spend consumes amount from the on-chain allowance like the router does on ccip-send
+func (a *allowanceToken) spend(amount int64) {
+	a.allowance.Sub(a.allowance, big.NewInt(amount))
+}
+
+func TestTrackedAllowanceConsume(t *testing.T) {
+	t.Parallel()
+	token := &allowanceToken{allowance: big.NewInt(100)}
+	tracked := &trackedAllowance{}
+	consume := func(amount int64) bool {
+		toppedUp, err := tracked.consume(token, "owner", "router", big.NewInt(amount), big.NewInt(150))
+		require.NoError(t, err)
+		token.spend(amount)
+		return toppedUp
+	}
+
+	// the allowance is read from the chain once and then tracked locally
+	require.False(t, consume(40))
+	require.False(t, consume(40))
+	require.Equal(t, 1, token.reads)
+	require.Empty(t, token.approvals)
+
+	// the allowance left is not enough, it's read again and topped up
+	require.True(t, consume(40))
+	require.Equal(t, 2, token.reads)
+	require.Equal(t, []*big.Int{big.NewInt(150)}, token.approvals)
+	require.Equal(t, big.NewInt(110), tracked.remaining)
+
+	// the approval is at least the amount to be spent
+	require.True(t, consume(200))
+	require.Equal(t, big.NewInt(200), token.approvals[1])
+	require.Zero(t, tracked.remaining.Sign())
+}
+
+func TestTrackedAllowanceApprovedElsewhere(t *testing.T) {
+	t.Parallel()
+	token := &allowanceToken{allowance: big.NewInt(10)}
+	tracked := &trackedAllowance{}
+	_, err := tracked.consume(token, "owner", "router", big.NewInt(10), big.NewInt(150))
+	require.NoError(t, err)
+	token.spend(10)
+
+	// another lane sending from the same wallet approved the router in the meantime
+	token.allowance = big.NewInt(1000)
+	toppedUp, err := tracked.consume(token, "owner", "router", big.NewInt(10), big.NewInt(150))
+	require.NoError(t, err)
+	require.False(t, toppedUp, "allowance approved elsewhere should be used before topping up")
+	require.Empty(t, token.approvals)
+	require.Equal(t, big.NewInt(990), tracked.remaining)
+}
+
+func TestTrackedAllowanceApproveFailure(t *testing.T) {
+	t.Parallel()
+	token := &allowanceToken{allowance: big.NewInt(0), approveErr: errors.New("nonce too low")}
+	tracked := &trackedAllowance{}
+	_, err := tracked.consume(token, "owner", "router", big.NewInt(10), big.NewInt(150))
+	require.ErrorContains(t, err, "nonce too low")
+	require.Nil(t, tracked.remaining, "allowance should be read again after a failed approval")
+
+	token.approveErr = nil
+	toppedUp, err := tracked.consume(token, "owner", "router", big.NewInt(10), big.NewInt(150))
+	require.NoError(t, err)
+	require.True(t, toppedUp)
+}
+
+func TestRouterApprovalAmount(t *testing.T) {
+	t.Parallel()
+	ccipModule := &CCIPCommon{}
+	require.Equal(t, big.NewInt(5), ccipModule.routerApprovalAmount(big.NewInt(5)))
+	ccipModule.InfiniteRouterApproval = true
+	require.Equal(t, math.MaxBig256, ccipModule.routerApprovalAmount(big.NewInt(5)))
+}
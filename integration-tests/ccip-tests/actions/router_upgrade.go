@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+// MovePoolsFrom sets the Router on the bridge token pools which are still on the router from, e.g. the Router replaced
+// with UpgradeRouter
+func (ccipModule *CCIPCommon) MovePoolsFrom(from *contracts.Router) error {
+	for _, pool := range ccipModule.BridgeTokenPools {
+		poolRouter, err := pool.GetRouter()
+		if err != nil {
+			return fmt.Errorf("failed to get the router of pool %s: %w", pool.Address(), err)
+		}
+		if poolRouter != from.EthAddress {
+			continue
+		}
+		if err := pool.SetRouter(ccipModule.Router.EthAddress); err != nil {
+			return fmt.Errorf("failed to move pool %s to the router: %w", pool.Address(), err)
+		}
+	}
+	return ccipModule.ChainClient.WaitForEvents()
+}
+
+// UpgradeRouter deploys a new Router with the wrapped native and the ARM of the chain and makes it the Router of the
+// chain, the requests are sent through it from then on. It returns the Router it replaces, the lanes of the chain are
+// to be migrated from it with CCIPLane.MigrateRouter and the token pools with MovePoolsFrom.
+func (ccipModule *CCIPCommon) UpgradeRouter() (*contracts.Router, error) {
+	if ccipModule.ExistingDeployment {
+		return nil, fmt.Errorf("the router of an existing deployment is not upgraded")
+	}
+	newRouter, err := ccipModule.Deployer.DeployRouter(ccipModule.WrappedNative, *ccipModule.ARMContract)
+	if err != nil {
+		return nil, fmt.Errorf("deploying new router shouldn't fail %w", err)
+	}
+	if err := ccipModule.ChainClient.WaitForEvents(); err != nil {
+		return nil, fmt.Errorf("error in waiting for new router deployment %w", err)
+	}
+	oldRouter := ccipModule.Router
+	ccipModule.Router = newRouter
+	return oldRouter, nil
+}
+
+// MigrateRouter migrates the lane from the routers oldSource and oldDest, replaced with CCIPCommon.UpgradeRouter, to
+// the Routers of its chains, the way the router migration runbook does it. The OnRamp is enabled on the new source
+// Router and pointed to it, and the OffRamp is added to the new dest Router, before the lane is disabled on the old
+// Routers, which reject the requests sent through them with UnsupportedDestinationChain from then on.
+// The OffRamp routes the messages through the router of its OCR2 config, which is set again here in mock DON mode;
+// otherwise SetOCR2Config is to be called again for the messages to be executed through the new Router.
+func (lane *CCIPLane) MigrateRouter(oldSource, oldDest *contracts.Router) error {
+	src, dest := lane.Source, lane.Dest
+	if oldSource == nil || oldSource.EthAddress == src.Common.Router.EthAddress {
+		return fmt.Errorf("router of %s is not upgraded", lane.SourceNetworkName)
+	}
+	if oldDest == nil || oldDest.EthAddress == dest.Common.Router.EthAddress {
+		return fmt.Errorf("router of %s is not upgraded", lane.DestNetworkName)
+	}
+
+	if err := src.Common.Router.SetOnRamp(src.DestChainSelector, src.OnRamp.EthAddress); err != nil {
+		return fmt.Errorf("failed to enable the onRamp on the new router: %w", err)
+	}
+	if err := src.OnRamp.SetRouter(src.Common.Router.EthAddress); err != nil {
+		return fmt.Errorf("failed to point the onRamp to the new router: %w", err)
+	}
+	if _, err := dest.Common.Router.AddOffRamp(dest.OffRamp.EthAddress, dest.SourceChainSelector); err != nil {
+		return fmt.Errorf("failed to add the offRamp to the new router: %w", err)
+	}
+	if lane.MockDON != nil {
+		if err := lane.MockDON.SetOCR2Config(); err != nil {
+			return fmt.Errorf("failed to point the offRamp to the new router: %w", err)
+		}
+	}
+
+	if err := oldSource.SetOnRamp(src.DestChainSelector, common.Address{}); err != nil {
+		return fmt.Errorf("failed to disable the onRamp on the old router: %w", err)
+	}
+	if _, err := oldDest.RemoveOffRamp(dest.OffRamp.EthAddress, dest.SourceChainSelector); err != nil {
+		return fmt.Errorf("failed to remove the offRamp from the old router: %w", err)
+	}
+	lane.Logger.Info().
+		Str("Old Source Router", oldSource.Address()).
+		Str("Source Router", src.Common.Router.Address()).
+		Str("Old Dest Router", oldDest.Address()).
+		Str("Dest Router", dest.Common.Router.Address()).
+		Msg("Lane is migrated to the new routers")
+	return nil
+}
@@ -0,0 +1,26 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+func TestMigrateRouterNotUpgraded(t *testing.T) {
+	t.Parallel()
+	srcRouter := &contracts.Router{EthAddress: common.HexToAddress("0x01")}
+	destRouter := &contracts.Router{EthAddress: common.HexToAddress("0x02")}
+	lane := &CCIPLane{
+		SourceNetworkName: "source",
+		DestNetworkName:   "dest",
+		Source:            &SourceCCIPModule{Common: &CCIPCommon{Router: srcRouter}},
+		Dest:              &DestCCIPModule{Common: &CCIPCommon{Router: destRouter}},
+	}
+	require.EqualError(t, lane.MigrateRouter(nil, destRouter), "router of source is not upgraded")
+	require.EqualError(t, lane.MigrateRouter(srcRouter, destRouter), "router of source is not upgraded")
+	oldSrcRouter := &contracts.Router{EthAddress: common.HexToAddress("0x03")}
+	require.EqualError(t, lane.MigrateRouter(oldSrcRouter, destRouter), "router of dest is not upgraded")
+}
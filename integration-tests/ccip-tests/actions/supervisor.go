@@ -0,0 +1,184 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/multierr"
+)
+
+// DefaultSupervisorStopTimeout is the time Stop waits for the routines to return after their context is cancelled
+const DefaultSupervisorStopTimeout = 30 * time.Second
+
+// RestartPolicy decides if a supervised routine is restarted after it returns an error or panics. A routine returning
+// nil is done and never restarted.
+type RestartPolicy struct {
+	MaxRestarts int           // number of restarts after which the routine is given up on, 0 never restarts it
+	Backoff     time.Duration // delay before the first restart, doubled with every restart
+}
+
+var (
+	// NoRestart gives up on a routine on its first failure
+	NoRestart = RestartPolicy{}
+	// DefaultRestartPolicy restarts a routine up to 5 times, the 5th restart happening 16s after the 4th
+	DefaultRestartPolicy = RestartPolicy{MaxRestarts: 5, Backoff: time.Second}
+)
+
+// Supervisor owns the background routines of a lane, i.e. the event watchers, pollers and price updaters, so that all
+// of them are stopped in CleanUp however far the lane set-up got. The panics of the routines are recovered and the
+// failed routines are restarted with their RestartPolicy till the supervisor is stopped.
+type Supervisor struct {
+	lggr     zerolog.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	running  map[string]int // number of running routines by name
+	onStop   []func()
+	errs     error // errors of the routines given up on
+	stopped  bool
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// NewSupervisor returns a supervisor whose routines run till parent is done or the supervisor is stopped
+func NewSupervisor(parent context.Context, lggr zerolog.Logger) *Supervisor {
+	ctx, cancel := context.WithCancel(parent)
+	return &Supervisor{
+		lggr:    lggr.With().Str("Component", "Supervisor").Logger(),
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Context returns the context the routines run with, it's done once the supervisor is stopped
+func (s *Supervisor) Context() context.Context {
+	return s.ctx
+}
+
+// Go runs routine in the background till it returns nil or its context is done. If routine returns an error or panics,
+// it's restarted according to policy. Routines started after the supervisor is stopped are not run.
+func (s *Supervisor) Go(name string, policy RestartPolicy, routine func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		s.lggr.Warn().Str("Routine", name).Msg("Supervisor is stopped, not starting routine")
+		return
+	}
+	s.running[name]++
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.running[name]--; s.running[name] == 0 {
+				delete(s.running, name)
+			}
+			s.wg.Done()
+		}()
+		s.supervise(name, policy, routine)
+	}()
+}
+
+// supervise runs routine, restarting it on failures till the policy gives up on it or the context is done
+func (s *Supervisor) supervise(name string, policy RestartPolicy, routine func(ctx context.Context) error) {
+	backoff := policy.Backoff
+	for restarts := 0; ; restarts++ {
+		err := runRecovered(s.ctx, routine)
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+		if restarts >= policy.MaxRestarts {
+			s.lggr.Error().Err(err).Str("Routine", name).Int("Restarts", restarts).Msg("Routine failed, giving up")
+			s.mu.Lock()
+			s.errs = multierr.Append(s.errs, fmt.Errorf("routine %s failed after %d restarts: %w", name, restarts, err))
+			s.mu.Unlock()
+			return
+		}
+		s.lggr.Warn().Err(err).Str("Routine", name).Dur("Backoff", backoff).Msg("Routine failed, restarting")
+		select {
+		case <-time.After(backoff):
+		case <-s.ctx.Done():
+			return
+		}
+		backoff *= 2
+	}
+}
+
+// runRecovered runs routine, returning its panic as an error
+func runRecovered(ctx context.Context, routine func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return routine(ctx)
+}
+
+// OnStop registers f to be called in Stop once the routines have returned, e.g. to unsubscribe the subscriptions
+// the routines read from
+func (s *Supervisor) OnStop(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		f()
+		return
+	}
+	s.onStop = append(s.onStop, f)
+}
+
+// Running returns the names of the routines which are running, sorted
+func (s *Supervisor) Running() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runningNames()
+}
+
+// Stop cancels the context of the routines and waits for them to return for at most timeout. It returns the errors of
+// the routines given up on and of the routines not returning in time. Stopping more than once returns the same result.
+func (s *Supervisor) Stop(timeout time.Duration) error {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopped = true
+		s.mu.Unlock()
+		s.cancel()
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			s.mu.Lock()
+			s.errs = multierr.Append(s.errs, fmt.Errorf("routines %v did not stop in %s", s.runningNames(), timeout))
+			s.mu.Unlock()
+		}
+		s.mu.Lock()
+		onStop := s.onStop
+		s.onStop = nil
+		s.stopErr = s.errs
+		s.mu.Unlock()
+		for _, f := range onStop {
+			f()
+		}
+		s.lggr.Info().Err(s.stopErr).Msg("Supervisor stopped")
+	})
+	return s.stopErr
+}
+
+// runningNames returns the names of the running routines, s.mu must be held
+func (s *Supervisor) runningNames() []string {
+	names := make([]string, 0, len(s.running))
+	for name := range s.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestSupervisorRestarts(t *testing.T) {
+	t.Parallel()
+	s := NewSupervisor(context.Background(), zerolog.Nop())
+	policy := RestartPolicy{MaxRestarts: 2, Backoff: time.Millisecond}
+
+	// a panicking routine is restarted till it succeeds
+	panics := atomic.NewInt32(0)
+	s.Go("panicking", policy, func(ctx context.Context) error {
+		if panics.Inc() < 3 {
+			panic("boom")
+		}
+		return nil
+	})
+	// a failing routine is given up on after MaxRestarts
+	failures := atomic.NewInt32(0)
+	s.Go("failing", policy, func(ctx context.Context) error {
+		failures.Inc()
+		return errors.New("rpc down")
+	})
+	require.Eventually(t, func() bool {
+		return len(s.Running()) == 0
+	}, 5*time.Second, time.Millisecond)
+	require.Equal(t, int32(3), panics.Load())
+	require.Equal(t, int32(3), failures.Load())
+
+	err := s.Stop(time.Second)
+	require.ErrorContains(t, err, "routine failing failed after 2 restarts: rpc down")
+	require.NotContains(t, err.Error(), "panicking")
+}
+
+func TestSupervisorStop(t *testing.T) {
+	t.Parallel()
+	s := NewSupervisor(context.Background(), zerolog.Nop())
+	unsubscribed := atomic.NewBool(false)
+	s.OnStop(func() {
+		unsubscribed.Store(true)
+	})
+	s.Go("watcher", DefaultRestartPolicy, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	stuck := make(chan struct{})
+	s.Go("stuck", DefaultRestartPolicy, func(ctx context.Context) error {
+		<-stuck
+		return nil
+	})
+	require.Equal(t, []string{"stuck", "watcher"}, s.Running())
+
+	err := s.Stop(50 * time.Millisecond)
+	require.ErrorContains(t, err, "routines [stuck] did not stop")
+	require.True(t, unsubscribed.Load(), "stop hooks should be called")
+	require.Error(t, s.Context().Err())
+	// stopping again returns the same result
+	require.Equal(t, err, s.Stop(time.Second))
+	close(stuck)
+
+	// routines started after stop are not run
+	started := atomic.NewBool(false)
+	s.Go("late", DefaultRestartPolicy, func(ctx context.Context) error {
+		started.Store(true)
+		return nil
+	})
+	require.Empty(t, s.Running())
+	require.False(t, started.Load())
+	hooked := false
+	s.OnStop(func() {
+		hooked = true
+	})
+	require.True(t, hooked, "stop hooks registered after stop should be called right away")
+}
@@ -0,0 +1,353 @@
+package actions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+const (
+	// SyntheticPollingInterval is the interval the assertions of a synthetic lane poll the event watchers at
+	SyntheticPollingInterval = 5 * time.Millisecond
+	syntheticTxGas           = 100_000
+)
+
+// SyntheticChain is an in-memory chain which only implements the subset of blockchain.EVMClient used by the
+// assertions of a lane - headers, receipts and finality of the txs sent with MineTx. Calling any other method panics.
+// Every tx is mined in its own block, one second after the previous one, and is finalized as soon as it's mined.
+type SyntheticChain struct {
+	blockchain.EVMClient
+	name     string
+	chainID  *big.Int
+	mu       sync.Mutex
+	genesis  time.Time
+	latest   uint64
+	receipts map[common.Hash]*types.Receipt
+}
+
+func NewSyntheticChain(name string, chainID int64) *SyntheticChain {
+	return &SyntheticChain{
+		name:     name,
+		chainID:  big.NewInt(chainID),
+		genesis:  time.Now().UTC(),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+// MineTx mines a new tx in a new block and returns its receipt
+func (c *SyntheticChain) MineTx() *types.Receipt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latest++
+	var seed [16]byte
+	binary.BigEndian.PutUint64(seed[:8], c.chainID.Uint64())
+	binary.BigEndian.PutUint64(seed[8:], c.latest)
+	rcpt := &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      sha256.Sum256(seed[:]),
+		GasUsed:     syntheticTxGas,
+		BlockNumber: new(big.Int).SetUint64(c.latest),
+	}
+	c.receipts[rcpt.TxHash] = rcpt
+	return rcpt
+}
+
+func (c *SyntheticChain) blockTime(blockNumber uint64) time.Time {
+	return c.genesis.Add(time.Duration(blockNumber) * time.Second)
+}
+
+func (c *SyntheticChain) GetNetworkName() string {
+	return c.name
+}
+
+func (c *SyntheticChain) GetChainID() *big.Int {
+	return c.chainID
+}
+
+func (c *SyntheticChain) HeaderByNumber(_ context.Context, number *big.Int) (*blockchain.SafeEVMHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	blockNumber := c.latest
+	if number != nil {
+		blockNumber = number.Uint64()
+	}
+	if blockNumber > c.latest {
+		return nil, fmt.Errorf("block %d not found, latest block is %d", blockNumber, c.latest)
+	}
+	return &blockchain.SafeEVMHeader{
+		Number:    new(big.Int).SetUint64(blockNumber),
+		Timestamp: c.blockTime(blockNumber),
+	}, nil
+}
+
+func (c *SyntheticChain) GetTxReceipt(txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rcpt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, fmt.Errorf("receipt not found for tx %s", txHash.Hex())
+	}
+	return rcpt, nil
+}
+
+func (c *SyntheticChain) WaitForFinalizedTx(txHash common.Hash) (*big.Int, time.Time, error) {
+	rcpt, err := c.GetTxReceipt(txHash)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return rcpt.BlockNumber, c.blockTime(rcpt.BlockNumber.Uint64()), nil
+}
+
+// SyntheticLane is a lane between two SyntheticChain with no contracts and no CL nodes behind it. The lane events
+// are produced by calling SendRequests, Commit, Bless and Execute, which are consumed by the event watchers of the
+// lane the same way as the events of the deployed contracts. This allows the assertion pipeline of the lane
+// (e.g. CCIPLane.ValidateRequests) and the reporters to be tested without any chain.
+type SyntheticLane struct {
+	Lane        *CCIPLane
+	SourceChain *SyntheticChain
+	DestChain   *SyntheticChain
+
+	sendReqFeed  event.Feed
+	acceptedFeed event.Feed
+	blessedFeed  event.Feed
+	execFeed     event.Feed
+
+	subscriptions atomic.Int32
+	mu            sync.Mutex
+	nextSeqNum    uint64
+	msgIDs        map[uint64][32]byte
+}
+
+// NewSyntheticLane returns a synthetic lane with its event watchers started, the watchers are stopped at the end of the test.
+// If withARM is true, the commit reports are to be blessed with Bless before they are considered for execution.
+// As there is no CommitStore to query, the messages are to be committed with Commit before they are validated.
+func NewSyntheticLane(t *testing.T, lggr zerolog.Logger, withARM bool) (*SyntheticLane, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	sourceChain := NewSyntheticChain("synthetic-source", 1337)
+	destChain := NewSyntheticChain("synthetic-dest", 2337)
+	reporter := testreporters.NewCCIPTestReporter(t, lggr)
+	laneName := fmt.Sprintf("%s To %s", sourceChain.GetNetworkName(), destChain.GetNetworkName())
+	s := &SyntheticLane{
+		SourceChain: sourceChain,
+		DestChain:   destChain,
+		nextSeqNum:  1,
+		msgIDs:      make(map[uint64][32]byte),
+	}
+	s.Lane = &CCIPLane{
+		Test:              t,
+		Logger:            lggr,
+		SourceNetworkName: sourceChain.GetNetworkName(),
+		DestNetworkName:   destChain.GetNetworkName(),
+		SourceChain:       sourceChain,
+		DestChain:         destChain,
+		Source: &SourceCCIPModule{
+			Common: &CCIPCommon{
+				ChainClient:     sourceChain,
+				PollingInterval: SyntheticPollingInterval,
+			},
+			DestinationChainId:       destChain.GetChainID().Uint64(),
+			DestNetworkName:          destChain.GetNetworkName(),
+			CCIPSendRequestedWatcher: &sync.Map{},
+			SentMsgs:                 &sync.Map{},
+		},
+		Dest: &DestCCIPModule{
+			Common: &CCIPCommon{
+				ChainClient:     destChain,
+				PollingInterval: SyntheticPollingInterval,
+			},
+			SourceChainId:           sourceChain.GetChainID().Uint64(),
+			SourceNetworkName:       sourceChain.GetNetworkName(),
+			CommitStore:             &contracts.CommitStore{EthAddress: common.HexToAddress("0xc0")},
+			ReportAcceptedWatcher:   &sync.Map{},
+			ExecStateChangedWatcher: &sync.Map{},
+			ReportBlessedWatcher:    &sync.Map{},
+			ReportBlessedBySeqNum:   &sync.Map{},
+			NextSeqNumToCommit:      atomic.NewUint64(1),
+		},
+		Reports:           reporter.AddNewLane(laneName, lggr),
+		SentReqs:          make(map[common.Hash][]CCIPRequest),
+		TotalFee:          big.NewInt(0),
+		ValidationTimeout: time.Second,
+		Context:           ctx,
+		EventSource:       s,
+	}
+	if withARM {
+		s.Lane.Dest.Common.ARM = &contracts.ARM{EthAddress: common.HexToAddress("0xa0")}
+	}
+	t.Cleanup(func() {
+		if err := s.Lane.Routines().Stop(DefaultSupervisorStopTimeout); err != nil {
+			lggr.Error().Err(err).Msg("Failed to stop the routines of the synthetic lane")
+		}
+	})
+	if err := s.Lane.startEventWatchers(s, SyntheticPollingInterval); err != nil {
+		return nil, err
+	}
+	// the watchers subscribe asynchronously, the events sent before they subscribe would be dropped
+	watchers := int32(3)
+	if withARM {
+		watchers++
+	}
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	for s.subscriptions.Load() < watchers {
+		select {
+		case <-timer.C:
+			return nil, fmt.Errorf("event watchers of the synthetic lane are not subscribed")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	return s, nil
+}
+
+func (s *SyntheticLane) WatchCCIPSendRequested(sink chan *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) (event.Subscription, error) {
+	s.subscriptions.Inc()
+	return s.sendReqFeed.Subscribe(sink), nil
+}
+
+func (s *SyntheticLane) WatchReportAccepted(sink chan *commit_store.CommitStoreReportAccepted) (event.Subscription, error) {
+	s.subscriptions.Inc()
+	return s.acceptedFeed.Subscribe(sink), nil
+}
+
+func (s *SyntheticLane) WatchTaggedRootBlessed(sink chan *arm_contract.ARMContractTaggedRootBlessed) (event.Subscription, error) {
+	s.subscriptions.Inc()
+	return s.blessedFeed.Subscribe(sink), nil
+}
+
+func (s *SyntheticLane) WatchExecutionStateChanged(sink chan *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged) (event.Subscription, error) {
+	s.subscriptions.Inc()
+	return s.execFeed.Subscribe(sink), nil
+}
+
+// SendRequests sends noOfRequests messages in a single tx on the source chain, the requests are added to the
+// sent requests of the lane to be validated. It returns the tx hash and the sequence numbers of the messages.
+func (s *SyntheticLane) SendRequests(noOfRequests int) (common.Hash, []uint64, error) {
+	rcpt := s.SourceChain.MineTx()
+	var stats []*testreporters.RequestStat
+	var seqNums []uint64
+	s.mu.Lock()
+	for i := 0; i < noOfRequests; i++ {
+		seqNum := s.nextSeqNum
+		s.nextSeqNum++
+		msgID := sha256.Sum256(binary.BigEndian.AppendUint64(rcpt.TxHash.Bytes(), seqNum))
+		s.msgIDs[seqNum] = msgID
+		seqNums = append(seqNums, seqNum)
+		stats = append(stats, s.Lane.NewRequestStat(int64(s.Lane.NumberOfReq+i+1)))
+	}
+	s.mu.Unlock()
+	if _, err := s.Lane.AddToSentReqs(rcpt.TxHash, stats); err != nil {
+		return common.Hash{}, nil, err
+	}
+	for _, stat := range stats {
+		stat.UpdateState(s.Lane.Logger, 0, testreporters.TX, 0, testreporters.Success, testreporters.TransactionStats{
+			TxHash:  rcpt.TxHash.Hex(),
+			GasUsed: rcpt.GasUsed,
+		})
+	}
+	for i, seqNum := range seqNums {
+		s.sendReqFeed.Send(&evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested{
+			Message: evm_2_evm_onramp.InternalEVM2EVMMessage{
+				SequenceNumber: seqNum,
+				MessageId:      s.msgIDs[seqNum],
+			},
+			Raw: s.log(rcpt, uint(i)),
+		})
+	}
+	return rcpt.TxHash, seqNums, nil
+}
+
+// Commit commits the messages with sequence numbers from minSeqNum to maxSeqNum in a single report on the dest chain
+// and returns the merkle root of the report
+func (s *SyntheticLane) Commit(minSeqNum, maxSeqNum uint64) [32]byte {
+	rcpt := s.DestChain.MineTx()
+	root := sha256.Sum256(binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, minSeqNum), maxSeqNum))
+	s.acceptedFeed.Send(&commit_store.CommitStoreReportAccepted{
+		Report: commit_store.CommitStoreCommitReport{
+			Interval:   commit_store.CommitStoreInterval{Min: minSeqNum, Max: maxSeqNum},
+			MerkleRoot: root,
+		},
+		Raw: s.log(rcpt, 0),
+	})
+	if next := s.Lane.Dest.NextSeqNumToCommit.Load(); next <= maxSeqNum {
+		s.Lane.Dest.NextSeqNumToCommit.Store(maxSeqNum + 1)
+	}
+	return root
+}
+
+// Bless blesses the committed root on the dest chain
+func (s *SyntheticLane) Bless(root [32]byte) {
+	rcpt := s.DestChain.MineTx()
+	s.blessedFeed.Send(&arm_contract.ARMContractTaggedRootBlessed{
+		TaggedRoot: arm_contract.IRMNTaggedRoot{
+			CommitStore: s.Lane.Dest.CommitStore.EthAddress,
+			Root:        root,
+		},
+		Raw: s.log(rcpt, 0),
+	})
+}
+
+// Execute executes the messages with seqNums in a single tx on the dest chain with the execution state
+func (s *SyntheticLane) Execute(state testhelpers.MessageExecutionState, seqNums ...uint64) {
+	rcpt := s.DestChain.MineTx()
+	for i, seqNum := range seqNums {
+		s.mu.Lock()
+		msgID := s.msgIDs[seqNum]
+		s.mu.Unlock()
+		s.execFeed.Send(&evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged{
+			SequenceNumber: seqNum,
+			MessageId:      msgID,
+			State:          uint8(state),
+			Raw:            s.log(rcpt, uint(i)),
+		})
+	}
+}
+
+// Deliver commits, blesses if required, and successfully executes all the messages sent so far in a single report
+func (s *SyntheticLane) Deliver() {
+	s.mu.Lock()
+	maxSeqNum := s.nextSeqNum - 1
+	s.mu.Unlock()
+	minSeqNum := s.Lane.Dest.NextSeqNumToCommit.Load()
+	if minSeqNum > maxSeqNum {
+		return
+	}
+	root := s.Commit(minSeqNum, maxSeqNum)
+	if s.Lane.Dest.Common.ARM != nil {
+		s.Bless(root)
+	}
+	var seqNums []uint64
+	for seqNum := minSeqNum; seqNum <= maxSeqNum; seqNum++ {
+		seqNums = append(seqNums, seqNum)
+	}
+	s.Execute(testhelpers.ExecutionStateSuccess, seqNums...)
+}
+
+func (s *SyntheticLane) log(rcpt *types.Receipt, index uint) types.Log {
+	return types.Log{
+		TxHash:      rcpt.TxHash,
+		BlockNumber: rcpt.BlockNumber.Uint64(),
+		Index:       index,
+	}
+}
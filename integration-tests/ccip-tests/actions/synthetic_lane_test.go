@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+func TestSyntheticLaneDelivered(t *testing.T) {
+	t.Parallel()
+	for _, withARM := range []bool{false, true} {
+		withARM := withARM
+		name := "without ARM"
+		if withARM {
+			name = "with ARM"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s, err := NewSyntheticLane(t, zerolog.Nop(), withARM)
+			require.NoError(t, err)
+			_, seqNums, err := s.SendRequests(3)
+			require.NoError(t, err)
+			require.Equal(t, []uint64{1, 2, 3}, seqNums)
+			_, seqNums, err = s.SendRequests(2)
+			require.NoError(t, err)
+			require.Equal(t, []uint64{4, 5}, seqNums)
+			s.Deliver()
+
+			s.Lane.ValidateRequests()
+			stats := s.Lane.Reports.RequestStats()
+			require.Len(t, stats, 5)
+			for i, stat := range stats {
+				require.Equal(t, uint64(i+1), stat.SeqNum)
+				for _, phase := range []testreporters.Phase{testreporters.TX, testreporters.CCIPSendRe, testreporters.SourceLogFinalized,
+					testreporters.Commit, testreporters.ExecStateChanged, testreporters.E2E} {
+					require.Equal(t, testreporters.Success, stat.StatusByPhase[phase].Status, "phase %s of req %d", phase, stat.ReqNo)
+				}
+				_, blessed := stat.StatusByPhase[testreporters.ReportBlessed]
+				require.Equal(t, withARM, blessed, "ReportBlessed should only be recorded with ARM")
+			}
+		})
+	}
+}
+
+func TestSyntheticLaneExecutionFailed(t *testing.T) {
+	t.Parallel()
+	s, err := NewSyntheticLane(t, zerolog.Nop(), false)
+	require.NoError(t, err)
+	_, seqNums, err := s.SendRequests(1)
+	require.NoError(t, err)
+	s.Commit(seqNums[0], seqNums[0])
+	s.Execute(testhelpers.ExecutionStateFailure, seqNums...)
+
+	s.Lane.ValidateRequests(ExpectPhaseToFail(testreporters.ExecStateChanged, ShouldExist()))
+	stats := s.Lane.Reports.RequestStats()
+	require.Len(t, stats, 1)
+	require.Equal(t, testreporters.Success, stats[0].StatusByPhase[testreporters.Commit].Status)
+	require.Equal(t, testreporters.Failure, stats[0].StatusByPhase[testreporters.ExecStateChanged].Status)
+	require.Equal(t, testreporters.Failure, stats[0].StatusByPhase[testreporters.E2E].Status)
+}
+
+func TestSyntheticLaneMissingPhases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not executed", func(t *testing.T) {
+		t.Parallel()
+		s, err := NewSyntheticLane(t, zerolog.Nop(), false)
+		require.NoError(t, err)
+		_, seqNums, err := s.SendRequests(2)
+		require.NoError(t, err)
+		s.Commit(seqNums[0], seqNums[1])
+
+		s.Lane.ValidateRequests(ExpectPhaseToFail(testreporters.ExecStateChanged, WithTimeout(50*time.Millisecond)))
+		stats := s.Lane.Reports.RequestStats()
+		require.Len(t, stats, 2)
+		require.Equal(t, testreporters.Failure, stats[0].StatusByPhase[testreporters.ExecStateChanged].Status)
+	})
+
+	t.Run("not blessed", func(t *testing.T) {
+		t.Parallel()
+		s, err := NewSyntheticLane(t, zerolog.Nop(), true)
+		require.NoError(t, err)
+		_, seqNums, err := s.SendRequests(1)
+		require.NoError(t, err)
+		s.Commit(seqNums[0], seqNums[0])
+		s.Execute(testhelpers.ExecutionStateSuccess, seqNums...)
+
+		s.Lane.ValidateRequests(ExpectPhaseToFail(testreporters.ReportBlessed, WithTimeout(50*time.Millisecond)))
+		stats := s.Lane.Reports.RequestStats()
+		require.Len(t, stats, 1)
+		require.Equal(t, testreporters.Failure, stats[0].StatusByPhase[testreporters.ReportBlessed].Status)
+		_, executed := stats[0].StatusByPhase[testreporters.ExecStateChanged]
+		require.False(t, executed, "execution should not be validated once the report is not blessed")
+	})
+}
+
+func TestSyntheticLaneValidationCancelled(t *testing.T) {
+	t.Parallel()
+	s, err := NewSyntheticLane(t, zerolog.Nop(), false)
+	require.NoError(t, err)
+	_, seqNums, err := s.SendRequests(1)
+	require.NoError(t, err)
+	s.Commit(seqNums[0], seqNums[0])
+
+	ctx, cancel := context.WithCancel(s.Lane.Context)
+	cancel()
+	// the timeouts are long enough for the test to time out if the cancellation is not honoured
+	stat := testreporters.NewCCIPRequestStats(1, s.Lane.SourceNetworkName, s.Lane.DestNetworkName)
+	start := time.Now()
+	_, err = s.Lane.Dest.AssertEventExecutionStateChanged(ctx, zerolog.Nop(), seqNums[0], time.Hour, start, stat,
+		testhelpers.ExecutionStateSuccess)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, testreporters.Failure, stat.StatusByPhase[testreporters.ExecStateChanged].Status)
+
+	err = s.Lane.Dest.AssertNoExecutionStateChangedEventReceived(ctx, zerolog.Nop(), time.Hour, start)
+	require.ErrorIs(t, err, context.Canceled, "cancellation should not validate the absence of the event")
+	require.Less(t, time.Since(start), time.Minute)
+}
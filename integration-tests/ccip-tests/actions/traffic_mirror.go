@@ -0,0 +1,95 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+// trafficMirrorBlockRange is the number of blocks queried for CCIPSendRequested logs in one call,
+// so that the range is within the limits of the public rpcs
+const trafficMirrorBlockRange = uint64(2000)
+
+// mirroredMsg returns the shape of the message sent on the production lane at sentAt
+func mirroredMsg(msg evm_2_evm_onramp.InternalEVM2EVMMessage, sentAt time.Time) testconfig.MirroredMsg {
+	gasLimit := int64(0)
+	if msg.GasLimit != nil {
+		gasLimit = msg.GasLimit.Int64()
+	}
+	return testconfig.MirroredMsg{
+		SentAt:     sentAt,
+		DataLength: int64(len(msg.Data)),
+		NoOfTokens: len(msg.TokenAmounts),
+		GasLimit:   gasLimit,
+	}
+}
+
+// ImportTraffic reads the CCIPSendRequested events emitted by the on ramp of a production lane in the block range
+// set in cfg, and returns the shape of the sent messages along with the time of the block they were sent in
+func ImportTraffic(ctx context.Context, lggr zerolog.Logger, cfg *testconfig.TrafficMirrorConfig) ([]testconfig.MirroredMsg, error) {
+	client, err := ethclient.DialContext(ctx, *cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", *cfg.RPCURL, err)
+	}
+	defer client.Close()
+	onRamp, err := evm_2_evm_onramp.NewEVM2EVMOnRampFilterer(common.HexToAddress(*cfg.OnRamp), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create on ramp filterer: %w", err)
+	}
+
+	var msgs []testconfig.MirroredMsg
+	blockTimes := make(map[uint64]time.Time)
+	for from := *cfg.FromBlock; from <= *cfg.ToBlock; from += trafficMirrorBlockRange {
+		to := from + trafficMirrorBlockRange - 1
+		if to > *cfg.ToBlock {
+			to = *cfg.ToBlock
+		}
+		it, err := onRamp.FilterCCIPSendRequested(&bind.FilterOpts{Start: from, End: &to, Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter CCIPSendRequested events in blocks %d-%d: %w", from, to, err)
+		}
+		for it.Next() {
+			blockNum := it.Event.Raw.BlockNumber
+			sentAt, ok := blockTimes[blockNum]
+			if !ok {
+				header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNum))
+				if err != nil {
+					_ = it.Close()
+					return nil, fmt.Errorf("failed to get header of block %d: %w", blockNum, err)
+				}
+				sentAt = time.Unix(int64(header.Time), 0)
+				blockTimes[blockNum] = sentAt
+			}
+			msgs = append(msgs, mirroredMsg(it.Event.Message, sentAt))
+		}
+		if err := it.Error(); err != nil {
+			_ = it.Close()
+			return nil, fmt.Errorf("failed to iterate CCIPSendRequested events in blocks %d-%d: %w", from, to, err)
+		}
+		if err := it.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no CCIPSendRequested events emitted by on ramp %s in blocks %d-%d",
+			*cfg.OnRamp, *cfg.FromBlock, *cfg.ToBlock)
+	}
+	lggr.Info().
+		Str("On Ramp", *cfg.OnRamp).
+		Uint64("From Block", *cfg.FromBlock).
+		Uint64("To Block", *cfg.ToBlock).
+		Int("Messages", len(msgs)).
+		Dur("Span", msgs[len(msgs)-1].SentAt.Sub(msgs[0].SentAt)).
+		Msg("Imported production traffic to mirror")
+	return msgs, nil
+}
@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+func TestMirroredMsg(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Unix(1_700_000_000, 0)
+	msg := mirroredMsg(evm_2_evm_onramp.InternalEVM2EVMMessage{
+		GasLimit:     big.NewInt(200_000),
+		Data:         make([]byte, 42),
+		TokenAmounts: make([]evm_2_evm_onramp.ClientEVMTokenAmount, 3),
+	}, sentAt)
+	require.Equal(t, testconfig.MirroredMsg{SentAt: sentAt, DataLength: 42, NoOfTokens: 3, GasLimit: 200_000}, msg)
+}
+
+func TestApplyMirroredTraffic(t *testing.T) {
+	t.Parallel()
+	start := time.Unix(1_700_000_000, 0)
+	msgs := []testconfig.MirroredMsg{
+		{SentAt: start.Add(25 * time.Second), DataLength: 0, NoOfTokens: 0, GasLimit: 0},
+		{SentAt: start, DataLength: 100, NoOfTokens: 0, GasLimit: 100_000},
+		{SentAt: start.Add(5 * time.Second), DataLength: 0, NoOfTokens: 3, GasLimit: 0},
+		{SentAt: start.Add(8 * time.Second), DataLength: 10, NoOfTokens: 1, GasLimit: 50_000},
+	}
+	profile := &testconfig.LoadProfile{
+		TimeUnit:      config.MustNewDuration(10 * time.Second),
+		TrafficMirror: &testconfig.TrafficMirrorConfig{AmountPerToken: pointer.ToInt64(5)},
+	}
+	require.NoError(t, profile.ApplyMirroredTraffic(msgs, 2))
+
+	// the 2nd time unit is idle and skipped
+	require.Equal(t, []int64{3, 1}, profile.RequestPerUnitTime)
+	require.Len(t, profile.StepDuration, 2)
+	require.Equal(t, 20*time.Second, profile.TestDuration.Duration())
+
+	expected := []struct {
+		msgType    string
+		dataLength int64
+		noOfTokens int
+		gasLimit   int64
+	}{
+		{testconfig.DataOnlyTransfer, 100, 0, 100_000},
+		{testconfig.TokenOnlyTransfer, 0, 2, 0},
+		{testconfig.DataAndTokenTransfer, 10, 1, 50_000},
+		{testconfig.DataOnlyTransfer, 1, 0, 0},
+	}
+	for i, exp := range expected {
+		details := profile.MsgProfile.MsgDetailsForIteration(int64(i + 1))
+		require.Equal(t, exp.msgType, pointer.GetString(details.MsgType), "msg %d", i)
+		require.Equal(t, exp.dataLength, pointer.GetInt64(details.DataLength), "msg %d", i)
+		require.Equal(t, exp.noOfTokens, pointer.GetInt(details.NoOfTokens), "msg %d", i)
+		require.Equal(t, exp.gasLimit, pointer.GetInt64(details.DestGasLimit), "msg %d", i)
+		if exp.noOfTokens > 0 {
+			require.Equal(t, int64(5), pointer.GetInt64(details.AmountPerToken), "msg %d", i)
+		}
+	}
+
+	require.Error(t, profile.ApplyMirroredTraffic(nil, 2))
+}
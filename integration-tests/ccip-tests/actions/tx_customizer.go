@@ -0,0 +1,147 @@
+package actions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+// TxCustomizer rewrites an unsigned tx before it's signed, for chains which don't accept the tx shape the bindings
+// build by default
+type TxCustomizer func(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+
+// NewTxCustomizer returns the customizer converting the txs to the tx type of cfg and adding its access list
+func NewTxCustomizer(cfg *testconfig.TxCustomizationConfig) (TxCustomizer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var accessList types.AccessList
+	for _, entry := range cfg.AccessList {
+		tuple := types.AccessTuple{Address: common.HexToAddress(*entry.Address)}
+		for _, key := range entry.StorageKeys {
+			tuple.StorageKeys = append(tuple.StorageKeys, common.HexToHash(key))
+		}
+		accessList = append(accessList, tuple)
+	}
+	txType := pointer.GetString(cfg.TxType)
+	return func(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+		return convertTx(chainID, tx, txType, accessList)
+	}, nil
+}
+
+// convertTx returns tx converted to txType with accessList appended to its access list. The gas limit is increased by
+// the intrinsic gas of the added access list. If txType is not set, legacy txs become access list txs when an access
+// list is added and the other txs keep their type.
+func convertTx(chainID *big.Int, tx *types.Transaction, txType string, accessList types.AccessList) (*types.Transaction, error) {
+	if txType == "" {
+		txType = testconfig.TxTypeDynamicFee
+		if tx.Type() == types.LegacyTxType {
+			txType = testconfig.TxTypeLegacy
+			if len(accessList) > 0 {
+				txType = testconfig.TxTypeAccessList
+			}
+		}
+	}
+	gas := tx.Gas()
+	for _, tuple := range accessList {
+		gas += params.TxAccessListAddressGas + uint64(len(tuple.StorageKeys))*params.TxAccessListStorageKeyGas
+	}
+	mergedAccessList := append(append(types.AccessList{}, tx.AccessList()...), accessList...)
+	switch txType {
+	case testconfig.TxTypeLegacy:
+		if len(mergedAccessList) > 0 {
+			return nil, fmt.Errorf("tx with access list cannot be converted to legacy tx")
+		}
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: tx.GasFeeCap(),
+			Gas:      gas,
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	case testconfig.TxTypeAccessList:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      tx.Nonce(),
+			GasPrice:   tx.GasFeeCap(),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: mergedAccessList,
+		}), nil
+	case testconfig.TxTypeDynamicFee:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: mergedAccessList,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported tx type %s", txType)
+	}
+}
+
+// CustomizeTransactOpts makes the txs signed with opts be rewritten by customize before they are signed
+func CustomizeTransactOpts(opts *bind.TransactOpts, chainID *big.Int, customize TxCustomizer) {
+	signer := opts.Signer
+	opts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		customized, err := customize(chainID, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to customize tx: %w", err)
+		}
+		return signer(from, customized)
+	}
+}
+
+// TxCustomizingClient is an EVMClient which rewrites the txs of the contract deployments and the contract calls made
+// with its transaction opts before they are signed. Txs built by the client itself, e.g. native transfers, are not
+// rewritten.
+type TxCustomizingClient struct {
+	blockchain.EVMClient
+	customize TxCustomizer
+}
+
+// WithTxCustomizer wraps client with the customizer, the client is returned as is if customize is nil
+func WithTxCustomizer(client blockchain.EVMClient, customize TxCustomizer) blockchain.EVMClient {
+	if customize == nil {
+		return client
+	}
+	return &TxCustomizingClient{EVMClient: client, customize: customize}
+}
+
+func (c *TxCustomizingClient) TransactionOpts(from *blockchain.EthereumWallet) (*bind.TransactOpts, error) {
+	opts, err := c.EVMClient.TransactionOpts(from)
+	if err != nil {
+		return nil, err
+	}
+	CustomizeTransactOpts(opts, c.GetChainID(), c.customize)
+	return opts, nil
+}
+
+func (c *TxCustomizingClient) DeployContract(
+	contractName string,
+	deployer blockchain.ContractDeployer,
+) (*common.Address, *types.Transaction, interface{}, error) {
+	return c.EVMClient.DeployContract(contractName, func(auth *bind.TransactOpts, backend bind.ContractBackend) (
+		common.Address, *types.Transaction, interface{}, error,
+	) {
+		CustomizeTransactOpts(auth, c.GetChainID(), c.customize)
+		return deployer(auth, backend)
+	})
+}
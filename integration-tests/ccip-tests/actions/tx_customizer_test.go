@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+)
+
+func TestTxCustomizer(t *testing.T) {
+	t.Parallel()
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x1")
+	dynamicTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(5),
+		Data:      []byte("data"),
+	})
+	legacyTx := types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(100), Gas: 100_000, To: &to, Data: []byte("data")})
+	key := common.HexToHash("0x2")
+	accessList := []*testconfig.AccessListEntry{{Address: pointer.ToString(to.Hex()), StorageKeys: []string{key.Hex()}}}
+	accessListGas := params.TxAccessListAddressGas + params.TxAccessListStorageKeyGas
+
+	customize, err := NewTxCustomizer(&testconfig.TxCustomizationConfig{TxType: pointer.ToString(testconfig.TxTypeLegacy)})
+	require.NoError(t, err)
+	tx, err := customize(chainID, dynamicTx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.LegacyTxType), tx.Type())
+	require.Equal(t, big.NewInt(100), tx.GasPrice())
+	require.Equal(t, dynamicTx.Nonce(), tx.Nonce())
+	require.Equal(t, dynamicTx.Value(), tx.Value())
+	require.Equal(t, dynamicTx.Data(), tx.Data())
+	require.Equal(t, dynamicTx.Gas(), tx.Gas())
+
+	// without a tx type legacy txs become access list txs
+	customize, err = NewTxCustomizer(&testconfig.TxCustomizationConfig{AccessList: accessList})
+	require.NoError(t, err)
+	tx, err = customize(chainID, legacyTx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.AccessListTxType), tx.Type())
+	require.Equal(t, chainID, tx.ChainId())
+	require.Equal(t, types.AccessList{{Address: to, StorageKeys: []common.Hash{key}}}, tx.AccessList())
+	require.Equal(t, legacyTx.Gas()+accessListGas, tx.Gas())
+	// and dynamic fee txs keep their type
+	tx, err = customize(chainID, dynamicTx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	require.Equal(t, dynamicTx.GasTipCap(), tx.GasTipCap())
+	require.Len(t, tx.AccessList(), 1)
+
+	_, err = NewTxCustomizer(&testconfig.TxCustomizationConfig{TxType: pointer.ToString(testconfig.TxTypeLegacy), AccessList: accessList})
+	require.ErrorContains(t, err, "access list cannot be set for legacy txs")
+	_, err = NewTxCustomizer(&testconfig.TxCustomizationConfig{TxType: pointer.ToString("blob")})
+	require.ErrorContains(t, err, "invalid tx type blob")
+}
+
+func TestCustomizeTransactOpts(t *testing.T) {
+	t.Parallel()
+	chainID := big.NewInt(1337)
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+	customize, err := NewTxCustomizer(&testconfig.TxCustomizationConfig{TxType: pointer.ToString(testconfig.TxTypeAccessList)})
+	require.NoError(t, err)
+	CustomizeTransactOpts(opts, chainID, customize)
+
+	to := common.HexToAddress("0x1")
+	signed, err := opts.Signer(opts.From, types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1), Gas: 21_000, To: &to}))
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.AccessListTxType), signed.Type())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, opts.From, sender)
+}
@@ -0,0 +1,398 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_usdc_token_transmitter"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+)
+
+const (
+	// usdcMessageHeaderLength is the length of the fixed size header of a CCTP message
+	// version(4) | sourceDomain(4) | destDomain(4) | nonce(8) | sender(32) | recipient(32) | destinationCaller(32)
+	usdcMessageHeaderLength = 116
+	usdcAttestationPath     = "/v1/attestations/"
+	usdcAttestationComplete = "complete"
+	usdcAttestationPending  = "pending_confirmations"
+)
+
+// USDCMessage is the decoded form of the message emitted in the MessageSent event of the token transmitter
+type USDCMessage struct {
+	Version           uint32
+	SourceDomain      uint32
+	DestinationDomain uint32
+	Nonce             uint64
+	Sender            [32]byte
+	Recipient         [32]byte
+	DestinationCaller [32]byte
+	MessageBody       []byte
+	Raw               []byte
+}
+
+// Hash returns the hash of the message the attestation api is queried with
+func (m *USDCMessage) Hash() common.Hash {
+	return crypto.Keccak256Hash(m.Raw)
+}
+
+// ParseUSDCMessage decodes the raw message bytes emitted in MessageSent event
+func ParseUSDCMessage(raw []byte) (*USDCMessage, error) {
+	if len(raw) < usdcMessageHeaderLength {
+		return nil, fmt.Errorf("invalid USDC message length %d, expected at least %d", len(raw), usdcMessageHeaderLength)
+	}
+	msg := &USDCMessage{
+		Version:           binary.BigEndian.Uint32(raw[0:4]),
+		SourceDomain:      binary.BigEndian.Uint32(raw[4:8]),
+		DestinationDomain: binary.BigEndian.Uint32(raw[8:12]),
+		Nonce:             binary.BigEndian.Uint64(raw[12:20]),
+		MessageBody:       raw[usdcMessageHeaderLength:],
+		Raw:               raw,
+	}
+	copy(msg.Sender[:], raw[20:52])
+	copy(msg.Recipient[:], raw[52:84])
+	copy(msg.DestinationCaller[:], raw[84:116])
+	return msg, nil
+}
+
+// USDCAttestationService is a light in-process replacement of the circle attestation api.
+// It watches MessageSent events from the source token transmitters, signs every observed message with a set of
+// test attester keys and serves the per-message attestation in the same format as the circle api -
+// GET /v1/attestations/0x{messageHash} => {"status": "complete", "attestation": "0x{signatures}"}
+// Messages which are not yet observed are reported as pending, so that the nodes keep retrying.
+//
+// Similar to the circle message transmitter, the service maintains a set of enabled attesters and a signature threshold.
+// Every attestation is signed by the first threshold enabled attesters ordered by ascending address. The attesters can be
+// enabled, disabled or rotated mid-run to exercise attester key rotation while messages are in-flight.
+type USDCAttestationService struct {
+	logger        zerolog.Logger
+	mu            *sync.RWMutex
+	attesters     []*ecdsa.PrivateKey // enabled attesters
+	threshold     int
+	messages      map[common.Hash]*USDCMessage
+	subscriptions []event.Subscription
+	server        *http.Server
+	listener      net.Listener
+	ExternalURL   string
+}
+
+// NewUSDCAttestationService creates the service with noOfAttesters randomly generated attester keys.
+// externalURL is the url at which the chainlink nodes can reach the service listening at listenAddress.
+func NewUSDCAttestationService(lggr zerolog.Logger, noOfAttesters int, listenAddress, externalURL string) (*USDCAttestationService, error) {
+	if noOfAttesters <= 0 {
+		return nil, fmt.Errorf("number of attesters should be greater than 0")
+	}
+	s := &USDCAttestationService{
+		logger:      lggr.With().Str("Service", "USDC Attestation").Logger(),
+		mu:          &sync.RWMutex{},
+		messages:    make(map[common.Hash]*USDCMessage),
+		threshold:   noOfAttesters,
+		ExternalURL: strings.TrimSuffix(externalURL, "/"),
+	}
+	for i := 0; i < noOfAttesters; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate attester key: %w", err)
+		}
+		s.attesters = append(s.attesters, key)
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddress, err)
+	}
+	s.listener = listener
+	mux := http.NewServeMux()
+	mux.HandleFunc(usdcAttestationPath, s.handleAttestation)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error().Err(err).Msg("USDC attestation service stopped unexpectedly")
+		}
+	}()
+	s.logger.Info().
+		Str("Listen Address", listener.Addr().String()).
+		Str("External URL", s.ExternalURL).
+		Interface("Attesters", s.Attesters()).
+		Msg("USDC attestation service started")
+	return s, nil
+}
+
+// Attesters returns the addresses of the attester keys currently signing messages
+func (s *USDCAttestationService) Attesters() []common.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var addrs []common.Address
+	for _, key := range s.attesters {
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return addrs
+}
+
+// SignatureThreshold returns the number of signatures included in every attestation
+func (s *USDCAttestationService) SignatureThreshold() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.threshold
+}
+
+// SetSignatureThreshold sets the number of enabled attesters signing every attestation
+func (s *USDCAttestationService) SetSignatureThreshold(threshold int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if threshold <= 0 || threshold > len(s.attesters) {
+		return fmt.Errorf("invalid signature threshold %d, should be between 1 and %d", threshold, len(s.attesters))
+	}
+	s.threshold = threshold
+	s.logger.Info().Int("Threshold", threshold).Msg("USDC attestation signature threshold updated")
+	return nil
+}
+
+// EnableAttester generates a new attester key and adds it to the enabled attesters
+func (s *USDCAttestationService) EnableAttester() (common.Address, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to generate attester key: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attesters = append(s.attesters, key)
+	attester := crypto.PubkeyToAddress(key.PublicKey)
+	s.logger.Info().Str("Attester", attester.Hex()).Msg("USDC attester enabled")
+	return attester, nil
+}
+
+// DisableAttester removes the attester from the enabled attesters. It fails if the number of enabled
+// attesters would drop below the signature threshold.
+func (s *USDCAttestationService) DisableAttester(attester common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.attesters) <= s.threshold {
+		return fmt.Errorf("cannot disable attester %s, number of enabled attesters %d would go below threshold %d",
+			attester.Hex(), len(s.attesters), s.threshold)
+	}
+	for i, key := range s.attesters {
+		if crypto.PubkeyToAddress(key.PublicKey) == attester {
+			s.attesters = append(s.attesters[:i], s.attesters[i+1:]...)
+			s.logger.Info().Str("Attester", attester.Hex()).Msg("USDC attester disabled")
+			return nil
+		}
+	}
+	return fmt.Errorf("attester %s is not enabled", attester.Hex())
+}
+
+// RotateAttester replaces the given attester with a newly generated one, following the enable first, then disable
+// sequence so that the number of enabled attesters never goes below the threshold
+func (s *USDCAttestationService) RotateAttester(attester common.Address) (common.Address, error) {
+	newAttester, err := s.EnableAttester()
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := s.DisableAttester(attester); err != nil {
+		return common.Address{}, fmt.Errorf("failed to disable attester after enabling %s: %w", newAttester.Hex(), err)
+	}
+	return newAttester, nil
+}
+
+// Messages returns all the USDC messages observed by the service
+func (s *USDCAttestationService) Messages() []*USDCMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var msgs []*USDCMessage
+	for _, msg := range s.messages {
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// AddMessage records the raw message emitted in MessageSent event so that it can be attested
+func (s *USDCAttestationService) AddMessage(raw []byte) (*USDCMessage, error) {
+	msg, err := ParseUSDCMessage(raw)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.messages[msg.Hash()] = msg
+	s.mu.Unlock()
+	s.logger.Debug().
+		Str("Message Hash", msg.Hash().Hex()).
+		Uint32("Source Domain", msg.SourceDomain).
+		Uint32("Destination Domain", msg.DestinationDomain).
+		Uint64("Nonce", msg.Nonce).
+		Msg("USDC message observed")
+	return msg, nil
+}
+
+// Attestation returns the attestation for the message with the given hash, signed by the first threshold enabled
+// attesters. The signatures are ordered by ascending attester address as expected by the circle message transmitter.
+func (s *USDCAttestationService) Attestation(msgHash common.Hash) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	msg, ok := s.messages[msgHash]
+	if !ok {
+		return nil, false, nil
+	}
+	keys := make([]*ecdsa.PrivateKey, len(s.attesters))
+	copy(keys, s.attesters)
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(
+			crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(),
+			crypto.PubkeyToAddress(keys[j].PublicKey).Bytes(),
+		) < 0
+	})
+	var attestation []byte
+	for _, key := range keys[:s.threshold] {
+		sig, err := crypto.Sign(msg.Hash().Bytes(), key)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to sign message %s: %w", msgHash.Hex(), err)
+		}
+		// circle attestations use 27/28 as recovery id
+		sig[64] += 27
+		attestation = append(attestation, sig...)
+	}
+	return attestation, true, nil
+}
+
+// WatchMessageSent subscribes to MessageSent events of the given token transmitter and records every
+// emitted message. The subscription is closed when the context is done or the service is stopped.
+func (s *USDCAttestationService) WatchMessageSent(ctx context.Context, transmitter *contracts.TokenTransmitter) error {
+	if transmitter == nil {
+		return fmt.Errorf("token transmitter is nil")
+	}
+	msgSent := make(chan *mock_usdc_token_transmitter.MockE2EUSDCTransmitterMessageSent)
+	sub := event.Resubscribe(3*time.Hour, func(_ context.Context) (event.Subscription, error) {
+		return transmitter.WatchMessageSent(&bind.WatchOpts{Context: ctx}, msgSent)
+	})
+	if sub == nil {
+		return fmt.Errorf("no event subscription found for MessageSent")
+	}
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mu.Unlock()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case e := <-msgSent:
+				if _, err := s.AddMessage(e.Message); err != nil {
+					s.logger.Error().Err(err).Str("Transmitter", transmitter.ContractAddress.Hex()).Msg("failed to parse USDC message")
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *USDCAttestationService) handleAttestation(w http.ResponseWriter, r *http.Request) {
+	response := struct {
+		Status      string `json:"status,omitempty"`
+		Attestation string `json:"attestation,omitempty"`
+		Error       string `json:"error,omitempty"`
+	}{}
+	w.Header().Set("Content-Type", "application/json")
+	hashStr := strings.TrimPrefix(r.URL.Path, usdcAttestationPath)
+	if r.Method != http.MethodGet || !isHexHash(hashStr) {
+		w.WriteHeader(http.StatusBadRequest)
+		response.Error = fmt.Sprintf("invalid request %s %s", r.Method, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+	attestation, found, err := s.Attestation(common.HexToHash(hashStr))
+	switch {
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+		response.Error = err.Error()
+	case !found:
+		response.Status = usdcAttestationPending
+	default:
+		response.Status = usdcAttestationComplete
+		response.Attestation = fmt.Sprintf("0x%x", attestation)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Stop closes all event subscriptions and shuts down the http server
+func (s *USDCAttestationService) Stop() error {
+	s.mu.Lock()
+	for _, sub := range s.subscriptions {
+		sub.Unsubscribe()
+	}
+	s.subscriptions = nil
+	s.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// VerifyUSDCAttestation recovers the signers of the attestation for the given raw message and asserts
+// that the attestation has threshold signatures from enabled attesters in strictly ascending signer order.
+func VerifyUSDCAttestation(raw, attestation []byte, enabledAttesters []common.Address, threshold int) error {
+	if len(attestation) == 0 || len(attestation)%65 != 0 {
+		return fmt.Errorf("invalid attestation length %d", len(attestation))
+	}
+	if len(attestation)/65 != threshold {
+		return fmt.Errorf("expected %d signatures, found %d", threshold, len(attestation)/65)
+	}
+	enabled := make(map[common.Address]bool)
+	for _, attester := range enabledAttesters {
+		enabled[attester] = true
+	}
+	hash := crypto.Keccak256(raw)
+	var prevSigner common.Address
+	for i := 0; i < len(attestation)/65; i++ {
+		sig := make([]byte, 65)
+		copy(sig, attestation[i*65:(i+1)*65])
+		if sig[64] >= 27 {
+			sig[64] -= 27
+		}
+		pub, err := crypto.SigToPub(hash, sig)
+		if err != nil {
+			return fmt.Errorf("failed to recover signer for signature %d: %w", i, err)
+		}
+		signer := crypto.PubkeyToAddress(*pub)
+		if !enabled[signer] {
+			return fmt.Errorf("signature %d is signed by %s which is not an enabled attester", i, signer.Hex())
+		}
+		if i > 0 && bytes.Compare(signer.Bytes(), prevSigner.Bytes()) <= 0 {
+			return fmt.Errorf("signature %d by %s is not in increasing order of signers", i, signer.Hex())
+		}
+		prevSigner = signer
+	}
+	return nil
+}
+
+func isHexHash(s string) bool {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s) != 2*common.HashLength {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
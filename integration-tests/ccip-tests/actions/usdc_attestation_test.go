@@ -0,0 +1,79 @@
+package actions
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUSDCAttestationService(t *testing.T) {
+	t.Parallel()
+	svc, err := NewUSDCAttestationService(zerolog.New(zerolog.Nop()), 3, "127.0.0.1:0", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, svc.Stop())
+	})
+	url := fmt.Sprintf("http://%s%s", svc.listener.Addr().String(), usdcAttestationPath)
+
+	raw := make([]byte, usdcMessageHeaderLength+4)
+	binary.BigEndian.PutUint32(raw[4:8], 1)
+	binary.BigEndian.PutUint32(raw[8:12], 2)
+	binary.BigEndian.PutUint64(raw[12:20], 7)
+	copy(raw[usdcMessageHeaderLength:], []byte{0xde, 0xad, 0xbe, 0xef})
+
+	getAttestation := func(hash common.Hash) (string, string) {
+		resp, err := http.Get(url + hash.Hex())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body struct {
+			Status      string `json:"status"`
+			Attestation string `json:"attestation"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Status, body.Attestation
+	}
+
+	msg, err := ParseUSDCMessage(raw)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), msg.SourceDomain)
+	require.Equal(t, uint32(2), msg.DestinationDomain)
+	require.Equal(t, uint64(7), msg.Nonce)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, msg.MessageBody)
+
+	// message is not observed yet
+	status, _ := getAttestation(msg.Hash())
+	require.Equal(t, usdcAttestationPending, status)
+
+	_, err = svc.AddMessage(raw)
+	require.NoError(t, err)
+	status, attestation := getAttestation(msg.Hash())
+	require.Equal(t, usdcAttestationComplete, status)
+	require.NoError(t, VerifyUSDCAttestation(raw, hexutil.MustDecode(attestation), svc.Attesters(), svc.SignatureThreshold()))
+
+	// rotate an attester, the attestation for the already observed message should be signed by the new set
+	oldAttester := svc.Attesters()[0]
+	_, err = svc.RotateAttester(oldAttester)
+	require.NoError(t, err)
+	require.NotContains(t, svc.Attesters(), oldAttester)
+	_, attestation = getAttestation(msg.Hash())
+	require.NoError(t, VerifyUSDCAttestation(raw, hexutil.MustDecode(attestation), svc.Attesters(), svc.SignatureThreshold()))
+	require.Error(t, VerifyUSDCAttestation(raw, hexutil.MustDecode(attestation), []common.Address{oldAttester}, svc.SignatureThreshold()))
+
+	// attesters can't be disabled below the threshold
+	require.Error(t, svc.DisableAttester(svc.Attesters()[0]))
+	require.NoError(t, svc.SetSignatureThreshold(2))
+	require.NoError(t, svc.DisableAttester(svc.Attesters()[0]))
+	_, attestation = getAttestation(msg.Hash())
+	require.NoError(t, VerifyUSDCAttestation(raw, hexutil.MustDecode(attestation), svc.Attesters(), 2))
+
+	_, err = ParseUSDCMessage(raw[:usdcMessageHeaderLength-1])
+	require.Error(t, err)
+}
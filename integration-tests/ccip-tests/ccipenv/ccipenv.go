@@ -0,0 +1,222 @@
+// Package ccipenv is the entry point for the repos running CCIP lanes in their own tests. It wraps the set-up of the
+// environment and the lanes, the sending and the validation of the requests and the reporting in a small API:
+//
+//	env := ccipenv.New(t)
+//	lane, err := env.DeployLane("SIMULATED_1", "SIMULATED_2")
+//	require.NoError(t, err)
+//	require.NoError(t, lane.Send(2))
+//	lane.Validate(t)
+//	require.NoError(t, env.Report("./reports"))
+//
+// Stability: the exported API of this package is versioned with Version following semantic versioning. Within a
+// major version, exported identifiers are neither removed nor changed incompatibly, new options and methods can be
+// added with a minor version. The packages underneath, actions, testsetups, contracts and testreporters, carry no such
+// guarantee and change with the tests of this repo; depend on them at your own risk.
+package ccipenv
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+	"github.com/smartcontractkit/chainlink-testing-framework/logging"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testsetups"
+)
+
+// Version is the version of the API of this package
+const Version = "v1.0.0"
+
+// DefaultGasLimit is the gas limit of the receiver call of the requests sent if the test config doesn't set one
+const DefaultGasLimit int64 = 100_000
+
+// Option customises the environment created by New
+type Option func(*options)
+
+type options struct {
+	testType         string
+	envName          string
+	logger           *zerolog.Logger
+	tokenDeployerFns []blockchain.ContractDeployer
+}
+
+// WithTestType reads the test config of the test group tType, e.g. "smoke" or "load", instead of "smoke"
+func WithTestType(tType string) Option {
+	return func(o *options) {
+		o.testType = tType
+	}
+}
+
+// WithEnvName names the environment set up, "ccipenv" if not set
+func WithEnvName(name string) Option {
+	return func(o *options) {
+		o.envName = name
+	}
+}
+
+// WithLogger logs with lggr instead of the test logger
+func WithLogger(lggr zerolog.Logger) Option {
+	return func(o *options) {
+		o.logger = &lggr
+	}
+}
+
+// WithTokenDeployers deploys the bridge tokens with deployers instead of the default ERC20 tokens
+func WithTokenDeployers(deployers ...blockchain.ContractDeployer) Option {
+	return func(o *options) {
+		o.tokenDeployerFns = deployers
+	}
+}
+
+// Env is a CCIP environment with the lanes between the networks of the test config
+type Env struct {
+	t    *testing.T
+	lggr zerolog.Logger
+	cfg  *testsetups.CCIPTestConfig
+	opts options
+
+	mu  sync.Mutex
+	out *testsetups.CCIPTestSetUpOutputs
+}
+
+// New reads the test config and returns the environment, which is set up with the first DeployLane. The environment is
+// torn down in the clean-up of t.
+func New(t *testing.T, opts ...Option) *Env {
+	o := options{
+		testType: testconfig.Smoke,
+		envName:  "ccipenv",
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	lggr := logging.GetTestLogger(t)
+	if o.logger != nil {
+		lggr = *o.logger
+	}
+	return &Env{
+		t:    t,
+		lggr: lggr,
+		cfg:  testsetups.NewCCIPTestConfig(t, lggr, o.testType),
+		opts: o,
+	}
+}
+
+// DeployLane returns the lane from the network source to the network dest. The first DeployLane sets up the
+// environment and deploys the lanes between all the network pairs of the test config, after adding the pair of source
+// and dest to them if it's missing. The later ones return the lanes already deployed, a pair missing from the test
+// config can't be deployed anymore.
+func (e *Env) DeployLane(source, dest string) (*Lane, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.out == nil {
+		if err := e.addNetworkPair(source, dest); err != nil {
+			return nil, err
+		}
+		e.out = testsetups.CCIPDefaultTestSetUp(e.t, e.lggr, e.opts.envName, e.opts.tokenDeployerFns, e.cfg)
+		e.t.Cleanup(func() {
+			if e.out.TearDown != nil {
+				require.NoError(e.t, e.out.TearDown())
+			}
+		})
+	}
+	lane := findLane(e.out.ReadLanes(), source, dest)
+	if lane == nil {
+		return nil, fmt.Errorf("no lane from %s to %s in the environment, the lanes are deployed with the first DeployLane", source, dest)
+	}
+	gasLimit := big.NewInt(DefaultGasLimit)
+	if limit := e.cfg.TestGroupInput.MsgDetails.DestGasLimit; limit != nil {
+		gasLimit = big.NewInt(*limit)
+	}
+	return &Lane{lane: lane, gasLimit: gasLimit}, nil
+}
+
+// addNetworkPair adds the pair of source and dest to the network pairs of the test config if it's not in there,
+// both networks should be selected in the test config
+func (e *Env) addNetworkPair(source, dest string) error {
+	for _, pair := range e.cfg.NetworkPairs {
+		if (pair.NetworkA.Name == source && pair.NetworkB.Name == dest) ||
+			(pair.NetworkA.Name == dest && pair.NetworkB.Name == source) {
+			return nil
+		}
+	}
+	var networkA, networkB *blockchain.EVMNetwork
+	for i := range e.cfg.SelectedNetworks {
+		switch e.cfg.SelectedNetworks[i].Name {
+		case source:
+			networkA = &e.cfg.SelectedNetworks[i]
+		case dest:
+			networkB = &e.cfg.SelectedNetworks[i]
+		}
+	}
+	if networkA == nil || networkB == nil {
+		return fmt.Errorf("networks %s and %s should both be selected in the test config", source, dest)
+	}
+	e.cfg.AddPairToNetworkList(*networkA, *networkB)
+	return nil
+}
+
+// findLane returns the lane from source to dest among lanes, nil if there is none
+func findLane(lanes []*testsetups.BiDirectionalLaneConfig, source, dest string) *actions.CCIPLane {
+	for _, pair := range lanes {
+		for _, lane := range []*actions.CCIPLane{pair.ForwardLane, pair.ReverseLane} {
+			if lane != nil && lane.SourceNetworkName == source && lane.DestNetworkName == dest {
+				return lane
+			}
+		}
+	}
+	return nil
+}
+
+// Report writes the report of the requests sent on all the lanes under folderPath
+func (e *Env) Report(folderPath string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.out == nil {
+		return fmt.Errorf("no lane deployed to report on")
+	}
+	return e.out.Reporter.WriteReport(folderPath)
+}
+
+// Lane is a one-way lane between two networks
+type Lane struct {
+	lane     *actions.CCIPLane
+	gasLimit *big.Int
+	sent     bool // true if requests were sent since the last Validate
+}
+
+// Source returns the name of the source network of the lane
+func (l *Lane) Source() string {
+	return l.lane.SourceNetworkName
+}
+
+// Dest returns the name of the dest network of the lane
+func (l *Lane) Dest() string {
+	return l.lane.DestNetworkName
+}
+
+// Send sends n requests with the message type and the token amounts of the test config, each in its own transaction
+func (l *Lane) Send(n int) error {
+	if !l.sent {
+		l.lane.RecordStateBeforeTransfer()
+		l.sent = true
+	}
+	return l.lane.SendRequests(n, l.gasLimit)
+}
+
+// Validate waits for all the requests sent since the last Validate to be committed and executed on the dest network,
+// failing the test of t if any of them is not
+func (l *Lane) Validate(t *testing.T) {
+	if !l.sent {
+		return
+	}
+	l.lane.Test = t
+	l.lane.ValidateRequests()
+	l.sent = false
+}
@@ -0,0 +1,50 @@
+package ccipenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testsetups"
+)
+
+func TestFindLane(t *testing.T) {
+	a2b := &actions.CCIPLane{SourceNetworkName: "A", DestNetworkName: "B"}
+	b2a := &actions.CCIPLane{SourceNetworkName: "B", DestNetworkName: "A"}
+	a2c := &actions.CCIPLane{SourceNetworkName: "A", DestNetworkName: "C"}
+	lanes := []*testsetups.BiDirectionalLaneConfig{
+		{ForwardLane: a2b, ReverseLane: b2a},
+		{ForwardLane: a2c},
+	}
+	require.Same(t, a2b, findLane(lanes, "A", "B"))
+	require.Same(t, b2a, findLane(lanes, "B", "A"))
+	require.Same(t, a2c, findLane(lanes, "A", "C"))
+	require.Nil(t, findLane(lanes, "C", "A"), "lanes are one-way")
+	require.Nil(t, findLane(lanes, "A", "D"))
+}
+
+func TestAddNetworkPair(t *testing.T) {
+	netA := blockchain.EVMNetwork{Name: "A", ChainID: 1}
+	netB := blockchain.EVMNetwork{Name: "B", ChainID: 2}
+	netC := blockchain.EVMNetwork{Name: "C", ChainID: 3}
+	e := &Env{cfg: &testsetups.CCIPTestConfig{
+		TestGroupInput:   &testconfig.CCIPTestConfig{},
+		SelectedNetworks: []blockchain.EVMNetwork{netA, netB, netC},
+		NetworkPairs:     []testsetups.NetworkPair{{NetworkA: netA, NetworkB: netB}},
+	}}
+
+	require.NoError(t, e.addNetworkPair("B", "A"), "pair in the config in the other direction")
+	require.Len(t, e.cfg.NetworkPairs, 1)
+
+	require.NoError(t, e.addNetworkPair("C", "A"))
+	require.Len(t, e.cfg.NetworkPairs, 2)
+	require.Equal(t, "C", e.cfg.NetworkPairs[1].NetworkA.Name)
+	require.Equal(t, "A", e.cfg.NetworkPairs[1].NetworkB.Name)
+
+	require.Error(t, e.addNetworkPair("A", "D"), "network not selected")
+	require.Len(t, e.cfg.NetworkPairs, 2)
+}
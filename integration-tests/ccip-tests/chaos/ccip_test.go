@@ -28,6 +28,7 @@ func TestChaosCCIP(t *testing.T) {
 		chaosFunc            chaos.ManifestFunc
 		chaosProps           *chaos.Props
 		waitForChaosRecovery bool
+		replayAfterRecovery  bool // replay the logs from the last known good blocks once the chaos is recovered
 	}{
 		{
 			testName:  "CCIP works after rpc is down for NetworkA @network-chaos",
@@ -78,6 +79,16 @@ func TestChaosCCIP(t *testing.T) {
 			},
 			waitForChaosRecovery: true,
 		},
+		{
+			testName:  "CCIP recovers backlog with log replay after majority of CL nodes are recovered from pod failure @pod-chaos",
+			chaosFunc: chaos.NewFailPods,
+			chaosProps: &chaos.Props{
+				LabelsSelector: &map[string]*string{actions.ChaosGroupCommitAndExecFaultyPlus: ptr.Ptr("1")},
+				DurationStr:    "1m",
+			},
+			waitForChaosRecovery: true,
+			replayAfterRecovery:  true,
+		},
 		{
 			testName:  "CCIP Commit works while minority of CL nodes are in failed state for pod failure @pod-chaos",
 			chaosFunc: chaos.NewFailPods,
@@ -128,6 +139,10 @@ func TestChaosCCIP(t *testing.T) {
 			require.NoError(t, err)
 			lane.ValidateRequests(nil)
 
+			// the blocks to replay the logs from once the nodes are back up
+			lastGoodBlocks, err := lane.LatestBlocks()
+			require.NoError(t, err)
+
 			// apply chaos
 			chaosId, err := testEnvironment.Chaos.Run(in.chaosFunc(testEnvironment.Cfg.Namespace, in.chaosProps))
 			require.NoError(t, err)
@@ -144,6 +159,9 @@ func TestChaosCCIP(t *testing.T) {
 			if in.waitForChaosRecovery {
 				// wait for chaos to be recovered before further validation
 				require.NoError(t, testEnvironment.Chaos.WaitForAllRecovered(chaosId, 1*time.Minute))
+				if in.replayAfterRecovery {
+					require.NoError(t, lane.ReplayFromBlocks(testSetup, lastGoodBlocks))
+				}
 			} else {
 				l.Info().Msg("proceeding without waiting for chaos recovery")
 			}
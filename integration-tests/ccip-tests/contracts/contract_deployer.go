@@ -28,6 +28,8 @@ import (
 	"github.com/smartcontractkit/chainlink/integration-tests/contracts"
 	"github.com/smartcontractkit/chainlink/integration-tests/wrappers"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool_1_4_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store_1_2_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
@@ -215,6 +217,74 @@ func (e *CCIPContractsDeployer) DeployBurnMintERC677(ownerMintingAmount *big.Int
 	return token, err
 }
 
+// DeployBurnMintBridgeToken deploys a BurnMintERC677 with 18 decimals and no max supply to be used as a bridge token
+// with burn mint pools, and mints ownerMintingAmount of it to the owner
+func (e *CCIPContractsDeployer) DeployBurnMintBridgeToken(ownerMintingAmount *big.Int) (*ERC677Token, error) {
+	address, _, instance, err := e.evmClient.DeployContract("Burn Mint Bridge Token", func(
+		auth *bind.TransactOpts,
+		_ bind.ContractBackend,
+	) (common.Address, *types.Transaction, interface{}, error) {
+		return burn_mint_erc677.DeployBurnMintERC677(auth, wrappers.MustNewWrappedContractBackend(e.evmClient, nil), "Burn Mint Token", "BMT", 18, big.NewInt(0))
+	})
+	if err != nil {
+		return nil, err
+	}
+	token := &ERC677Token{
+		client:          e.evmClient,
+		logger:          e.logger,
+		ContractAddress: *address,
+		instance:        instance.(*burn_mint_erc677.BurnMintERC677),
+	}
+	owner := common.HexToAddress(e.evmClient.GetDefaultWallet().Address())
+	err = token.GrantMintRole(owner)
+	if err != nil {
+		return nil, fmt.Errorf("granting minter role to owner shouldn't fail %w", err)
+	}
+	err = e.evmClient.WaitForEvents()
+	if err != nil {
+		return nil, fmt.Errorf("error in waiting for granting mint role %w", err)
+	}
+	err = token.Mint(owner, ownerMintingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("minting tokens shouldn't fail %w", err)
+	}
+	return token, nil
+}
+
+// DeployFeeTokenWithDecimals deploys a burn mint ERC677 with the given decimals to be used as the fee token in place of
+// LINK, which has 18 decimals, and mints ownerMintingAmount of it to the owner
+func (e *CCIPContractsDeployer) DeployFeeTokenWithDecimals(decimals uint8, ownerMintingAmount *big.Int) (*LinkToken, error) {
+	address, _, instance, err := e.evmClient.DeployContract("Fee Token", func(
+		auth *bind.TransactOpts,
+		_ bind.ContractBackend,
+	) (common.Address, *types.Transaction, interface{}, error) {
+		return burn_mint_erc677.DeployBurnMintERC677(auth, wrappers.MustNewWrappedContractBackend(e.evmClient, nil), "Fee Token", "FEE", decimals, big.NewInt(0))
+	})
+	if err != nil {
+		return nil, err
+	}
+	token := &ERC677Token{
+		client:          e.evmClient,
+		logger:          e.logger,
+		ContractAddress: *address,
+		instance:        instance.(*burn_mint_erc677.BurnMintERC677),
+	}
+	owner := common.HexToAddress(e.evmClient.GetDefaultWallet().Address())
+	err = token.GrantMintRole(owner)
+	if err != nil {
+		return nil, fmt.Errorf("granting minter role to owner shouldn't fail %w", err)
+	}
+	err = e.evmClient.WaitForEvents()
+	if err != nil {
+		return nil, fmt.Errorf("error in waiting for granting mint role %w", err)
+	}
+	err = token.Mint(owner, ownerMintingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("minting fee token shouldn't fail %w", err)
+	}
+	return e.NewLinkTokenContract(*address)
+}
+
 func (e *CCIPContractsDeployer) DeployERC20TokenContract(deployerFn blockchain.ContractDeployer) (*ERC20Token, error) {
 	address, _, _, err := e.evmClient.DeployContract("Custom ERC20 Token", deployerFn)
 	if err != nil {
@@ -247,6 +317,26 @@ func (e *CCIPContractsDeployer) NewLinkTokenContract(addr common.Address) (*Link
 	}, err
 }
 
+// NewERC677TokenContract returns the wrapper of a deployed BurnMintERC677, to grant its mint and burn roles
+func (e *CCIPContractsDeployer) NewERC677TokenContract(addr common.Address) (*ERC677Token, error) {
+	token, err := burn_mint_erc677.NewBurnMintERC677(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
+	if err != nil {
+		return nil, err
+	}
+	e.logger.Info().
+		Str("Contract Address", addr.Hex()).
+		Str("Contract Name", "Burn Mint ERC 677").
+		Str("From", e.evmClient.GetDefaultWallet().Address()).
+		Str("Network Name", e.evmClient.GetNetworkConfig().Name).
+		Msg("New contract")
+	return &ERC677Token{
+		client:          e.evmClient,
+		logger:          e.logger,
+		instance:        token,
+		ContractAddress: addr,
+	}, nil
+}
+
 func (e *CCIPContractsDeployer) NewERC20TokenContract(addr common.Address) (*ERC20Token, error) {
 	token, err := erc20.NewERC20(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
 
@@ -332,6 +422,70 @@ func (e *CCIPContractsDeployer) NewLockReleaseTokenPoolContract(addr common.Addr
 	}
 }
 
+func (e *CCIPContractsDeployer) NewBurnMintTokenPoolContract(addr common.Address) (
+	*TokenPool,
+	error,
+) {
+	version := VersionMap[TokenPoolContract]
+	e.logger.Info().Str("version", string(version)).Msg("New BurnMint Token Pool")
+	switch version {
+	case Latest:
+		pool, err := burn_mint_token_pool.NewBurnMintTokenPool(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
+		if err != nil {
+			return nil, err
+		}
+		e.logger.Info().
+			Str("Contract Address", addr.Hex()).
+			Str("Contract Name", "BurnMint Token Pool").
+			Str("From", e.evmClient.GetDefaultWallet().Address()).
+			Str("Network Name", e.evmClient.GetNetworkConfig().Name).
+			Msg("New contract")
+		poolInstance, err := token_pool.NewTokenPool(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
+		if err != nil {
+			return nil, err
+		}
+		return &TokenPool{
+			client: e.evmClient,
+			logger: e.logger,
+			Instance: &TokenPoolWrapper{
+				Latest: &LatestPool{
+					PoolInterface: poolInstance,
+					BurnMintPool:  pool,
+				},
+			},
+			EthAddress: addr,
+		}, err
+	case V1_4_0:
+		pool, err := burn_mint_token_pool_1_4_0.NewBurnMintTokenPool(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
+		if err != nil {
+			return nil, err
+		}
+		e.logger.Info().
+			Str("Contract Address", addr.Hex()).
+			Str("Contract Name", "BurnMint Token Pool").
+			Str("From", e.evmClient.GetDefaultWallet().Address()).
+			Str("Network Name", e.evmClient.GetNetworkConfig().Name).
+			Msg("New contract")
+		poolInstance, err := token_pool_1_4_0.NewTokenPool(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
+		if err != nil {
+			return nil, err
+		}
+		return &TokenPool{
+			client: e.evmClient,
+			logger: e.logger,
+			Instance: &TokenPoolWrapper{
+				V1_4_0: &V1_4_0Pool{
+					PoolInterface: poolInstance,
+					BurnMintPool:  pool,
+				},
+			},
+			EthAddress: addr,
+		}, err
+	default:
+		return nil, fmt.Errorf("version not supported: %s", version)
+	}
+}
+
 func (e *CCIPContractsDeployer) NewUSDCTokenPoolContract(addr common.Address) (
 	*TokenPool,
 	error,
@@ -452,7 +606,7 @@ func (e *CCIPContractsDeployer) DeployUSDCTokenPoolContract(tokenAddr string, to
 	}
 }
 
-func (e *CCIPContractsDeployer) DeployLockReleaseTokenPoolContract(tokenAddr string, rmnProxy common.Address, router common.Address) (
+func (e *CCIPContractsDeployer) DeployLockReleaseTokenPoolContract(tokenAddr string, rmnProxy common.Address, router common.Address, allowList []common.Address) (
 	*TokenPool,
 	error,
 ) {
@@ -469,7 +623,7 @@ func (e *CCIPContractsDeployer) DeployLockReleaseTokenPoolContract(tokenAddr str
 				auth,
 				wrappers.MustNewWrappedContractBackend(e.evmClient, nil),
 				token,
-				[]common.Address{},
+				allowList,
 				rmnProxy,
 				true,
 				router,
@@ -489,7 +643,7 @@ func (e *CCIPContractsDeployer) DeployLockReleaseTokenPoolContract(tokenAddr str
 				auth,
 				wrappers.MustNewWrappedContractBackend(e.evmClient, nil),
 				token,
-				[]common.Address{},
+				allowList,
 				rmnProxy,
 				true,
 				router,
@@ -505,6 +659,59 @@ func (e *CCIPContractsDeployer) DeployLockReleaseTokenPoolContract(tokenAddr str
 	}
 }
 
+// DeployBurnMintTokenPoolContract deploys a pool burning and minting the token, the pool should be granted the mint
+// and burn roles on the token before any token is transferred through it
+func (e *CCIPContractsDeployer) DeployBurnMintTokenPoolContract(tokenAddr string, rmnProxy common.Address, router common.Address, allowList []common.Address) (
+	*TokenPool,
+	error,
+) {
+	version := VersionMap[TokenPoolContract]
+	e.logger.Info().Str("version", string(version)).Msg("Deploying BurnMint Token Pool")
+	token := common.HexToAddress(tokenAddr)
+	switch version {
+	case Latest:
+		address, _, _, err := e.evmClient.DeployContract("BurnMint Token Pool", func(
+			auth *bind.TransactOpts,
+			_ bind.ContractBackend,
+		) (common.Address, *types.Transaction, interface{}, error) {
+			return burn_mint_token_pool.DeployBurnMintTokenPool(
+				auth,
+				wrappers.MustNewWrappedContractBackend(e.evmClient, nil),
+				token,
+				allowList,
+				rmnProxy,
+				router,
+			)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+		return e.NewBurnMintTokenPoolContract(*address)
+	case V1_4_0:
+		address, _, _, err := e.evmClient.DeployContract("BurnMint Token Pool", func(
+			auth *bind.TransactOpts,
+			_ bind.ContractBackend,
+		) (common.Address, *types.Transaction, interface{}, error) {
+			return burn_mint_token_pool_1_4_0.DeployBurnMintTokenPool(
+				auth,
+				wrappers.MustNewWrappedContractBackend(e.evmClient, nil),
+				token,
+				allowList,
+				rmnProxy,
+				router,
+			)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+		return e.NewBurnMintTokenPoolContract(*address)
+	default:
+		return nil, fmt.Errorf("version not supported: %s", version)
+	}
+}
+
 func (e *CCIPContractsDeployer) DeployMockARMContract() (*common.Address, error) {
 	address, _, _, err := e.evmClient.DeployContract("Mock ARM Contract", func(
 		auth *bind.TransactOpts,
@@ -1217,28 +1424,33 @@ func (e *CCIPContractsDeployer) NewMockAggregator(addr common.Address) (*MockAgg
 }
 
 func (e *CCIPContractsDeployer) TypeAndVersion(addr common.Address) (string, error) {
+	_, versionStr, err := e.ContractTypeAndVersion(addr)
+	if err != nil {
+		return versionStr, err
+	}
+	v, err := semver.NewVersion(versionStr)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing version %s: %w", versionStr, err)
+	}
+	return v.String(), nil
+}
+
+// ContractTypeAndVersion returns the type and the version of the contract at addr as reported by its typeAndVersion
+func (e *CCIPContractsDeployer) ContractTypeAndVersion(addr common.Address) (string, string, error) {
 	tv, err := type_and_version.NewTypeAndVersionInterface(addr, wrappers.MustNewWrappedContractBackend(e.evmClient, nil))
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	tvStr, err := tv.TypeAndVersion(nil)
 	if err != nil {
-		return "", fmt.Errorf("error calling typeAndVersion on addr: %s %w", addr.Hex(), err)
+		return "", "", fmt.Errorf("error calling typeAndVersion on addr: %s %w", addr.Hex(), err)
 	}
 	e.logger.Info().
 		Str("TypeAndVersion", tvStr).
 		Str("Contract Address", addr.Hex()).
 		Msg("TypeAndVersion")
 
-	_, versionStr, err := ccipconfig.ParseTypeAndVersion(tvStr)
-	if err != nil {
-		return versionStr, err
-	}
-	v, err := semver.NewVersion(versionStr)
-	if err != nil {
-		return "", fmt.Errorf("failed parsing version %s: %w", versionStr, err)
-	}
-	return v.String(), nil
+	return ccipconfig.ParseTypeAndVersion(tvStr)
 }
 
 var OCR2ParamsForCommit = contracts.OffChainAggregatorV2Config{
@@ -19,6 +19,8 @@ import (
 	"github.com/smartcontractkit/ccip/integration-tests/wrappers"
 
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/arm_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool_1_4_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store_1_2_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
@@ -116,6 +118,23 @@ type TokenTransmitter struct {
 	ContractAddress common.Address
 }
 
+// WatchMessageSent returns a subscription to watch for MessageSent events emitted by the transmitter
+// for every USDC burn on the source chain
+func (t *TokenTransmitter) WatchMessageSent(opts *bind.WatchOpts, msgSent chan *mock_usdc_token_transmitter.MockE2EUSDCTransmitterMessageSent) (event.Subscription, error) {
+	if t.instance == nil {
+		return nil, fmt.Errorf("no instance found to watch for MessageSent")
+	}
+	return t.instance.WatchMessageSent(opts, msgSent)
+}
+
+// LocalDomain returns the USDC domain the transmitter is deployed with
+func (t *TokenTransmitter) LocalDomain() (uint32, error) {
+	if t.instance == nil {
+		return 0, fmt.Errorf("no instance found to get local domain")
+	}
+	return t.instance.LocalDomain(nil)
+}
+
 type ERC677Token struct {
 	client          blockchain.EVMClient
 	logger          zerolog.Logger
@@ -268,6 +287,14 @@ func (l *LinkToken) BalanceOf(ctx context.Context, addr string) (*big.Int, error
 	return balance, nil
 }
 
+func (l *LinkToken) Decimals() (uint8, error) {
+	decimals, err := l.instance.Decimals(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get decimals of fee token: %w", err)
+	}
+	return decimals, nil
+}
+
 func (l *LinkToken) Allowance(owner, spender string) (*big.Int, error) {
 	allowance, err := l.instance.Allowance(nil, common.HexToAddress(owner), common.HexToAddress(spender))
 	if err != nil {
@@ -318,12 +345,14 @@ func (l *LinkToken) Transfer(to string, amount *big.Int) error {
 type LatestPool struct {
 	PoolInterface   *token_pool.TokenPool
 	LockReleasePool *lock_release_token_pool.LockReleaseTokenPool
+	BurnMintPool    *burn_mint_token_pool.BurnMintTokenPool
 	USDCPool        *usdc_token_pool.USDCTokenPool
 }
 
 type V1_4_0Pool struct {
 	PoolInterface   *token_pool_1_4_0.TokenPool
 	LockReleasePool *lock_release_token_pool_1_4_0.LockReleaseTokenPool
+	BurnMintPool    *burn_mint_token_pool_1_4_0.BurnMintTokenPool
 	USDCPool        *usdc_token_pool_1_4_0.USDCTokenPool
 }
 
@@ -539,6 +568,36 @@ func (w TokenPoolWrapper) GetRebalancer(opts *bind.CallOpts) (common.Address, er
 	return common.Address{}, fmt.Errorf("no pool found to get rebalancer")
 }
 
+func (w TokenPoolWrapper) GetAllowListEnabled(opts *bind.CallOpts) (bool, error) {
+	if w.Latest != nil && w.Latest.PoolInterface != nil {
+		return w.Latest.PoolInterface.GetAllowListEnabled(opts)
+	}
+	if w.V1_4_0 != nil && w.V1_4_0.PoolInterface != nil {
+		return w.V1_4_0.PoolInterface.GetAllowListEnabled(opts)
+	}
+	return false, fmt.Errorf("no pool found to get allowlist status")
+}
+
+func (w TokenPoolWrapper) GetAllowList(opts *bind.CallOpts) ([]common.Address, error) {
+	if w.Latest != nil && w.Latest.PoolInterface != nil {
+		return w.Latest.PoolInterface.GetAllowList(opts)
+	}
+	if w.V1_4_0 != nil && w.V1_4_0.PoolInterface != nil {
+		return w.V1_4_0.PoolInterface.GetAllowList(opts)
+	}
+	return nil, fmt.Errorf("no pool found to get allowlist")
+}
+
+func (w TokenPoolWrapper) ApplyAllowListUpdates(opts *bind.TransactOpts, removes []common.Address, adds []common.Address) (*types.Transaction, error) {
+	if w.Latest != nil && w.Latest.PoolInterface != nil {
+		return w.Latest.PoolInterface.ApplyAllowListUpdates(opts, removes, adds)
+	}
+	if w.V1_4_0 != nil && w.V1_4_0.PoolInterface != nil {
+		return w.V1_4_0.PoolInterface.ApplyAllowListUpdates(opts, removes, adds)
+	}
+	return nil, fmt.Errorf("no pool found to apply allowlist updates")
+}
+
 // TokenPool represents a TokenPool address
 type TokenPool struct {
 	client     blockchain.EVMClient
@@ -571,6 +630,18 @@ func (pool *TokenPool) IsLockRelease() bool {
 	return false
 }
 
+// IsBurnMint returns true if the pool burns the tokens on the source chain and mints them on the dest chain, so it
+// holds no liquidity
+func (pool *TokenPool) IsBurnMint() bool {
+	if pool.Instance.Latest != nil && pool.Instance.Latest.BurnMintPool != nil {
+		return true
+	}
+	if pool.Instance.V1_4_0 != nil && pool.Instance.V1_4_0.BurnMintPool != nil {
+		return true
+	}
+	return false
+}
+
 func (pool *TokenPool) SyncUSDCDomain(destTokenTransmitter *TokenTransmitter, destPoolAddr common.Address, destChainSelector uint64) error {
 	if !pool.IsUSDC() {
 		return fmt.Errorf("pool is not a USDC pool, cannot sync domain")
@@ -811,6 +882,43 @@ func (pool *TokenPool) GetRebalancer() (common.Address, error) {
 	return pool.Instance.GetRebalancer(nil)
 }
 
+// IsAllowListEnabled returns true if the pool was deployed with an allowlist of senders.
+// The allowlist can only be enabled at the time of deployment.
+func (pool *TokenPool) IsAllowListEnabled() (bool, error) {
+	return pool.Instance.GetAllowListEnabled(nil)
+}
+
+func (pool *TokenPool) GetAllowList() ([]common.Address, error) {
+	return pool.Instance.GetAllowList(nil)
+}
+
+// UpdateAllowList removes and adds the given senders to the allowlist of the pool.
+// It returns an error if the pool is not deployed with allowlist enabled.
+func (pool *TokenPool) UpdateAllowList(removes []common.Address, adds []common.Address) error {
+	enabled, err := pool.IsAllowListEnabled()
+	if err != nil {
+		return fmt.Errorf("failed to get allowlist status: %w", err)
+	}
+	if !enabled {
+		return fmt.Errorf("allowlist is not enabled on pool %s", pool.Address())
+	}
+	opts, err := pool.client.TransactionOpts(pool.client.GetDefaultWallet())
+	if err != nil {
+		return fmt.Errorf("failed to get transaction opts: %w", err)
+	}
+	tx, err := pool.Instance.ApplyAllowListUpdates(opts, removes, adds)
+	if err != nil {
+		return fmt.Errorf("failed to apply allowlist updates: %w", err)
+	}
+	pool.logger.Info().
+		Str("Token Pool", pool.Address()).
+		Str(Network, pool.client.GetNetworkName()).
+		Interface("Removed", removes).
+		Interface("Added", adds).
+		Msg("Allowlist updated on pool")
+	return pool.client.ProcessTransaction(tx)
+}
+
 type ARM struct {
 	client     blockchain.EVMClient
 	Instance   *arm_contract.ARMContract
@@ -873,6 +981,76 @@ func (w CommitStoreWrapper) GetExpectedNextSequenceNumber(opts *bind.CallOpts) (
 	return 0, fmt.Errorf("no instance found to get expected next sequence number")
 }
 
+func (w CommitStoreWrapper) Transmit(opts *bind.TransactOpts, reportContext [3][32]byte, report []byte, rs, ss [][32]byte, rawVs [32]byte) (*types.Transaction, error) {
+	if w.Latest != nil {
+		return w.Latest.Transmit(opts, reportContext, report, rs, ss, rawVs)
+	}
+	if w.V1_2_0 != nil {
+		return w.V1_2_0.Transmit(opts, reportContext, report, rs, ss, rawVs)
+	}
+	return nil, fmt.Errorf("no instance found to transmit")
+}
+
+// LatestConfigDigest returns the digest of the OCR2 config the reports are to be signed for
+func (w CommitStoreWrapper) LatestConfigDigest(opts *bind.CallOpts) ([32]byte, error) {
+	if w.Latest != nil {
+		details, err := w.Latest.LatestConfigDetails(opts)
+		return details.ConfigDigest, err
+	}
+	if w.V1_2_0 != nil {
+		details, err := w.V1_2_0.LatestConfigDetails(opts)
+		return details.ConfigDigest, err
+	}
+	return [32]byte{}, fmt.Errorf("no instance found to get latest config digest")
+}
+
+// CommitStoreConfig is the part of the static and dynamic config of the CommitStore the lane is wired with
+type CommitStoreConfig struct {
+	ChainSelector       uint64
+	SourceChainSelector uint64
+	OnRamp              common.Address
+	ARMProxy            common.Address
+	PriceRegistry       common.Address
+}
+
+func (w CommitStoreWrapper) GetConfig(opts *bind.CallOpts) (CommitStoreConfig, error) {
+	if w.Latest != nil {
+		static, err := w.Latest.GetStaticConfig(opts)
+		if err != nil {
+			return CommitStoreConfig{}, err
+		}
+		dynamic, err := w.Latest.GetDynamicConfig(opts)
+		if err != nil {
+			return CommitStoreConfig{}, err
+		}
+		return CommitStoreConfig{
+			ChainSelector:       static.ChainSelector,
+			SourceChainSelector: static.SourceChainSelector,
+			OnRamp:              static.OnRamp,
+			ARMProxy:            static.RmnProxy,
+			PriceRegistry:       dynamic.PriceRegistry,
+		}, nil
+	}
+	if w.V1_2_0 != nil {
+		static, err := w.V1_2_0.GetStaticConfig(opts)
+		if err != nil {
+			return CommitStoreConfig{}, err
+		}
+		dynamic, err := w.V1_2_0.GetDynamicConfig(opts)
+		if err != nil {
+			return CommitStoreConfig{}, err
+		}
+		return CommitStoreConfig{
+			ChainSelector:       static.ChainSelector,
+			SourceChainSelector: static.SourceChainSelector,
+			OnRamp:              static.OnRamp,
+			ARMProxy:            static.ArmProxy,
+			PriceRegistry:       dynamic.PriceRegistry,
+		}, nil
+	}
+	return CommitStoreConfig{}, fmt.Errorf("no instance found to get config")
+}
+
 type CommitStore struct {
 	client     blockchain.EVMClient
 	logger     zerolog.Logger
@@ -884,6 +1062,15 @@ func (b *CommitStore) Address() string {
 	return b.EthAddress.Hex()
 }
 
+// Config reads the static and dynamic config of the CommitStore from the chain
+func (b *CommitStore) Config() (CommitStoreConfig, error) {
+	cfg, err := b.Instance.GetConfig(nil)
+	if err != nil {
+		return CommitStoreConfig{}, fmt.Errorf("failed to get commit store config: %w", err)
+	}
+	return cfg, nil
+}
+
 // SetOCR2Config sets the offchain reporting protocol configuration
 func (b *CommitStore) SetOCR2Config(
 	signers []common.Address,
@@ -921,6 +1108,25 @@ func (b *CommitStore) SetOCR2Config(
 	return b.client.ProcessTransaction(tx)
 }
 
+// Transmit transmits a signed commit report from the default wallet, which should be one of the transmitters set in
+// the OCR2 config. It returns the hash of the transmit tx.
+func (b *CommitStore) Transmit(reportContext [3][32]byte, report []byte, rs, ss [][32]byte, rawVs [32]byte) (common.Hash, error) {
+	opts, err := b.client.TransactionOpts(b.client.GetDefaultWallet())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting transaction opts: %w", err)
+	}
+	tx, err := b.Instance.Transmit(opts, reportContext, report, rs, ss, rawVs)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error transmitting commit report: %w", err)
+	}
+	b.logger.Info().
+		Str("Contract Address", b.Address()).
+		Str(Network, b.client.GetNetworkConfig().Name).
+		Str("TxHash", tx.Hash().Hex()).
+		Msg("Commit report transmitted")
+	return tx.Hash(), b.client.ProcessTransaction(tx)
+}
+
 // WatchReportAccepted watches for report accepted events
 // There is no need to differentiate between the two versions of the contract as the event signature is the same
 // we can cast the contract to the latest version
@@ -1002,6 +1208,49 @@ func (p *PriceRegistryWrapper) GetTokenPrice(opts *bind.CallOpts, token common.A
 	return nil, fmt.Errorf("no instance found to get token price")
 }
 
+// GetTimestampedTokenPrice returns the price of token with the time it was last updated at
+func (p *PriceRegistryWrapper) GetTimestampedTokenPrice(opts *bind.CallOpts, token common.Address) (InternalTimestampedPackedUint224, error) {
+	if p.Latest != nil {
+		price, err := p.Latest.GetTokenPrice(opts, token)
+		if err != nil {
+			return InternalTimestampedPackedUint224{}, err
+		}
+		return InternalTimestampedPackedUint224{
+			Value:     price.Value,
+			Timestamp: price.Timestamp,
+		}, nil
+	}
+	if p.V1_2_0 != nil {
+		price, err := p.V1_2_0.GetTokenPrice(opts, token)
+		if err != nil {
+			return InternalTimestampedPackedUint224{}, err
+		}
+		return InternalTimestampedPackedUint224{
+			Value:     price.Value,
+			Timestamp: price.Timestamp,
+		}, nil
+	}
+	return InternalTimestampedPackedUint224{}, fmt.Errorf("no instance found to get token price")
+}
+
+// GetStalenessThreshold returns the number of seconds after which a price is stale and the fee quotes using it revert
+func (p *PriceRegistryWrapper) GetStalenessThreshold(opts *bind.CallOpts) (uint64, error) {
+	var threshold *big.Int
+	var err error
+	switch {
+	case p.Latest != nil:
+		threshold, err = p.Latest.GetStalenessThreshold(opts)
+	case p.V1_2_0 != nil:
+		threshold, err = p.V1_2_0.GetStalenessThreshold(opts)
+	default:
+		return 0, fmt.Errorf("no instance found to get staleness threshold")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return threshold.Uint64(), nil
+}
+
 func (p *PriceRegistryWrapper) AddPriceUpdater(opts *bind.TransactOpts, addr common.Address) (*types.Transaction, error) {
 	if p.Latest != nil {
 		return p.Latest.ApplyPriceUpdatersUpdates(opts, []common.Address{addr}, []common.Address{})
@@ -1229,6 +1478,25 @@ func (r *TokenAdminRegistry) SetAdminAndRegisterPool(tokenAddr, poolAddr common.
 	return nil
 }
 
+// SetPool sets poolAddr as the pool of the already registered tokenAddr, replacing the pool which was set before.
+// The messages executed after this use the new pool, including those sent before the pool was switched.
+func (r *TokenAdminRegistry) SetPool(tokenAddr, poolAddr common.Address) error {
+	opts, err := r.client.TransactionOpts(r.client.GetDefaultWallet())
+	if err != nil {
+		return fmt.Errorf("error getting transaction opts: %w", err)
+	}
+	tx, err := r.Instance.SetPool(opts, tokenAddr, poolAddr)
+	if err != nil {
+		return fmt.Errorf("error setting token %s and pool %s : %w", tokenAddr.Hex(), poolAddr.Hex(), err)
+	}
+	r.logger.Info().
+		Str("token", tokenAddr.Hex()).
+		Str("Pool", poolAddr.Hex()).
+		Str("TokenAdminRegistry", r.Address()).
+		Msg("pool is switched for token on TokenAdminRegistry")
+	return r.client.ProcessTransaction(tx)
+}
+
 type Router struct {
 	client     blockchain.EVMClient
 	logger     zerolog.Logger
@@ -1313,6 +1581,25 @@ func (r *Router) AddOffRamp(offRamp common.Address, sourceChainId uint64) (*type
 	return tx, r.client.ProcessTransaction(tx)
 }
 
+// RemoveOffRamp removes the offRamp of the source chain from the Router, the Router doesn't route the messages the
+// offRamp executes to the receivers anymore
+func (r *Router) RemoveOffRamp(offRamp common.Address, sourceChainSelector uint64) (*types.Transaction, error) {
+	opts, err := r.client.TransactionOpts(r.client.GetDefaultWallet())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction opts: %w", err)
+	}
+	tx, err := r.Instance.ApplyRampUpdates(opts, nil, []router.RouterOffRamp{{SourceChainSelector: sourceChainSelector, OffRamp: offRamp}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove offRamp: %w", err)
+	}
+	r.logger.Info().
+		Str("offRamp", offRamp.Hex()).
+		Str("Router", r.Address()).
+		Str(Network, r.client.GetNetworkConfig().Name).
+		Msg("offRamp is removed from Router")
+	return tx, r.client.ProcessTransaction(tx)
+}
+
 func (r *Router) SetWrappedNative(wNative common.Address) (*types.Transaction, error) {
 	opts, err := r.client.TransactionOpts(r.client.GetDefaultWallet())
 	if err != nil {
@@ -1340,6 +1627,34 @@ type SendReqEventData struct {
 	DataLength     int
 	NoOfTokens     int
 	Raw            types.Log
+	// the fields of the message as emitted by the OnRamp, to be compared with the message sent to the router
+	Receiver     common.Address
+	Data         []byte
+	TokenAmounts []router.ClientEVMTokenAmount
+	FeeToken     common.Address
+	GasLimit     *big.Int
+	Strict       bool
+}
+
+// NewSendReqEventData returns the event data of a CCIPSendRequested event decoded with the latest OnRamp wrapper
+func NewSendReqEventData(e *evm_2_evm_onramp.EVM2EVMOnRampCCIPSendRequested) *SendReqEventData {
+	tokenAmounts := make([]router.ClientEVMTokenAmount, 0, len(e.Message.TokenAmounts))
+	for _, tokenAmount := range e.Message.TokenAmounts {
+		tokenAmounts = append(tokenAmounts, router.ClientEVMTokenAmount{Token: tokenAmount.Token, Amount: tokenAmount.Amount})
+	}
+	return &SendReqEventData{
+		MessageId:      e.Message.MessageId,
+		SequenceNumber: e.Message.SequenceNumber,
+		DataLength:     len(e.Message.Data),
+		NoOfTokens:     len(e.Message.TokenAmounts),
+		Raw:            e.Raw,
+		Receiver:       e.Message.Receiver,
+		Data:           e.Message.Data,
+		TokenAmounts:   tokenAmounts,
+		FeeToken:       e.Message.FeeToken,
+		GasLimit:       e.Message.GasLimit,
+		Strict:         e.Message.Strict,
+	}
 }
 
 type OnRampWrapper struct {
@@ -1402,6 +1717,16 @@ func (w OnRampWrapper) PayNops(opts *bind.TransactOpts) (*types.Transaction, err
 	return nil, fmt.Errorf("no instance found to pay nops")
 }
 
+func (w OnRampWrapper) GetNopFeesJuels(opts *bind.CallOpts) (*big.Int, error) {
+	if w.Latest != nil {
+		return w.Latest.GetNopFeesJuels(opts)
+	}
+	if w.V1_2_0 != nil {
+		return w.V1_2_0.GetNopFeesJuels(opts)
+	}
+	return nil, fmt.Errorf("no instance found to get nop fees")
+}
+
 func (w OnRampWrapper) WithdrawNonLinkFees(opts *bind.TransactOpts, native common.Address, owner common.Address) (*types.Transaction, error) {
 	if w.Latest != nil {
 		return w.Latest.WithdrawNonLinkFees(opts, native, owner)
@@ -1426,22 +1751,38 @@ func (w OnRampWrapper) SetRateLimiterConfig(opts *bind.TransactOpts, config evm_
 	return nil, fmt.Errorf("no instance found to set rate limiter config")
 }
 
-func (w OnRampWrapper) ParseCCIPSendRequested(l types.Log) (uint64, error) {
+func (w OnRampWrapper) ParseCCIPSendRequested(l types.Log) (*SendReqEventData, error) {
 	if w.Latest != nil {
 		sendReq, err := w.Latest.ParseCCIPSendRequested(l)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		return sendReq.Message.SequenceNumber, nil
+		return NewSendReqEventData(sendReq), nil
 	}
 	if w.V1_2_0 != nil {
 		sendReq, err := w.V1_2_0.ParseCCIPSendRequested(l)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		return sendReq.Message.SequenceNumber, nil
+		tokenAmounts := make([]router.ClientEVMTokenAmount, 0, len(sendReq.Message.TokenAmounts))
+		for _, tokenAmount := range sendReq.Message.TokenAmounts {
+			tokenAmounts = append(tokenAmounts, router.ClientEVMTokenAmount{Token: tokenAmount.Token, Amount: tokenAmount.Amount})
+		}
+		return &SendReqEventData{
+			MessageId:      sendReq.Message.MessageId,
+			SequenceNumber: sendReq.Message.SequenceNumber,
+			DataLength:     len(sendReq.Message.Data),
+			NoOfTokens:     len(sendReq.Message.TokenAmounts),
+			Raw:            sendReq.Raw,
+			Receiver:       sendReq.Message.Receiver,
+			Data:           sendReq.Message.Data,
+			TokenAmounts:   tokenAmounts,
+			FeeToken:       sendReq.Message.FeeToken,
+			GasLimit:       sendReq.Message.GasLimit,
+			Strict:         sendReq.Message.Strict,
+		}, nil
 	}
-	return 0, fmt.Errorf("no instance found to parse CCIPSendRequested")
+	return nil, fmt.Errorf("no instance found to parse CCIPSendRequested")
 }
 
 func (w OnRampWrapper) GetDynamicConfig(opts *bind.CallOpts) (uint32, error) {
@@ -1462,6 +1803,78 @@ func (w OnRampWrapper) GetDynamicConfig(opts *bind.CallOpts) (uint32, error) {
 	return 0, fmt.Errorf("no instance found to get dynamic config")
 }
 
+// SetRouter sets the router of the dynamic config, the OnRamp only accepts the messages sent through it.
+// The rest of the dynamic config is kept as is.
+func (w OnRampWrapper) SetRouter(opts *bind.TransactOpts, routerAddr common.Address) (*types.Transaction, error) {
+	if w.Latest != nil {
+		cfg, err := w.Latest.GetDynamicConfig(nil)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Router = routerAddr
+		return w.Latest.SetDynamicConfig(opts, cfg)
+	}
+	if w.V1_2_0 != nil {
+		cfg, err := w.V1_2_0.GetDynamicConfig(nil)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Router = routerAddr
+		return w.V1_2_0.SetDynamicConfig(opts, cfg)
+	}
+	return nil, fmt.Errorf("no instance found to set router")
+}
+
+// OnRampConfig is the part of the static and dynamic config of the OnRamp the lane is wired with
+type OnRampConfig struct {
+	ChainSelector     uint64
+	DestChainSelector uint64
+	LinkToken         common.Address
+	ARMProxy          common.Address
+	Router            common.Address
+	PriceRegistry     common.Address
+}
+
+func (w OnRampWrapper) GetConfig(opts *bind.CallOpts) (OnRampConfig, error) {
+	if w.Latest != nil {
+		static, err := w.Latest.GetStaticConfig(opts)
+		if err != nil {
+			return OnRampConfig{}, err
+		}
+		dynamic, err := w.Latest.GetDynamicConfig(opts)
+		if err != nil {
+			return OnRampConfig{}, err
+		}
+		return OnRampConfig{
+			ChainSelector:     static.ChainSelector,
+			DestChainSelector: static.DestChainSelector,
+			LinkToken:         static.LinkToken,
+			ARMProxy:          static.RmnProxy,
+			Router:            dynamic.Router,
+			PriceRegistry:     dynamic.PriceRegistry,
+		}, nil
+	}
+	if w.V1_2_0 != nil {
+		static, err := w.V1_2_0.GetStaticConfig(opts)
+		if err != nil {
+			return OnRampConfig{}, err
+		}
+		dynamic, err := w.V1_2_0.GetDynamicConfig(opts)
+		if err != nil {
+			return OnRampConfig{}, err
+		}
+		return OnRampConfig{
+			ChainSelector:     static.ChainSelector,
+			DestChainSelector: static.DestChainSelector,
+			LinkToken:         static.LinkToken,
+			ARMProxy:          static.ArmProxy,
+			Router:            dynamic.Router,
+			PriceRegistry:     dynamic.PriceRegistry,
+		}, nil
+	}
+	return OnRampConfig{}, fmt.Errorf("no instance found to get config")
+}
+
 func (w OnRampWrapper) ApplyPoolUpdates(opts *bind.TransactOpts, tokens []common.Address, pools []common.Address) (*types.Transaction, error) {
 	if w.Latest != nil {
 		return nil, fmt.Errorf("latest version does not support ApplyPoolUpdates")
@@ -1541,6 +1954,15 @@ func (onRamp *OnRamp) Address() string {
 	return onRamp.EthAddress.Hex()
 }
 
+// Config reads the static and dynamic config of the OnRamp from the chain
+func (onRamp *OnRamp) Config() (OnRampConfig, error) {
+	cfg, err := onRamp.Instance.GetConfig(nil)
+	if err != nil {
+		return OnRampConfig{}, fmt.Errorf("failed to get onRamp config: %w", err)
+	}
+	return cfg, nil
+}
+
 func (onRamp *OnRamp) SetNops() error {
 	opts, err := onRamp.client.TransactionOpts(onRamp.client.GetDefaultWallet())
 	if err != nil {
@@ -1584,6 +2006,15 @@ func (onRamp *OnRamp) PayNops() error {
 	return onRamp.client.ProcessTransaction(tx)
 }
 
+// NopFeesJuels returns the fees in the fee token collected by the OnRamp and not yet paid to the nops
+func (onRamp *OnRamp) NopFeesJuels() (*big.Int, error) {
+	fees, err := onRamp.Instance.GetNopFeesJuels(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nop fees: %w", err)
+	}
+	return fees, nil
+}
+
 func (onRamp *OnRamp) WithdrawNonLinkFees(wrappedNative common.Address) error {
 	opts, err := onRamp.client.TransactionOpts(onRamp.client.GetDefaultWallet())
 	if err != nil {
@@ -1617,6 +2048,24 @@ func (onRamp *OnRamp) SetRateLimit(rlConfig evm_2_evm_onramp.RateLimiterConfig)
 	return onRamp.client.ProcessTransaction(tx)
 }
 
+// SetRouter moves the OnRamp to the router, the requests sent through any other router are rejected
+func (onRamp *OnRamp) SetRouter(routerAddr common.Address) error {
+	opts, err := onRamp.client.TransactionOpts(onRamp.client.GetDefaultWallet())
+	if err != nil {
+		return err
+	}
+	tx, err := onRamp.Instance.SetRouter(opts, routerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to set router: %w", err)
+	}
+	onRamp.logger.Info().
+		Str("Router", routerAddr.Hex()).
+		Str("onRamp", onRamp.Address()).
+		Str(Network, onRamp.client.GetNetworkConfig().Name).
+		Msg("Setting router in OnRamp")
+	return onRamp.client.ProcessTransaction(tx)
+}
+
 func (onRamp *OnRamp) ApplyPoolUpdates(tokens []common.Address, pools []common.Address) error {
 	// if the latest version is used, no need to apply pool updates
 	if onRamp.Instance.Latest != nil {
@@ -1651,6 +2100,15 @@ func (offRamp *OffRamp) Address() string {
 	return offRamp.EthAddress.Hex()
 }
 
+// Config reads the static and dynamic config of the OffRamp from the chain
+func (offRamp *OffRamp) Config() (OffRampConfig, error) {
+	cfg, err := offRamp.Instance.GetConfig(nil)
+	if err != nil {
+		return OffRampConfig{}, fmt.Errorf("failed to get offRamp config: %w", err)
+	}
+	return cfg, nil
+}
+
 // WatchExecutionStateChanged returns a subscription to watch for ExecutionStateChanged events
 // there is no difference in the event between the two versions
 // so we can use the latest version to watch for events
@@ -1726,6 +2184,25 @@ func (offRamp *OffRamp) SetOCR2Config(
 	return fmt.Errorf("no instance found to set OCR2 config")
 }
 
+// Transmit transmits an execution report from the default wallet, which should be one of the transmitters set in
+// the OCR2 config. The OffRamp doesn't verify the signatures of the reports. It returns the hash of the transmit tx.
+func (offRamp *OffRamp) Transmit(reportContext [3][32]byte, report []byte) (common.Hash, error) {
+	opts, err := offRamp.client.TransactionOpts(offRamp.client.GetDefaultWallet())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get transaction options: %w", err)
+	}
+	tx, err := offRamp.Instance.Transmit(opts, reportContext, report, nil, nil, [32]byte{})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to transmit execution report: %w", err)
+	}
+	offRamp.logger.Info().
+		Str("Contract Address", offRamp.Address()).
+		Str(Network, offRamp.client.GetNetworkConfig().Name).
+		Str("TxHash", tx.Hash().Hex()).
+		Msg("Execution report transmitted")
+	return tx.Hash(), offRamp.client.ProcessTransaction(tx)
+}
+
 // AddRateLimitTokens adds token pairs to the OffRamp's rate limit
 func (offRamp *OffRamp) AddRateLimitTokens(sourceTokens, destTokens []common.Address) error {
 	if offRamp.Instance.V1_2_0 != nil {
@@ -1938,6 +2415,33 @@ func (offRamp *OffRamp) SyncTokensAndPools(sourceTokens, pools []common.Address)
 	return fmt.Errorf("no instance found to sync tokens and pools")
 }
 
+// SwitchPool replaces the pool oldPool of sourceToken with newPool. It's only applicable to the v1.2.0 OffRamp,
+// the later versions get the pools from the TokenAdminRegistry.
+func (offRamp *OffRamp) SwitchPool(sourceToken, oldPool, newPool common.Address) error {
+	if offRamp.Instance.V1_2_0 == nil {
+		return fmt.Errorf("pools can only be switched on v1.2.0 OffRamp, use TokenAdminRegistry for later versions")
+	}
+	opts, err := offRamp.client.TransactionOpts(offRamp.client.GetDefaultWallet())
+	if err != nil {
+		return fmt.Errorf("failed to get transaction opts: %w", err)
+	}
+	tx, err := offRamp.Instance.V1_2_0.ApplyPoolUpdates(opts,
+		[]evm_2_evm_offramp_1_2_0.InternalPoolUpdate{{Token: sourceToken, Pool: oldPool}},
+		[]evm_2_evm_offramp_1_2_0.InternalPoolUpdate{{Token: sourceToken, Pool: newPool}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to switch pool: %w", err)
+	}
+	offRamp.logger.Info().
+		Str("Source Token", sourceToken.Hex()).
+		Str("Old Pool", oldPool.Hex()).
+		Str("New Pool", newPool.Hex()).
+		Str("offRamp", offRamp.Address()).
+		Str(Network, offRamp.client.GetNetworkConfig().Name).
+		Msg("Pool switched in OffRamp")
+	return offRamp.client.ProcessTransaction(tx)
+}
+
 // OffRampWrapper wraps multiple versions of the OffRamp contract as we support multiple at once.
 // If you are using any of the functions in this struct, be sure to follow best practices:
 //  1. If the function does not make sense for a specific version,
@@ -1952,6 +2456,82 @@ type OffRampWrapper struct {
 	V1_2_0 *evm_2_evm_offramp_1_2_0.EVM2EVMOffRamp
 }
 
+// OffRampConfig is the part of the static and dynamic config of the OffRamp the lane is wired with
+type OffRampConfig struct {
+	ChainSelector       uint64
+	SourceChainSelector uint64
+	CommitStore         common.Address
+	OnRamp              common.Address
+	ARMProxy            common.Address
+	Router              common.Address
+	PriceRegistry       common.Address
+}
+
+func (offRamp *OffRampWrapper) GetConfig(opts *bind.CallOpts) (OffRampConfig, error) {
+	if offRamp.Latest != nil {
+		static, err := offRamp.Latest.GetStaticConfig(opts)
+		if err != nil {
+			return OffRampConfig{}, err
+		}
+		dynamic, err := offRamp.Latest.GetDynamicConfig(opts)
+		if err != nil {
+			return OffRampConfig{}, err
+		}
+		return OffRampConfig{
+			ChainSelector:       static.ChainSelector,
+			SourceChainSelector: static.SourceChainSelector,
+			CommitStore:         static.CommitStore,
+			OnRamp:              static.OnRamp,
+			ARMProxy:            static.RmnProxy,
+			Router:              dynamic.Router,
+			PriceRegistry:       dynamic.PriceRegistry,
+		}, nil
+	}
+	if offRamp.V1_2_0 != nil {
+		static, err := offRamp.V1_2_0.GetStaticConfig(opts)
+		if err != nil {
+			return OffRampConfig{}, err
+		}
+		dynamic, err := offRamp.V1_2_0.GetDynamicConfig(opts)
+		if err != nil {
+			return OffRampConfig{}, err
+		}
+		return OffRampConfig{
+			ChainSelector:       static.ChainSelector,
+			SourceChainSelector: static.SourceChainSelector,
+			CommitStore:         static.CommitStore,
+			OnRamp:              static.OnRamp,
+			ARMProxy:            static.ArmProxy,
+			Router:              dynamic.Router,
+			PriceRegistry:       dynamic.PriceRegistry,
+		}, nil
+	}
+	return OffRampConfig{}, fmt.Errorf("no instance found to get config")
+}
+
+func (offRamp *OffRampWrapper) Transmit(opts *bind.TransactOpts, reportContext [3][32]byte, report []byte, rs, ss [][32]byte, rawVs [32]byte) (*types.Transaction, error) {
+	if offRamp.Latest != nil {
+		return offRamp.Latest.Transmit(opts, reportContext, report, rs, ss, rawVs)
+	}
+	if offRamp.V1_2_0 != nil {
+		return offRamp.V1_2_0.Transmit(opts, reportContext, report, rs, ss, rawVs)
+	}
+	return nil, fmt.Errorf("no instance found to transmit")
+}
+
+// LatestConfigDigest returns the digest of the OCR2 config the reports are to be transmitted for
+func (offRamp *OffRampWrapper) LatestConfigDigest(opts *bind.CallOpts) ([32]byte, error) {
+	if offRamp.Latest != nil {
+		details, err := offRamp.Latest.LatestConfigDetails(opts)
+		return details.ConfigDigest, err
+	}
+	if offRamp.V1_2_0 != nil {
+		details, err := offRamp.V1_2_0.LatestConfigDetails(opts)
+		return details.ConfigDigest, err
+	}
+	return [32]byte{}, fmt.Errorf("no instance found to get latest config digest")
+}
+
 // CurrentRateLimiterState retrieves the current rate limiter state for the OffRamp contract
 func (offRamp *OffRampWrapper) CurrentRateLimiterState(opts *bind.CallOpts) (RateLimiterConfig, error) {
 	if offRamp.Latest != nil {
@@ -0,0 +1,230 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	cciptypes "github.com/smartcontractkit/chainlink-common/pkg/types/ccip"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+)
+
+// DefaultLaneReaderBlockRange is the max number of blocks queried in a single log filter call by LaneReader
+const DefaultLaneReaderBlockRange = uint64(5000)
+
+// LaneReader provides read-only access to the state of a lane. It only needs the contract addresses and
+// a backend (e.g. *ethclient.Client) for the source and dest chains, so it can be used without the test harness.
+// The events and the getters used are the same across the supported versions of the contracts,
+// so the contracts are always bound with the latest wrappers.
+type LaneReader struct {
+	source      bind.ContractBackend
+	dest        bind.ContractBackend
+	onRamp      *evm_2_evm_onramp.EVM2EVMOnRamp
+	commitStore *commit_store.CommitStore
+	offRamp     *evm_2_evm_offramp.EVM2EVMOffRamp
+	// BlockRange is the max number of blocks queried in a single log filter call, some rpcs limit the range
+	BlockRange uint64
+}
+
+func NewLaneReader(source, dest bind.ContractBackend, onRamp, commitStore, offRamp common.Address) (*LaneReader, error) {
+	onRampInstance, err := evm_2_evm_onramp.NewEVM2EVMOnRamp(onRamp, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OnRamp %s: %w", onRamp.Hex(), err)
+	}
+	commitStoreInstance, err := commit_store.NewCommitStore(commitStore, dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind CommitStore %s: %w", commitStore.Hex(), err)
+	}
+	offRampInstance, err := evm_2_evm_offramp.NewEVM2EVMOffRamp(offRamp, dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OffRamp %s: %w", offRamp.Hex(), err)
+	}
+	return &LaneReader{
+		source:      source,
+		dest:        dest,
+		onRamp:      onRampInstance,
+		commitStore: commitStoreInstance,
+		offRamp:     offRampInstance,
+		BlockRange:  DefaultLaneReaderBlockRange,
+	}, nil
+}
+
+// GetLatestSeqNum returns the sequence number of the last message sent on the lane, 0 if no message is sent yet
+func (r *LaneReader) GetLatestSeqNum(ctx context.Context) (uint64, error) {
+	next, err := r.onRamp.GetExpectedNextSequenceNumber(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expected next sequence number from OnRamp: %w", err)
+	}
+	return next - 1, nil
+}
+
+// GetCommitReportForSeq returns the commit report covering seqNum, looking for ReportAccepted events
+// from fromBlock onwards on the dest chain. It returns nil if the sequence number is not committed yet.
+func (r *LaneReader) GetCommitReportForSeq(ctx context.Context, seqNum uint64, fromBlock uint64) (*CommitStoreReportAccepted, error) {
+	var report *CommitStoreReportAccepted
+	err := r.filterInRange(ctx, r.dest, fromBlock, func(opts *bind.FilterOpts) (bool, error) {
+		it, err := r.commitStore.FilterReportAccepted(opts)
+		if err != nil {
+			return false, err
+		}
+		defer it.Close()
+		for it.Next() {
+			e := it.Event
+			if seqNum >= e.Report.Interval.Min && seqNum <= e.Report.Interval.Max {
+				report = &CommitStoreReportAccepted{
+					Min:        e.Report.Interval.Min,
+					Max:        e.Report.Interval.Max,
+					MerkleRoot: e.Report.MerkleRoot,
+					Raw:        e.Raw,
+				}
+				return true, nil
+			}
+		}
+		return false, it.Error()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter ReportAccepted events: %w", err)
+	}
+	return report, nil
+}
+
+// GetExecutionStateForSeq returns the execution state of seqNum in the OffRamp
+func (r *LaneReader) GetExecutionStateForSeq(ctx context.Context, seqNum uint64) (cciptypes.MessageExecutionState, error) {
+	state, err := r.offRamp.GetExecutionState(&bind.CallOpts{Context: ctx}, seqNum)
+	if err != nil {
+		return cciptypes.ExecutionStateUntouched, fmt.Errorf("failed to get execution state from OffRamp: %w", err)
+	}
+	return cciptypes.MessageExecutionState(state), nil
+}
+
+// GetMessageById returns the CCIPSendRequested event of the message with messageId, looking for the event
+// from fromBlock onwards on the source chain. It returns nil if no such message is found.
+func (r *LaneReader) GetMessageById(ctx context.Context, messageId [32]byte, fromBlock uint64) (*SendReqEventData, error) {
+	var msg *SendReqEventData
+	err := r.filterInRange(ctx, r.source, fromBlock, func(opts *bind.FilterOpts) (bool, error) {
+		it, err := r.onRamp.FilterCCIPSendRequested(opts)
+		if err != nil {
+			return false, err
+		}
+		defer it.Close()
+		for it.Next() {
+			e := it.Event
+			if e.Message.MessageId == messageId {
+				msg = NewSendReqEventData(e)
+				return true, nil
+			}
+		}
+		return false, it.Error()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter CCIPSendRequested events: %w", err)
+	}
+	return msg, nil
+}
+
+// filterInRange calls filter for consecutive block ranges of at most BlockRange blocks from fromBlock till
+// the latest block of the chain, it stops as soon as filter returns true or an error
+func (r *LaneReader) filterInRange(
+	ctx context.Context,
+	backend bind.ContractBackend,
+	fromBlock uint64,
+	filter func(opts *bind.FilterOpts) (bool, error),
+) error {
+	hdr, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header: %w", err)
+	}
+	for _, blocks := range SplitBlockRange(fromBlock, hdr.Number.Uint64(), r.BlockRange) {
+		end := blocks.To
+		found, err := filter(&bind.FilterOpts{
+			Start:   blocks.From,
+			End:     &end,
+			Context: ctx,
+		})
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+	return nil
+}
+
+// DefaultExecBackfillShardSize is the max number of sequence numbers filtered for in a single ExecutionStateChanged
+// log filter call, sequence numbers are an indexed topic of the event so the rpc filters them as an OR of the values
+const DefaultExecBackfillShardSize = 100
+
+// BackfillExecutionStates looks up the ExecutionStateChanged events of seqNums on the dest chain from the cursor till
+// toBlock. Over large block ranges a single filter call is too heavy, so the sequence numbers are sharded and every
+// shard is queried in chunks of BlockRange blocks with at most DefaultLogQueryConcurrency calls in flight. The cursor
+// only advances over the block ranges queried for all the shards, so an interrupted backfill resumes without missing
+// any events. The events found are returned by sequence number, along with an error if the backfill didn't complete.
+func (r *LaneReader) BackfillExecutionStates(
+	ctx context.Context,
+	seqNums []uint64,
+	cursor *RangeCursor,
+	toBlock uint64,
+) (map[uint64]*EVM2EVMOffRampExecutionStateChanged, error) {
+	var (
+		mu     sync.Mutex
+		events = make(map[uint64]*EVM2EVMOffRampExecutionStateChanged)
+		shards [][]uint64
+	)
+	for start := 0; start < len(seqNums); start += DefaultExecBackfillShardSize {
+		end := start + DefaultExecBackfillShardSize
+		if end > len(seqNums) {
+			end = len(seqNums)
+		}
+		shards = append(shards, seqNums[start:end])
+	}
+	if len(shards) == 0 {
+		return events, nil
+	}
+	err := QueryRanges(ctx, cursor, toBlock, r.BlockRange, DefaultLogQueryConcurrency, func(ctx context.Context, blocks BlockRange) error {
+		for _, shard := range shards {
+			it, err := r.offRamp.FilterExecutionStateChanged(&bind.FilterOpts{
+				Start:   blocks.From,
+				End:     &blocks.To,
+				Context: ctx,
+			}, shard, nil)
+			if err != nil {
+				return err
+			}
+			for it.Next() {
+				e := it.Event
+				mu.Lock()
+				// a message failed to execute might be executed again later, the latest state is kept
+				if prev, ok := events[e.SequenceNumber]; ok && (prev.Raw.BlockNumber > e.Raw.BlockNumber ||
+					(prev.Raw.BlockNumber == e.Raw.BlockNumber && prev.Raw.Index > e.Raw.Index)) {
+					mu.Unlock()
+					continue
+				}
+				events[e.SequenceNumber] = &EVM2EVMOffRampExecutionStateChanged{
+					SequenceNumber: e.SequenceNumber,
+					MessageId:      e.MessageId,
+					State:          e.State,
+					ReturnData:     e.ReturnData,
+					Raw:            e.Raw,
+				}
+				mu.Unlock()
+			}
+			err = it.Error()
+			_ = it.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return events, fmt.Errorf("failed to backfill ExecutionStateChanged events: %w", err)
+	}
+	return events, nil
+}
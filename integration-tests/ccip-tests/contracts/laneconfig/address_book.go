@@ -0,0 +1,432 @@
+package laneconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+// contract types of the address book entries, as returned by typeAndVersion of the contracts if they implement it
+const (
+	ARMProxyType           = "ARMProxy"
+	MockARMType            = "MockRMN"
+	RouterType             = "Router"
+	PriceRegistryType      = "PriceRegistry"
+	TokenAdminRegistryType = "TokenAdminRegistry"
+	FeeTokenType           = "LinkToken"
+	WrappedNativeType      = "WETH9"
+	MulticallType          = "Multicall3"
+	BridgeTokenType        = "BurnMintERC677"
+	BridgeTokenPoolType    = "TokenPool" // the pool type is not recorded in the lane config
+	PriceAggregatorType    = "PriceFeed"
+	TokenTransmitterType   = "USDCMessageTransmitter"
+	TokenMessengerType     = "USDCTokenMessenger"
+	OnRampType             = "EVM2EVMOnRamp"
+	OffRampType            = "EVM2EVMOffRamp"
+	CommitStoreType        = "CommitStore"
+	ReceiverDappType       = "MaybeRevertMessageReceiver"
+)
+
+// labels of the address book entries which carry the lane config fields the contract type does not
+const (
+	DestChainSelectorLabel   = "dest_chain_selector"   // dest chain of an onRamp
+	SourceChainSelectorLabel = "source_chain_selector" // source chains of an offRamp, commit store and receiver dapp, comma separated
+	DeployedAtLabel          = "deployed_at"           // block number at which an onRamp is deployed
+	IndexLabel               = "index"                 // index of a bridge token and its pool
+	TokenLabel               = "token"                 // token of a price aggregator
+	NativeFeeTokenLabel      = "native_fee_token"      // set on the wrapped native if it's the fee token
+)
+
+// AddressBookVersions is the version of every contract type recorded in the address book. The lane config does not
+// record the versions of the contracts, so they default to the latest deployed by the tests.
+var AddressBookVersions = map[string]string{
+	ARMProxyType:           "1.0.0",
+	MockARMType:            "1.0.0",
+	RouterType:             "1.2.0",
+	PriceRegistryType:      "1.2.0",
+	TokenAdminRegistryType: "1.5.0-dev",
+	FeeTokenType:           "1.0.0",
+	WrappedNativeType:      "1.0.0",
+	MulticallType:          "1.0.0",
+	BridgeTokenType:        "1.0.0",
+	BridgeTokenPoolType:    "1.5.0-dev",
+	PriceAggregatorType:    "1.0.0",
+	TokenTransmitterType:   "1.0.0",
+	TokenMessengerType:     "1.0.0",
+	OnRampType:             "1.5.0-dev",
+	OffRampType:            "1.5.0-dev",
+	CommitStoreType:        "1.5.0-dev",
+	ReceiverDappType:       "1.0.0",
+}
+
+// TypeAndVersion is an entry of the address book, encoded as "<type> <version> [<label>...]" with the labels in
+// "<key>:<value>" format
+type TypeAndVersion struct {
+	Type    string
+	Version string
+	Labels  map[string]string
+}
+
+func (tv TypeAndVersion) String() string {
+	parts := []string{tv.Type, tv.Version}
+	var labels []string
+	for key, value := range tv.Labels {
+		labels = append(labels, fmt.Sprintf("%s:%s", key, value))
+	}
+	sort.Strings(labels)
+	return strings.Join(append(parts, labels...), " ")
+}
+
+func (tv TypeAndVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tv.String())
+}
+
+func (tv *TypeAndVersion) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parts := strings.Fields(s)
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid type and version %q", s)
+	}
+	tv.Type, tv.Version = parts[0], parts[1]
+	tv.Labels = nil
+	for _, label := range parts[2:] {
+		key, value, found := strings.Cut(label, ":")
+		if !found {
+			// labels without value are kept as is, they carry nothing for the lane config
+			value = ""
+		}
+		if tv.Labels == nil {
+			tv.Labels = make(map[string]string)
+		}
+		tv.Labels[key] = value
+	}
+	return nil
+}
+
+func newTypeAndVersion(contractType string, labels map[string]string) TypeAndVersion {
+	return TypeAndVersion{Type: contractType, Version: AddressBookVersions[contractType], Labels: labels}
+}
+
+// AddressBook is the address book of the ccip deployment tooling. It holds the contracts by address keyed by the
+// selector of their chain.
+type AddressBook map[uint64]map[string]TypeAndVersion
+
+// add adds the contract to the book. A contract of the same type shared by several lanes, like a receiver dapp, is
+// recorded once with the chain selectors of all the lanes.
+func (b AddressBook) add(chainSelector uint64, address string, tv TypeAndVersion) {
+	if address == "" {
+		return
+	}
+	if b[chainSelector] == nil {
+		b[chainSelector] = make(map[string]TypeAndVersion)
+	}
+	address = common.HexToAddress(address).Hex()
+	if existing, ok := b[chainSelector][address]; ok && existing.Type == tv.Type {
+		if selectors, ok := existing.Labels[SourceChainSelectorLabel]; ok {
+			tv.Labels[SourceChainSelectorLabel] = selectors + "," + tv.Labels[SourceChainSelectorLabel]
+		}
+	}
+	b[chainSelector][address] = tv
+}
+
+// AddressBook converts the lane configs into an address book, chainIDByNetwork maps the network names the lane
+// configs are keyed by to their chain ids. Lane configs of networks sharing a chain id end up in the same chain of
+// the address book.
+func (l *Lanes) AddressBook(chainIDByNetwork map[string]uint64) (AddressBook, error) {
+	laneMu.Lock()
+	defer laneMu.Unlock()
+	selectorOf := func(network string) (uint64, error) {
+		chainID, ok := chainIDByNetwork[network]
+		if !ok {
+			return 0, fmt.Errorf("chain id of network %s not found", network)
+		}
+		selector, err := chainselectors.SelectorFromChainId(chainID)
+		if err != nil {
+			return 0, fmt.Errorf("chain selector of network %s: %w", network, err)
+		}
+		return selector, nil
+	}
+	book := make(AddressBook)
+	for network, cfg := range l.LaneConfigs {
+		selector, err := selectorOf(network)
+		if err != nil {
+			return nil, err
+		}
+		armType := ARMProxyType
+		if cfg.IsMockARM {
+			armType = MockARMType
+		}
+		book.add(selector, cfg.ARM, newTypeAndVersion(armType, nil))
+		book.add(selector, cfg.Router, newTypeAndVersion(RouterType, nil))
+		book.add(selector, cfg.PriceRegistry, newTypeAndVersion(PriceRegistryType, nil))
+		book.add(selector, cfg.TokenAdminRegistry, newTypeAndVersion(TokenAdminRegistryType, nil))
+		book.add(selector, cfg.Multicall, newTypeAndVersion(MulticallType, nil))
+		book.add(selector, cfg.TokenTransmitter, newTypeAndVersion(TokenTransmitterType, nil))
+		book.add(selector, cfg.TokenMessenger, newTypeAndVersion(TokenMessengerType, nil))
+		var wrappedNativeLabels map[string]string
+		if cfg.IsNativeFeeToken {
+			wrappedNativeLabels = map[string]string{NativeFeeTokenLabel: ""}
+		}
+		book.add(selector, cfg.WrappedNative, newTypeAndVersion(WrappedNativeType, wrappedNativeLabels))
+		// the fee token is usually a bridge token as well, it's then recorded as such with its index
+		book.add(selector, cfg.FeeToken, newTypeAndVersion(FeeTokenType, nil))
+		for i, token := range cfg.BridgeTokens {
+			labels := map[string]string{IndexLabel: strconv.Itoa(i)}
+			if common.HexToAddress(token) == common.HexToAddress(cfg.FeeToken) {
+				book.add(selector, token, newTypeAndVersion(FeeTokenType, labels))
+				continue
+			}
+			book.add(selector, token, newTypeAndVersion(BridgeTokenType, labels))
+		}
+		for i, pool := range cfg.BridgeTokenPools {
+			book.add(selector, pool, newTypeAndVersion(BridgeTokenPoolType, map[string]string{IndexLabel: strconv.Itoa(i)}))
+		}
+		for token, aggregator := range cfg.PriceAggregators {
+			book.add(selector, aggregator, newTypeAndVersion(PriceAggregatorType, map[string]string{TokenLabel: common.HexToAddress(token).Hex()}))
+		}
+		for destNetwork, src := range cfg.SrcContracts {
+			destSelector, err := selectorOf(destNetwork)
+			if err != nil {
+				return nil, err
+			}
+			book.add(selector, src.OnRamp, newTypeAndVersion(OnRampType, map[string]string{
+				DestChainSelectorLabel: strconv.FormatUint(destSelector, 10),
+				DeployedAtLabel:        strconv.FormatUint(src.DepolyedAt, 10),
+			}))
+		}
+		for sourceNetwork, dest := range cfg.DestContracts {
+			sourceSelector, err := selectorOf(sourceNetwork)
+			if err != nil {
+				return nil, err
+			}
+			for contractType, address := range map[string]string{
+				OffRampType:      dest.OffRamp,
+				CommitStoreType:  dest.CommitStore,
+				ReceiverDappType: dest.ReceiverDapp,
+			} {
+				book.add(selector, address, newTypeAndVersion(contractType, map[string]string{
+					SourceChainSelectorLabel: strconv.FormatUint(sourceSelector, 10),
+				}))
+			}
+		}
+	}
+	return book, nil
+}
+
+// LanesFromAddressBook converts an address book into lane configs keyed by the network names of chainIDByNetwork.
+// The lane contracts are only assigned to a lane through the chain selector labels set by Lanes.AddressBook, unless
+// the address book holds only two chains. Entries of unknown contract types are ignored.
+func LanesFromAddressBook(book AddressBook, chainIDByNetwork map[string]uint64) (*Lanes, error) {
+	networkBySelector := make(map[uint64]string)
+	for network, chainID := range chainIDByNetwork {
+		selector, err := chainselectors.SelectorFromChainId(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("chain selector of network %s: %w", network, err)
+		}
+		if existing, ok := networkBySelector[selector]; ok && existing != network {
+			return nil, fmt.Errorf("networks %s and %s share chain id %d, the address book can not tell them apart", existing, network, chainID)
+		}
+		networkBySelector[selector] = network
+	}
+	networkOf := func(selector uint64) (string, error) {
+		network, ok := networkBySelector[selector]
+		if !ok {
+			return "", fmt.Errorf("network of chain selector %d not found", selector)
+		}
+		return network, nil
+	}
+	// the other chains of the lanes of a contract, without chain selector label it can only be the other chain of
+	// the book
+	otherNetworks := func(selector uint64, labels map[string]string, label string) ([]string, error) {
+		if value, ok := labels[label]; ok {
+			var networks []string
+			for _, s := range strings.Split(value, ",") {
+				other, err := strconv.ParseUint(s, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s label %q: %w", label, value, err)
+				}
+				network, err := networkOf(other)
+				if err != nil {
+					return nil, err
+				}
+				networks = append(networks, network)
+			}
+			return networks, nil
+		}
+		if len(book) != 2 {
+			return nil, fmt.Errorf("%s label is required with %d chains in the address book", label, len(book))
+		}
+		for other := range book {
+			if other != selector {
+				network, err := networkOf(other)
+				return []string{network}, err
+			}
+		}
+		return nil, fmt.Errorf("no other chain than %d in the address book", selector)
+	}
+	lanes := &Lanes{LaneConfigs: make(map[string]*LaneConfig)}
+	for selector, contracts := range book {
+		network, err := networkOf(selector)
+		if err != nil {
+			return nil, err
+		}
+		cfg := lanes.ReadLaneConfig(network)
+		tokens := make(map[int]string)
+		pools := make(map[int]string)
+		for address, tv := range contracts {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("invalid address %s on chain selector %d", address, selector)
+			}
+			index := -1
+			if value, ok := tv.Labels[IndexLabel]; ok {
+				index, err = strconv.Atoi(value)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid %s label %q of %s", IndexLabel, value, address)
+				}
+			}
+			switch tv.Type {
+			case ARMProxyType:
+				cfg.ARM = address
+			case MockARMType:
+				cfg.ARM = address
+				cfg.IsMockARM = true
+			case RouterType:
+				cfg.Router = address
+			case PriceRegistryType:
+				cfg.PriceRegistry = address
+			case TokenAdminRegistryType:
+				cfg.TokenAdminRegistry = address
+			case MulticallType:
+				cfg.Multicall = address
+			case TokenTransmitterType:
+				cfg.TokenTransmitter = address
+			case TokenMessengerType:
+				cfg.TokenMessenger = address
+			case WrappedNativeType:
+				cfg.WrappedNative = address
+				if _, ok := tv.Labels[NativeFeeTokenLabel]; ok {
+					cfg.IsNativeFeeToken = true
+				}
+			case FeeTokenType:
+				cfg.FeeToken = address
+				if index >= 0 {
+					tokens[index] = address
+				}
+			case BridgeTokenType:
+				if index < 0 {
+					return nil, fmt.Errorf("%s label is required for bridge token %s", IndexLabel, address)
+				}
+				tokens[index] = address
+			case BridgeTokenPoolType:
+				if index < 0 {
+					return nil, fmt.Errorf("%s label is required for bridge token pool %s", IndexLabel, address)
+				}
+				pools[index] = address
+			case PriceAggregatorType:
+				token, ok := tv.Labels[TokenLabel]
+				if !ok {
+					return nil, fmt.Errorf("%s label is required for price aggregator %s", TokenLabel, address)
+				}
+				if cfg.PriceAggregators == nil {
+					cfg.PriceAggregators = make(map[string]string)
+				}
+				cfg.PriceAggregators[token] = address
+			case OnRampType:
+				destNetworks, err := otherNetworks(selector, tv.Labels, DestChainSelectorLabel)
+				if err != nil {
+					return nil, fmt.Errorf("onRamp %s: %w", address, err)
+				}
+				if len(destNetworks) != 1 {
+					return nil, fmt.Errorf("onRamp %s should have a single dest chain, got %d", address, len(destNetworks))
+				}
+				destNetwork := destNetworks[0]
+				src := cfg.SrcContracts[destNetwork]
+				src.OnRamp = address
+				if value, ok := tv.Labels[DeployedAtLabel]; ok {
+					src.DepolyedAt, err = strconv.ParseUint(value, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid %s label %q of onRamp %s: %w", DeployedAtLabel, value, address, err)
+					}
+				}
+				cfg.SrcContracts[destNetwork] = src
+			case OffRampType, CommitStoreType, ReceiverDappType:
+				sourceNetworks, err := otherNetworks(selector, tv.Labels, SourceChainSelectorLabel)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", tv.Type, address, err)
+				}
+				for _, sourceNetwork := range sourceNetworks {
+					dest := cfg.DestContracts[sourceNetwork]
+					switch tv.Type {
+					case OffRampType:
+						dest.OffRamp = address
+					case CommitStoreType:
+						dest.CommitStore = address
+					default:
+						dest.ReceiverDapp = address
+					}
+					cfg.DestContracts[sourceNetwork] = dest
+				}
+			}
+		}
+		cfg.BridgeTokens = indexedAddresses(tokens)
+		cfg.BridgeTokenPools = indexedAddresses(pools)
+		// the pools are matched with the tokens by index, a token without pool is kept with an empty one
+		for len(cfg.BridgeTokenPools) < len(cfg.BridgeTokens) {
+			cfg.BridgeTokenPools = append(cfg.BridgeTokenPools, "")
+		}
+	}
+	return lanes, nil
+}
+
+// indexedAddresses returns the addresses ordered by index, the missing indices are left empty
+func indexedAddresses(byIndex map[int]string) []string {
+	if len(byIndex) == 0 {
+		return nil
+	}
+	maxIndex := 0
+	for i := range byIndex {
+		if i > maxIndex {
+			maxIndex = i
+		}
+	}
+	addresses := make([]string, maxIndex+1)
+	for i, address := range byIndex {
+		addresses[i] = address
+	}
+	return addresses
+}
+
+// ReadLanesFromAddressBook reads the lane configs from the address book JSON of the ccip deployment tooling
+func ReadLanesFromAddressBook(data []byte, chainIDByNetwork map[string]uint64) (*Lanes, error) {
+	var book AddressBook
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, fmt.Errorf("failed to parse address book: %w", err)
+	}
+	return LanesFromAddressBook(book, chainIDByNetwork)
+}
+
+// WriteAddressBookToJSON writes the lane configs to path in the address book JSON of the ccip deployment tooling
+func WriteAddressBookToJSON(path string, lanes *Lanes, chainIDByNetwork map[string]uint64) error {
+	book, err := lanes.AddressBook(chainIDByNetwork)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.WriteFile(path, b, 0600)
+}
@@ -0,0 +1,120 @@
+package laneconfig
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var mainnetChainIDs = map[string]uint64{
+	"Arbitrum Mainnet":  42161,
+	"Avalanche Mainnet": 43114,
+	"Base Mainnet":      8453,
+	"BSC Mainnet":       56,
+	"Ethereum Mainnet":  1,
+	"Optimism Mainnet":  10,
+	"Polygon Mainnet":   137,
+}
+
+// normalized returns the lane configs with checksummed addresses, the address book does not keep their case
+func normalized(t *testing.T, lanes *Lanes) map[string]*LaneConfig {
+	b, err := json.Marshal(lanes.LaneConfigs)
+	require.NoError(t, err)
+	var configs map[string]*LaneConfig
+	require.NoError(t, json.Unmarshal(b, &configs))
+	checksum := func(address string) string {
+		if address == "" {
+			return ""
+		}
+		return common.HexToAddress(address).Hex()
+	}
+	for _, cfg := range configs {
+		cfg.FeeToken, cfg.ARM, cfg.Router = checksum(cfg.FeeToken), checksum(cfg.ARM), checksum(cfg.Router)
+		cfg.PriceRegistry, cfg.WrappedNative, cfg.Multicall = checksum(cfg.PriceRegistry), checksum(cfg.WrappedNative), checksum(cfg.Multicall)
+		cfg.TokenTransmitter, cfg.TokenMessenger = checksum(cfg.TokenTransmitter), checksum(cfg.TokenMessenger)
+		cfg.TokenAdminRegistry = checksum(cfg.TokenAdminRegistry)
+		for i := range cfg.BridgeTokens {
+			cfg.BridgeTokens[i] = checksum(cfg.BridgeTokens[i])
+		}
+		for i := range cfg.BridgeTokenPools {
+			cfg.BridgeTokenPools[i] = checksum(cfg.BridgeTokenPools[i])
+		}
+		aggregators := make(map[string]string)
+		for token, aggregator := range cfg.PriceAggregators {
+			aggregators[checksum(token)] = checksum(aggregator)
+		}
+		cfg.PriceAggregators = aggregators
+		for network, src := range cfg.SrcContracts {
+			src.OnRamp = checksum(src.OnRamp)
+			cfg.SrcContracts[network] = src
+		}
+		for network, dest := range cfg.DestContracts {
+			dest.OffRamp, dest.CommitStore, dest.ReceiverDapp = checksum(dest.OffRamp), checksum(dest.CommitStore), checksum(dest.ReceiverDapp)
+			cfg.DestContracts[network] = dest
+		}
+	}
+	return configs
+}
+
+func TestAddressBookRoundTrip(t *testing.T) {
+	t.Parallel()
+	lanes, err := ReadLanesFromExistingDeployment(nil)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "address_book.json")
+	require.NoError(t, WriteAddressBookToJSON(path, lanes, mainnetChainIDs))
+	book, err := lanes.AddressBook(mainnetChainIDs)
+	require.NoError(t, err)
+	require.Len(t, book, len(mainnetChainIDs))
+	ethereum := book[5009297550715157269]
+	require.Equal(t, "EVM2EVMOnRamp 1.5.0-dev deployed_at:18029393 dest_chain_selector:4949039107694359620",
+		ethereum[common.HexToAddress("0x333f976915195ba9044fD0cd603cEcE936f6264e").Hex()].String())
+
+	b, err := json.Marshal(book)
+	require.NoError(t, err)
+	imported, err := ReadLanesFromAddressBook(b, mainnetChainIDs)
+	require.NoError(t, err)
+	require.Equal(t, normalized(t, lanes), normalized(t, imported))
+}
+
+func TestLanesFromAddressBook(t *testing.T) {
+	t.Parallel()
+	chainIDs := map[string]uint64{"source": 1337, "dest": 2337}
+	// an address book written by the deployment tooling, without the labels of the lane contracts
+	data := `{
+		"3379446385462418246": {
+			"0x0000000000000000000000000000000000000001": "Router 1.2.0",
+			"0x0000000000000000000000000000000000000002": "EVM2EVMOnRamp 1.5.0",
+			"0x0000000000000000000000000000000000000003": "LinkToken 1.0.0",
+			"0x0000000000000000000000000000000000000004": "SomeOtherContract 1.0.0"
+		},
+		"12922642891491394802": {
+			"0x0000000000000000000000000000000000000011": "EVM2EVMOffRamp 1.5.0",
+			"0x0000000000000000000000000000000000000012": "CommitStore 1.5.0"
+		}
+	}`
+	lanes, err := ReadLanesFromAddressBook([]byte(data), chainIDs)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x1").Hex(), lanes.LaneConfigs["source"].Router)
+	require.Equal(t, common.HexToAddress("0x3").Hex(), lanes.LaneConfigs["source"].FeeToken)
+	require.Equal(t, common.HexToAddress("0x2").Hex(), lanes.LaneConfigs["source"].SrcContracts["dest"].OnRamp)
+	require.Equal(t, DestContracts{
+		OffRamp:     common.HexToAddress("0x11").Hex(),
+		CommitStore: common.HexToAddress("0x12").Hex(),
+	}, lanes.LaneConfigs["dest"].DestContracts["source"])
+
+	// with a third chain the lane of the contracts is ambiguous
+	chainIDs["other"] = 1
+	data = strings.Replace(data, `"12922642891491394802": {`, `"5009297550715157269": {}, "12922642891491394802": {`, 1)
+	_, err = ReadLanesFromAddressBook([]byte(data), chainIDs)
+	require.ErrorContains(t, err, "dest_chain_selector label is required")
+
+	_, err = ReadLanesFromAddressBook([]byte(`{"1": {"0x1": "Router"}}`), chainIDs)
+	require.ErrorContains(t, err, "invalid type and version")
+	_, err = LanesFromAddressBook(AddressBook{}, map[string]uint64{"a": 1337, "b": 1337})
+	require.ErrorContains(t, err, "share chain id 1337")
+}
@@ -0,0 +1,154 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultLogQueryConcurrency is the max number of log filter calls in flight in a chunked range query
+const DefaultLogQueryConcurrency = 4
+
+// BlockRange is an inclusive range of blocks
+type BlockRange struct {
+	From uint64
+	To   uint64
+}
+
+// SplitBlockRange splits the blocks from from till to, both inclusive, into consecutive ranges of at most size blocks
+func SplitBlockRange(from, to, size uint64) []BlockRange {
+	if size == 0 {
+		size = DefaultLaneReaderBlockRange
+	}
+	var ranges []BlockRange
+	for start := from; start <= to; start += size {
+		end := start + size - 1
+		if end > to || end < start {
+			end = to
+		}
+		ranges = append(ranges, BlockRange{From: start, To: end})
+		if end == to {
+			break
+		}
+	}
+	return ranges
+}
+
+// RangeCursor is the progress of a chunked range query, so that a query interrupted by an error or a cancelled context
+// is resumed from where it stopped instead of from the start. The chunks of a concurrent query complete out of order,
+// the cursor only advances over the completed chunks contiguous to it, the ones ahead of it are queried again on resume.
+// The same cursor can be passed along the components querying the same range, e.g. from the replay of the logs to
+// the backfill and the post-run verification, and saved to a file to be resumed by another run.
+type RangeCursor struct {
+	mu        sync.Mutex
+	next      uint64
+	completed map[uint64]uint64 // key - first block of a completed chunk ahead of next; value - its last block
+}
+
+// NewRangeCursor returns a cursor starting at fromBlock
+func NewRangeCursor(fromBlock uint64) *RangeCursor {
+	return &RangeCursor{next: fromBlock}
+}
+
+// Next returns the first block which is not queried yet
+func (c *RangeCursor) Next() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.next
+}
+
+// complete marks r as queried, advancing the cursor if r is contiguous to it
+func (c *RangeCursor) complete(r BlockRange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r.To < c.next {
+		return
+	}
+	if c.completed == nil {
+		c.completed = make(map[uint64]uint64)
+	}
+	c.completed[r.From] = r.To
+	for {
+		end, ok := c.completed[c.next]
+		if !ok {
+			return
+		}
+		delete(c.completed, c.next)
+		c.next = end + 1
+	}
+}
+
+type rangeCursorFile struct {
+	Next uint64 `json:"next_block"`
+}
+
+// Save writes the position of the cursor to path
+func (c *RangeCursor) Save(path string) error {
+	data, err := json.Marshal(rangeCursorFile{Next: c.Next()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadRangeCursor reads the cursor saved at path, it returns a cursor starting at fromBlock if there is no such file
+func LoadRangeCursor(path string, fromBlock uint64) (*RangeCursor, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewRangeCursor(fromBlock), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved rangeCursorFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse range cursor file %s: %w", path, err)
+	}
+	return NewRangeCursor(saved.Next), nil
+}
+
+// QueryRanges calls query for every chunk of at most size blocks from the cursor till toBlock, with at most
+// concurrency calls in flight. The cursor advances as the chunks complete; on the first error the remaining chunks
+// are not queried and the error is returned, calling QueryRanges again with the same cursor resumes the query.
+func QueryRanges(
+	ctx context.Context,
+	cursor *RangeCursor,
+	toBlock uint64,
+	size uint64,
+	concurrency int,
+	query func(ctx context.Context, r BlockRange) error,
+) error {
+	from := cursor.Next()
+	if from > toBlock {
+		return nil
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultLogQueryConcurrency
+	}
+	grp, grpCtx := errgroup.WithContext(ctx)
+	grp.SetLimit(concurrency)
+	for _, r := range SplitBlockRange(from, toBlock, size) {
+		r := r
+		if grpCtx.Err() != nil {
+			break
+		}
+		grp.Go(func() error {
+			if err := grpCtx.Err(); err != nil {
+				return err
+			}
+			if err := query(grpCtx, r); err != nil {
+				return fmt.Errorf("failed to query blocks %d-%d: %w", r.From, r.To, err)
+			}
+			cursor.complete(r)
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
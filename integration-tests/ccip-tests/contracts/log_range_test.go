@@ -0,0 +1,71 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBlockRange(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []BlockRange{{10, 19}, {20, 29}, {30, 32}}, SplitBlockRange(10, 32, 10))
+	require.Equal(t, []BlockRange{{5, 5}}, SplitBlockRange(5, 5, 10))
+	require.Nil(t, SplitBlockRange(6, 5, 10))
+}
+
+func TestQueryRangesResume(t *testing.T) {
+	t.Parallel()
+	var (
+		mu      sync.Mutex
+		queried []BlockRange
+		failAt  = uint64(40)
+	)
+	query := func(_ context.Context, r BlockRange) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.From == failAt {
+			return fmt.Errorf("rpc timeout")
+		}
+		queried = append(queried, r)
+		return nil
+	}
+	cursor := NewRangeCursor(0)
+	// sequentially, so that the chunks after the failing one are not queried
+	err := QueryRanges(context.Background(), cursor, 99, 10, 1, query)
+	require.ErrorContains(t, err, "failed to query blocks 40-49: rpc timeout")
+	require.Equal(t, uint64(40), cursor.Next())
+	require.Len(t, queried, 4)
+
+	path := filepath.Join(t.TempDir(), "cursor.json")
+	require.NoError(t, cursor.Save(path))
+	resumed, err := LoadRangeCursor(path, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), resumed.Next())
+
+	failAt = 0
+	queried = nil
+	require.NoError(t, QueryRanges(context.Background(), resumed, 99, 10, 3, query))
+	require.Equal(t, uint64(100), resumed.Next())
+	require.Len(t, queried, 6)
+	// nothing left to query
+	require.NoError(t, QueryRanges(context.Background(), resumed, 99, 10, 3, query))
+	require.Len(t, queried, 6)
+
+	fresh, err := LoadRangeCursor(filepath.Join(t.TempDir(), "missing.json"), 7)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), fresh.Next())
+}
+
+func TestRangeCursorOutOfOrder(t *testing.T) {
+	t.Parallel()
+	cursor := NewRangeCursor(0)
+	cursor.complete(BlockRange{10, 19})
+	cursor.complete(BlockRange{20, 29})
+	require.Equal(t, uint64(0), cursor.Next())
+	cursor.complete(BlockRange{0, 9})
+	require.Equal(t, uint64(30), cursor.Next())
+}
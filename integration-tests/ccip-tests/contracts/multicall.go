@@ -280,3 +280,67 @@ func TransferTokens(
 	}
 	return nil
 }
+
+// BalanceOfCall is an ERC20 balanceOf call of holder in token
+type BalanceOfCall struct {
+	Token  common.Address
+	Holder common.Address
+}
+
+// BalancesOf reads the ERC20 balances of calls in a single eth_call of aggregate3 of the Multicall contract at
+// multicall. The balances are returned in the order of calls, read at the same block. It errors if any of the
+// balanceOf calls fails.
+func BalancesOf(ctx context.Context, caller bind.ContractCaller, multicall common.Address, calls []BalanceOfCall) ([]*big.Int, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	multiCallABI, err := abi.JSON(strings.NewReader(MultiCallABI))
+	if err != nil {
+		return nil, err
+	}
+	erc20ABI, err := abi.JSON(strings.NewReader(erc20.ERC20ABI))
+	if err != nil {
+		return nil, err
+	}
+	balanceOf := erc20ABI.Methods["balanceOf"]
+	var callData []Call
+	for _, c := range calls {
+		inputs, err := balanceOf.Inputs.Pack(c.Holder)
+		if err != nil {
+			return nil, err
+		}
+		callData = append(callData, Call{Target: c.Token, AllowFailure: true, CallData: append(balanceOf.ID[:], inputs...)})
+	}
+	boundContract := bind.NewBoundContract(multicall, multiCallABI, caller, nil, nil)
+	var out []interface{}
+	if err := boundContract.Call(&bind.CallOpts{Context: ctx}, &out, "aggregate3", callData); err != nil {
+		return nil, fmt.Errorf("failed to call aggregate3 of multicall %s: %w", multicall.Hex(), err)
+	}
+	if len(out) != 1 {
+		return nil, fmt.Errorf("unexpected output of aggregate3 of multicall %s", multicall.Hex())
+	}
+	results := *abi.ConvertType(out[0], new([]Result)).(*[]Result)
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("aggregate3 returned %d results for %d calls", len(results), len(calls))
+	}
+	balances := make([]*big.Int, len(calls))
+	for i, res := range results {
+		if !res.Success {
+			return nil, fmt.Errorf("balanceOf %s in token %s failed", calls[i].Holder.Hex(), calls[i].Token.Hex())
+		}
+		unpacked, err := balanceOf.Outputs.Unpack(res.ReturnData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack balanceOf %s in token %s: %w", calls[i].Holder.Hex(), calls[i].Token.Hex(), err)
+		}
+		var balance *big.Int
+		ok := len(unpacked) == 1
+		if ok {
+			balance, ok = unpacked[0].(*big.Int)
+		}
+		if !ok {
+			return nil, fmt.Errorf("unexpected balanceOf %s in token %s: %v", calls[i].Holder.Hex(), calls[i].Token.Hex(), unpacked)
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
@@ -39,6 +39,17 @@ type CCIPLaneOptimized struct {
 	Source            *actions.SourceCCIPModule
 	Dest              *actions.DestCCIPModule
 	Reports           *testreporters.CCIPLaneStats
+	Context           context.Context             // lane context, cancelling it aborts the in-flight validations
+	PhaseNotifier     testreporters.PhaseNotifier // notified of the phase transitions of the requests sent on the lane, if set
+}
+
+// NewRequestStat returns the stat of a request sent on the lane, notifying the phase notifier of the lane if any
+func (lane *CCIPLaneOptimized) NewRequestStat(reqNo int64) *testreporters.RequestStat {
+	stat := testreporters.NewCCIPRequestStats(reqNo, lane.SourceNetworkName, lane.DestNetworkName)
+	if lane.PhaseNotifier != nil {
+		stat.SetNotifier(lane.PhaseNotifier)
+	}
+	return stat
 }
 
 type CCIPE2ELoad struct {
@@ -74,6 +85,8 @@ func NewCCIPLoad(
 		Source:            lane.Source,
 		Dest:              lane.Dest,
 		Reports:           lane.Reports,
+		Context:           lane.Context,
+		PhaseNotifier:     lane.PhaseNotifier,
 	}
 
 	return &CCIPE2ELoad{
@@ -166,7 +179,7 @@ func (c *CCIPE2ELoad) CCIPMsg() (router.ClientEVM2AnyMessage, *testreporters.Req
 	msgSerialNo := c.CurrentMsgSerialNo.Load()
 	c.CurrentMsgSerialNo.Inc()
 	msgDetails := c.MsgProfiles.MsgDetailsForIteration(msgSerialNo)
-	stats := testreporters.NewCCIPRequestStats(msgSerialNo, c.Lane.SourceNetworkName, c.Lane.DestNetworkName)
+	stats := c.Lane.NewRequestStat(msgSerialNo)
 	// form the message for transfer
 	msgLength := pointer.GetInt64(msgDetails.DataLength)
 	gasLimit := pointer.GetInt64(msgDetails.DestGasLimit)
@@ -188,6 +201,9 @@ func (c *CCIPE2ELoad) CCIPMsg() (router.ClientEVM2AnyMessage, *testreporters.Req
 	}
 	if !msgDetails.IsTokenTransfer() {
 		msg.TokenAmounts = []router.ClientEVMTokenAmount{}
+	} else if noOfTokens := pointer.GetInt(msgDetails.NoOfTokens); noOfTokens < len(msg.TokenAmounts) {
+		// the lane transfers the max no of tokens in the msg profile, send only as many as the msg details set
+		msg.TokenAmounts = msg.TokenAmounts[:noOfTokens]
 	}
 	extraArgsV1, err := testhelpers.GetEVMExtraArgsV1(big.NewInt(gasLimit), false)
 	if err != nil {
@@ -218,6 +234,10 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 		res.Failed = true
 		return res
 	}
+	// record the request stats for the lane so that the run can be simulated offline after the test
+	if c.Lane.Reports != nil {
+		defer c.Lane.Reports.UpdatePhaseStatsForReq(stats)
+	}
 	msgSerialNo := stats.ReqNo
 	lggr := c.Lane.Logger.With().Int64("msg Number", stats.ReqNo).Logger()
 
@@ -241,6 +261,13 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 		res.Failed = true
 		return res
 	}
+	// top up the router allowance if the long run has used it up
+	err = sourceCCIP.Common.EnsureRouterAllowance(lggr, msg, fee)
+	if err != nil {
+		res.Error = err.Error()
+		res.Failed = true
+		return res
+	}
 	startTime := time.Now()
 	if feeToken != common.HexToAddress("0x0") {
 		sendTx, err = sourceCCIP.Common.Router.CCIPSend(destChainSelector, msg, nil)
@@ -256,6 +283,7 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 		return res
 	}
 
+	sourceCCIP.RecordSentMsgs(sendTx.Hash(), msg)
 	err = sourceCCIP.Common.ChainClient.MarkTxAsSentOnL2(sendTx)
 
 	if err != nil {
@@ -287,6 +315,7 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 				TxHash:             sendTx.Hash().Hex(),
 				NoOfTokensSent:     len(msg.TokenAmounts),
 				MessageBytesLength: int64(len(msg.Data)),
+				BlockNumber:        rcpt.BlockNumber.Uint64(),
 			})
 		errReason, v, err := c.Lane.Source.Common.ChainClient.RevertReasonFromTx(rcpt.TxHash, router.RouterABI)
 		if err != nil {
@@ -304,6 +333,7 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 			TxHash:             sendTx.Hash().Hex(),
 			NoOfTokensSent:     len(msg.TokenAmounts),
 			MessageBytesLength: int64(len(msg.Data)),
+			BlockNumber:        rcpt.BlockNumber.Uint64(),
 		})
 	err = c.Validate(lggr, sendTx, txConfirmationTime, []*testreporters.RequestStat{stats})
 	if err != nil {
@@ -319,7 +349,7 @@ func (c *CCIPE2ELoad) Call(_ *wasp.Generator) *wasp.Response {
 func (c *CCIPE2ELoad) Validate(lggr zerolog.Logger, sendTx *types.Transaction, txConfirmationTime time.Time, stats []*testreporters.RequestStat) error {
 	// wait for
 	// - CCIPSendRequested Event log to be generated,
-	msgLogs, sourceLogTime, err := c.Lane.Source.AssertEventCCIPSendRequested(lggr, sendTx.Hash().Hex(), c.CallTimeOut, txConfirmationTime, stats)
+	msgLogs, sourceLogTime, err := c.Lane.Source.AssertEventCCIPSendRequested(c.Lane.Context, lggr, sendTx.Hash().Hex(), c.CallTimeOut, txConfirmationTime, stats)
 	if err != nil {
 		return err
 	}
@@ -343,7 +373,7 @@ func (c *CCIPE2ELoad) Validate(lggr zerolog.Logger, sendTx *types.Transaction, t
 	} else {
 		var finalizingBlock uint64
 		sourceLogFinalizedAt, finalizingBlock, err = c.Lane.Source.AssertSendRequestedLogFinalized(
-			lggr, sendTx.Hash(), sourceLogTime, stats)
+			c.Lane.Context, lggr, sendTx.Hash(), sourceLogTime, stats)
 		if err != nil {
 			return err
 		}
@@ -366,20 +396,20 @@ func (c *CCIPE2ELoad) Validate(lggr zerolog.Logger, sendTx *types.Transaction, t
 		}
 		// wait for
 		// - CommitStore to increase the seq number,
-		err = c.Lane.Dest.AssertSeqNumberExecuted(lggr, seqNum, c.CallTimeOut, sourceLogFinalizedAt, reqStat)
+		err = c.Lane.Dest.AssertSeqNumberExecuted(c.Lane.Context, lggr, seqNum, c.CallTimeOut, sourceLogFinalizedAt, reqStat)
 		if err != nil {
 			return err
 		}
 		// wait for ReportAccepted event
-		commitReport, reportAcceptedAt, err := c.Lane.Dest.AssertEventReportAccepted(lggr, seqNum, c.CallTimeOut, sourceLogFinalizedAt, reqStat)
+		commitReport, reportAcceptedAt, err := c.Lane.Dest.AssertEventReportAccepted(c.Lane.Context, lggr, seqNum, c.CallTimeOut, sourceLogFinalizedAt, reqStat)
 		if err != nil || commitReport == nil {
 			return err
 		}
-		blessedAt, err := c.Lane.Dest.AssertReportBlessed(lggr, seqNum, c.CallTimeOut, *commitReport, reportAcceptedAt, reqStat)
+		blessedAt, err := c.Lane.Dest.AssertReportBlessed(c.Lane.Context, lggr, seqNum, c.CallTimeOut, *commitReport, reportAcceptedAt, reqStat)
 		if err != nil {
 			return err
 		}
-		_, err = c.Lane.Dest.AssertEventExecutionStateChanged(lggr, seqNum, c.CallTimeOut, blessedAt, reqStat, testhelpers.ExecutionStateSuccess)
+		_, err = c.Lane.Dest.AssertEventExecutionStateChanged(c.Lane.Context, lggr, seqNum, c.CallTimeOut, blessedAt, reqStat, testhelpers.ExecutionStateSuccess)
 		if err != nil {
 			return err
 		}
@@ -23,6 +23,7 @@ import (
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testsetups"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 )
 
 // CCIPMultiCallLoadGenerator represents a load generator for the CCIP lanes originating from same network
@@ -222,6 +223,11 @@ func (m *CCIPMultiCallLoadGenerator) Call(_ *wasp.Generator) *wasp.Response {
 
 		lggr = lggr.With().Str("Source Network", c.Lane.Source.Common.ChainClient.GetNetworkName()).Str("Dest Network", c.Lane.Dest.Common.ChainClient.GetNetworkName()).Logger()
 		stats := rValues.Stats
+		var sentMsgs []router.ClientEVM2AnyMessage
+		for _, msg := range rValues.Msgs {
+			sentMsgs = append(sentMsgs, msg.Msg)
+		}
+		c.Lane.Source.RecordSentMsgs(sendTx.Hash(), sentMsgs...)
 		txConfirmationTime := txConfirmationTime
 		sendTx := sendTx
 		lggr := lggr
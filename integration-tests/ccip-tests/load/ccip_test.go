@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/utils/ptr"
 
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testsetups"
 )
 
@@ -277,9 +278,7 @@ func TestLoadCCIPStableWithPodChaosDiffCommitAndExec(t *testing.T) {
 			t.Parallel()
 			lggr := logging.GetTestLogger(t)
 			testArgs := NewLoadArgs(t, lggr, in)
-			testArgs.TestCfg.TestGroupInput.LoadProfile.TestDuration = config.MustNewDuration(5 * time.Minute)
-			testArgs.TestCfg.TestGroupInput.LoadProfile.TimeUnit = config.MustNewDuration(1 * time.Second)
-			testArgs.TestCfg.TestGroupInput.LoadProfile.RequestPerUnitTime = []int64{2}
+			require.NoError(t, testArgs.TestCfg.TestGroupInput.LoadProfile.ApplyPresetSchedule(testconfig.LoadPresetSmoke))
 			testArgs.TestCfg.TestGroupInput.PhaseTimeout = config.MustNewDuration(15 * time.Minute)
 
 			testArgs.Setup()
@@ -326,3 +325,50 @@ func TestLoadCCIPStableRPSAfterARMCurseAndUncurse(t *testing.T) {
 	testArgs.ValidateCurseFollowedByUncurse()
 	testArgs.Wait()
 }
+
+// TestLoadCCIPWithChaosMonkey runs the load while a chaos monkey keeps injecting random faults from a safe action set
+// (node failure, rpc partition, gas spike) within the fault tolerance of the DONs.
+// Every injected fault is annotated in the chaos timeline of the test report.
+func TestLoadCCIPWithChaosMonkey(t *testing.T) {
+	t.Parallel()
+	lggr := logging.GetTestLogger(t)
+	testArgs := NewLoadArgs(t, lggr)
+	require.NotNil(t, testArgs.TestCfg.TestGroupInput.LoadProfile.ChaosMonkey, "chaos monkey config should be set in load profile")
+	testArgs.Setup()
+	// if the test runs on remote runner
+	if len(testArgs.TestSetupArgs.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		log.Info().Msg("Tearing down the environment")
+		require.NoError(t, testArgs.TestSetupArgs.TearDown())
+	})
+	testArgs.TriggerLoadByLane()
+	testArgs.LoadStarterWg.Wait()
+	testArgs.RunChaosMonkey()
+	testArgs.Wait()
+}
+
+// TestLoadCCIPWithNodeConfigReload runs the load while the CL node config is changed by restarting one node at a time.
+// As only one node is down at a time, no request should miss its phase timeout.
+func TestLoadCCIPWithNodeConfigReload(t *testing.T) {
+	t.Parallel()
+	lggr := logging.GetTestLogger(t)
+	testArgs := NewLoadArgs(t, lggr)
+	require.NotNil(t, testArgs.TestCfg.TestGroupInput.LoadProfile.NodeConfigReload, "node config reload should be set in load profile")
+	testArgs.Setup()
+	// if the test runs on remote runner
+	if len(testArgs.TestSetupArgs.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		log.Info().Msg("Tearing down the environment")
+		require.NoError(t, testArgs.TestSetupArgs.TearDown())
+	})
+	testArgs.TriggerLoadByLane()
+	testArgs.LoadStarterWg.Wait()
+	testArgs.ReloadNodeConfigs()
+	// after the reload send a request to all lanes as a sanity check
+	testArgs.SanityCheck()
+	testArgs.Wait()
+}
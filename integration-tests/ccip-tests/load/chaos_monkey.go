@@ -0,0 +1,195 @@
+package load
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/k8s/chaos"
+	"github.com/smartcontractkit/chainlink-testing-framework/utils/ptr"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+)
+
+const (
+	ChaosMonkeyKillNodes    = "kill-nodes"    // fail pods of f nodes of the DON
+	ChaosMonkeyRPCPartition = "rpc-partition" // partition f nodes of the DON from all the rpcs
+	ChaosMonkeyGasSpike     = "gas-spike"     // spike the dest chain gas price in source price registry of a random lane
+
+	defaultGasSpikeFactor = 10
+)
+
+// chaosMonkeyAction injects a fault for the given duration and waits for it to be recovered.
+// It returns the target of the fault.
+type chaosMonkeyAction func(rnd *rand.Rand, faultDuration time.Duration) (string, error)
+
+// RunChaosMonkey injects a random fault from the safe action set at random intervals till the end of the load.
+// The safety envelope is -
+//  1. only one fault is injected at a time and the next fault is injected only after the previous one is recovered
+//  2. node faults are limited to the f nodes of the DON labeled with the minority chaos group
+//  3. no fault is injected if it can't be recovered before the load finishes
+//
+// Every injected fault is added to the chaos timeline of the test report.
+func (l *LoadArgs) RunChaosMonkey() {
+	cfg := l.TestCfg.TestGroupInput.LoadProfile.ChaosMonkey
+	require.NotNil(l.t, cfg, "chaos monkey config should be set")
+	testEnv := l.TestSetupArgs.Env
+	if testEnv == nil || testEnv.K8Env == nil {
+		l.lggr.Warn().Msg("test environment is nil, skipping chaos monkey")
+		return
+	}
+	names, actionsByName := l.chaosMonkeyActions(cfg)
+	require.NotEmpty(l.t, names, "no chaos monkey action can be run within the fault tolerance of the environment")
+
+	seed := time.Now().UnixNano()
+	if cfg.Seed != nil {
+		seed = *cfg.Seed
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	faultDuration := cfg.FaultDuration.Duration()
+	minInterval := cfg.MinInterval.Duration()
+	maxInterval := cfg.MaxInterval.Duration()
+	deadline := time.Now().Add(l.TestCfg.TestGroupInput.LoadProfile.TestDuration.Duration())
+	l.lggr.Info().
+		Int64("Seed", seed).
+		Strs("Actions", names).
+		Time("Deadline", deadline).
+		Msg("Starting chaos monkey")
+
+	for {
+		wait := minInterval + time.Duration(rnd.Int63n(int64(maxInterval-minInterval)+1))
+		// the fault and the recovery should be completed before the load finishes
+		if time.Now().Add(wait + faultDuration + time.Minute).After(deadline) {
+			l.lggr.Info().Msg("Not enough time left for another fault, stopping chaos monkey")
+			return
+		}
+		select {
+		case <-l.Ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		name := names[rnd.Intn(len(names))]
+		event := testreporters.ChaosEvent{
+			Name:  name,
+			Start: time.Now().UTC(),
+		}
+		target, err := actionsByName[name](rnd, faultDuration)
+		event.Target = target
+		event.End = time.Now().UTC()
+		if err != nil {
+			event.Error = err.Error()
+		}
+		l.TestSetupArgs.Reporter.AddChaosEvent(event)
+		require.NoError(l.t, err, "chaos monkey action %s failed", name)
+	}
+}
+
+// chaosMonkeyActions returns the actions which can be run within the fault tolerance of the environment
+func (l *LoadArgs) chaosMonkeyActions(cfg *testconfig.ChaosMonkeyConfig) ([]string, map[string]chaosMonkeyAction) {
+	testEnv := l.TestSetupArgs.Env
+	available := make(map[string]chaosMonkeyAction)
+	if testEnv.NumOfAllowedFaultyCommit > 0 {
+		testEnv.ChaosLabelForCLNodes(l.TestCfg.Test)
+		minorityGroup := actions.ChaosGroupCommitFaulty
+		if pointer.GetBool(l.TestCfg.TestGroupInput.CommitAndExecuteOnSameDON) {
+			minorityGroup = actions.ChaosGroupCommitAndExecFaulty
+		}
+		available[ChaosMonkeyKillNodes] = func(_ *rand.Rand, faultDuration time.Duration) (string, error) {
+			return minorityGroup, l.runChaosAndWait(chaos.NewFailPods, &chaos.Props{
+				LabelsSelector: &map[string]*string{minorityGroup: ptr.Ptr("1")},
+				DurationStr:    faultDuration.String(),
+			}, faultDuration)
+		}
+
+		var gethNetworksLabels []string
+		for _, net := range l.TestCfg.SelectedNetworks {
+			gethNetworksLabels = append(gethNetworksLabels, actions.GethLabel(net.Name))
+		}
+		testEnv.ChaosLabelForAllGeth(l.TestCfg.Test, gethNetworksLabels)
+		available[ChaosMonkeyRPCPartition] = func(_ *rand.Rand, faultDuration time.Duration) (string, error) {
+			return minorityGroup, l.runChaosAndWait(chaos.NewNetworkPartition, &chaos.Props{
+				FromLabels:  &map[string]*string{"geth": ptr.Ptr(actions.ChaosGroupCCIPGeth)},
+				ToLabels:    &map[string]*string{minorityGroup: ptr.Ptr("1")},
+				DurationStr: faultDuration.String(),
+			}, faultDuration)
+		}
+	}
+	// the default wallet might not be a price updater in an existing deployment
+	if !pointer.GetBool(l.TestCfg.TestGroupInput.ExistingDeployment) {
+		factor := int64(defaultGasSpikeFactor)
+		if cfg.GasSpikeFactor != nil {
+			factor = *cfg.GasSpikeFactor
+		}
+		available[ChaosMonkeyGasSpike] = func(rnd *rand.Rand, faultDuration time.Duration) (string, error) {
+			lanes := l.TestSetupArgs.ReadLanes()
+			lane := lanes[rnd.Intn(len(lanes))].ForwardLane
+			return fmt.Sprintf("%s -> %s", lane.SourceNetworkName, lane.DestNetworkName), l.spikeGasPrice(lane, factor, faultDuration)
+		}
+	}
+
+	requested := cfg.Actions
+	if len(requested) == 0 {
+		requested = []string{ChaosMonkeyKillNodes, ChaosMonkeyRPCPartition, ChaosMonkeyGasSpike}
+	}
+	var names []string
+	for _, name := range requested {
+		if _, ok := available[name]; !ok {
+			l.lggr.Warn().Str("Action", name).Msg("chaos monkey action is unknown or not safe in this environment, skipping")
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, available
+}
+
+func (l *LoadArgs) runChaosAndWait(chaosFunc chaos.ManifestFunc, props *chaos.Props, faultDuration time.Duration) error {
+	testEnv := l.TestSetupArgs.Env
+	chaosId, err := testEnv.K8Env.Chaos.Run(chaosFunc(testEnv.K8Env.Cfg.Namespace, props))
+	if err != nil {
+		return err
+	}
+	if chaosId == "" {
+		return nil
+	}
+	err = testEnv.K8Env.Chaos.WaitForAllRecovered(chaosId, faultDuration+1*time.Minute)
+	if err != nil {
+		return err
+	}
+	return testEnv.K8Env.Chaos.Stop(chaosId)
+}
+
+// spikeGasPrice multiplies the dest chain gas price in source price registry by factor and restores it after faultDuration
+func (l *LoadArgs) spikeGasPrice(lane *actions.CCIPLane, factor int64, faultDuration time.Duration) error {
+	src := lane.Source
+	current, err := src.Common.PriceRegistry.Instance.GetDestinationChainGasPrice(nil, src.DestChainSelector)
+	if err != nil {
+		return fmt.Errorf("failed to get dest chain gas price: %w", err)
+	}
+	updateGasPrice := func(price *big.Int) error {
+		err := src.Common.PriceRegistry.UpdatePrices(nil, []contracts.InternalGasPriceUpdate{
+			{
+				DestChainSelector: src.DestChainSelector,
+				UsdPerUnitGas:     price,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return src.Common.ChainClient.WaitForEvents()
+	}
+	if err := updateGasPrice(new(big.Int).Mul(current.Value, big.NewInt(factor))); err != nil {
+		return fmt.Errorf("failed to spike dest chain gas price: %w", err)
+	}
+	time.Sleep(faultDuration)
+	if err := updateGasPrice(current.Value); err != nil {
+		return fmt.Errorf("failed to restore dest chain gas price: %w", err)
+	}
+	return nil
+}
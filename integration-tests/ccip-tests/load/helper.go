@@ -21,6 +21,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 )
@@ -61,8 +62,25 @@ func (l *LoadArgs) SetReportParams() {
 	require.NoError(l.t, err, "failed to set grafana query params")
 }
 
+// mirrorTraffic replaces the schedule and the message profile of the load with the messages sent on the production
+// lane, if traffic mirroring is set
+func (l *LoadArgs) mirrorTraffic() {
+	loadProfile := l.TestCfg.TestGroupInput.LoadProfile
+	if loadProfile.TrafficMirror == nil {
+		return
+	}
+	msgs, err := actions.ImportTraffic(l.Ctx, l.lggr, loadProfile.TrafficMirror)
+	require.NoError(l.t, err, "failed to import production traffic")
+	maxTokens := 0
+	if l.TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+		maxTokens = pointer.GetInt(l.TestCfg.TestGroupInput.MsgDetails.NoOfTokens)
+	}
+	require.NoError(l.t, loadProfile.ApplyMirroredTraffic(msgs, maxTokens), "failed to mirror production traffic")
+}
+
 func (l *LoadArgs) Setup() {
 	lggr := l.lggr
+	l.mirrorTraffic()
 	existing := pointer.GetBool(l.TestCfg.TestGroupInput.ExistingDeployment)
 	envName := "load-ccip"
 	if existing {
@@ -195,6 +213,14 @@ func (l *LoadArgs) ValidateCurseFollowedByUncurse() {
 	for _, lane := range lanes {
 		require.NoError(l.t, lane.Source.Common.UnvoteToCurseARM(), "error to unvote in cursing arm")
 	}
+	// the blessing in the curse windows is excluded from the bless latency
+	for network, cursedAt := range curseTimeStamps {
+		l.TestSetupArgs.Reporter.AddCurseWindow(testreporters.CurseWindow{
+			Network: network,
+			Start:   cursedAt,
+			End:     time.Now().UTC(),
+		})
+	}
 	l.lggr.Info().Msg("Curse is lifted on all lanes")
 	// lift the pause on load test
 	l.pauseLoad.Store(false)
@@ -219,12 +245,12 @@ func (l *LoadArgs) ValidateCurseFollowedByUncurse() {
 		errGrp.Go(func() error {
 			lane.Logger.Info().Msg("Validating no CommitReportAccepted event is received for 29 minutes")
 			// we allow additional 1 minute after curse timestamp for curse to be visible by plugin
-			return lane.Dest.AssertNoReportAcceptedEventReceived(lane.Logger, 25*time.Minute, curseTimeStamp.Add(1*time.Minute))
+			return lane.Dest.AssertNoReportAcceptedEventReceived(lane.Context, lane.Logger, 25*time.Minute, curseTimeStamp.Add(1*time.Minute))
 		})
 		errGrp.Go(func() error {
 			lane.Logger.Info().Msg("Validating no ExecutionStateChanged event is received for 25 minutes")
 			// we allow additional 1 minute after curse timestamp for curse to be visible by plugin
-			return lane.Dest.AssertNoExecutionStateChangedEventReceived(lane.Logger, 25*time.Minute, curseTimeStamp.Add(1*time.Minute))
+			return lane.Dest.AssertNoExecutionStateChangedEventReceived(lane.Context, lane.Logger, 25*time.Minute, curseTimeStamp.Add(1*time.Minute))
 		})
 	}
 	l.lggr.Info().Msg("waiting for no commit/execution validation")
@@ -346,6 +372,7 @@ func (l *LoadArgs) Wait() {
 	err := l.RunnerWg.Wait()
 	require.NoError(l.t, err, "load run is failed")
 	l.lggr.Info().Msg("Load finished on all lanes")
+	require.NoError(l.t, l.TestSetupArgs.Reporter.AssertBlessLatencySLO(), "bless latency SLO is not met")
 }
 
 func (l *LoadArgs) ApplyChaos() {
@@ -0,0 +1,93 @@
+package load
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testreporters"
+	"github.com/smartcontractkit/chainlink/integration-tests/docker/test_env"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	coretoml "github.com/smartcontractkit/chainlink/v2/core/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+)
+
+// NodeConfigReload is the name of the reloads in the chaos timeline of the test report
+const NodeConfigReload = "node-config-reload"
+
+// ReloadNodeConfigs restarts the CL nodes one at a time with the config from the NodeConfigReload of the load profile.
+// The next node is restarted only after the previous one is back up and the configured interval has passed, so that
+// at most one node of each DON is down at any time. This is within the fault tolerance of the DONs only if they
+// tolerate at least one faulty node, any request which misses its phase timeout fails the load.
+// Every reload is added to the chaos timeline of the test report.
+// Only the nodes of a local docker cluster can be restarted with a new config.
+func (l *LoadArgs) ReloadNodeConfigs() {
+	cfg := l.TestCfg.TestGroupInput.LoadProfile.NodeConfigReload
+	require.NotNil(l.t, cfg, "node config reload should be set in load profile")
+	testEnv := l.TestSetupArgs.Env
+	if testEnv == nil || testEnv.LocalCluster == nil || testEnv.LocalCluster.ClCluster == nil {
+		l.lggr.Warn().Msg("node configs can only be reloaded on local docker cluster, skipping node config reload")
+		return
+	}
+	require.GreaterOrEqual(l.t, testEnv.NumOfAllowedFaultyCommit, 1, "commit DON should tolerate a faulty node to reload node configs one at a time")
+	require.GreaterOrEqual(l.t, testEnv.NumOfAllowedFaultyExec, 1, "exec DON should tolerate a faulty node to reload node configs one at a time")
+
+	for _, clNode := range testEnv.LocalCluster.ClCluster.Nodes {
+		select {
+		case <-l.Ctx.Done():
+			return
+		default:
+		}
+		event := testreporters.ChaosEvent{
+			Name:   NodeConfigReload,
+			Target: clNode.ContainerName,
+			Start:  time.Now().UTC(),
+		}
+		err := reloadNodeConfig(clNode, cfg)
+		event.End = time.Now().UTC()
+		if err != nil {
+			event.Error = err.Error()
+		}
+		l.TestSetupArgs.Reporter.AddChaosEvent(event)
+		require.NoError(l.t, err, "failed to reload config of node %s", clNode.ContainerName)
+		l.lggr.Info().
+			Str("Node", clNode.ContainerName).
+			Str("Wait", cfg.Interval.Duration().String()).
+			Msg("Node config reloaded, waiting before reloading the next node")
+		time.Sleep(cfg.Interval.Duration())
+	}
+}
+
+// reloadNodeConfig restarts the node with its config updated with cfg, the db of the node is reused
+func reloadNodeConfig(clNode *test_env.ClNode, cfg *testconfig.NodeConfigReload) error {
+	if clNode.NodeConfig == nil {
+		return fmt.Errorf("config of node %s is not known", clNode.ContainerName)
+	}
+	if err := applyNodeConfigReload(clNode.NodeConfig, cfg); err != nil {
+		return err
+	}
+	return clNode.Restart(clNode.NodeConfig)
+}
+
+// applyNodeConfigReload updates the log level and the max gas price of every evm chain in nodeConfig
+func applyNodeConfigReload(nodeConfig *chainlink.Config, cfg *testconfig.NodeConfigReload) error {
+	if cfg.LogLevel != nil {
+		level := new(coretoml.LogLevel)
+		if err := level.UnmarshalText([]byte(*cfg.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level %s: %w", *cfg.LogLevel, err)
+		}
+		nodeConfig.Log.Level = level
+	}
+	if cfg.MaxGasPrice != nil {
+		for _, chain := range nodeConfig.EVM {
+			price := new(assets.Wei)
+			if err := price.UnmarshalText([]byte(*cfg.MaxGasPrice)); err != nil {
+				return fmt.Errorf("invalid max gas price %s: %w", *cfg.MaxGasPrice, err)
+			}
+			chain.GasEstimator.PriceMax = price
+		}
+	}
+	return nil
+}
@@ -1,12 +1,17 @@
 package smoke
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-testing-framework/logging"
@@ -661,8 +666,9 @@ func TestSmokeCCIPSelfServeRateLimitOffRamp(t *testing.T) {
 				Msg("Limited token transfer failed on destination chain (a good thing in this context)")
 
 			// Manually execute the rate limited token transfer and expect a similar error
-			tc.lane.Logger.Info().Str("Wait Time", actions.DefaultPermissionlessExecThreshold.String()).Msg("Waiting for Exec Threshold to Expire")
-			time.Sleep(actions.DefaultPermissionlessExecThreshold) // Give time to exit the window
+			execThreshold := tc.lane.Dest.PermissionlessExecThreshold()
+			tc.lane.Logger.Info().Str("Wait Time", execThreshold.String()).Msg("Waiting for Exec Threshold to Expire")
+			time.Sleep(execThreshold) // Give time to exit the window
 			// See above comment on timeout
 			err = tc.lane.ExecuteManually(actions.WithConfirmationTimeout(time.Minute))
 			require.Error(t, err, "There should be errors executing manually at this point")
@@ -738,6 +744,81 @@ func TestSmokeCCIPMulticall(t *testing.T) {
 	}
 }
 
+// TestSmokeCCIPCommitBatching sends a single request and waits for it to be committed, then sends a burst of
+// CommitBatchBurstSize requests in one transaction and asserts that the single request is committed alone and the
+// burst in as few commit reports as the max commit interval size allows.
+func TestSmokeCCIPCommitBatching(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	burstSize := 10
+	if TestCfg.TestGroupInput.CommitBatchBurstSize != nil {
+		burstSize = *TestCfg.TestGroupInput.CommitBatchBurstSize
+	}
+	// the burst is sent with multicall for all of its requests to be finalized at once
+	TestCfg.TestGroupInput.MulticallInOneTx = ptr.Ptr(true)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		if TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+			setUpOutput.Balance.Verify(t)
+		}
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("CCIP commit batching from network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+		if lane.ReverseLane != nil {
+			tests = append(tests, testDefinition{
+				testName: fmt.Sprintf("CCIP commit batching from network %s to network %s",
+					lane.ReverseLane.SourceNetworkName, lane.ReverseLane.DestNetworkName),
+				lane: lane.ReverseLane,
+			})
+		}
+	}
+
+	log.Info().Int("Total Lanes", len(tests)).Int("Burst Size", burstSize).Msg("Starting CCIP commit batching test")
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			tc.lane.Test = t
+			require.NotNil(t, tc.lane.Reports, "commit intervals are recorded in the lane report")
+			log.Info().
+				Str("Source", tc.lane.SourceNetworkName).
+				Str("Destination", tc.lane.DestNetworkName).
+				Msgf("Starting lane %s -> %s", tc.lane.SourceNetworkName, tc.lane.DestNetworkName)
+
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			single := tc.lane.SentSeqNums()
+			require.Len(t, single, 1, "single request should be validated")
+
+			tc.lane.RecordStateBeforeTransfer()
+			err = tc.lane.Multicall(burstSize, tc.lane.Source.Common.MulticallContract)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			burst := tc.lane.SentSeqNums()
+			require.Len(t, burst, burstSize, "burst requests should be validated")
+
+			intervals := tc.lane.Reports.CommitIntervals()
+			log.Info().Interface("Intervals", intervals).Msg("Commit reports accepted")
+			require.NoError(t, actions.AssertCommitBatching(intervals, single[0], burst, actions.MaxCommitIntervalSize))
+		})
+	}
+}
+
 func TestSmokeCCIPManuallyExecuteAfterExecutionFailingDueToInsufficientGas(t *testing.T) {
 	t.Parallel()
 	log := logging.GetTestLogger(t)
@@ -798,3 +879,722 @@ func TestSmokeCCIPManuallyExecuteAfterExecutionFailingDueToInsufficientGas(t *te
 		})
 	}
 }
+
+// TestSmokeCCIPUSDCAttesterRotation rotates the USDC attesters of the in-process attestation service while messages
+// are in-flight and asserts that messages sent before and after the rotation are executed with attestations signed by
+// the enabled attesters.
+func TestSmokeCCIPUSDCAttesterRotation(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.True(t, pointer.GetBool(TestCfg.TestGroupInput.USDCMockDeployment), "Test config should have USDC mock deployment")
+	require.NotNil(t, TestCfg.TestGroupInput.USDCAttestation, "Test config should have USDC attestation service config")
+	require.True(t, TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer(), "Test config should have token transfer message type")
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		require.NoError(t, setUpOutput.TearDown())
+	})
+	attestationSvc := setUpOutput.Env.USDCAttestationService
+	require.NotNil(t, attestationSvc, "USDC attestation service should be running")
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("Network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(fmt.Sprintf("%s - USDC Attester Rotation", tc.testName), func(t *testing.T) {
+			tc.lane.Test = t
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+
+			// rotate one of the attesters while the message is in-flight
+			oldAttester := attestationSvc.Attesters()[0]
+			newAttester, err := attestationSvc.RotateAttester(oldAttester)
+			require.NoError(t, err)
+			tc.lane.Logger.Info().
+				Str("Old Attester", oldAttester.Hex()).
+				Str("New Attester", newAttester.Hex()).
+				Msg("Rotated USDC attester with message in-flight")
+
+			err = tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+
+			// every observed message should be attested by the currently enabled attesters only
+			for _, msg := range attestationSvc.Messages() {
+				attestation, found, err := attestationSvc.Attestation(msg.Hash())
+				require.NoError(t, err)
+				require.True(t, found)
+				require.NoError(t, actions.VerifyUSDCAttestation(msg.Raw, attestation, attestationSvc.Attesters(), attestationSvc.SignatureThreshold()))
+				require.Error(t, actions.VerifyUSDCAttestation(msg.Raw, attestation, []common.Address{oldAttester}, attestationSvc.SignatureThreshold()),
+					"attestation should not be signed by the disabled attester")
+			}
+		})
+	}
+}
+
+// TestSmokeCCIPPoolAllowList verifies that a message with tokens from a sender which is not in the allowlist of the
+// source token pool is rejected and is never executed on destination, and that the sender can transfer tokens
+// again once it's added back to the allowlist.
+// The OffRamp doesn't support allowlisting of source senders, the allowlist is enforced by the token pools on source.
+func TestSmokeCCIPPoolAllowList(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.True(t, TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer(), "Test config should have token transfer message type")
+	TestCfg.TestGroupInput.TokenConfig.WithAllowList = ptr.Ptr(true)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		setUpOutput.Balance.Verify(t)
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("Network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(fmt.Sprintf("%s - Pool AllowList", tc.testName), func(t *testing.T) {
+			tc.lane.Test = t
+			src := tc.lane.Source
+			var pool *contracts.TokenPool
+			for _, p := range src.Common.BridgeTokenPools {
+				if !p.IsLockRelease() {
+					continue
+				}
+				enabled, err := p.IsAllowListEnabled()
+				require.NoError(t, err)
+				if enabled {
+					pool = p
+					break
+				}
+			}
+			require.NotNil(t, pool, "no lock release pool with allowlist enabled found on source")
+			sender := common.HexToAddress(src.Common.ChainClient.GetDefaultWallet().Address())
+			allowList, err := pool.GetAllowList()
+			require.NoError(t, err)
+			require.Contains(t, allowList, sender, "sender should be in the allowlist")
+
+			// allowed sender
+			tc.lane.RecordStateBeforeTransfer()
+			err = tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			tc.lane.Source.UpdateBalance(int64(tc.lane.NumberOfReq), tc.lane.TotalFee, tc.lane.Balance)
+			tc.lane.Dest.UpdateBalance(tc.lane.Source.TransferAmount, int64(tc.lane.NumberOfReq), tc.lane.Balance)
+
+			// non-allowed sender, the request should be rejected by the pool
+			require.NoError(t, pool.UpdateAllowList([]common.Address{sender}, nil))
+			require.NoError(t, src.Common.ChainClient.WaitForEvents())
+			require.NoError(t, tc.lane.SendRequestExpectingRevert(gasLimit, "SenderNotAllowed", lock_release_token_pool.LockReleaseTokenPoolABI))
+
+			// sender added back to the allowlist
+			require.NoError(t, pool.UpdateAllowList(nil, []common.Address{sender}))
+			require.NoError(t, src.Common.ChainClient.WaitForEvents())
+			tc.lane.RecordStateBeforeTransfer()
+			err = tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			tc.lane.Source.UpdateBalance(int64(tc.lane.NumberOfReq), tc.lane.TotalFee, tc.lane.Balance)
+			tc.lane.Dest.UpdateBalance(tc.lane.Source.TransferAmount, int64(tc.lane.NumberOfReq), tc.lane.Balance)
+		})
+	}
+}
+
+// TestSmokeCCIPUnsupportedDestination covers onboarding of a new destination. Fee quotes and requests to the
+// destination revert till the onRamp for the destination is set in the source router, after that the same message is
+// delivered.
+func TestSmokeCCIPUnsupportedDestination(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	if pointer.GetBool(TestCfg.TestGroupInput.ExistingDeployment) {
+		t.Skip("unsupported destination test disables the lanes in the router, it's not run on existing deployments")
+	}
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		setUpOutput.Balance.Verify(t)
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("Network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(fmt.Sprintf("%s - Unsupported Destination", tc.testName), func(t *testing.T) {
+			tc.lane.Test = t
+			src := tc.lane.Source
+
+			// destination is not configured in the router yet
+			require.NoError(t, src.Common.Router.SetOnRamp(src.DestChainSelector, common.Address{}))
+			require.NoError(t, src.Common.ChainClient.WaitForEvents())
+			require.NoError(t, tc.lane.SendRequestToUnsupportedDestination(gasLimit))
+
+			// destination is onboarded, the same message should be delivered
+			require.NoError(t, src.Common.Router.SetOnRamp(src.DestChainSelector, src.OnRamp.EthAddress))
+			require.NoError(t, src.Common.ChainClient.WaitForEvents())
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			tc.lane.Source.UpdateBalance(int64(tc.lane.NumberOfReq), tc.lane.TotalFee, tc.lane.Balance)
+			tc.lane.Dest.UpdateBalance(tc.lane.Source.TransferAmount, int64(tc.lane.NumberOfReq), tc.lane.Balance)
+		})
+	}
+}
+
+// TestSmokeCCIPRouterUpgrade replaces the Router of every chain with a newly deployed one, migrates the ramps and the
+// token pools to it and re-points the senders to it, as per the router migration runbook. The requests sent through the
+// old Routers are asserted to revert with UnsupportedDestinationChain while the new ones serve the traffic.
+func TestSmokeCCIPRouterUpgrade(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	if pointer.GetBool(TestCfg.TestGroupInput.ExistingDeployment) {
+		t.Skip("router upgrade test deploys new routers, it's not run on existing deployments")
+	}
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	// the OffRamps are pointed to the new routers with their OCR2 config, which the mock DON sets again
+	TestCfg.TestGroupInput.MockDON = ptr.Ptr(true)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		if TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+			setUpOutput.Balance.Verify(t)
+		}
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var lanes []*actions.CCIPLane
+	for _, lane := range setUpOutput.ReadLanes() {
+		lanes = append(lanes, lane.ForwardLane)
+		if lane.ReverseLane != nil {
+			lanes = append(lanes, lane.ReverseLane)
+		}
+	}
+	sendAndValidate := func(lane *actions.CCIPLane) {
+		lane.RecordStateBeforeTransfer()
+		require.NoError(t, lane.SendRequests(1, gasLimit))
+		lane.ValidateRequests()
+		lane.Source.UpdateBalance(int64(lane.NumberOfReq), lane.TotalFee, lane.Balance)
+		lane.Dest.UpdateBalance(lane.Source.TransferAmount, int64(lane.NumberOfReq), lane.Balance)
+	}
+
+	commons := make(map[*actions.CCIPCommon]*contracts.Router)
+	for _, lane := range lanes {
+		lane.Test = t
+		sendAndValidate(lane)
+		commons[lane.Source.Common] = nil
+		commons[lane.Dest.Common] = nil
+	}
+	// the routers are shared by all the lanes of a network, they are all upgraded before the lanes are migrated
+	for ccipCommon := range commons {
+		oldRouter, err := ccipCommon.UpgradeRouter()
+		require.NoError(t, err)
+		commons[ccipCommon] = oldRouter
+	}
+	for _, lane := range lanes {
+		require.NoError(t, lane.MigrateRouter(commons[lane.Source.Common], commons[lane.Dest.Common]))
+	}
+	for ccipCommon, oldRouter := range commons {
+		require.NoError(t, ccipCommon.MovePoolsFrom(oldRouter))
+	}
+	for _, lane := range lanes {
+		sendAndValidate(lane)
+		// a client still on the old router gets its requests rejected
+		newRouter := lane.Source.Common.Router
+		lane.Source.Common.Router = commons[lane.Source.Common]
+		err := lane.SendRequestToUnsupportedDestination(gasLimit)
+		lane.Source.Common.Router = newRouter
+		require.NoError(t, err)
+	}
+}
+
+// TestSmokeCCIPMockDON sends requests through the lanes with the commit and exec DONs replaced by the in-process mock
+// DON, which tests the full lifecycle of the messages on the contracts without running CL nodes
+func TestSmokeCCIPMockDON(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	TestCfg.TestGroupInput.MockDON = ptr.Ptr(true)
+	require.NoError(t, TestCfg.TestGroupInput.Validate(), "test config should be valid in mock DON mode")
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		if TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+			setUpOutput.Balance.Verify(t)
+		}
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("CCIP message transfer with mock DON from network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+		if lane.ReverseLane != nil {
+			tests = append(tests, testDefinition{
+				testName: fmt.Sprintf("CCIP message transfer with mock DON from network %s to network %s",
+					lane.ReverseLane.SourceNetworkName, lane.ReverseLane.DestNetworkName),
+				lane: lane.ReverseLane,
+			})
+		}
+	}
+
+	log.Info().Int("Total Lanes", len(tests)).Msg("Starting CCIP mock DON test")
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			tc.lane.Test = t
+			require.NotNil(t, tc.lane.MockDON, "lane should be set up with the mock DON")
+			log.Info().
+				Str("Source", tc.lane.SourceNetworkName).
+				Str("Destination", tc.lane.DestNetworkName).
+				Msgf("Starting lane %s -> %s", tc.lane.SourceNetworkName, tc.lane.DestNetworkName)
+
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			tc.lane.Source.UpdateBalance(int64(tc.lane.NumberOfReq), tc.lane.TotalFee, tc.lane.Balance)
+			tc.lane.Dest.UpdateBalance(tc.lane.Source.TransferAmount, int64(tc.lane.NumberOfReq), tc.lane.Balance)
+		})
+	}
+}
+
+// TestSmokeCCIPTokenPoolSwitchInFlight switches the dest pool of a bridge token while requests transferring the token
+// are in flight, and validates that every request is executed with its tokens released by the pool set at the time of
+// its execution, and that the receiver gets all the tokens sent, i.e. none are left stranded by the switch.
+func TestSmokeCCIPTokenPoolSwitchInFlight(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.True(t, TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer(), "Test config should have token transfer message type")
+	if pointer.GetBool(TestCfg.TestGroupInput.ExistingDeployment) || pointer.GetBool(TestCfg.TestGroupInput.USDCMockDeployment) {
+		t.Skip("token pool switch test deploys new lock release pools, it's not run on existing or USDC deployments")
+	}
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		// the balance sheet expects the tokens to be released by the pools set before the switch, the released
+		// amounts are validated per pool instead
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("Network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+	}
+
+	const noOfRequests = 3
+	for _, test := range tests {
+		tc := test
+		t.Run(fmt.Sprintf("%s - Token Pool Switch In Flight", tc.testName), func(t *testing.T) {
+			tc.lane.Test = t
+			dest := tc.lane.Dest
+			require.NotEmpty(t, dest.Common.BridgeTokens, "no bridge token found on dest")
+			token := dest.Common.BridgeTokens[0]
+			receiverBalanceBefore, err := token.BalanceOf(tc.lane.Context, dest.ReceiverDapp.Address())
+			require.NoError(t, err)
+
+			tc.lane.RecordStateBeforeTransfer()
+			require.NoError(t, tc.lane.SendRequests(noOfRequests, gasLimit))
+			// the requests are sent but not committed or executed yet
+			poolSwitch, err := tc.lane.SwitchTokenPool(0)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+
+			transfers, err := tc.lane.TransfersServedByPools(token.ContractAddress, []common.Address{poolSwitch.OldPool, poolSwitch.NewPool})
+			require.NoError(t, err)
+			require.Len(t, transfers, tc.lane.NumberOfReq, "every request should transfer the token")
+			expectedTotal := new(big.Int).Mul(big.NewInt(int64(tc.lane.NumberOfReq)), tc.lane.Source.TransferAmount[0])
+			require.NoError(t, actions.AssertServedByPools(transfers, poolSwitch, expectedTotal))
+
+			receiverBalanceAfter, err := token.BalanceOf(tc.lane.Context, dest.ReceiverDapp.Address())
+			require.NoError(t, err)
+			require.Equal(t, expectedTotal, new(big.Int).Sub(receiverBalanceAfter, receiverBalanceBefore),
+				"receiver should get all the tokens sent across the pool switch")
+		})
+	}
+}
+
+// TestCCIPEnvironmentDoctor checks the preconditions of the environment in the smoke config without deploying anything,
+// and prints the pass/fail checklist
+func TestCCIPEnvironmentDoctor(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.NoError(t, TestCfg.Doctor(context.Background(), log).Print(log), "environment doctor checks should pass")
+}
+
+// TestSmokeCCIPGasGolden compares the gas used by a single ccipSend, its commit report and exec, along with the commit
+// reports and exec batches of a burst of requests, against the gas recorded in the golden file. With Update set in
+// GasGolden, the gas used in the run is recorded into the golden file instead.
+func TestSmokeCCIPGasGolden(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	burstSize := 5
+	goldenCfg := TestCfg.TestGroupInput.GasGolden
+	if goldenCfg == nil {
+		goldenCfg = &testconfig.GasGoldenConfig{File: ptr.Ptr("./testdata/gas_golden.json")}
+	}
+	tolerance := 0.05
+	if goldenCfg.Tolerance != nil {
+		tolerance = *goldenCfg.Tolerance
+	}
+	update := pointer.GetBool(goldenCfg.Update)
+	golden, err := actions.ReadGasGolden(*goldenCfg.File)
+	switch {
+	case os.IsNotExist(err) && update:
+		golden = make(actions.GasGolden)
+	case os.IsNotExist(err):
+		t.Skipf("no gas golden file at %s, run with Update set in GasGolden to record one", *goldenCfg.File)
+	default:
+		require.NoError(t, err)
+	}
+	// the burst is sent with multicall for all of its requests to be committed and executed in batches
+	TestCfg.TestGroupInput.MulticallInOneTx = ptr.Ptr(true)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	var (
+		recordedMu sync.Mutex
+		recorded   []actions.GasSample
+	)
+	t.Cleanup(func() {
+		if update && len(recorded) > 0 {
+			golden.Merge(actions.NewGasGolden(recorded))
+			require.NoError(t, golden.Write(*goldenCfg.File))
+			log.Info().Str("File", *goldenCfg.File).Interface("Golden", golden).Msg("Gas golden file updated")
+		}
+		if TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+			setUpOutput.Balance.Verify(t)
+		}
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("CCIP gas usage from network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+		if lane.ReverseLane != nil {
+			tests = append(tests, testDefinition{
+				testName: fmt.Sprintf("CCIP gas usage from network %s to network %s",
+					lane.ReverseLane.SourceNetworkName, lane.ReverseLane.DestNetworkName),
+				lane: lane.ReverseLane,
+			})
+		}
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			tc.lane.Test = t
+			log.Info().
+				Str("Source", tc.lane.SourceNetworkName).
+				Str("Destination", tc.lane.DestNetworkName).
+				Msgf("Starting lane %s -> %s", tc.lane.SourceNetworkName, tc.lane.DestNetworkName)
+
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(1, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			samples := tc.lane.GasSamples()
+
+			tc.lane.RecordStateBeforeTransfer()
+			err = tc.lane.Multicall(burstSize, tc.lane.Source.Common.MulticallContract)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			samples = append(samples, tc.lane.GasSamples()...)
+			log.Info().Interface("Samples", samples).Msg("Gas used by the lane")
+
+			if update {
+				recordedMu.Lock()
+				recorded = append(recorded, samples...)
+				recordedMu.Unlock()
+				return
+			}
+			unmatched, err := golden.Compare(samples, tolerance)
+			for _, s := range unmatched {
+				log.Warn().Str("Operation", s.Operation).Uint64("Gas Used", s.GasUsed).Msg("Operation not recorded in gas golden file")
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestSmokeCCIPFeeTokenDecimals runs the lanes with a fee token with 6 decimals, 18 decimals being the only ones LINK
+// is deployed with. It verifies the fee quoted in the fee token is priced the same as in the wrapped native, the fees
+// are collected for the nops and paid out in full, and the balances of the fee token add up after the transfers.
+func TestSmokeCCIPFeeTokenDecimals(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	if pointer.GetBool(TestCfg.TestGroupInput.ExistingDeployment) {
+		t.Skip("fee token decimals test deploys its own fee token, it's not run on existing deployments")
+	}
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	if TestCfg.TestGroupInput.TokenConfig.FeeTokenDecimals == nil {
+		TestCfg.TestGroupInput.TokenConfig.FeeTokenDecimals = ptr.Ptr(uint8(6))
+	}
+	// the fee token of the earlier runs in the lane config would be reused otherwise
+	TestCfg.TestGroupInput.ReuseContracts = ptr.Ptr(false)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		setUpOutput.Balance.Verify(t)
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("CCIP fee token decimals from network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+		if lane.ReverseLane != nil {
+			tests = append(tests, testDefinition{
+				testName: fmt.Sprintf("CCIP fee token decimals from network %s to network %s",
+					lane.ReverseLane.SourceNetworkName, lane.ReverseLane.DestNetworkName),
+				lane: lane.ReverseLane,
+			})
+		}
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			tc.lane.Test = t
+			log.Info().
+				Str("Source", tc.lane.SourceNetworkName).
+				Str("Destination", tc.lane.DestNetworkName).
+				Msgf("Starting lane %s -> %s", tc.lane.SourceNetworkName, tc.lane.DestNetworkName)
+
+			decimals, err := tc.lane.Source.Common.FeeToken.Decimals()
+			require.NoError(t, err)
+			require.Equal(t, *TestCfg.TestGroupInput.TokenConfig.FeeTokenDecimals, decimals, "fee token decimals")
+			require.NoError(t, tc.lane.ValidateFeeQuote(gasLimit), "fee quoted in fee token")
+
+			nopFeesBefore, err := tc.lane.Source.OnRamp.NopFeesJuels()
+			require.NoError(t, err)
+			tc.lane.RecordStateBeforeTransfer()
+			err = tc.lane.SendRequests(2, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+			require.NoError(t, tc.lane.ValidateNopPayout(nopFeesBefore), "nop payout in fee token")
+		})
+	}
+}
+
+// TestSmokeCCIPBurnMintPools sends token transfers on lanes with burn mint pools for all the bridge tokens, the balances
+// verify the tokens are burnt on the source chain and minted on the dest chain instead of being locked and released
+func TestSmokeCCIPBurnMintPools(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	if pointer.GetBool(TestCfg.TestGroupInput.ExistingDeployment) {
+		t.Skip("burn mint pools test deploys its own pools, it's not run on existing deployments")
+	}
+	if !TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+		t.Skip("burn mint pools test needs token transfers")
+	}
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	if len(TestCfg.TestGroupInput.TokenConfig.PoolTypes) == 0 {
+		noOfTokens := pointer.GetInt(TestCfg.TestGroupInput.TokenConfig.NoOfTokensPerChain)
+		for i := 0; i < noOfTokens; i++ {
+			poolType := testconfig.BurnMintPoolType
+			if i == 0 && pointer.GetBool(TestCfg.TestGroupInput.USDCMockDeployment) {
+				poolType = testconfig.USDCPoolType
+			}
+			TestCfg.TestGroupInput.TokenConfig.PoolTypes = append(TestCfg.TestGroupInput.TokenConfig.PoolTypes, poolType)
+		}
+	}
+	// the lock release pools of the earlier runs in the lane config would be reused otherwise
+	TestCfg.TestGroupInput.ReuseContracts = ptr.Ptr(false)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		setUpOutput.Balance.Verify(t)
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	var tests []testDefinition
+	for _, lane := range setUpOutput.Lanes {
+		tests = append(tests, testDefinition{
+			testName: fmt.Sprintf("CCIP burn mint pools from network %s to network %s",
+				lane.ForwardLane.SourceNetworkName, lane.ForwardLane.DestNetworkName),
+			lane: lane.ForwardLane,
+		})
+		if lane.ReverseLane != nil {
+			tests = append(tests, testDefinition{
+				testName: fmt.Sprintf("CCIP burn mint pools from network %s to network %s",
+					lane.ReverseLane.SourceNetworkName, lane.ReverseLane.DestNetworkName),
+				lane: lane.ReverseLane,
+			})
+		}
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			tc.lane.Test = t
+			log.Info().
+				Str("Source", tc.lane.SourceNetworkName).
+				Str("Destination", tc.lane.DestNetworkName).
+				Msgf("Starting lane %s -> %s", tc.lane.SourceNetworkName, tc.lane.DestNetworkName)
+
+			for i, pool := range tc.lane.Source.Common.BridgeTokenPools {
+				if tc.lane.Source.Common.PoolType(i) == testconfig.BurnMintPoolType {
+					require.True(t, pool.IsBurnMint(), "source pool %s of token %d should be a burn mint pool", pool.Address(), i)
+					require.True(t, tc.lane.Dest.Common.BridgeTokenPools[i].IsBurnMint(), "dest pool of token %d should be a burn mint pool", i)
+				}
+			}
+			tc.lane.RecordStateBeforeTransfer()
+			err := tc.lane.SendRequests(2, gasLimit)
+			require.NoError(t, err)
+			tc.lane.ValidateRequests()
+		})
+	}
+}
+
+// TestSmokeCCIPPriceStaleness moves the time of the source chain of a lane past the staleness threshold of the prices in
+// the source PriceRegistry and validates the fee quotes and the requests revert on stale prices from the exact second
+// the threshold is exceeded. It needs a simulated chain the node of which supports time control, like anvil, as
+// the threshold of the PriceRegistry deployed by the tests is 14 days; it's skipped otherwise.
+func TestSmokeCCIPPriceStaleness(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	for _, network := range TestCfg.SelectedNetworks {
+		if !network.Simulated {
+			t.Skipf("price staleness test moves the time of the chains, network %s is not simulated", network.Name)
+		}
+	}
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		return
+	}
+	t.Cleanup(func() {
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	// the time of a chain moved by a lane would make the prices of the other lanes from the same chain stale as well,
+	// so only a single lane is run
+	lane := setUpOutput.Lanes[0].ForwardLane
+	lane.Test = t
+	rpcURLs := lane.Source.Common.ChainClient.GetNetworkConfig().HTTPURLs
+	require.NotEmpty(t, rpcURLs, "no http url of network %s", lane.SourceNetworkName)
+	clock, err := actions.NewChainClock(lane.Context, rpcURLs[0])
+	require.NoError(t, err)
+	defer clock.Close()
+
+	err = lane.ValidatePriceStaleness(clock, gasLimit)
+	if errors.Is(err, actions.ErrTimeControlUnsupported) {
+		t.Skipf("price staleness test needs time control on network %s: %v", lane.SourceNetworkName, err)
+	}
+	require.NoError(t, err, "price staleness on lane %s -> %s", lane.SourceNetworkName, lane.DestNetworkName)
+
+	// the lane keeps working on the refreshed prices
+	lane.RecordStateBeforeTransfer()
+	require.NoError(t, lane.SendRequests(1, gasLimit))
+	lane.ValidateRequests()
+}
+
+// TestSmokeCCIPQuick sends 2 messages on a single lane. It is the entry point of the quick smoke mode, run it with
+// `make test_quick_smoke_ccip` to validate changes locally within minutes, see tomls/quick-smoke.toml for the setup.
+func TestSmokeCCIPQuick(t *testing.T) {
+	t.Parallel()
+	log := logging.GetTestLogger(t)
+	TestCfg := testsetups.NewCCIPTestConfig(t, log, testconfig.Smoke)
+	require.NotNil(t, TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	gasLimit := big.NewInt(*TestCfg.TestGroupInput.MsgDetails.DestGasLimit)
+	setUpOutput := testsetups.CCIPDefaultTestSetUp(t, log, "smoke-ccip", nil, TestCfg)
+	if len(setUpOutput.Lanes) == 0 {
+		log.Info().Msg("No lanes found")
+		return
+	}
+	t.Cleanup(func() {
+		if TestCfg.TestGroupInput.MsgDetails.IsTokenTransfer() {
+			setUpOutput.Balance.Verify(t)
+		}
+		require.NoError(t, setUpOutput.TearDown())
+	})
+
+	lane := setUpOutput.Lanes[0].ForwardLane
+	lane.Test = t
+	log.Info().
+		Str("Source", lane.SourceNetworkName).
+		Str("Destination", lane.DestNetworkName).
+		Msgf("Starting quick smoke on lane %s -> %s", lane.SourceNetworkName, lane.DestNetworkName)
+	lane.RecordStateBeforeTransfer()
+	require.NoError(t, lane.SendRequests(2, gasLimit))
+	lane.ValidateRequests()
+}
@@ -2,10 +2,15 @@ package testconfig
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/rs/zerolog"
 
@@ -18,6 +23,8 @@ import (
 
 	ccipcontracts "github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
 	"github.com/smartcontractkit/chainlink/integration-tests/contracts"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	coretoml "github.com/smartcontractkit/chainlink/v2/core/config/toml"
 )
 
 const (
@@ -36,6 +43,171 @@ type OffRampConfig struct {
 	RootSnooze     *config.Duration `toml:",omitempty"`
 }
 
+// USDCAttestationConfig configures the in-process attestation service which replaces the canned
+// mock attestation response for new USDC mock deployments
+type USDCAttestationConfig struct {
+	NoOfAttesters *int    `toml:",omitempty"` // number of test attester keys signing every message
+	ListenAddress *string `toml:",omitempty"` // address the service listens on, for example ":8091"
+	ExternalURL   *string `toml:",omitempty"` // url at which the chainlink nodes can reach the service
+}
+
+func (u *USDCAttestationConfig) Validate() error {
+	if pointer.GetInt(u.NoOfAttesters) <= 0 {
+		return fmt.Errorf("number of USDC attesters should be greater than 0")
+	}
+	if pointer.GetString(u.ListenAddress) == "" {
+		return fmt.Errorf("listen address should be set for USDC attestation service")
+	}
+	if pointer.GetString(u.ExternalURL) == "" {
+		return fmt.Errorf("external url should be set for USDC attestation service")
+	}
+	return nil
+}
+
+// FaucetConfig configures the HTTP faucet which tops up the funding wallet of a public testnet when its balance is low.
+// In URL, Body and the header values "{address}" is replaced with the address to fund and the env vars are expanded,
+// so that the api keys don't need to be in the config.
+type FaucetConfig struct {
+	URL        *string           `toml:",omitempty"` // url of the faucet, for example "https://faucet.example.com/api/claim?address={address}"
+	Method     *string           `toml:",omitempty"` // http method of the faucet request, defaults to POST
+	Body       *string           `toml:",omitempty"` // body of the faucet request, for example '{"address":"{address}"}'
+	Headers    map[string]string `toml:",omitempty"` // headers of the faucet request, for example Authorization = "Bearer ${FAUCET_API_KEY}"
+	MinBalance *float64          `toml:",omitempty"` // balance in native units below which the faucet is requested
+	MaxRetries *int              `toml:",omitempty"` // number of retries of a failed or rate-limited faucet request
+	RetryDelay *config.Duration  `toml:",omitempty"` // delay before the first retry, doubled on every retry unless the faucet sets Retry-After
+	FundsWait  *config.Duration  `toml:",omitempty"` // max time to wait for the funds to arrive once the faucet accepted the request
+}
+
+func (f *FaucetConfig) Validate() error {
+	if pointer.GetString(f.URL) == "" {
+		return fmt.Errorf("faucet url should be set")
+	}
+	if f.MinBalance == nil || *f.MinBalance <= 0 {
+		return fmt.Errorf("faucet min balance should be greater than 0")
+	}
+	if f.MaxRetries != nil && *f.MaxRetries < 0 {
+		return fmt.Errorf("faucet max retries should not be negative")
+	}
+	if f.RetryDelay != nil && f.RetryDelay.Duration() <= 0 {
+		return fmt.Errorf("faucet retry delay should be greater than 0")
+	}
+	if f.FundsWait != nil && f.FundsWait.Duration() <= 0 {
+		return fmt.Errorf("faucet funds wait should be greater than 0")
+	}
+	return nil
+}
+
+const (
+	TxTypeLegacy     = "legacy"
+	TxTypeAccessList = "access-list"
+	TxTypeDynamicFee = "dynamic-fee"
+)
+
+// AccessListEntry is an address with its storage keys added to the access list of every tx sent to a network
+type AccessListEntry struct {
+	Address     *string  `toml:",omitempty"`
+	StorageKeys []string `toml:",omitempty"` // 32 byte hex keys
+}
+
+// ArchiveNodeConfig configures the archive node the historical queries of a network are sent to, i.e. the headers,
+// receipts and logs of the blocks older than the pruning window of the primary node and the queries failing on it
+type ArchiveNodeConfig struct {
+	URL           *string `toml:",omitempty"` // http or ws rpc url of the archive node, env vars in it are expanded
+	PruningWindow *uint64 `toml:",omitempty"` // number of latest blocks kept by the primary node, 128 if not set
+}
+
+func (a *ArchiveNodeConfig) Validate() error {
+	if pointer.GetString(a.URL) == "" {
+		return fmt.Errorf("archive node url should be set")
+	}
+	if a.PruningWindow != nil && *a.PruningWindow == 0 {
+		return fmt.Errorf("archive node pruning window should be greater than 0")
+	}
+	return nil
+}
+
+// TxCustomizationConfig configures how the txs sent to a network are rewritten before they are signed, for chains which
+// don't accept the tx shape the bindings build by default. It applies to the contract deployments and the contract calls.
+type TxCustomizationConfig struct {
+	TxType     *string            `toml:",omitempty"` // type the txs are converted to, one of 'legacy', 'access-list' or 'dynamic-fee'
+	AccessList []*AccessListEntry `toml:",omitempty"` // added to the access list of every tx, not allowed with 'legacy'
+}
+
+func (c *TxCustomizationConfig) Validate() error {
+	switch pointer.GetString(c.TxType) {
+	case "", TxTypeAccessList, TxTypeDynamicFee:
+	case TxTypeLegacy:
+		if len(c.AccessList) > 0 {
+			return fmt.Errorf("access list cannot be set for legacy txs")
+		}
+	default:
+		return fmt.Errorf("invalid tx type %s, should be one of %s, %s or %s",
+			*c.TxType, TxTypeLegacy, TxTypeAccessList, TxTypeDynamicFee)
+	}
+	if c.TxType == nil && len(c.AccessList) == 0 {
+		return fmt.Errorf("either tx type or access list should be set for tx customization")
+	}
+	for _, entry := range c.AccessList {
+		if entry == nil || !common.IsHexAddress(pointer.GetString(entry.Address)) {
+			return fmt.Errorf("invalid address in access list")
+		}
+		for _, key := range entry.StorageKeys {
+			if b, err := hexutil.Decode(key); err != nil || len(b) != common.HashLength {
+				return fmt.Errorf("invalid storage key %s for %s in access list", key, *entry.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceLockConfig configures the lock used to coordinate access to the shared resources (funding wallets, OCR configs,
+// lane config files) when multiple independent test processes target the same persistent environment
+type ResourceLockConfig struct {
+	Type        *string `toml:",omitempty"` // "file" or "postgres"
+	Dir         *string `toml:",omitempty"` // directory for the lock files shared by all the processes; required if Type = "file"
+	PostgresURL *string `toml:",omitempty"` // url of the database used for advisory locks; required if Type = "postgres"
+}
+
+func (r *ResourceLockConfig) Target() string {
+	if pointer.GetString(r.Type) == "postgres" {
+		return pointer.GetString(r.PostgresURL)
+	}
+	return pointer.GetString(r.Dir)
+}
+
+func (r *ResourceLockConfig) Validate() error {
+	switch pointer.GetString(r.Type) {
+	case "file":
+		if pointer.GetString(r.Dir) == "" {
+			return fmt.Errorf("lock directory should be set for file resource lock")
+		}
+	case "postgres":
+		if pointer.GetString(r.PostgresURL) == "" {
+			return fmt.Errorf("postgres url should be set for postgres resource lock")
+		}
+	default:
+		return fmt.Errorf("resource lock type should be one of file or postgres, got %s", pointer.GetString(r.Type))
+	}
+	return nil
+}
+
+// DockerComposeConfig brings up the local environment (rpcs, CL nodes with their dbs and mockserver) with plain docker compose
+// instead of the chainlink-testing-framework docker environment. The CL nodes are configured from the NewCLCluster config.
+type DockerComposeConfig struct {
+	Dir             *string `toml:",omitempty"` // directory the compose file and the node configs are generated in; defaults to a temp directory
+	ProjectName     *string `toml:",omitempty"` // compose project name; defaults to ccip-local
+	RPCImage        *string `toml:",omitempty"` // image of the rpc services, must provide anvil; defaults to ghcr.io/foundry-rs/foundry:latest
+	MockserverImage *string `toml:",omitempty"` // defaults to mockserver/mockserver:5.15.0
+	KeepUp          *bool   `toml:",omitempty"` // if true, the services are not brought down at the end of the test
+}
+
+func (d *DockerComposeConfig) Validate() error {
+	if d.ProjectName != nil && *d.ProjectName == "" {
+		return fmt.Errorf("docker compose project name should not be empty")
+	}
+	return nil
+}
+
 type MsgDetails struct {
 	MsgType        *string `toml:",omitempty"`
 	DestGasLimit   *int64  `toml:",omitempty"`
@@ -96,12 +268,22 @@ func (m *MsgDetails) Validate() error {
 	return nil
 }
 
+// pool types of the bridge tokens
+const (
+	LockReleasePoolType = "LockRelease"
+	BurnMintPoolType    = "BurnMint"
+	USDCPoolType        = "USDC"
+)
+
 type TokenConfig struct {
 	NoOfTokensPerChain         *int             `toml:",omitempty"`
 	WithPipeline               *bool            `toml:",omitempty"`
 	TimeoutForPriceUpdate      *config.Duration `toml:",omitempty"`
 	NoOfTokensWithDynamicPrice *int             `toml:",omitempty"`
 	DynamicPriceUpdateInterval *config.Duration `toml:",omitempty"`
+	WithAllowList              *bool            `toml:",omitempty"` // deploy lock release pools with the default wallet as the only allowed sender
+	FeeTokenDecimals           *uint8           `toml:",omitempty"` // deploy a fee token with these decimals in place of LINK, which has 18
+	PoolTypes                  []string         `toml:",omitempty"` // pool type of each bridge token in order, LockRelease, BurnMint or USDC; LockRelease for the tokens not listed
 }
 
 func (tc *TokenConfig) IsDynamicPriceUpdate() bool {
@@ -112,6 +294,26 @@ func (tc *TokenConfig) IsPipelineSpec() bool {
 	return pointer.GetBool(tc.WithPipeline)
 }
 
+func (tc *TokenConfig) IsAllowListEnabled() bool {
+	return pointer.GetBool(tc.WithAllowList)
+}
+
+// FeeTokenDecimalsOrLink returns the decimals of the fee token to be deployed, 0 if LINK is to be deployed
+func (tc *TokenConfig) FeeTokenDecimalsOrLink() uint8 {
+	if tc.FeeTokenDecimals == nil {
+		return 0
+	}
+	return *tc.FeeTokenDecimals
+}
+
+// PoolType returns the pool type of the bridge token at index i
+func (tc *TokenConfig) PoolType(i int) string {
+	if tc == nil || i >= len(tc.PoolTypes) {
+		return LockReleasePoolType
+	}
+	return tc.PoolTypes[i]
+}
+
 func (tc *TokenConfig) Validate() error {
 	if tc == nil {
 		return fmt.Errorf("token config should be set")
@@ -124,6 +326,22 @@ func (tc *TokenConfig) Validate() error {
 			return fmt.Errorf("dynamic price update interval should be set if NoOfTokensWithDynamicPrice is greater than 0")
 		}
 	}
+	if tc.FeeTokenDecimals != nil && (*tc.FeeTokenDecimals == 0 || *tc.FeeTokenDecimals > 18) {
+		return fmt.Errorf("fee token decimals should be between 1 and 18, got %d", *tc.FeeTokenDecimals)
+	}
+	for i, poolType := range tc.PoolTypes {
+		switch poolType {
+		case LockReleasePoolType, BurnMintPoolType:
+		case USDCPoolType:
+			// the usdc token and pool are always the first ones
+			if i != 0 {
+				return fmt.Errorf("only the first bridge token can have a USDC pool, got one for token %d", i)
+			}
+		default:
+			return fmt.Errorf("invalid pool type %s for token %d, should be one of %s, %s or %s",
+				poolType, i, LockReleasePoolType, BurnMintPoolType, USDCPoolType)
+		}
+	}
 	return nil
 }
 
@@ -204,24 +422,35 @@ func (m *MsgProfile) Validate() error {
 	return nil
 }
 
+// LoadProfile configures the load generated on every lane.
+// If Preset is set, the schedule and the message profile of the preset replace the respective fields.
 type LoadProfile struct {
-	MsgProfile                                 *MsgProfile        `toml:",omitempty"`
-	RequestPerUnitTime                         []int64            `toml:",omitempty"`
-	TimeUnit                                   *config.Duration   `toml:",omitempty"`
-	StepDuration                               []*config.Duration `toml:",omitempty"`
-	TestDuration                               *config.Duration   `toml:",omitempty"`
-	WaitBetweenChaosDuringLoad                 *config.Duration   `toml:",omitempty"`
-	SkipRequestIfAnotherRequestTriggeredWithin *config.Duration   `toml:",omitempty"`
-	OptimizeSpace                              *bool              `toml:",omitempty"`
-	FailOnFirstErrorInLoad                     *bool              `toml:",omitempty"`
-	SendMaxDataInEveryMsgCount                 *int64             `toml:",omitempty"`
-	TestRunName                                string             `toml:",omitempty"`
+	Preset                                     *string              `toml:",omitempty"` // one of smoke/soak/stress/spike
+	MsgProfile                                 *MsgProfile          `toml:",omitempty"`
+	RequestPerUnitTime                         []int64              `toml:",omitempty"`
+	TimeUnit                                   *config.Duration     `toml:",omitempty"`
+	StepDuration                               []*config.Duration   `toml:",omitempty"`
+	TestDuration                               *config.Duration     `toml:",omitempty"`
+	WaitBetweenChaosDuringLoad                 *config.Duration     `toml:",omitempty"`
+	SkipRequestIfAnotherRequestTriggeredWithin *config.Duration     `toml:",omitempty"`
+	OptimizeSpace                              *bool                `toml:",omitempty"`
+	FailOnFirstErrorInLoad                     *bool                `toml:",omitempty"`
+	SendMaxDataInEveryMsgCount                 *int64               `toml:",omitempty"`
+	TestRunName                                string               `toml:",omitempty"`
+	ChaosMonkey                                *ChaosMonkeyConfig   `toml:",omitempty"`
+	NodeConfigReload                           *NodeConfigReload    `toml:",omitempty"`
+	TrafficMirror                              *TrafficMirrorConfig `toml:",omitempty"` // replay the messages of a production lane instead of the schedule and the MsgProfile
 }
 
 func (l *LoadProfile) Validate() error {
 	if l == nil {
 		return fmt.Errorf("load profile should be set")
 	}
+	if l.Preset != nil {
+		if err := l.ApplyPreset(*l.Preset); err != nil {
+			return err
+		}
+	}
 	if err := l.MsgProfile.Validate(); err != nil {
 		return err
 	}
@@ -234,6 +463,223 @@ func (l *LoadProfile) Validate() error {
 	if l.TestDuration == nil || l.TestDuration.Duration().Minutes() == 0 {
 		return fmt.Errorf("test duration should be set")
 	}
+	if l.ChaosMonkey != nil {
+		if err := l.ChaosMonkey.Validate(); err != nil {
+			return err
+		}
+	}
+	if l.NodeConfigReload != nil {
+		if err := l.NodeConfigReload.Validate(); err != nil {
+			return err
+		}
+	}
+	if l.TrafficMirror != nil {
+		if l.Preset != nil {
+			return fmt.Errorf("traffic mirroring and load preset cannot be used at the same time")
+		}
+		if err := l.TrafficMirror.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChaosMonkeyConfig configures the randomized chaos injected during a soak.
+// Only one fault is injected at a time and every fault is kept within the fault tolerance of the DONs.
+type ChaosMonkeyConfig struct {
+	MinInterval    *config.Duration `toml:",omitempty"` // min wait before injecting the next fault
+	MaxInterval    *config.Duration `toml:",omitempty"` // max wait before injecting the next fault
+	FaultDuration  *config.Duration `toml:",omitempty"` // duration of every injected fault
+	GasSpikeFactor *int64           `toml:",omitempty"` // multiplier applied to the dest gas price for the gas spike action
+	Actions        []string         `toml:",omitempty"` // subset of actions to pick from; all safe actions are used if empty
+	Seed           *int64           `toml:",omitempty"` // seed for the random choices, to reproduce a run
+}
+
+func (c *ChaosMonkeyConfig) Validate() error {
+	if c.MinInterval == nil || c.MaxInterval == nil || c.MinInterval.Duration() <= 0 {
+		return fmt.Errorf("min and max interval should be set for chaos monkey")
+	}
+	if c.MaxInterval.Duration() < c.MinInterval.Duration() {
+		return fmt.Errorf("max interval should be greater than or equal to min interval for chaos monkey")
+	}
+	if c.FaultDuration == nil || c.FaultDuration.Duration() <= 0 {
+		return fmt.Errorf("fault duration should be set for chaos monkey")
+	}
+	if c.GasSpikeFactor != nil && *c.GasSpikeFactor <= 1 {
+		return fmt.Errorf("gas spike factor should be greater than 1")
+	}
+	return nil
+}
+
+// NodeConfigReload configures the CL node config applied by restarting one node at a time while the load is running.
+type NodeConfigReload struct {
+	LogLevel    *string          `toml:",omitempty"` // log level of the nodes after the reload, e.g. 'debug'
+	MaxGasPrice *string          `toml:",omitempty"` // GasEstimator.PriceMax of every evm chain of the nodes after the reload, e.g. '500 gwei'
+	Interval    *config.Duration `toml:",omitempty"` // wait after a node is back up before reloading the next one
+}
+
+func (n *NodeConfigReload) Validate() error {
+	if n.LogLevel == nil && n.MaxGasPrice == nil {
+		return fmt.Errorf("either log level or max gas price should be set for node config reload")
+	}
+	if n.LogLevel != nil {
+		var level coretoml.LogLevel
+		if err := level.UnmarshalText([]byte(*n.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level %s for node config reload: %w", *n.LogLevel, err)
+		}
+	}
+	if n.MaxGasPrice != nil {
+		var price assets.Wei
+		if err := price.UnmarshalText([]byte(*n.MaxGasPrice)); err != nil {
+			return fmt.Errorf("invalid max gas price %s for node config reload: %w", *n.MaxGasPrice, err)
+		}
+	}
+	if n.Interval == nil || n.Interval.Duration() <= 0 {
+		return fmt.Errorf("interval should be set for node config reload")
+	}
+	return nil
+}
+
+// BlessLatencySLOConfig is the SLO of the bless latency, the time from a commit report being accepted till the ARM
+// blesses it. The blessing overlapping a curse window recorded by the test is excluded unless IncludeCurse is set.
+type BlessLatencySLOConfig struct {
+	Latency      *config.Duration `toml:",omitempty"` // max bless latency at the percentile
+	Percentile   *float64         `toml:",omitempty"` // percentile of the bless latencies compared against Latency, defaults to 95
+	CurseGrace   *config.Duration `toml:",omitempty"` // time after a curse is lifted in which blessing is still excluded
+	IncludeCurse *bool            `toml:",omitempty"` // count the blessing overlapping a curse window against the SLO
+}
+
+func (b *BlessLatencySLOConfig) Validate() error {
+	if b.Latency == nil || b.Latency.Duration() <= 0 {
+		return fmt.Errorf("latency should be set for bless latency SLO")
+	}
+	if b.Percentile != nil && (*b.Percentile <= 0 || *b.Percentile > 100) {
+		return fmt.Errorf("percentile of bless latency SLO should be in (0, 100]")
+	}
+	if b.CurseGrace != nil && b.CurseGrace.Duration() < 0 {
+		return fmt.Errorf("curse grace of bless latency SLO should not be negative")
+	}
+	return nil
+}
+
+// AdaptiveTimeoutConfig configures the phase timeouts adapting to the phase latencies observed on every lane, so that
+// the requests are not failed on the fixed PhaseTimeout when congestion on a public testnet slows all of them down
+type AdaptiveTimeoutConfig struct {
+	Alpha           *float64         `toml:",omitempty"` // smoothing factor of the EWMA of the phase latencies in (0, 1], the weight of the latest latency; 0.2 if not set
+	LatencyFactor   *float64         `toml:",omitempty"` // the timeout of a phase is stretched to LatencyFactor times the EWMA of its latency; 3 if not set
+	MinObservations *int             `toml:",omitempty"` // number of latencies observed for a phase before its timeout is adapted; 3 if not set
+	MaxTimeout      *config.Duration `toml:",omitempty"` // upper bound of the adapted timeouts; 3 times the PhaseTimeout if not set
+}
+
+func (a *AdaptiveTimeoutConfig) Validate() error {
+	if a.Alpha != nil && (*a.Alpha <= 0 || *a.Alpha > 1) {
+		return fmt.Errorf("adaptive timeout alpha should be in (0, 1]")
+	}
+	if a.LatencyFactor != nil && *a.LatencyFactor < 1 {
+		return fmt.Errorf("adaptive timeout latency factor should be at least 1")
+	}
+	if a.MinObservations != nil && *a.MinObservations < 1 {
+		return fmt.Errorf("adaptive timeout min observations should be at least 1")
+	}
+	if a.MaxTimeout != nil && a.MaxTimeout.Duration() <= 0 {
+		return fmt.Errorf("adaptive timeout max timeout should be greater than 0")
+	}
+	return nil
+}
+
+// WebhookConfig configures the webhook notified of the phase transitions of every message sent in the test
+type WebhookConfig struct {
+	URL    *string  `toml:",omitempty"` // env vars in it are expanded
+	Secret *string  `toml:",omitempty"` // key of the HMAC-SHA256 signature of the body sent in the X-CCIP-Signature header, env vars in it are expanded; the body is not signed if not set
+	Events []string `toml:",omitempty"` // any of 'sent', 'committed', 'blessed', 'executed' and 'failed'; all of them are notified if not set
+}
+
+func (w *WebhookConfig) Validate() error {
+	if pointer.GetString(w.URL) == "" {
+		return fmt.Errorf("webhook url should be set")
+	}
+	for _, event := range w.Events {
+		switch event {
+		case "sent", "committed", "blessed", "executed", "failed":
+		default:
+			return fmt.Errorf("invalid webhook event %s, should be one of sent, committed, blessed, executed or failed", event)
+		}
+	}
+	return nil
+}
+
+// FailureRuleConfig classifies the failed executions matching it into Class in the failure breakdown of the report.
+// A failure matches if its category is Category, when set, and its decoded reason contains any of ReasonContains, when set.
+type FailureRuleConfig struct {
+	Class          *string  `toml:",omitempty"` // e.g. receiver_bug, out_of_gas, rate_limited, pool_liquidity or a custom class
+	Category       *string  `toml:",omitempty"` // any of 'ReceiverRevert', 'TokenHandling', 'OffRamp' and 'Unknown'
+	ReasonContains []string `toml:",omitempty"` // substrings of the decoded revert reason, e.g. the name of a custom error
+}
+
+func (f *FailureRuleConfig) Validate() error {
+	if pointer.GetString(f.Class) == "" {
+		return fmt.Errorf("class should be set for failure rule")
+	}
+	if pointer.GetString(f.Category) == "" && len(f.ReasonContains) == 0 {
+		return fmt.Errorf("category or reason should be set for failure rule %s", *f.Class)
+	}
+	return nil
+}
+
+// GasGoldenConfig configures the comparison of the gas used by the canonical operations of the lanes, i.e. ccipSend,
+// commit reports and exec batches, against the gas recorded in a golden file
+type GasGoldenConfig struct {
+	File      *string  `toml:",omitempty"` // path of the golden file, relative to the dir the test runs in
+	Tolerance *float64 `toml:",omitempty"` // allowed deviation from the recorded gas as a fraction of it, unless set for the operation in the golden file
+	Update    *bool    `toml:",omitempty"` // record the gas used in the run into the golden file instead of comparing against it
+}
+
+func (g *GasGoldenConfig) Validate() error {
+	if pointer.GetString(g.File) == "" {
+		return fmt.Errorf("gas golden file should be set")
+	}
+	if g.Tolerance != nil && (*g.Tolerance <= 0 || *g.Tolerance >= 1) {
+		return fmt.Errorf("gas golden tolerance should be between 0 and 1")
+	}
+	return nil
+}
+
+// LaneTimingConfig overrides the timing params set in the OCR2 config of the CommitStore and the OffRamp of a lane,
+// so that fast finality chains and slow testnets can be tested in the same run. The params which are not set fall
+// back to the ones set for the whole group.
+type LaneTimingConfig struct {
+	PermissionlessExecThreshold *config.Duration `toml:",omitempty"` // window in which the DON executes a message, manual execution is required after that
+	RootSnooze                  *config.Duration `toml:",omitempty"` // time a root is skipped for once no batch could be built from it
+	InflightExpiry              *config.Duration `toml:",omitempty"` // time after which an exec report not yet executed is considered for execution again
+	CommitInflightExpiry        *config.Duration `toml:",omitempty"` // time after which a commit report not yet accepted is considered for commit again
+}
+
+func (l *LaneTimingConfig) Validate() error {
+	if l == nil {
+		return fmt.Errorf("lane timing should be set")
+	}
+	for name, d := range map[string]*config.Duration{
+		"root snooze":            l.RootSnooze,
+		"inflight expiry":        l.InflightExpiry,
+		"commit inflight expiry": l.CommitInflightExpiry,
+	} {
+		if d != nil && d.Duration() <= 0 {
+			return fmt.Errorf("%s should be greater than 0", name)
+		}
+	}
+	if l.PermissionlessExecThreshold != nil {
+		threshold := l.PermissionlessExecThreshold.Duration()
+		// the threshold is set in seconds as uint32 in the OffRamp config
+		if threshold < time.Second || threshold.Seconds() > math.MaxUint32 {
+			return fmt.Errorf("permissionless exec threshold should be between 1s and %ds", uint32(math.MaxUint32))
+		}
+		if l.RootSnooze != nil && l.RootSnooze.Duration() >= threshold {
+			return fmt.Errorf("root snooze should be less than permissionless exec threshold")
+		}
+		if l.InflightExpiry != nil && l.InflightExpiry.Duration() >= threshold {
+			return fmt.Errorf("inflight expiry should be less than permissionless exec threshold")
+		}
+	}
 	return nil
 }
 
@@ -264,12 +710,49 @@ type CCIPTestConfig struct {
 	MaxNoOfLanes              int                                   `toml:",omitempty"`
 	ChaosDuration             *config.Duration                      `toml:",omitempty"`
 	USDCMockDeployment        *bool                                 `toml:",omitempty"`
+	USDCAttestation           *USDCAttestationConfig                `toml:",omitempty"`
 	CommitOCRParams           *contracts.OffChainAggregatorV2Config `toml:",omitempty"`
 	ExecOCRParams             *contracts.OffChainAggregatorV2Config `toml:",omitempty"`
 	OffRampConfig             *OffRampConfig                        `toml:",omitempty"`
 	CommitInflightExpiry      *config.Duration                      `toml:",omitempty"`
 	StoreLaneConfig           *bool                                 `toml:",omitempty"`
+	AddressBookFile           *string                               `toml:",omitempty"` // path to write the lane contracts to in the address book format of the ccip deployment tooling
+	Faucets                   map[string]*FaucetConfig              `toml:",omitempty"` // key is network name; faucets topping up the funding wallets of public testnets
 	LoadProfile               *LoadProfile                          `toml:",omitempty"`
+	ResourceLock              *ResourceLockConfig                   `toml:",omitempty"`
+	PollingInterval           map[string]*config.Duration           `toml:",omitempty"` // key is network name; if not set, it's adapted to the block time of the network
+	DockerCompose             *DockerComposeConfig                  `toml:",omitempty"`
+	InfiniteRouterApproval    *bool                                 `toml:",omitempty"` // approve the router for the max uint256 of the tokens instead of topping up the approval as it's used up
+	ConcurrentDeployment      *bool                                 `toml:",omitempty"` // deploy the common contracts which don't depend on one another concurrently
+	LaneTiming                map[string]*LaneTimingConfig          `toml:",omitempty"` // key is dest network name or 'SOURCE,DEST' for a single lane
+	TimelineRequests          *int                                  `toml:",omitempty"` // number of slowest requests in the timeline of the test report, failed requests are always included
+	CommitBatchBurstSize      *int                                  `toml:",omitempty"` // number of requests sent in one tx after a single committed request in the commit batching test
+	MockDON                   *bool                                 `toml:",omitempty"` // commit and execute the requests in-process with test OCR keys instead of running CL nodes
+	BlessLatencySLO           *BlessLatencySLOConfig                `toml:",omitempty"` // SLO of the time from commit till bless, asserted at the end of the load tests
+	TxCustomization           map[string]*TxCustomizationConfig     `toml:",omitempty"` // key is network name; rewrites the txs for chains needing custom tx fields
+	ArchiveNodes              map[string]*ArchiveNodeConfig         `toml:",omitempty"` // key is network name; archive node the queries for the blocks pruned by the primary node are sent to
+	Doctor                    *bool                                 `toml:",omitempty"` // check the preconditions of the environment and fail before anything is deployed if any of them is not met
+	GasGolden                 *GasGoldenConfig                      `toml:",omitempty"` // compare the gas of the canonical operations against a golden file in TestSmokeCCIPGasGolden
+	Webhook                   *WebhookConfig                        `toml:",omitempty"` // notify a webhook of the phase transitions of the messages
+	AdaptiveTimeout           *AdaptiveTimeoutConfig                `toml:",omitempty"` // adapt the phase timeouts to the latencies observed on the lanes, reporting the SLA against both the raw and the adapted timeouts
+	FailureTaxonomy           []*FailureRuleConfig                  `toml:",omitempty"` // rules classifying the failed executions in the report, applied in order ahead of the default ones
+}
+
+// LaneTimingFor returns the timing params set for the lane from source to dest, which take precedence over
+// the ones set for all the lanes towards dest. It returns nil if none is set.
+func (c *CCIPTestConfig) LaneTimingFor(source, dest string) *LaneTimingConfig {
+	if timing, ok := c.LaneTiming[fmt.Sprintf("%s,%s", source, dest)]; ok && timing != nil {
+		return timing
+	}
+	return c.LaneTiming[dest]
+}
+
+// PollingIntervalFor returns the event polling interval set for the network, 0 if it's not set
+func (c *CCIPTestConfig) PollingIntervalFor(networkName string) time.Duration {
+	if interval, ok := c.PollingInterval[networkName]; ok && interval != nil {
+		return interval.Duration()
+	}
+	return 0
 }
 
 func (c *CCIPTestConfig) Validate() error {
@@ -298,6 +781,12 @@ func (c *CCIPTestConfig) Validate() error {
 	if c.NoOfCommitNodes < 4 {
 		return fmt.Errorf("insuffcient number of commit nodes provided")
 	}
+	if c.TimelineRequests != nil && *c.TimelineRequests < 0 {
+		return fmt.Errorf("timeline requests should not be negative")
+	}
+	if c.CommitBatchBurstSize != nil && *c.CommitBatchBurstSize <= 0 {
+		return fmt.Errorf("commit batch burst size should be greater than 0")
+	}
 	if err := c.TokenConfig.Validate(); err != nil {
 		return err
 	}
@@ -312,13 +801,123 @@ func (c *CCIPTestConfig) Validate() error {
 			return fmt.Errorf("number of sends in multisend should be greater than 0 if multisend is true")
 		}
 	}
+	if len(c.TokenConfig.PoolTypes) > 0 && (c.TokenConfig.PoolType(0) == USDCPoolType) != pointer.GetBool(c.USDCMockDeployment) {
+		return fmt.Errorf("the first bridge token should have a USDC pool if and only if USDCMockDeployment is set")
+	}
+	if c.USDCAttestation != nil {
+		if !pointer.GetBool(c.USDCMockDeployment) {
+			return fmt.Errorf("USDC attestation service can only be used with USDC mock deployment")
+		}
+		if err := c.USDCAttestation.Validate(); err != nil {
+			return err
+		}
+	}
+	if pointer.GetBool(c.MockDON) {
+		if pointer.GetBool(c.ExistingDeployment) {
+			return fmt.Errorf("mock DON cannot be used with existing deployment")
+		}
+		if pointer.GetBool(c.USDCMockDeployment) {
+			return fmt.Errorf("mock DON cannot be used with USDC mock deployment, it provides no attestation")
+		}
+		if pointer.GetBool(c.LocalCluster) || c.DockerCompose != nil {
+			return fmt.Errorf("mock DON cannot be used with local cluster or docker compose, it runs no CL nodes")
+		}
+	}
+	if c.AdaptiveTimeout != nil {
+		if err := c.AdaptiveTimeout.Validate(); err != nil {
+			return err
+		}
+		if c.AdaptiveTimeout.MaxTimeout != nil && c.PhaseTimeout != nil &&
+			c.AdaptiveTimeout.MaxTimeout.Duration() < c.PhaseTimeout.Duration() {
+			return fmt.Errorf("adaptive timeout max timeout should not be less than the phase timeout")
+		}
+	}
+	if c.Webhook != nil {
+		if err := c.Webhook.Validate(); err != nil {
+			return err
+		}
+	}
+	for i, rule := range c.FailureTaxonomy {
+		if rule == nil {
+			return fmt.Errorf("failure rule %d should not be empty", i)
+		}
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.GasGolden != nil {
+		if err := c.GasGolden.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.BlessLatencySLO != nil {
+		if err := c.BlessLatencySLO.Validate(); err != nil {
+			return err
+		}
+	}
+	for network, txCfg := range c.TxCustomization {
+		if txCfg == nil {
+			return fmt.Errorf("tx customization for %s should not be empty", network)
+		}
+		if err := txCfg.Validate(); err != nil {
+			return fmt.Errorf("tx customization for %s: %w", network, err)
+		}
+	}
+	for network, archive := range c.ArchiveNodes {
+		if archive == nil {
+			return fmt.Errorf("archive node for %s should not be empty", network)
+		}
+		if err := archive.Validate(); err != nil {
+			return fmt.Errorf("archive node for %s: %w", network, err)
+		}
+	}
+	for network, faucet := range c.Faucets {
+		if faucet == nil {
+			return fmt.Errorf("faucet config for %s should not be empty", network)
+		}
+		if err := faucet.Validate(); err != nil {
+			return fmt.Errorf("faucet for %s: %w", network, err)
+		}
+	}
+	if c.ResourceLock != nil {
+		if err := c.ResourceLock.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.DockerCompose != nil {
+		if pointer.GetBool(c.LocalCluster) {
+			return fmt.Errorf("docker compose and local cluster cannot be used at the same time")
+		}
+		if err := c.DockerCompose.Validate(); err != nil {
+			return err
+		}
+	}
+	for network, interval := range c.PollingInterval {
+		if interval == nil || interval.Duration() < 50*time.Millisecond || interval.Duration() > time.Minute {
+			return fmt.Errorf("polling interval for %s should be between 50ms and 1m", network)
+		}
+	}
+	for key, timing := range c.LaneTiming {
+		if networks := strings.Split(key, ","); len(networks) > 2 || networks[0] == "" || networks[len(networks)-1] == "" {
+			return fmt.Errorf("lane timing key %s should be either a dest network name or 'SOURCE,DEST'", key)
+		}
+		if err := timing.Validate(); err != nil {
+			return fmt.Errorf("invalid lane timing for %s: %w", key, err)
+		}
+	}
 
 	return nil
 }
 
 type CCIPContractConfig struct {
-	DataFile *string `toml:",omitempty"`
-	Data     string  `toml:",omitempty"`
+	DataFile    *string `toml:",omitempty"`
+	Data        string  `toml:",omitempty"`
+	AddressBook *bool   `toml:",omitempty"` // Data and DataFile are in the address book format of the ccip deployment tooling instead of the lane config one
+}
+
+// IsAddressBook returns true if the contract config is in the address book format of the ccip deployment tooling
+func (c *CCIPContractConfig) IsAddressBook() bool {
+	return c != nil && pointer.GetBool(c.AddressBook)
 }
 
 func (c *CCIPContractConfig) DataFilePath() string {
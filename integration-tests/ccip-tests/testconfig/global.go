@@ -23,6 +23,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/config"
 	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/config"
 
+	testutils "github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/utils"
 	"github.com/smartcontractkit/chainlink/integration-tests/client"
 )
 
@@ -174,6 +175,7 @@ type Common struct {
 	Network                 *ctfconfig.NetworkConfig                    `toml:",omitempty"`
 	PrivateEthereumNetworks map[string]*ctfconfig.EthereumNetworkConfig `toml:",omitempty"`
 	Logging                 *ctfconfig.LoggingConfig                    `toml:",omitempty"`
+	Sharding                *K8sSharding                                `toml:",omitempty"` // Sharding splits a new k8s deployment across namespaces, if not specified everything is deployed in a single namespace
 }
 
 func (p *Common) GetNodeConfig() *ctfconfig.NodeConfig {
@@ -220,6 +222,18 @@ func (p *Common) Validate() error {
 		p.PrivateEthereumNetworks[k] = &ethNetwork.EthereumNetworkConfig
 	}
 
+	if p.Sharding != nil {
+		if p.ExistingCLCluster != nil {
+			return errors.New("sharding can not be used with existing chainlink cluster")
+		}
+		if pointer.GetString(p.EnvToConnect) != "" {
+			return errors.New("sharding can not be used with an existing k8s env")
+		}
+		if err := p.Sharding.Validate(); err != nil {
+			return fmt.Errorf("error validating sharding config %w", err)
+		}
+	}
+
 	if p.ExistingCLCluster != nil {
 		if err := p.ExistingCLCluster.Validate(); err != nil {
 			return fmt.Errorf("error validating existing chainlink cluster config %w", err)
@@ -227,6 +241,9 @@ func (p *Common) Validate() error {
 		if p.Mockserver == nil {
 			return errors.New("no mockserver specified for existing chainlink cluster")
 		}
+		if _, err := testutils.NormalizeURL(*p.Mockserver); err != nil {
+			return fmt.Errorf("invalid mockserver url: %w", err)
+		}
 		log.Warn().Msg("Using existing chainlink cluster, overriding new chainlink cluster config if specified")
 		p.NewCLCluster = nil
 	} else {
@@ -307,6 +324,9 @@ func (c *CLCluster) Validate() error {
 		if nodeConfig.URL == "" {
 			return fmt.Errorf("node %d url not specified", i+1)
 		}
+		if _, err := testutils.NormalizeURL(nodeConfig.URL); err != nil {
+			return fmt.Errorf("node %d url is invalid: %w", i+1, err)
+		}
 		if nodeConfig.Password == "" {
 			return fmt.Errorf("node %d password not specified", i+1)
 		}
@@ -321,6 +341,42 @@ func (c *CLCluster) Validate() error {
 	return nil
 }
 
+// K8sSharding splits the simulated networks and the chainlink nodes of a new k8s deployment across namespaces, so that
+// the load tests can scale beyond the resource quota of a single namespace. The first namespace holds the resources
+// which are not sharded, e.g. the mockserver, along with the first shard of networks and nodes. The pods in different
+// namespaces reach each other by the cluster wide dns names of their services, so the network policies of the cluster
+// must allow traffic across the namespaces. Sharding is not supported with the remote runner.
+type K8sSharding struct {
+	NetworksPerNamespace *int `toml:",omitempty"` // max number of simulated networks deployed in a namespace
+	NodesPerNamespace    *int `toml:",omitempty"` // max number of chainlink nodes deployed in a namespace
+}
+
+func (s *K8sSharding) Validate() error {
+	if s.NetworksPerNamespace == nil && s.NodesPerNamespace == nil {
+		return errors.New("either NetworksPerNamespace or NodesPerNamespace should be specified")
+	}
+	if s.NetworksPerNamespace != nil && *s.NetworksPerNamespace < 1 {
+		return errors.New("NetworksPerNamespace should be at least 1")
+	}
+	if s.NodesPerNamespace != nil && *s.NodesPerNamespace < 1 {
+		return errors.New("NodesPerNamespace should be at least 1")
+	}
+	return nil
+}
+
+// Shards splits total items in shards of at most perShard items and returns the size of each shard.
+// If perShard is nil, all the items are in a single shard.
+func Shards(total int, perShard *int) []int {
+	if perShard == nil || *perShard >= total {
+		return []int{total}
+	}
+	var shards []int
+	for remaining := total; remaining > 0; remaining -= *perShard {
+		shards = append(shards, min(remaining, *perShard))
+	}
+	return shards
+}
+
 type ChainlinkDeployment struct {
 	Common         *Node    `toml:",omitempty"`
 	NodeMemory     string   `toml:",omitempty"`
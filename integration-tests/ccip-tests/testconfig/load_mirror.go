@@ -0,0 +1,133 @@
+package testconfig
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+// TrafficMirrorConfig points to the on ramp of a production lane, the messages sent on it in the block range are
+// replayed on the test lanes with the same shape and timing, instead of the schedule and the MsgProfile of the load profile
+type TrafficMirrorConfig struct {
+	RPCURL         *string `toml:",omitempty"` // http or ws rpc of the source chain of the production lane
+	OnRamp         *string `toml:",omitempty"` // address of the on ramp of the production lane
+	FromBlock      *uint64 `toml:",omitempty"`
+	ToBlock        *uint64 `toml:",omitempty"`
+	AmountPerToken *int64  `toml:",omitempty"` // amount sent for each token in the replayed messages, production amounts are not replayed; defaults to 1
+}
+
+func (m *TrafficMirrorConfig) Validate() error {
+	if pointer.GetString(m.RPCURL) == "" {
+		return fmt.Errorf("rpc url of the production lane should be set for traffic mirroring")
+	}
+	if !common.IsHexAddress(pointer.GetString(m.OnRamp)) {
+		return fmt.Errorf("invalid on ramp address %s for traffic mirroring", pointer.GetString(m.OnRamp))
+	}
+	if m.FromBlock == nil || m.ToBlock == nil {
+		return fmt.Errorf("block range should be set for traffic mirroring")
+	}
+	if *m.ToBlock < *m.FromBlock {
+		return fmt.Errorf("to block %d should not be before from block %d for traffic mirroring", *m.ToBlock, *m.FromBlock)
+	}
+	if m.AmountPerToken != nil && *m.AmountPerToken <= 0 {
+		return fmt.Errorf("amount per token should be greater than 0 for traffic mirroring")
+	}
+	return nil
+}
+
+// MirroredMsg is the shape of a message sent on a production lane along with the time it was sent at
+type MirroredMsg struct {
+	SentAt     time.Time
+	DataLength int64
+	NoOfTokens int
+	GasLimit   int64
+}
+
+// msgDetails returns the MsgDetails replaying the message with at most maxTokens tokens
+func (m MirroredMsg) msgDetails(maxTokens int, amountPerToken int64) *MsgDetails {
+	noOfTokens := m.NoOfTokens
+	if noOfTokens > maxTokens {
+		noOfTokens = maxTokens
+	}
+	msgType := DataOnlyTransfer
+	switch {
+	case noOfTokens > 0 && m.DataLength > 0:
+		msgType = DataAndTokenTransfer
+	case noOfTokens > 0:
+		msgType = TokenOnlyTransfer
+	}
+	details := &MsgDetails{
+		MsgType:      pointer.ToString(msgType),
+		DestGasLimit: pointer.ToInt64(m.GasLimit),
+	}
+	if msgType != TokenOnlyTransfer {
+		// a message with neither data nor tokens is replayed with a single byte, as data messages can't be empty
+		details.DataLength = pointer.ToInt64(m.DataLength)
+		if m.DataLength == 0 {
+			details.DataLength = pointer.ToInt64(1)
+		}
+	}
+	if noOfTokens > 0 {
+		details.NoOfTokens = pointer.ToInt(noOfTokens)
+		details.AmountPerToken = pointer.ToInt64(amountPerToken)
+	}
+	return details
+}
+
+// ApplyMirroredTraffic replaces the schedule and the message profile of the load profile so that msgs are replayed
+// in the order they were sent, with the number of messages sent per TimeUnit matching the production lane.
+// The messages are replayed with at most maxTokens tokens, the number of bridge tokens available on the test lanes.
+// Time units in which nothing was sent are skipped, as a rate of 0 can't be scheduled, so idle periods are compressed.
+// Unlike ApplyPreset, it can be called after the load profile is validated.
+func (l *LoadProfile) ApplyMirroredTraffic(msgs []MirroredMsg, maxTokens int) error {
+	if len(msgs) == 0 {
+		return fmt.Errorf("no messages to mirror")
+	}
+	if l.TimeUnit == nil || l.TimeUnit.Duration() == 0 {
+		return fmt.Errorf("time unit should be set to mirror traffic")
+	}
+	amountPerToken := int64(1)
+	if l.TrafficMirror != nil && l.TrafficMirror.AmountPerToken != nil {
+		amountPerToken = *l.TrafficMirror.AmountPerToken
+	}
+	sorted := append([]MirroredMsg{}, msgs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SentAt.Before(sorted[j].SentAt)
+	})
+
+	timeUnit := l.TimeUnit.Duration()
+	start := sorted[0].SentAt
+	var (
+		details     []*MsgDetails
+		frequencies []int
+		rates       []int64
+		lastUnit    = int64(-1)
+	)
+	for _, msg := range sorted {
+		details = append(details, msg.msgDetails(maxTokens, amountPerToken))
+		frequencies = append(frequencies, 1)
+		unit := int64(msg.SentAt.Sub(start) / timeUnit)
+		if unit != lastUnit {
+			rates = append(rates, 0)
+			lastUnit = unit
+		}
+		rates[len(rates)-1]++
+	}
+	l.MsgProfile = &MsgProfile{MsgDetails: &details, Frequencies: frequencies}
+	if err := l.MsgProfile.Validate(); err != nil {
+		return fmt.Errorf("invalid mirrored message profile: %w", err)
+	}
+	l.MsgProfile.msgDetailsIndexMatrixByFrequency()
+	l.RequestPerUnitTime = rates
+	l.StepDuration = nil
+	for range rates {
+		l.StepDuration = append(l.StepDuration, config.MustNewDuration(timeUnit))
+	}
+	l.TestDuration = config.MustNewDuration(time.Duration(len(rates)) * timeUnit)
+	return nil
+}
@@ -0,0 +1,134 @@
+package testconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+const (
+	LoadPresetSmoke  = "smoke"  // short run to verify the lanes are healthy under load
+	LoadPresetSoak   = "soak"   // long run with steady low rate
+	LoadPresetStress = "stress" // step-wise increasing rate to find the breaking point
+	LoadPresetSpike  = "spike"  // steady rate with a short burst in between
+)
+
+// loadProfilePreset is a named set of load knobs, it can be selected with LoadProfile.Preset
+type loadProfilePreset struct {
+	RequestPerUnitTime []int64
+	TimeUnit           time.Duration
+	StepDuration       []time.Duration
+	TestDuration       time.Duration
+	MsgProfile         func() *MsgProfile
+}
+
+// msgDetails returns the MsgDetails for a message of msgType with dataLength bytes of data and noOfTokens tokens
+func msgDetails(msgType string, dataLength int64, noOfTokens int) *MsgDetails {
+	m := &MsgDetails{
+		MsgType:      pointer.ToString(msgType),
+		DestGasLimit: pointer.ToInt64(100_000),
+	}
+	if msgType != TokenOnlyTransfer {
+		m.DataLength = pointer.ToInt64(dataLength)
+	}
+	if msgType != DataOnlyTransfer {
+		m.NoOfTokens = pointer.ToInt(noOfTokens)
+		m.AmountPerToken = pointer.ToInt64(1)
+	}
+	return m
+}
+
+// mixedMsgProfile sends mostly data messages along with some token and data with token messages
+func mixedMsgProfile(dataLength int64) *MsgProfile {
+	return &MsgProfile{
+		MsgDetails: &[]*MsgDetails{
+			msgDetails(DataOnlyTransfer, dataLength, 0),
+			msgDetails(TokenOnlyTransfer, 0, 1),
+			msgDetails(DataAndTokenTransfer, dataLength, 2),
+		},
+		Frequencies: []int{6, 2, 2},
+	}
+}
+
+var loadProfilePresets = map[string]loadProfilePreset{
+	LoadPresetSmoke: {
+		RequestPerUnitTime: []int64{2},
+		TimeUnit:           time.Second,
+		TestDuration:       5 * time.Minute,
+		MsgProfile: func() *MsgProfile {
+			return &MsgProfile{
+				MsgDetails:  &[]*MsgDetails{msgDetails(DataAndTokenTransfer, 1000, 2)},
+				Frequencies: []int{1},
+			}
+		},
+	},
+	LoadPresetSoak: {
+		RequestPerUnitTime: []int64{1},
+		TimeUnit:           10 * time.Second,
+		TestDuration:       6 * time.Hour,
+		MsgProfile: func() *MsgProfile {
+			return mixedMsgProfile(1000)
+		},
+	},
+	LoadPresetStress: {
+		RequestPerUnitTime: []int64{2, 4, 8, 16},
+		TimeUnit:           10 * time.Second,
+		StepDuration:       []time.Duration{5 * time.Minute, 5 * time.Minute, 5 * time.Minute, 5 * time.Minute},
+		TestDuration:       20 * time.Minute,
+		MsgProfile: func() *MsgProfile {
+			return mixedMsgProfile(10_000)
+		},
+	},
+	LoadPresetSpike: {
+		RequestPerUnitTime: []int64{1, 20, 1},
+		TimeUnit:           10 * time.Second,
+		StepDuration:       []time.Duration{10 * time.Minute, 2 * time.Minute, 10 * time.Minute},
+		TestDuration:       22 * time.Minute,
+		MsgProfile: func() *MsgProfile {
+			return mixedMsgProfile(1000)
+		},
+	},
+}
+
+func loadPreset(name string) (loadProfilePreset, error) {
+	preset, ok := loadProfilePresets[name]
+	if !ok {
+		return loadProfilePreset{}, fmt.Errorf("unknown load profile preset %s, should be one of %s/%s/%s/%s",
+			name, LoadPresetSmoke, LoadPresetSoak, LoadPresetStress, LoadPresetSpike)
+	}
+	return preset, nil
+}
+
+// ApplyPresetSchedule replaces the schedule of the load profile (request rate, time unit, steps and test duration)
+// with the schedule of the preset. The message profile is kept as is.
+func (l *LoadProfile) ApplyPresetSchedule(name string) error {
+	preset, err := loadPreset(name)
+	if err != nil {
+		return err
+	}
+	l.RequestPerUnitTime = append([]int64{}, preset.RequestPerUnitTime...)
+	l.TimeUnit = config.MustNewDuration(preset.TimeUnit)
+	l.TestDuration = config.MustNewDuration(preset.TestDuration)
+	l.StepDuration = nil
+	for _, d := range preset.StepDuration {
+		l.StepDuration = append(l.StepDuration, config.MustNewDuration(d))
+	}
+	return nil
+}
+
+// ApplyPreset replaces the schedule and the message profile of the load profile with the ones of the preset.
+// It needs to be called before the load profile is validated, as the validation consumes the message profile.
+func (l *LoadProfile) ApplyPreset(name string) error {
+	preset, err := loadPreset(name)
+	if err != nil {
+		return err
+	}
+	if err := l.ApplyPresetSchedule(name); err != nil {
+		return err
+	}
+	l.MsgProfile = preset.MsgProfile()
+	return nil
+}
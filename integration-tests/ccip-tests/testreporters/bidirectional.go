@@ -0,0 +1,257 @@
+package testreporters
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/testreporters"
+)
+
+// BidirectionalFile is the file the combined report of the lanes run in both directions is written to
+const BidirectionalFile string = "bidirectional_ccip.json"
+
+// sourcePhases are the phases of a request which happen on the source chain, the rest happen on the destination chain
+var sourcePhases = map[Phase]bool{TX: true, CCIPSendRe: true, SourceLogFinalized: true}
+
+// DirectionSummary is the summary of the requests sent in one direction of a bidirectional lane
+type DirectionSummary struct {
+	Lane           string                      `json:"lane"`
+	Source         string                      `json:"source"`
+	Dest           string                      `json:"dest"`
+	Requests       int                         `json:"requests"`
+	Succeeded      int                         `json:"succeeded"`
+	Failed         int                         `json:"failed"`
+	FirstSeqNum    uint64                      `json:"first_seq_num,omitempty"`
+	LastSeqNum     uint64                      `json:"last_seq_num,omitempty"`
+	MissingSeqNums []uint64                    `json:"missing_seq_nums,omitempty"` // seq nums between the first and the last one with no request recorded
+	AvgE2E         float64                     `json:"avg_e2e_duration,omitempty"`
+	P90E2E         float64                     `json:"p90_e2e_duration,omitempty"`
+	FailedByPhase  map[Phase]int               `json:"failed_by_phase,omitempty"`
+	DurationStat   map[Phase]AggregatorMetrics `json:"duration_stat_by_phase,omitempty"`
+}
+
+// PhaseComparison compares the average duration of a phase for the successful requests in both directions
+type PhaseComparison struct {
+	Phase      Phase   `json:"phase"`
+	AvgForward float64 `json:"avg_forward"`
+	AvgReverse float64 `json:"avg_reverse"`
+	Diff       float64 `json:"diff"` // AvgReverse - AvgForward
+}
+
+// ChainHealth is the health of a chain across both directions, as the source of one and the destination of the other
+type ChainHealth struct {
+	Network         string  `json:"network"`
+	Sent            int     `json:"sent"`                                // requests sent from the chain
+	Received        int     `json:"received"`                            // requests executed on the chain
+	SourceFailures  int     `json:"source_failures"`                     // requests failed in a phase on the chain as source
+	DestFailures    int     `json:"dest_failures"`                       // requests failed in a phase on the chain as destination
+	AvgFinalization float64 `json:"avg_finalization_duration,omitempty"` // avg time for the ccip-send to be finalized on the chain
+	AvgCommit       float64 `json:"avg_commit_duration,omitempty"`       // avg time for the requests to be committed on the chain
+	AvgExec         float64 `json:"avg_exec_duration,omitempty"`         // avg time for the requests to be executed on the chain
+}
+
+// BidirectionalReport combines the lanes run in both directions between two networks
+type BidirectionalReport struct {
+	NetworkA   string            `json:"network_a"`
+	NetworkB   string            `json:"network_b"`
+	Forward    DirectionSummary  `json:"forward"` // NetworkA to NetworkB
+	Reverse    DirectionSummary  `json:"reverse"` // NetworkB to NetworkA
+	Comparison []PhaseComparison `json:"comparison"`
+	Chains     []ChainHealth     `json:"chains"`
+}
+
+// NewDirectionSummary summarizes the requests of a lane in one direction
+func NewDirectionSummary(lane string, stats []*RequestStat) DirectionSummary {
+	summary := DirectionSummary{
+		Lane:          lane,
+		FailedByPhase: make(map[Phase]int),
+		DurationStat:  make(map[Phase]AggregatorMetrics),
+	}
+	laneStats := &CCIPLaneStats{DurationStatByPhase: summary.DurationStat}
+	var e2e []float64
+	seqNums := make(map[uint64]bool)
+	for _, stat := range stats {
+		summary.Source, summary.Dest = stat.SourceNetwork, stat.DestNetwork
+		summary.Requests++
+		if stat.SeqNum != 0 {
+			seqNums[stat.SeqNum] = true
+			if summary.FirstSeqNum == 0 || stat.SeqNum < summary.FirstSeqNum {
+				summary.FirstSeqNum = stat.SeqNum
+			}
+			if stat.SeqNum > summary.LastSeqNum {
+				summary.LastSeqNum = stat.SeqNum
+			}
+		}
+		failed := false
+		for phase, phaseStat := range stat.StatusByPhase {
+			if phase == E2E {
+				continue
+			}
+			if phaseStat.Status != Success {
+				failed = true
+				summary.FailedByPhase[phase]++
+				continue
+			}
+			laneStats.Aggregate(phase, phaseStat.Duration)
+		}
+		if e2eStat, ok := stat.StatusByPhase[E2E]; ok && e2eStat.Status != Success {
+			failed = true
+		}
+		if failed {
+			summary.Failed++
+			continue
+		}
+		if execStat, ok := stat.StatusByPhase[ExecStateChanged]; ok && execStat.Status == Success {
+			summary.Succeeded++
+			e2e = append(e2e, stat.StatusByPhase[E2E].Duration)
+		}
+	}
+	for seqNum := summary.FirstSeqNum; summary.FirstSeqNum != 0 && seqNum < summary.LastSeqNum; seqNum++ {
+		if !seqNums[seqNum] {
+			summary.MissingSeqNums = append(summary.MissingSeqNums, seqNum)
+		}
+	}
+	for phase, stat := range summary.DurationStat {
+		summary.DurationStat[phase] = AggregatorMetrics{Min: stat.Min, Max: stat.Max, Avg: stat.sum / float64(stat.count)}
+	}
+	summary.AvgE2E, summary.P90E2E, _ = latencyStats(e2e)
+	return summary
+}
+
+// NewBidirectionalReport combines the summaries of the lanes in both directions between two networks
+func NewBidirectionalReport(forward, reverse DirectionSummary, forwardStats, reverseStats []*RequestStat) BidirectionalReport {
+	report := BidirectionalReport{
+		NetworkA: forward.Source,
+		NetworkB: forward.Dest,
+		Forward:  forward,
+		Reverse:  reverse,
+	}
+	for _, phase := range timelinePhases {
+		f, fOk := forward.DurationStat[phase]
+		r, rOk := reverse.DurationStat[phase]
+		if !fOk && !rOk {
+			continue
+		}
+		report.Comparison = append(report.Comparison, PhaseComparison{
+			Phase:      phase,
+			AvgForward: f.Avg,
+			AvgReverse: r.Avg,
+			Diff:       r.Avg - f.Avg,
+		})
+	}
+	report.Chains = []ChainHealth{
+		chainHealth(report.NetworkA, forwardStats, reverseStats),
+		chainHealth(report.NetworkB, reverseStats, forwardStats),
+	}
+	return report
+}
+
+// chainHealth returns the health of network from the requests sent from it and the requests sent to it
+func chainHealth(network string, sentFrom, sentTo []*RequestStat) ChainHealth {
+	health := ChainHealth{Network: network}
+	var finalization, commit, exec []float64
+	for _, stat := range sentFrom {
+		health.Sent++
+		for phase, phaseStat := range stat.StatusByPhase {
+			if !sourcePhases[phase] {
+				continue
+			}
+			if phaseStat.Status != Success {
+				health.SourceFailures++
+			} else if phase == SourceLogFinalized {
+				finalization = append(finalization, phaseStat.Duration)
+			}
+		}
+	}
+	for _, stat := range sentTo {
+		for phase, phaseStat := range stat.StatusByPhase {
+			if sourcePhases[phase] || phase == E2E {
+				continue
+			}
+			if phaseStat.Status != Success {
+				health.DestFailures++
+				continue
+			}
+			switch phase {
+			case Commit:
+				commit = append(commit, phaseStat.Duration)
+			case ExecStateChanged:
+				health.Received++
+				exec = append(exec, phaseStat.Duration)
+			}
+		}
+	}
+	health.AvgFinalization, _, _ = latencyStats(finalization)
+	health.AvgCommit, _, _ = latencyStats(commit)
+	health.AvgExec, _, _ = latencyStats(exec)
+	return health
+}
+
+// PairBidirectionalLanes pairs the lanes run in both directions between the same networks by the networks of their
+// requests. It returns the pairs of lane names, with the lanes of each pair in the order of the forward direction.
+func PairBidirectionalLanes(requestStats map[string][]*RequestStat) [][2]string {
+	type direction struct{ source, dest string }
+	laneByDirection := make(map[direction]string)
+	for lane, stats := range requestStats {
+		if len(stats) == 0 {
+			continue
+		}
+		laneByDirection[direction{stats[0].SourceNetwork, stats[0].DestNetwork}] = lane
+	}
+	var pairs [][2]string
+	for d, lane := range laneByDirection {
+		reverse, ok := laneByDirection[direction{d.dest, d.source}]
+		// each pair is added once, from the lane with the lower name
+		if ok && lane < reverse {
+			pairs = append(pairs, [2]string{lane, reverse})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0]
+	})
+	return pairs
+}
+
+// WriteBidirectionalReport writes the combined report of every pair of lanes run in both directions in
+// BidirectionalFile under folderPath. Nothing is written if no lane is run in both directions.
+func (r *CCIPTestReporter) WriteBidirectionalReport(folderPath string) error {
+	requestStats := make(map[string][]*RequestStat)
+	for lane, laneStats := range r.LaneStats {
+		if stats := laneStats.RequestStats(); len(stats) > 0 {
+			requestStats[lane] = stats
+		}
+	}
+	var reports []BidirectionalReport
+	for _, pair := range PairBidirectionalLanes(requestStats) {
+		forwardStats, reverseStats := requestStats[pair[0]], requestStats[pair[1]]
+		report := NewBidirectionalReport(
+			NewDirectionSummary(pair[0], forwardStats),
+			NewDirectionSummary(pair[1], reverseStats),
+			forwardStats, reverseStats,
+		)
+		r.logger.Info().
+			Str("Forward", pair[0]).
+			Str("Reverse", pair[1]).
+			Int("Forward Failed", report.Forward.Failed).
+			Int("Reverse Failed", report.Reverse.Failed).
+			Float64("Forward Avg E2E", report.Forward.AvgE2E).
+			Float64("Reverse Avg E2E", report.Reverse.AvgE2E).
+			Msg("Bidirectional lane summary")
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	if err := testreporters.MkdirIfNotExists(folderPath); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	reportLocation := filepath.Join(folderPath, BidirectionalFile)
+	r.logger.Info().Str("File", reportLocation).Msg("Writing CCIP bidirectional report")
+	return os.WriteFile(reportLocation, content, 0o600)
+}
@@ -0,0 +1,116 @@
+package testreporters
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func directedRequest(reqNo int64, source, dest string, execDuration time.Duration, execStatus Status) *RequestStat {
+	stat := timedRequest(reqNo, time.Now().UTC(), execDuration, execStatus)
+	stat.SourceNetwork, stat.DestNetwork = source, dest
+	return stat
+}
+
+func TestNewDirectionSummary(t *testing.T) {
+	t.Parallel()
+	stats := []*RequestStat{
+		directedRequest(1, "A", "B", 10*time.Second, Success),
+		directedRequest(2, "A", "B", 20*time.Second, Success),
+		directedRequest(4, "A", "B", 5*time.Second, Failure),
+		// not sent at all
+		NewCCIPRequestStats(5, "A", "B"),
+	}
+	summary := NewDirectionSummary("A To B", stats)
+
+	require.Equal(t, "A", summary.Source)
+	require.Equal(t, "B", summary.Dest)
+	require.Equal(t, 4, summary.Requests)
+	require.Equal(t, 2, summary.Succeeded)
+	require.Equal(t, 1, summary.Failed)
+	require.Equal(t, uint64(1), summary.FirstSeqNum)
+	require.Equal(t, uint64(4), summary.LastSeqNum)
+	require.Equal(t, []uint64{3}, summary.MissingSeqNums)
+	require.Equal(t, 1, summary.FailedByPhase[ExecStateChanged])
+	// e2e is the sum of the commit and the exec durations
+	require.InDelta(t, 25, summary.AvgE2E, 1e-9)
+	require.InDelta(t, 30, summary.P90E2E, 1e-9)
+	require.InDelta(t, 15, summary.DurationStat[ExecStateChanged].Avg, 1e-9)
+}
+
+func TestPairBidirectionalLanes(t *testing.T) {
+	t.Parallel()
+	requestStats := map[string][]*RequestStat{
+		"A To B": {directedRequest(1, "A", "B", time.Second, Success)},
+		"B To A": {directedRequest(1, "B", "A", time.Second, Success)},
+		"A To C": {directedRequest(1, "A", "C", time.Second, Success)},
+		"C To D": {},
+	}
+	require.Equal(t, [][2]string{{"A To B", "B To A"}}, PairBidirectionalLanes(requestStats))
+}
+
+func TestNewBidirectionalReport(t *testing.T) {
+	t.Parallel()
+	forwardStats := []*RequestStat{
+		directedRequest(1, "A", "B", 10*time.Second, Success),
+		directedRequest(2, "A", "B", 10*time.Second, Failure),
+	}
+	reverseStats := []*RequestStat{
+		directedRequest(1, "B", "A", 30*time.Second, Success),
+	}
+	report := NewBidirectionalReport(
+		NewDirectionSummary("A To B", forwardStats),
+		NewDirectionSummary("B To A", reverseStats),
+		forwardStats, reverseStats,
+	)
+
+	require.Equal(t, "A", report.NetworkA)
+	require.Equal(t, "B", report.NetworkB)
+	var execComparison *PhaseComparison
+	for i := range report.Comparison {
+		if report.Comparison[i].Phase == ExecStateChanged {
+			execComparison = &report.Comparison[i]
+		}
+	}
+	require.NotNil(t, execComparison)
+	require.InDelta(t, 20, execComparison.Diff, 1e-9)
+
+	require.Len(t, report.Chains, 2)
+	chainA, chainB := report.Chains[0], report.Chains[1]
+	require.Equal(t, "A", chainA.Network)
+	require.Equal(t, 2, chainA.Sent)
+	require.Equal(t, 1, chainA.Received)
+	require.Zero(t, chainA.DestFailures)
+	require.InDelta(t, 30, chainA.AvgExec, 1e-9)
+	require.InDelta(t, 4, chainA.AvgFinalization, 1e-9)
+	require.Equal(t, "B", chainB.Network)
+	require.Equal(t, 1, chainB.Sent)
+	require.Equal(t, 1, chainB.Received)
+	require.Equal(t, 1, chainB.DestFailures, "failed execution should count against the destination chain")
+}
+
+func TestWriteBidirectionalReport(t *testing.T) {
+	t.Parallel()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	dir := t.TempDir()
+	reporter.AddNewLane("A To B", zerolog.Nop()).
+		UpdatePhaseStatsForReq(directedRequest(1, "A", "B", time.Second, Success))
+	require.NoError(t, reporter.WriteBidirectionalReport(dir))
+	require.NoFileExists(t, filepath.Join(dir, BidirectionalFile), "report should not be written without lanes in both directions")
+
+	reporter.AddNewLane("B To A", zerolog.Nop()).
+		UpdatePhaseStatsForReq(directedRequest(1, "B", "A", time.Second, Success))
+	require.NoError(t, reporter.WriteBidirectionalReport(dir))
+	content, err := os.ReadFile(filepath.Join(dir, BidirectionalFile))
+	require.NoError(t, err)
+	var reports []BidirectionalReport
+	require.NoError(t, json.Unmarshal(content, &reports))
+	require.Len(t, reports, 1)
+	require.Equal(t, "A To B", reports[0].Forward.Lane)
+	require.Equal(t, "B To A", reports[0].Reverse.Lane)
+}
@@ -0,0 +1,168 @@
+package testreporters
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBlessLatencyPercentile is the percentile of the bless latencies compared against the SLO if it's not set otherwise
+const DefaultBlessLatencyPercentile = 95.0
+
+// BlessLatencySLO is the service level objective of the bless latency, the time from a commit report being accepted
+// till the ARM blesses it. It's tracked apart from the other phases as the RMN is operated separately from the DON.
+type BlessLatencySLO struct {
+	Percentile   float64       // percentile of the bless latencies compared against Latency
+	Latency      time.Duration // max bless latency at the percentile
+	CurseGrace   time.Duration // time after the end of a curse window in which blessing is still excluded
+	IncludeCurse bool          // whether blessing overlapping a curse window counts against the SLO
+}
+
+// CurseWindow is a period in which the ARM of a network was cursed, no blessing is expected to happen in it
+type CurseWindow struct {
+	Network string    `json:"network"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// overlaps returns whether the window, extended by grace, overlaps the period from start to end on any of the networks
+func (w CurseWindow) overlaps(start, end time.Time, grace time.Duration, networks ...string) bool {
+	for _, network := range networks {
+		if network == w.Network {
+			return start.Before(w.End.Add(grace)) && end.After(w.Start)
+		}
+	}
+	return false
+}
+
+// BlessLatencyStats are the bless latencies of the requests of a lane, in seconds
+type BlessLatencyStats struct {
+	Count             int     `json:"count"`                               // number of blessed requests counted
+	Excluded          int     `json:"excluded_in_curse_windows,omitempty"` // number of blessed requests excluded as they overlap a curse window
+	Min               float64 `json:"min(s)"`
+	Max               float64 `json:"max(s)"`
+	Avg               float64 `json:"avg(s)"`
+	Percentile        float64 `json:"percentile"`
+	PercentileLatency float64 `json:"percentile_latency(s)"`
+	SLOLatency        float64 `json:"slo_latency(s),omitempty"`
+	Breaches          int     `json:"breaches,omitempty"` // number of counted requests slower than the SLO latency
+}
+
+// Met returns whether the latency at the percentile is within the SLO. It's always met if no SLO is set.
+func (s *BlessLatencyStats) Met() bool {
+	return s.SLOLatency == 0 || s.PercentileLatency <= s.SLOLatency
+}
+
+// NewBlessLatencyStats returns the bless latencies of the successfully blessed requests of a lane. The requests with
+// blessing overlapping a curse window on either of their networks are excluded unless slo.IncludeCurse is set.
+// It returns nil if no request was blessed, e.g. with the mock ARM.
+func NewBlessLatencyStats(lane string, stats []*RequestStat, slo BlessLatencySLO, curses []CurseWindow) *BlessLatencyStats {
+	if slo.Percentile == 0 {
+		slo.Percentile = DefaultBlessLatencyPercentile
+	}
+	result := &BlessLatencyStats{
+		Percentile: slo.Percentile,
+		SLOLatency: slo.Latency.Seconds(),
+	}
+	var latencies []float64
+	for _, stat := range stats {
+		for _, span := range NewRequestTimeline(lane, stat).Spans {
+			if span.Phase != ReportBlessed || span.Status != Success {
+				continue
+			}
+			excluded := false
+			for i := 0; i < len(curses) && !slo.IncludeCurse && !excluded; i++ {
+				excluded = curses[i].overlaps(span.Start, span.End, slo.CurseGrace, stat.SourceNetwork, stat.DestNetwork)
+			}
+			if excluded {
+				result.Excluded++
+				continue
+			}
+			latency := span.End.Sub(span.Start).Seconds()
+			if result.SLOLatency > 0 && latency > result.SLOLatency {
+				result.Breaches++
+			}
+			latencies = append(latencies, latency)
+		}
+	}
+	if len(latencies) == 0 && result.Excluded == 0 {
+		return nil
+	}
+	result.Count = len(latencies)
+	if result.Count == 0 {
+		return result
+	}
+	sort.Float64s(latencies)
+	var sum float64
+	for _, l := range latencies {
+		sum += l
+	}
+	result.Min, result.Max, result.Avg = latencies[0], latencies[len(latencies)-1], sum/float64(len(latencies))
+	// nearest rank
+	index := int(math.Ceil(slo.Percentile/100*float64(len(latencies)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	result.PercentileLatency = latencies[index]
+	return result
+}
+
+// SetBlessLatencySLO sets the SLO the bless latency of every lane is checked against
+func (r *CCIPTestReporter) SetBlessLatencySLO(slo BlessLatencySLO) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blessLatencySLO = &slo
+}
+
+// AddCurseWindow records a period in which the ARM of a network was cursed, the blessing overlapping it is excluded
+// from the bless latency
+func (r *CCIPTestReporter) AddCurseWindow(window CurseWindow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.CurseWindows = append(r.CurseWindows, window)
+	r.logger.Info().
+		Str("Network", window.Network).
+		Time("Start", window.Start).
+		Time("End", window.End).
+		Msg("Curse window added")
+}
+
+// BlessLatencyStats returns the bless latency stats by lane, lanes with no blessed request are left out
+func (r *CCIPTestReporter) BlessLatencyStats() map[string]*BlessLatencyStats {
+	r.mu.Lock()
+	slo := BlessLatencySLO{}
+	if r.blessLatencySLO != nil {
+		slo = *r.blessLatencySLO
+	}
+	curses := append([]CurseWindow{}, r.CurseWindows...)
+	r.mu.Unlock()
+	statsByLane := make(map[string]*BlessLatencyStats)
+	for lane, laneStats := range r.LaneStats {
+		if stats := NewBlessLatencyStats(lane, laneStats.RequestStats(), slo, curses); stats != nil {
+			statsByLane[lane] = stats
+		}
+	}
+	return statsByLane
+}
+
+// AssertBlessLatencySLO returns an error listing the lanes with the bless latency at the SLO percentile above the
+// SLO latency. It returns nil if no SLO is set.
+func (r *CCIPTestReporter) AssertBlessLatencySLO() error {
+	if r.blessLatencySLO == nil || r.blessLatencySLO.Latency == 0 {
+		return nil
+	}
+	var breached []string
+	for lane, stats := range r.BlessLatencyStats() {
+		if !stats.Met() {
+			breached = append(breached, fmt.Sprintf("%s: p%g %.02fs > %.02fs (%d of %d requests slower)",
+				lane, stats.Percentile, stats.PercentileLatency, stats.SLOLatency, stats.Breaches, stats.Count))
+		}
+	}
+	if len(breached) == 0 {
+		return nil
+	}
+	sort.Strings(breached)
+	return fmt.Errorf("bless latency SLO breached on %d lanes: %s", len(breached), strings.Join(breached, "; "))
+}
@@ -0,0 +1,82 @@
+package testreporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// blessedRequest returns a request committed 15s after sentAt and blessed blessDuration after the commit
+func blessedRequest(reqNo int64, sentAt time.Time, blessDuration time.Duration) *RequestStat {
+	stat := NewCCIPRequestStats(reqNo, "source", "dest")
+	stat.SentAt = sentAt
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), TX, 5*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), Commit, 10*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), ReportBlessed, blessDuration, Success)
+	return stat
+}
+
+func TestNewBlessLatencyStats(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	var stats []*RequestStat
+	for i := int64(1); i <= 10; i++ {
+		stats = append(stats, blessedRequest(i, sentAt, time.Duration(i)*time.Second))
+	}
+	// not blessed, e.g. with the mock ARM
+	stats = append(stats, timedRequest(11, sentAt, time.Second, Success))
+
+	blessStats := NewBlessLatencyStats("lane", stats, BlessLatencySLO{Percentile: 90, Latency: 8 * time.Second}, nil)
+	require.NotNil(t, blessStats)
+	require.Equal(t, 10, blessStats.Count)
+	require.Equal(t, 1.0, blessStats.Min)
+	require.Equal(t, 10.0, blessStats.Max)
+	require.Equal(t, 5.5, blessStats.Avg)
+	require.Equal(t, 9.0, blessStats.PercentileLatency)
+	require.Equal(t, 2, blessStats.Breaches)
+	require.False(t, blessStats.Met())
+
+	// the slowest requests are blessed across a curse on the dest, the window ends before they are committed
+	curses := []CurseWindow{{Network: "dest", Start: sentAt, End: sentAt.Add(14 * time.Second)}}
+	slo := BlessLatencySLO{Percentile: 90, Latency: 8 * time.Second}
+	blessStats = NewBlessLatencyStats("lane", stats, slo, curses)
+	require.Equal(t, 10, blessStats.Count, "window ends before the commit")
+	slo.CurseGrace = 2 * time.Second
+	blessStats = NewBlessLatencyStats("lane", stats, slo, curses)
+	require.Equal(t, 0, blessStats.Count)
+	require.Equal(t, 10, blessStats.Excluded)
+	require.True(t, blessStats.Met())
+	slo.IncludeCurse = true
+	require.Equal(t, 10, NewBlessLatencyStats("lane", stats, slo, curses).Count)
+	// curses on other networks don't matter
+	curses[0].Network = "other"
+	slo.IncludeCurse = false
+	require.Equal(t, 10, NewBlessLatencyStats("lane", stats, slo, curses).Count)
+
+	require.Nil(t, NewBlessLatencyStats("lane", stats[10:], slo, nil))
+	require.Equal(t, DefaultBlessLatencyPercentile, NewBlessLatencyStats("lane", stats, BlessLatencySLO{}, nil).Percentile)
+}
+
+func TestAssertBlessLatencySLO(t *testing.T) {
+	t.Parallel()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	sentAt := time.Now().UTC()
+	fast := reporter.AddNewLane("fast", zerolog.Nop())
+	slow := reporter.AddNewLane("slow", zerolog.Nop())
+	for i := int64(1); i <= 4; i++ {
+		fast.UpdatePhaseStatsForReq(blessedRequest(i, sentAt, time.Second))
+		slow.UpdatePhaseStatsForReq(blessedRequest(i, sentAt, time.Minute))
+	}
+	require.NoError(t, reporter.AssertBlessLatencySLO(), "no SLO set")
+
+	reporter.SetBlessLatencySLO(BlessLatencySLO{Latency: 30 * time.Second})
+	err := reporter.AssertBlessLatencySLO()
+	require.ErrorContains(t, err, "breached on 1 lanes")
+	require.ErrorContains(t, err, "slow: p95 60.00s > 30.00s (4 of 4 requests slower)")
+
+	reporter.AddCurseWindow(CurseWindow{Network: "dest", Start: sentAt, End: sentAt.Add(time.Hour)})
+	require.NoError(t, reporter.AssertBlessLatencySLO())
+	require.Equal(t, 4, reporter.BlessLatencyStats()["slow"].Excluded)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -35,6 +36,8 @@ const (
 	Failure   Status = "❌"
 	Unsure           = "⚠️"
 	slackFile string = "payload_ccip.json"
+	// RequestStatsFile is the file the stats of every request are written to, it can be replayed offline with SimulateWhatIf
+	RequestStatsFile string = "requests_ccip.json"
 )
 
 type AggregatorMetrics struct {
@@ -54,6 +57,9 @@ type TransactionStats struct {
 	FinalizedByBlock   string `json:"finalized_block_num,omitempty"`
 	FinalizedAt        string `json:"finalized_at,omitempty"`
 	CommitRoot         string `json:"commit_root,omitempty"`
+	FailureReason      string `json:"failure_reason,omitempty"`   // decoded revert reason for failed execution
+	FailureCategory    string `json:"failure_category,omitempty"` // origin of the failure for failed execution
+	BlockNumber        uint64 `json:"block_number,omitempty"`     // block of the tx or the event of the phase
 }
 
 type PhaseStat struct {
@@ -61,6 +67,7 @@ type PhaseStat struct {
 	Duration             float64          `json:"duration,omitempty"`
 	Status               Status           `json:"success"`
 	SendTransactionStats TransactionStats `json:"ccip_send_data,omitempty"`
+	ObservedAt           time.Time        `json:"observed_at,omitempty"` // time the phase was recorded at
 }
 
 type RequestStat struct {
@@ -68,7 +75,14 @@ type RequestStat struct {
 	SeqNum        uint64
 	SourceNetwork string
 	DestNetwork   string
+	SentAt        time.Time           `json:"sent_at,omitempty"` // time at which the request stat is created, right before the request is sent
 	StatusByPhase map[Phase]PhaseStat `json:"status_by_phase,omitempty"`
+	notifier      PhaseNotifier
+}
+
+// SetNotifier sets the notifier of the phase transitions of the request, nil stops the notifications
+func (stat *RequestStat) SetNotifier(notifier PhaseNotifier) {
+	stat.notifier = notifier
 }
 
 func (stat *RequestStat) UpdateState(
@@ -82,14 +96,16 @@ func (stat *RequestStat) UpdateState(
 	durationInSec := duration.Seconds()
 	stat.SeqNum = seqNum
 	phaseDetails := PhaseStat{
-		SeqNum:   seqNum,
-		Duration: durationInSec,
-		Status:   state,
+		SeqNum:     seqNum,
+		Duration:   durationInSec,
+		Status:     state,
+		ObservedAt: time.Now().UTC(),
 	}
 	if len(sendTransactionStats) > 0 {
 		phaseDetails.SendTransactionStats = sendTransactionStats[0]
 	}
 	stat.StatusByPhase[step] = phaseDetails
+	stat.notify(step, phaseDetails)
 	event := lggr.Info()
 	if seqNum != 0 {
 		event.Uint64("seq num", seqNum)
@@ -121,9 +137,35 @@ func (stat *RequestStat) UpdateState(
 	}
 }
 
+// notify notifies the notifier of the request, if any, of its transition to step
+func (stat *RequestStat) notify(step Phase, phaseDetails PhaseStat) {
+	if stat.notifier == nil {
+		return
+	}
+	msgEvent, ok := msgEventForPhase(step, phaseDetails.Status)
+	if !ok {
+		return
+	}
+	stat.notifier.Notify(MsgTransition{
+		Event:         msgEvent,
+		Phase:         step,
+		Status:        phaseDetails.Status,
+		SourceNetwork: stat.SourceNetwork,
+		DestNetwork:   stat.DestNetwork,
+		ReqNo:         stat.ReqNo,
+		SeqNum:        phaseDetails.SeqNum,
+		MsgID:         phaseDetails.SendTransactionStats.MsgID,
+		TxHash:        phaseDetails.SendTransactionStats.TxHash,
+		Duration:      phaseDetails.Duration,
+		FailureReason: phaseDetails.SendTransactionStats.FailureReason,
+		Timestamp:     time.Now().UTC(),
+	})
+}
+
 func NewCCIPRequestStats(reqNo int64, source, dest string) *RequestStat {
 	return &RequestStat{
 		ReqNo:         reqNo,
+		SentAt:        time.Now().UTC(),
 		StatusByPhase: make(map[Phase]PhaseStat),
 		SourceNetwork: source,
 		DestNetwork:   dest,
@@ -137,11 +179,69 @@ type CCIPLaneStats struct {
 	SuccessCountsByPhase    map[Phase]int64             `json:"success_counts_by_phase,omitempty"` // SuccessCountsByPhase is the number of requests that succeeded in each phase
 	FailedCountsByPhase     map[Phase]int64             `json:"failed_counts_by_phase,omitempty"`  // FailedCountsByPhase is the number of requests that failed in each phase
 	DurationStatByPhase     map[Phase]AggregatorMetrics `json:"duration_stat_by_phase,omitempty"`  // DurationStatByPhase is the duration statistics for each phase
+	CommitIntervalSizes     map[uint64]int64            `json:"commit_interval_sizes,omitempty"`   // CommitIntervalSizes is the number of commit reports accepted by the size of their interval
+	BlessLatency            *BlessLatencyStats          `json:"bless_latency,omitempty"`           // BlessLatency is the time from commit till bless, apart from the SLO of the other phases
+	TimeoutSLA              map[Phase]*PhaseTimeoutSLA  `json:"timeout_sla,omitempty"`             // TimeoutSLA is the share of requests within the raw and the adjusted phase timeouts, set with adaptive timeouts
+	FailureBreakdown        map[FailureClass]int64      `json:"failure_breakdown,omitempty"`       // FailureBreakdown is the number of failed executions by failure class
 	statusByPhaseByRequests sync.Map
+	commitIntervals         sync.Map
+	timeoutsMu              sync.Mutex
+	phaseTimeouts           map[Phase]phaseTimeout
+}
+
+// CommitInterval is the interval of sequence numbers of a commit report accepted by the CommitStore
+type CommitInterval struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+// Size returns the number of sequence numbers in the interval
+func (i CommitInterval) Size() uint64 {
+	return i.Max - i.Min + 1
+}
+
+// RecordCommitInterval records the interval of an accepted commit report. The same report seen more than once, e.g.
+// after a resubscription, is recorded once.
+func (testStats *CCIPLaneStats) RecordCommitInterval(minSeqNum, maxSeqNum uint64) {
+	if minSeqNum == 0 || maxSeqNum < minSeqNum {
+		// reports with only price updates have no interval
+		return
+	}
+	testStats.commitIntervals.Store(minSeqNum, CommitInterval{Min: minSeqNum, Max: maxSeqNum})
+}
+
+// CommitIntervals returns the recorded intervals of the accepted commit reports ordered by their min sequence number
+func (testStats *CCIPLaneStats) CommitIntervals() []CommitInterval {
+	var intervals []CommitInterval
+	testStats.commitIntervals.Range(func(_, value interface{}) bool {
+		if interval, ok := value.(CommitInterval); ok {
+			intervals = append(intervals, interval)
+		}
+		return true
+	})
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Min < intervals[j].Min
+	})
+	return intervals
 }
 
 func (testStats *CCIPLaneStats) UpdatePhaseStatsForReq(stat *RequestStat) {
-	testStats.statusByPhaseByRequests.Store(stat.ReqNo, stat.StatusByPhase)
+	testStats.statusByPhaseByRequests.Store(stat.ReqNo, stat)
+}
+
+// RequestStats returns the stats of all the requests recorded for the lane ordered by request number
+func (testStats *CCIPLaneStats) RequestStats() []*RequestStat {
+	var stats []*RequestStat
+	testStats.statusByPhaseByRequests.Range(func(_, value interface{}) bool {
+		if stat, ok := value.(*RequestStat); ok {
+			stats = append(stats, stat)
+		}
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ReqNo < stats[j].ReqNo
+	})
+	return stats
 }
 
 func (testStats *CCIPLaneStats) Aggregate(phase Phase, durationInSec float64) {
@@ -170,8 +270,8 @@ func (testStats *CCIPLaneStats) Finalize(lane string) {
 	events := make(map[Phase]*zerolog.Event)
 	testStats.statusByPhaseByRequests.Range(func(key, value interface{}) bool {
 		if reqNo, ok := key.(int64); ok {
-			if stat, ok := value.(map[Phase]PhaseStat); ok {
-				for phase, phaseStat := range stat {
+			if stat, ok := value.(*RequestStat); ok {
+				for phase, phaseStat := range stat.StatusByPhase {
 					if phaseStat.Status == Success {
 						testStats.SuccessCountsByPhase[phase]++
 						testStats.Aggregate(phase, phaseStat.Duration)
@@ -191,6 +291,16 @@ func (testStats *CCIPLaneStats) Finalize(lane string) {
 		return
 	}
 	testStats.lggr.Info().Int64("Total Requests Triggerred", testStats.TotalRequests).Msg("Test Run Completed")
+	if intervals := testStats.CommitIntervals(); len(intervals) > 0 {
+		testStats.CommitIntervalSizes = make(map[uint64]int64)
+		for _, interval := range intervals {
+			testStats.CommitIntervalSizes[interval.Size()]++
+		}
+		testStats.lggr.Info().
+			Int("Commit Reports", len(intervals)).
+			Interface("Reports By Interval Size", testStats.CommitIntervalSizes).
+			Msgf("Commit Interval Stats for Lane %s", lane)
+	}
 	for _, phase := range phases {
 		events[phase] = testStats.lggr.Info().Str("Phase", string(phase))
 		if phaseStat, ok := testStats.DurationStatByPhase[phase]; ok {
@@ -212,6 +322,16 @@ func (testStats *CCIPLaneStats) Finalize(lane string) {
 		}
 		events[phase].Msgf("Phase Stats for Lane %s", lane)
 	}
+	testStats.finalizeTimeoutSLA(lane)
+}
+
+// ChaosEvent denotes a fault injected in the test environment
+type ChaosEvent struct {
+	Name   string    `json:"name"`
+	Target string    `json:"target,omitempty"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end,omitempty"`
+	Error  string    `json:"error,omitempty"`
 }
 
 type CCIPTestReporter struct {
@@ -227,8 +347,13 @@ type CCIPTestReporter struct {
 	duration           time.Duration             // duration is the duration of the test
 	FailedLanes        map[string]Phase          `json:"failed_lanes_and_phases,omitempty"` // FailedLanes is the list of lanes that failed and the phase at which it failed
 	LaneStats          map[string]*CCIPLaneStats `json:"lane_stats"`                        // LaneStats is the statistics for each lane
+	ChaosTimeline      []ChaosEvent              `json:"chaos_timeline,omitempty"`          // ChaosTimeline is the list of chaos events injected during the test
+	CurseWindows       []CurseWindow             `json:"curse_windows,omitempty"`           // CurseWindows are the periods the ARM was cursed in, excluded from the bless latency
 	mu                 *sync.Mutex
 	sendSlackReport    bool
+	timelineRequests   int // number of slowest successful requests in the timeline of the report
+	blessLatencySLO    *BlessLatencySLO
+	failureTaxonomy    FailureTaxonomy // rules the failed executions are classified with, DefaultFailureTaxonomy if not set
 }
 
 func (r *CCIPTestReporter) SetSendSlackReport(sendSlackReport bool) {
@@ -329,6 +454,9 @@ func (r *CCIPTestReporter) SendSlackNotification(t *testing.T, slackClient *slac
 					fmt.Sprintf(
 						"\nNumber of ccip-send= %d"+
 							"\nNo of failed requests = %d", lane.TotalRequests, lane.FailedCountsByPhase[E2E]))
+				if len(lane.FailureBreakdown) > 0 {
+					msgTexts = append(msgTexts, fmt.Sprintf("\nFailed executions = %s", formatFailureBreakdown(lane.FailureBreakdown)))
+				}
 			}
 		}
 
@@ -370,8 +498,25 @@ func (r *CCIPTestReporter) SendSlackNotification(t *testing.T, slackClient *slac
 
 func (r *CCIPTestReporter) WriteReport(folderPath string) error {
 	l := r.logger
+	blessLatency := r.BlessLatencyStats()
 	for k := range r.LaneStats {
 		r.LaneStats[k].Finalize(k)
+		r.LaneStats[k].FailureBreakdown = NewFailureBreakdown(r.LaneStats[k].RequestStats(), r.taxonomy())
+		if len(r.LaneStats[k].FailureBreakdown) > 0 {
+			l.Info().
+				Interface("Failures By Class", r.LaneStats[k].FailureBreakdown).
+				Msgf("Execution Failure Breakdown for Lane %s", k)
+		}
+		if stats, ok := blessLatency[k]; ok {
+			r.LaneStats[k].BlessLatency = stats
+			l.Info().
+				Int("Count", stats.Count).
+				Int("Excluded In Curse Windows", stats.Excluded).
+				Str("Average", fmt.Sprintf("%.02f", stats.Avg)).
+				Str(fmt.Sprintf("P%g", stats.Percentile), fmt.Sprintf("%.02f", stats.PercentileLatency)).
+				Bool("SLO Met", stats.Met()).
+				Msgf("Bless Latency for Lane %s", k)
+		}
 		// if E2E for the lane has failed
 		if _, ok := r.LaneStats[k].FailedCountsByPhase[E2E]; ok {
 			// find the phase at which it failed
@@ -387,6 +532,23 @@ func (r *CCIPTestReporter) WriteReport(folderPath string) error {
 		r.logger.Info().Interface("List of Failed Lanes", r.FailedLanes).Msg("Failed Lanes")
 	}
 
+	// the request stats are written irrespective of the grafana dashboard, so that the run can be simulated offline
+	if err := r.WriteRequestStats(folderPath); err != nil {
+		return err
+	}
+	if err := r.WriteTimeline(folderPath); err != nil {
+		return err
+	}
+	if err := r.WriteFailureBreakdown(folderPath); err != nil {
+		return err
+	}
+	if err := r.WriteBidirectionalReport(folderPath); err != nil {
+		return err
+	}
+	if err := r.WritePhaseEvents(folderPath); err != nil {
+		return err
+	}
+
 	// if grafanaURLProvider is set, we don't want to write the report in a file
 	// the report will be shared in terms of grafana dashboard link
 	if r.grafanaURLProvider != nil {
@@ -419,6 +581,29 @@ func (r *CCIPTestReporter) WriteReport(folderPath string) error {
 	return nil
 }
 
+// WriteRequestStats writes the stats of every request grouped by lane in RequestStatsFile under folderPath
+func (r *CCIPTestReporter) WriteRequestStats(folderPath string) error {
+	requestStats := make(map[string][]*RequestStat)
+	for lane, laneStats := range r.LaneStats {
+		if stats := laneStats.RequestStats(); len(stats) > 0 {
+			requestStats[lane] = stats
+		}
+	}
+	if len(requestStats) == 0 {
+		return nil
+	}
+	if err := testreporters.MkdirIfNotExists(folderPath); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(requestStats, "", "  ")
+	if err != nil {
+		return err
+	}
+	reportLocation := filepath.Join(folderPath, RequestStatsFile)
+	r.logger.Info().Str("File", reportLocation).Msg("Writing CCIP request stats")
+	return os.WriteFile(reportLocation, content, 0o600)
+}
+
 // SetNamespace sets the namespace of the report for clean reports
 func (r *CCIPTestReporter) SetNamespace(namespace string) {
 	// if the test is run in remote runner, the namespace will be set to the remote runner's namespace
@@ -430,6 +615,15 @@ func (r *CCIPTestReporter) SetNamespace(namespace string) {
 	r.namespace = namespace
 }
 
+// RunID identifies the run of the report across the runs of all the tests, it's the namespace of the run and the time
+// the report was created at
+func (r *CCIPTestReporter) RunID() string {
+	if r.namespace == "" {
+		return fmt.Sprintf("%d", r.startTime)
+	}
+	return fmt.Sprintf("%s-%d", r.namespace, r.startTime)
+}
+
 // SetDuration sets the duration of the test
 func (r *CCIPTestReporter) SetDuration(d time.Duration) {
 	r.duration = d
@@ -439,6 +633,22 @@ func (r *CCIPTestReporter) SetGrafanaURLProvider(provider testreporters.GrafanaU
 	r.grafanaURLProvider = provider
 }
 
+// AddChaosEvent adds the chaos event to the timeline of the report
+func (r *CCIPTestReporter) AddChaosEvent(event ChaosEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ChaosTimeline = append(r.ChaosTimeline, event)
+	e := r.logger.Info().
+		Str("Chaos", event.Name).
+		Str("Target", event.Target).
+		Time("Start", event.Start).
+		Time("End", event.End)
+	if event.Error != "" {
+		e = e.Str("Error", event.Error)
+	}
+	e.Msg("Chaos event added to timeline")
+}
+
 func (r *CCIPTestReporter) AddNewLane(name string, lggr zerolog.Logger) *CCIPLaneStats {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -463,11 +673,12 @@ func (r *CCIPTestReporter) SendReport(t *testing.T, namespace string, slackSend
 
 func NewCCIPTestReporter(t *testing.T, lggr zerolog.Logger) *CCIPTestReporter {
 	return &CCIPTestReporter{
-		LaneStats:   make(map[string]*CCIPLaneStats),
-		startTime:   time.Now().UTC().UnixMilli(),
-		logger:      lggr,
-		t:           t,
-		mu:          &sync.Mutex{},
-		FailedLanes: make(map[string]Phase),
+		LaneStats:        make(map[string]*CCIPLaneStats),
+		startTime:        time.Now().UTC().UnixMilli(),
+		logger:           lggr,
+		t:                t,
+		mu:               &sync.Mutex{},
+		FailedLanes:      make(map[string]Phase),
+		timelineRequests: DefaultTimelineRequests,
 	}
 }
@@ -0,0 +1,28 @@
+package testreporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitIntervalSizes(t *testing.T) {
+	t.Parallel()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	laneStats := reporter.AddNewLane("source-dest", zerolog.Nop())
+	for reqNo := int64(1); reqNo <= 6; reqNo++ {
+		laneStats.UpdatePhaseStatsForReq(timedRequest(reqNo, time.Now().UTC(), 20*time.Second, Success))
+	}
+	laneStats.RecordCommitInterval(5, 6)
+	laneStats.RecordCommitInterval(1, 1)
+	laneStats.RecordCommitInterval(2, 4)
+	// the same report seen again and a report with only price updates are not recorded
+	laneStats.RecordCommitInterval(2, 4)
+	laneStats.RecordCommitInterval(0, 0)
+
+	require.Equal(t, []CommitInterval{{Min: 1, Max: 1}, {Min: 2, Max: 4}, {Min: 5, Max: 6}}, laneStats.CommitIntervals())
+	laneStats.Finalize("source-dest")
+	require.Equal(t, map[uint64]int64{1: 1, 2: 1, 3: 1}, laneStats.CommitIntervalSizes)
+}
@@ -0,0 +1,292 @@
+package testreporters
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/testreporters"
+)
+
+// FailureBreakdownFile is the file the breakdown of the failed executions by failure class is written to
+const FailureBreakdownFile string = "failures_ccip.html"
+
+// FailureClass is the triage class of a failed execution on the dest chain
+type FailureClass string
+
+const (
+	FailureReceiverBug   FailureClass = "receiver_bug"   // ccipReceive of the receiver reverted
+	FailureOutOfGas      FailureClass = "out_of_gas"     // the execution ran out of gas, usually seen as empty revert data
+	FailureRateLimited   FailureClass = "rate_limited"   // the offRamp or the token pool rate limiter rejected the message
+	FailurePoolLiquidity FailureClass = "pool_liquidity" // the dest token pool did not have enough liquidity to release
+	FailureUnknown       FailureClass = "unknown"        // the failure matched none of the rules
+)
+
+// FailureRule classifies the failures matching it as Class. A failure matches if its category is Category, when set,
+// and its decoded reason contains any of ReasonContains, when set.
+type FailureRule struct {
+	Class          FailureClass
+	Category       string
+	ReasonContains []string
+}
+
+func (rule FailureRule) matches(category, reason string) bool {
+	if rule.Category == "" && len(rule.ReasonContains) == 0 {
+		return false
+	}
+	if rule.Category != "" && rule.Category != category {
+		return false
+	}
+	if len(rule.ReasonContains) == 0 {
+		return true
+	}
+	for _, s := range rule.ReasonContains {
+		if strings.Contains(reason, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// FailureTaxonomy classifies a failure by the first rule it matches
+type FailureTaxonomy []FailureRule
+
+// DefaultFailureTaxonomy classifies the failures by the errors of the offRamp, the token pools and the receiver.
+// The reason based rules come first, as a receiver running out of gas or a pool running out of liquidity are
+// reported in the category of the contract which reverted.
+var DefaultFailureTaxonomy = FailureTaxonomy{
+	{Class: FailureOutOfGas, ReasonContains: []string{"empty revert data", "out of gas"}},
+	{Class: FailureRateLimited, ReasonContains: []string{"RateLimitReached", "MaxCapacityExceeded", "BucketOverfilled"}},
+	{Class: FailurePoolLiquidity, ReasonContains: []string{"InsufficientLiquidity", "exceeds balance"}},
+	{Class: FailureReceiverBug, Category: "ReceiverRevert"},
+}
+
+// Classify returns the class of the first rule matched by the failure category and reason, FailureUnknown if none
+func (taxonomy FailureTaxonomy) Classify(category, reason string) FailureClass {
+	for _, rule := range taxonomy {
+		if rule.matches(category, reason) {
+			return rule.Class
+		}
+	}
+	return FailureUnknown
+}
+
+// NewFailureBreakdown returns the number of failed executions by class. Only the executions which failed on the dest
+// chain are counted, the requests failing in the other phases have no decoded failure.
+func NewFailureBreakdown(stats []*RequestStat, taxonomy FailureTaxonomy) map[FailureClass]int64 {
+	breakdown := make(map[FailureClass]int64)
+	for _, stat := range stats {
+		exec, ok := stat.StatusByPhase[ExecStateChanged]
+		if !ok || exec.Status != Failure {
+			continue
+		}
+		txStats := exec.SendTransactionStats
+		if txStats.FailureCategory == "" && txStats.FailureReason == "" {
+			continue
+		}
+		breakdown[taxonomy.Classify(txStats.FailureCategory, txStats.FailureReason)]++
+	}
+	if len(breakdown) == 0 {
+		return nil
+	}
+	return breakdown
+}
+
+// SetFailureTaxonomy sets the rules the failed executions are classified with, ahead of DefaultFailureTaxonomy
+func (r *CCIPTestReporter) SetFailureTaxonomy(rules FailureTaxonomy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failureTaxonomy = append(append(FailureTaxonomy{}, rules...), DefaultFailureTaxonomy...)
+}
+
+func (r *CCIPTestReporter) taxonomy() FailureTaxonomy {
+	if r.failureTaxonomy == nil {
+		return DefaultFailureTaxonomy
+	}
+	return r.failureTaxonomy
+}
+
+// formatFailureBreakdown returns the breakdown as class=count pairs ordered by count
+func formatFailureBreakdown(breakdown map[FailureClass]int64) string {
+	classes := sortedFailureClasses(breakdown)
+	parts := make([]string, 0, len(classes))
+	for _, class := range classes {
+		parts = append(parts, fmt.Sprintf("%s=%d", class, breakdown[class]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedFailureClasses returns the classes in the breakdown by descending count, then by name
+func sortedFailureClasses(breakdown map[FailureClass]int64) []FailureClass {
+	classes := make([]FailureClass, 0, len(breakdown))
+	for class := range breakdown {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if breakdown[classes[i]] != breakdown[classes[j]] {
+			return breakdown[classes[i]] > breakdown[classes[j]]
+		}
+		return classes[i] < classes[j]
+	})
+	return classes
+}
+
+const failurePieRadius = 120
+
+var failureColors = map[FailureClass]string{
+	FailureReceiverBug:   "#e6550d",
+	FailureOutOfGas:      "#756bb1",
+	FailureRateLimited:   "#3182bd",
+	FailurePoolLiquidity: "#31a354",
+	FailureUnknown:       "#969696",
+}
+
+// failureColorsExtra are used in order for the classes of the custom rules
+var failureColorsExtra = []string{"#fd8d3c", "#9e9ac8", "#6baed6", "#74c476", "#fdae6b", "#bcbddc"}
+
+type failureSlice struct {
+	Class   FailureClass
+	Count   int64
+	Percent float64
+	Color   string
+	Path    string // svg path of the slice, empty if the slice is the whole pie
+}
+
+type failureLane struct {
+	Lane      string
+	Breakdown string
+}
+
+type failurePage struct {
+	Size   int
+	Radius int
+	Total  int64
+	Slices []failureSlice
+	Lanes  []failureLane
+}
+
+var failureTemplate = template.Must(template.New("failures").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>CCIP execution failures</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+.legend i { display: inline-block; width: 12px; height: 12px; margin-right: 4px; vertical-align: middle; }
+td { padding: 2px 12px 2px 0; }
+</style>
+</head>
+<body>
+<h3>CCIP execution failures</h3>
+<p>{{.Total}} failed executions, hover over a slice for its details.</p>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Size}}" height="{{.Size}}">
+{{- range .Slices}}
+{{- if .Path}}
+<path d="{{.Path}}" fill="{{.Color}}" stroke="#fff"><title>{{.Class}}: {{.Count}} ({{printf "%.1f" .Percent}}%)</title></path>
+{{- else}}
+<circle cx="{{$.Radius}}" cy="{{$.Radius}}" r="{{$.Radius}}" fill="{{.Color}}"><title>{{.Class}}: {{.Count}} (100%)</title></circle>
+{{- end}}
+{{- end}}
+</svg>
+<table class="legend">
+{{- range .Slices}}
+<tr><td><i style="background:{{.Color}}"></i>{{.Class}}</td><td>{{.Count}}</td><td>{{printf "%.1f" .Percent}}%</td></tr>
+{{- end}}
+</table>
+<h4>By lane</h4>
+<table>
+{{- range .Lanes}}
+<tr><td>{{.Lane}}</td><td>{{.Breakdown}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteFailureBreakdownHTML writes the breakdown of all the lanes as a pie chart in an html page with an inline svg,
+// followed by the breakdown of every lane
+func WriteFailureBreakdownHTML(w io.Writer, breakdownByLane map[string]map[FailureClass]int64) error {
+	total := make(map[FailureClass]int64)
+	page := failurePage{
+		Size:   2 * failurePieRadius,
+		Radius: failurePieRadius,
+	}
+	for lane, breakdown := range breakdownByLane {
+		for class, count := range breakdown {
+			total[class] += count
+			page.Total += count
+		}
+		page.Lanes = append(page.Lanes, failureLane{Lane: lane, Breakdown: formatFailureBreakdown(breakdown)})
+	}
+	sort.Slice(page.Lanes, func(i, j int) bool {
+		return page.Lanes[i].Lane < page.Lanes[j].Lane
+	})
+	if page.Total == 0 {
+		return failureTemplate.Execute(w, page)
+	}
+	r := float64(failurePieRadius)
+	point := func(angle float64) (float64, float64) {
+		// angles start at 12 o'clock and go clockwise
+		return r + r*math.Sin(angle), r - r*math.Cos(angle)
+	}
+	extra := 0
+	angle := 0.0
+	for _, class := range sortedFailureClasses(total) {
+		color, ok := failureColors[class]
+		if !ok {
+			color = failureColorsExtra[extra%len(failureColorsExtra)]
+			extra++
+		}
+		share := float64(total[class]) / float64(page.Total)
+		slice := failureSlice{
+			Class:   class,
+			Count:   total[class],
+			Percent: share * 100,
+			Color:   color,
+		}
+		if share < 1 {
+			end := angle + share*2*math.Pi
+			x1, y1 := point(angle)
+			x2, y2 := point(end)
+			largeArc := 0
+			if share > 0.5 {
+				largeArc = 1
+			}
+			slice.Path = fmt.Sprintf("M %.2f %.2f L %.2f %.2f A %.2f %.2f 0 %d 1 %.2f %.2f Z",
+				r, r, x1, y1, r, r, largeArc, x2, y2)
+			angle = end
+		}
+		page.Slices = append(page.Slices, slice)
+	}
+	return failureTemplate.Execute(w, page)
+}
+
+// WriteFailureBreakdown writes the breakdown of the failed executions of all the lanes in FailureBreakdownFile
+// under folderPath. Nothing is written if there are no failed executions.
+func (r *CCIPTestReporter) WriteFailureBreakdown(folderPath string) error {
+	breakdownByLane := make(map[string]map[FailureClass]int64)
+	for lane, laneStats := range r.LaneStats {
+		if len(laneStats.FailureBreakdown) > 0 {
+			breakdownByLane[lane] = laneStats.FailureBreakdown
+		}
+	}
+	if len(breakdownByLane) == 0 {
+		return nil
+	}
+	if err := testreporters.MkdirIfNotExists(folderPath); err != nil {
+		return err
+	}
+	reportLocation := filepath.Join(folderPath, FailureBreakdownFile)
+	f, err := os.Create(reportLocation)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r.logger.Info().Str("File", reportLocation).Msg("Writing CCIP execution failure breakdown")
+	return WriteFailureBreakdownHTML(f, breakdownByLane)
+}
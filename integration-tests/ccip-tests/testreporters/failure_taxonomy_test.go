@@ -0,0 +1,85 @@
+package testreporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func failedExec(reqNo int64, category, reason string) *RequestStat {
+	stat := NewCCIPRequestStats(reqNo, "source", "dest")
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), ExecStateChanged, time.Second, Failure,
+		TransactionStats{FailureCategory: category, FailureReason: reason})
+	return stat
+}
+
+func TestFailureTaxonomyClassify(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		category string
+		reason   string
+		expected FailureClass
+	}{
+		{"ReceiverRevert", "ReceiverError(empty revert data)", FailureOutOfGas},
+		{"ReceiverRevert", "ReceiverError(CustomError)", FailureReceiverBug},
+		{"OffRamp", "AggregateValueRateLimitReached(100, 50)", FailureRateLimited},
+		{"TokenHandling", "TokenHandlingError(TokenMaxCapacityExceeded(10, 20, 0x1))", FailureRateLimited},
+		{"TokenHandling", "TokenHandlingError(InsufficientLiquidity())", FailurePoolLiquidity},
+		{"TokenHandling", "TokenHandlingError(ERC20: transfer amount exceeds balance)", FailurePoolLiquidity},
+		{"OffRamp", "InvalidManualExecutionGasLimit(1, 2)", FailureUnknown},
+		{"Unknown", "no return data", FailureUnknown},
+	} {
+		require.Equal(t, tc.expected, DefaultFailureTaxonomy.Classify(tc.category, tc.reason), tc.reason)
+	}
+
+	custom := append(FailureTaxonomy{
+		{Class: "price_stale", Category: "OffRamp", ReasonContains: []string{"StaleGasPrice"}},
+		// a rule matching anything is never applied
+		{Class: "everything"},
+	}, DefaultFailureTaxonomy...)
+	require.Equal(t, FailureClass("price_stale"), custom.Classify("OffRamp", "StaleGasPrice(1, 2, 3)"))
+	require.Equal(t, FailureUnknown, custom.Classify("TokenHandling", "StaleGasPrice(1, 2, 3)"))
+	require.Equal(t, FailureReceiverBug, custom.Classify("ReceiverRevert", "ReceiverError(0x)"))
+}
+
+func TestNewFailureBreakdown(t *testing.T) {
+	t.Parallel()
+	stats := []*RequestStat{
+		failedExec(1, "ReceiverRevert", "ReceiverError(empty revert data)"),
+		failedExec(2, "ReceiverRevert", "ReceiverError(CustomError)"),
+		failedExec(3, "ReceiverRevert", "ReceiverError(empty revert data)"),
+		failedExec(4, "", ""),
+		timedRequest(5, time.Now(), time.Second, Success),
+	}
+	breakdown := NewFailureBreakdown(stats, DefaultFailureTaxonomy)
+	require.Equal(t, map[FailureClass]int64{FailureOutOfGas: 2, FailureReceiverBug: 1}, breakdown)
+	require.Equal(t, "out_of_gas=2, receiver_bug=1", formatFailureBreakdown(breakdown))
+	require.Nil(t, NewFailureBreakdown(stats[3:], DefaultFailureTaxonomy))
+}
+
+func TestWriteFailureBreakdownHTML(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	require.NoError(t, WriteFailureBreakdownHTML(&buf, map[string]map[FailureClass]int64{
+		"lane1": {FailureOutOfGas: 3, FailureRateLimited: 1},
+		"lane2": {FailureOutOfGas: 1, "custom": 1},
+	}))
+	page := buf.String()
+	require.Contains(t, page, "6 failed executions")
+	require.Equal(t, 3, strings.Count(page, "<path "), "one slice per class")
+	require.Contains(t, page, "out_of_gas: 4 (66.7%)")
+	require.Contains(t, page, "out_of_gas=3, rate_limited=1")
+	require.Less(t, strings.Index(page, "lane1"), strings.Index(page, "lane2"))
+
+	// a single class is drawn as a full circle
+	buf.Reset()
+	require.NoError(t, WriteFailureBreakdownHTML(&buf, map[string]map[FailureClass]int64{
+		"lane1": {FailureReceiverBug: 2},
+	}))
+	require.Contains(t, buf.String(), "<circle ")
+	require.NotContains(t, buf.String(), "<path ")
+}
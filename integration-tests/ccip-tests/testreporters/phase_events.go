@@ -0,0 +1,139 @@
+package testreporters
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/testreporters"
+)
+
+// PhaseEventsFile is the parquet file the phase events of every request of a run are written to
+const PhaseEventsFile string = "phase_events_ccip.parquet"
+
+// PhaseEvent is a phase of a request as observed by the test, one row of PhaseEventsFile. The rows of the runs of
+// different tests can be queried together, RunID tells them apart.
+type PhaseEvent struct {
+	RunID           string  `parquet:"run_id"`
+	Test            string  `parquet:"test"`
+	Lane            string  `parquet:"lane"`
+	SourceNetwork   string  `parquet:"source_network"`
+	DestNetwork     string  `parquet:"dest_network"`
+	ReqNo           int64   `parquet:"req_no"`
+	SeqNum          uint64  `parquet:"seq_num"`
+	MsgID           string  `parquet:"msg_id"`
+	Phase           string  `parquet:"phase"`
+	Status          string  `parquet:"status"`
+	DurationSec     float64 `parquet:"duration_sec"`
+	TxHash          string  `parquet:"tx_hash"`
+	BlockNumber     uint64  `parquet:"block_number"`
+	GasUsed         uint64  `parquet:"gas_used"`
+	Fee             string  `parquet:"fee"` // in the smallest denomination of the fee token, it can overflow an int64
+	NoOfTokensSent  int64   `parquet:"no_of_tokens_sent"`
+	MessageBytes    int64   `parquet:"message_bytes"`
+	CommitRoot      string  `parquet:"commit_root"`
+	FailureReason   string  `parquet:"failure_reason"`
+	FailureCategory string  `parquet:"failure_category"`
+	SentAtMs        int64   `parquet:"sent_at_ms"`     // unix time in milliseconds the request was sent at
+	ObservedAtMs    int64   `parquet:"observed_at_ms"` // unix time in milliseconds the phase was recorded at
+}
+
+// statusName returns the status as a plain word, easier to query than the emoji it's reported with
+func statusName(status Status) string {
+	switch status {
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	case Unsure:
+		return "unsure"
+	default:
+		return string(status)
+	}
+}
+
+// NewPhaseEvents returns the events of the phases of the requests of the lanes, ordered by lane, request number and
+// the order the phases happen in. E2E is derived from the other phases, it's left out.
+func NewPhaseEvents(runID, test string, requestStats map[string][]*RequestStat) []PhaseEvent {
+	lanes := make([]string, 0, len(requestStats))
+	for lane := range requestStats {
+		lanes = append(lanes, lane)
+	}
+	sort.Strings(lanes)
+	var events []PhaseEvent
+	for _, lane := range lanes {
+		for _, stat := range requestStats[lane] {
+			// the msg id is only known from ccip-send requested on, it's set on all the phases of the request
+			var msgID string
+			for _, phase := range timelinePhases {
+				if id := stat.StatusByPhase[phase].SendTransactionStats.MsgID; id != "" {
+					msgID = id
+					break
+				}
+			}
+			for _, phase := range timelinePhases {
+				phaseStat, ok := stat.StatusByPhase[phase]
+				if !ok {
+					continue
+				}
+				txStats := phaseStat.SendTransactionStats
+				event := PhaseEvent{
+					RunID:           runID,
+					Test:            test,
+					Lane:            lane,
+					SourceNetwork:   stat.SourceNetwork,
+					DestNetwork:     stat.DestNetwork,
+					ReqNo:           stat.ReqNo,
+					SeqNum:          phaseStat.SeqNum,
+					MsgID:           msgID,
+					Phase:           string(phase),
+					Status:          statusName(phaseStat.Status),
+					DurationSec:     phaseStat.Duration,
+					TxHash:          txStats.TxHash,
+					BlockNumber:     txStats.BlockNumber,
+					GasUsed:         txStats.GasUsed,
+					Fee:             txStats.Fee,
+					NoOfTokensSent:  int64(txStats.NoOfTokensSent),
+					MessageBytes:    txStats.MessageBytesLength,
+					CommitRoot:      txStats.CommitRoot,
+					FailureReason:   txStats.FailureReason,
+					FailureCategory: txStats.FailureCategory,
+				}
+				if !stat.SentAt.IsZero() {
+					event.SentAtMs = stat.SentAt.UnixMilli()
+				}
+				if !phaseStat.ObservedAt.IsZero() {
+					event.ObservedAtMs = phaseStat.ObservedAt.UnixMilli()
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// WritePhaseEvents writes the phase events of every request of the run in PhaseEventsFile under folderPath, for the
+// offline analytics over many runs. Nothing is written if there are no requests.
+func (r *CCIPTestReporter) WritePhaseEvents(folderPath string) error {
+	requestStats := make(map[string][]*RequestStat)
+	for lane, laneStats := range r.LaneStats {
+		if stats := laneStats.RequestStats(); len(stats) > 0 {
+			requestStats[lane] = stats
+		}
+	}
+	var test string
+	if r.t != nil {
+		test = r.t.Name()
+	}
+	events := NewPhaseEvents(r.RunID(), test, requestStats)
+	if len(events) == 0 {
+		return nil
+	}
+	if err := testreporters.MkdirIfNotExists(folderPath); err != nil {
+		return err
+	}
+	reportLocation := filepath.Join(folderPath, PhaseEventsFile)
+	r.logger.Info().Str("File", reportLocation).Int("Events", len(events)).Msg("Writing CCIP phase events")
+	return parquet.WriteFile(reportLocation, events)
+}
@@ -0,0 +1,73 @@
+package testreporters
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhaseEvents(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	failed := NewCCIPRequestStats(1, "source", "dest")
+	failed.SentAt = sentAt
+	failed.UpdateState(zerolog.Nop(), 0, TX, time.Second, Failure, TransactionStats{
+		TxHash:          "0xfailed",
+		BlockNumber:     10,
+		FailureReason:   "InsufficientFeeTokenAmount",
+		FailureCategory: "SourceRevert",
+	})
+	events := NewPhaseEvents("run", "TestRun", map[string][]*RequestStat{
+		"b-lane": {timedRequest(2, sentAt, 20*time.Second, Success)},
+		"a-lane": {failed},
+	})
+
+	// the failed request has only the TX phase, the derived E2E is left out
+	require.Len(t, events, 6)
+	require.Equal(t, "a-lane", events[0].Lane)
+	require.Equal(t, string(TX), events[0].Phase)
+	require.Equal(t, "failure", events[0].Status)
+	require.Equal(t, uint64(10), events[0].BlockNumber)
+	require.Equal(t, "InsufficientFeeTokenAmount", events[0].FailureReason)
+	require.Equal(t, sentAt.UnixMilli(), events[0].SentAtMs)
+
+	phases := []string{string(TX), string(CCIPSendRe), string(SourceLogFinalized), string(Commit), string(ExecStateChanged)}
+	for i, event := range events[1:] {
+		require.Equal(t, "b-lane", event.Lane)
+		require.Equal(t, "run", event.RunID)
+		require.Equal(t, "TestRun", event.Test)
+		require.Equal(t, int64(2), event.ReqNo)
+		require.Equal(t, phases[i], event.Phase)
+		require.Equal(t, "success", event.Status)
+		// the msg id recorded at TX is set on all the phases
+		require.Equal(t, "0xmsg", event.MsgID)
+		require.NotZero(t, event.ObservedAtMs)
+	}
+	require.Equal(t, 20.0, events[5].DurationSec)
+}
+
+func TestWritePhaseEvents(t *testing.T) {
+	t.Parallel()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	dir := t.TempDir()
+
+	// nothing is written without requests
+	require.NoError(t, reporter.WritePhaseEvents(dir))
+	require.NoFileExists(t, filepath.Join(dir, PhaseEventsFile))
+
+	laneStats := reporter.AddNewLane("source-dest", zerolog.Nop())
+	laneStats.UpdatePhaseStatsForReq(timedRequest(1, time.Now().UTC(), 20*time.Second, Success))
+	laneStats.UpdatePhaseStatsForReq(timedRequest(2, time.Now().UTC(), 30*time.Second, Failure))
+	require.NoError(t, reporter.WritePhaseEvents(dir))
+
+	events, err := parquet.ReadFile[PhaseEvent](filepath.Join(dir, PhaseEventsFile))
+	require.NoError(t, err)
+	require.Equal(t, NewPhaseEvents(reporter.RunID(), t.Name(), map[string][]*RequestStat{
+		"source-dest": laneStats.RequestStats(),
+	}), events)
+	require.Equal(t, "failure", events[len(events)-1].Status)
+}
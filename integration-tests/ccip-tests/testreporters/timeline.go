@@ -0,0 +1,317 @@
+package testreporters
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/testreporters"
+)
+
+const (
+	// TimelineFile is the file the timeline of the slowest and the failed requests is written to
+	TimelineFile string = "timeline_ccip.html"
+	// DefaultTimelineRequests is the number of slowest requests in the timeline if it's not set otherwise
+	DefaultTimelineRequests = 10
+)
+
+// timelinePhases are the phases of a request in the order they happen, each phase is recorded with the
+// duration since the end of the previous one
+var timelinePhases = []Phase{TX, CCIPSendRe, SourceLogFinalized, Commit, ReportBlessed, ExecStateChanged}
+
+// TimelineSpan is a phase of a request with the time it started and ended at
+type TimelineSpan struct {
+	Phase   Phase
+	Network string // network the phase happens on
+	Start   time.Time
+	End     time.Time
+	Status  Status
+}
+
+// RequestTimeline is the timeline of a request from ccip-send till execution
+type RequestTimeline struct {
+	Lane   string
+	ReqNo  int64
+	SeqNum uint64
+	MsgID  string
+	Failed bool
+	Spans  []TimelineSpan
+}
+
+// End returns the time the last recorded phase of the request ended at
+func (tl RequestTimeline) End() time.Time {
+	if len(tl.Spans) == 0 {
+		return time.Time{}
+	}
+	return tl.Spans[len(tl.Spans)-1].End
+}
+
+// Duration returns the time from the request being sent till the end of its last recorded phase
+func (tl RequestTimeline) Duration() time.Duration {
+	if len(tl.Spans) == 0 {
+		return 0
+	}
+	return tl.End().Sub(tl.Spans[0].Start)
+}
+
+// NewRequestTimeline rebuilds the timeline of the request from the time it was sent at and the durations of its phases
+func NewRequestTimeline(lane string, stat *RequestStat) RequestTimeline {
+	tl := RequestTimeline{
+		Lane:   lane,
+		ReqNo:  stat.ReqNo,
+		SeqNum: stat.SeqNum,
+	}
+	cursor := stat.SentAt
+	for _, phase := range timelinePhases {
+		phaseStat, ok := stat.StatusByPhase[phase]
+		if !ok {
+			continue
+		}
+		if phaseStat.SendTransactionStats.MsgID != "" {
+			tl.MsgID = phaseStat.SendTransactionStats.MsgID
+		}
+		network := stat.DestNetwork
+		if phase == TX || phase == CCIPSendRe || phase == SourceLogFinalized {
+			network = stat.SourceNetwork
+		}
+		end := cursor.Add(time.Duration(phaseStat.Duration * float64(time.Second)))
+		tl.Spans = append(tl.Spans, TimelineSpan{
+			Phase:   phase,
+			Network: network,
+			Start:   cursor,
+			End:     end,
+			Status:  phaseStat.Status,
+		})
+		if phaseStat.Status != Success {
+			tl.Failed = true
+		}
+		cursor = end
+	}
+	return tl
+}
+
+// SelectTimelines returns the timelines of all the failed requests and of the slowest successful ones, at most
+// slowest of them across all the lanes. The failed requests come first, each group ordered by the duration.
+func SelectTimelines(requestStats map[string][]*RequestStat, slowest int) []RequestTimeline {
+	var failed, succeeded []RequestTimeline
+	for lane, stats := range requestStats {
+		for _, stat := range stats {
+			tl := NewRequestTimeline(lane, stat)
+			if len(tl.Spans) == 0 {
+				continue
+			}
+			if tl.Failed {
+				failed = append(failed, tl)
+			} else {
+				succeeded = append(succeeded, tl)
+			}
+		}
+	}
+	bySlowest := func(timelines []RequestTimeline) {
+		sort.SliceStable(timelines, func(i, j int) bool {
+			if timelines[i].Duration() != timelines[j].Duration() {
+				return timelines[i].Duration() > timelines[j].Duration()
+			}
+			if timelines[i].Lane != timelines[j].Lane {
+				return timelines[i].Lane < timelines[j].Lane
+			}
+			return timelines[i].ReqNo < timelines[j].ReqNo
+		})
+	}
+	bySlowest(failed)
+	bySlowest(succeeded)
+	if slowest < len(succeeded) {
+		succeeded = succeeded[:slowest]
+	}
+	return append(failed, succeeded...)
+}
+
+const (
+	timelineLabelWidth = 360
+	timelineChartWidth = 900
+	timelineRowHeight  = 22
+	timelineAxisHeight = 30
+)
+
+// timelineColors are the colors of the successful phases, failed phases are drawn in red
+var timelineColors = map[Phase]string{
+	TX:                 "#9ecae1",
+	CCIPSendRe:         "#4292c6",
+	SourceLogFinalized: "#08519c",
+	Commit:             "#74c476",
+	ReportBlessed:      "#fd8d3c",
+	ExecStateChanged:   "#31a354",
+}
+
+type timelineBar struct {
+	X, Y, Width float64
+	Color       string
+	Title       string
+}
+
+type timelineRow struct {
+	Y     float64
+	Label string
+	Bars  []timelineBar
+}
+
+type timelineTick struct {
+	X     float64
+	Label string
+}
+
+type timelineLegend struct {
+	Phase Phase
+	Color string
+}
+
+type timelinePage struct {
+	Width, Height int
+	LabelWidth    int
+	AxisY         int
+	Start         string
+	Rows          []timelineRow
+	Ticks         []timelineTick
+	Legend        []timelineLegend
+	Failed        int
+}
+
+var timelineTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>CCIP request timeline</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+.legend span { display: inline-block; margin-right: 12px; }
+.legend i { display: inline-block; width: 12px; height: 12px; margin-right: 4px; vertical-align: middle; }
+</style>
+</head>
+<body>
+<h3>CCIP request timeline</h3>
+<p>{{len .Rows}} requests, {{.Failed}} failed. Time is relative to {{.Start}}, hover over a phase for its details.</p>
+<div class="legend">{{range .Legend}}<span><i style="background:{{.Color}}"></i>{{.Phase}}</span>{{end}}<span><i style="background:#de2d26"></i>failed</span></div>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}">
+{{- range .Ticks}}
+<line x1="{{.X}}" y1="0" x2="{{.X}}" y2="{{$.AxisY}}" stroke="#ddd"/>
+<text x="{{.X}}" y="{{$.AxisY}}" dy="14" text-anchor="middle">{{.Label}}</text>
+{{- end}}
+{{- range .Rows}}
+<text x="{{$.LabelWidth}}" y="{{.Y}}" dx="-6" dy="15" text-anchor="end">{{.Label}}</text>
+{{- range .Bars}}
+<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="16" fill="{{.Color}}"><title>{{.Title}}</title></rect>
+{{- end}}
+{{- end}}
+</svg>
+</body>
+</html>
+`))
+
+// WriteTimelineHTML writes the timelines as a gantt chart in an html page with an inline svg, so that it can be
+// opened in a browser without any dependencies. All the timelines are drawn on the same time axis.
+func WriteTimelineHTML(w io.Writer, timelines []RequestTimeline) error {
+	page := timelinePage{
+		LabelWidth: timelineLabelWidth,
+		Width:      timelineLabelWidth + timelineChartWidth + 20,
+		Height:     len(timelines)*timelineRowHeight + timelineAxisHeight,
+		AxisY:      len(timelines) * timelineRowHeight,
+	}
+	for _, phase := range timelinePhases {
+		page.Legend = append(page.Legend, timelineLegend{Phase: phase, Color: timelineColors[phase]})
+	}
+	if len(timelines) == 0 {
+		return timelineTemplate.Execute(w, page)
+	}
+	start, end := timelines[0].Spans[0].Start, timelines[0].End()
+	for _, tl := range timelines {
+		if tl.Spans[0].Start.Before(start) {
+			start = tl.Spans[0].Start
+		}
+		if tl.End().After(end) {
+			end = tl.End()
+		}
+	}
+	total := end.Sub(start)
+	if total <= 0 {
+		total = time.Second
+	}
+	scale := func(t time.Time) float64 {
+		return float64(timelineLabelWidth) + float64(t.Sub(start))/float64(total)*timelineChartWidth
+	}
+	page.Start = start.UTC().Format(time.RFC3339)
+	for i := 0; i <= 10; i++ {
+		page.Ticks = append(page.Ticks, timelineTick{
+			X:     float64(timelineLabelWidth) + float64(i)*timelineChartWidth/10,
+			Label: (total * time.Duration(i) / 10).Round(time.Second).String(),
+		})
+	}
+	for i, tl := range timelines {
+		if tl.Failed {
+			page.Failed++
+		}
+		row := timelineRow{
+			Y:     float64(i * timelineRowHeight),
+			Label: fmt.Sprintf("%s req %d seq %d (%s)", tl.Lane, tl.ReqNo, tl.SeqNum, tl.Duration().Round(time.Second)),
+		}
+		for _, span := range tl.Spans {
+			color := timelineColors[span.Phase]
+			if span.Status != Success {
+				color = "#de2d26"
+			}
+			// instant phases are drawn with a minimum width so that they are visible
+			width := scale(span.End) - scale(span.Start)
+			if width < 1 {
+				width = 1
+			}
+			title := fmt.Sprintf("%s %s on %s\n%s - %s (%s)", span.Phase, span.Status, span.Network,
+				span.Start.UTC().Format(time.RFC3339), span.End.UTC().Format(time.RFC3339), span.End.Sub(span.Start).Round(time.Millisecond))
+			if tl.MsgID != "" {
+				title = fmt.Sprintf("%s\nmsg id %s", title, tl.MsgID)
+			}
+			row.Bars = append(row.Bars, timelineBar{
+				X:     scale(span.Start),
+				Y:     row.Y,
+				Width: width,
+				Color: color,
+				Title: title,
+			})
+		}
+		page.Rows = append(page.Rows, row)
+	}
+	return timelineTemplate.Execute(w, page)
+}
+
+// WriteTimeline writes the timeline of the failed requests and of the slowest successful ones in TimelineFile
+// under folderPath. Nothing is written if there are no requests.
+func (r *CCIPTestReporter) WriteTimeline(folderPath string) error {
+	requestStats := make(map[string][]*RequestStat)
+	for lane, laneStats := range r.LaneStats {
+		if stats := laneStats.RequestStats(); len(stats) > 0 {
+			requestStats[lane] = stats
+		}
+	}
+	timelines := SelectTimelines(requestStats, r.timelineRequests)
+	if len(timelines) == 0 {
+		return nil
+	}
+	if err := testreporters.MkdirIfNotExists(folderPath); err != nil {
+		return err
+	}
+	reportLocation := filepath.Join(folderPath, TimelineFile)
+	f, err := os.Create(reportLocation)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r.logger.Info().Str("File", reportLocation).Int("Requests", len(timelines)).Msg("Writing CCIP request timeline")
+	return WriteTimelineHTML(f, timelines)
+}
+
+// SetTimelineRequests sets the number of slowest successful requests in the timeline, the failed requests are always included
+func (r *CCIPTestReporter) SetTimelineRequests(n int) {
+	r.timelineRequests = n
+}
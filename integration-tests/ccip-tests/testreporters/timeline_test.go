@@ -0,0 +1,111 @@
+package testreporters
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func timedRequest(reqNo int64, sentAt time.Time, execDuration time.Duration, execStatus Status) *RequestStat {
+	stat := NewCCIPRequestStats(reqNo, "source", "dest")
+	stat.SentAt = sentAt
+	stat.UpdateState(zerolog.Nop(), 0, TX, time.Second, Success, TransactionStats{MsgID: "0xmsg"})
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), CCIPSendRe, 0, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), SourceLogFinalized, 4*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), Commit, 10*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), ExecStateChanged, execDuration, execStatus)
+	return stat
+}
+
+func TestNewRequestTimeline(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	tl := NewRequestTimeline("lane", timedRequest(1, sentAt, 20*time.Second, Success))
+
+	require.False(t, tl.Failed)
+	require.Equal(t, "0xmsg", tl.MsgID)
+	require.Equal(t, uint64(1), tl.SeqNum)
+	require.Len(t, tl.Spans, 5)
+	// phases are chained, each starting where the previous one ended
+	require.Equal(t, sentAt, tl.Spans[0].Start)
+	for i := 1; i < len(tl.Spans); i++ {
+		require.Equal(t, tl.Spans[i-1].End, tl.Spans[i].Start, "phase %s", tl.Spans[i].Phase)
+	}
+	require.Equal(t, "source", tl.Spans[2].Network)
+	require.Equal(t, SourceLogFinalized, tl.Spans[2].Phase)
+	require.Equal(t, "dest", tl.Spans[3].Network)
+	require.Equal(t, sentAt.Add(35*time.Second), tl.End())
+	require.Equal(t, 35*time.Second, tl.Duration())
+}
+
+func TestSelectTimelines(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	requestStats := map[string][]*RequestStat{
+		"lane1": {
+			timedRequest(1, sentAt, 10*time.Second, Success),
+			timedRequest(2, sentAt, 50*time.Second, Success),
+			timedRequest(3, sentAt, 5*time.Second, Failure),
+		},
+		"lane2": {
+			timedRequest(1, sentAt, 30*time.Second, Success),
+			// not sent at all
+			NewCCIPRequestStats(2, "source", "dest"),
+		},
+	}
+
+	timelines := SelectTimelines(requestStats, 2)
+	require.Len(t, timelines, 3)
+	require.True(t, timelines[0].Failed, "failed requests should come first")
+	require.Equal(t, "lane1", timelines[0].Lane)
+	require.Equal(t, int64(3), timelines[0].ReqNo)
+	require.Equal(t, "lane1", timelines[1].Lane)
+	require.Equal(t, int64(2), timelines[1].ReqNo)
+	require.Equal(t, "lane2", timelines[2].Lane)
+	require.Equal(t, int64(1), timelines[2].ReqNo)
+
+	require.Len(t, SelectTimelines(requestStats, 0), 1, "only failed requests should be selected")
+	require.Len(t, SelectTimelines(requestStats, 10), 4)
+}
+
+func TestWriteTimelineHTML(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	timelines := SelectTimelines(map[string][]*RequestStat{
+		"source-dest": {
+			timedRequest(1, sentAt, 20*time.Second, Success),
+			timedRequest(2, sentAt.Add(5*time.Second), 5*time.Second, Failure),
+		},
+	}, 10)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTimelineHTML(&buf, timelines))
+	page := buf.String()
+	require.Contains(t, page, "<svg")
+	require.Contains(t, page, "2 requests, 1 failed")
+	require.Contains(t, page, "source-dest req 1 seq 1 (35s)")
+	require.Equal(t, 10, strings.Count(page, "<rect"), "every phase of every request should be drawn")
+	require.Contains(t, page, `fill="#de2d26"`, "failed phase should be drawn in red")
+
+	buf.Reset()
+	require.NoError(t, WriteTimelineHTML(&buf, nil))
+	require.Contains(t, buf.String(), "0 requests, 0 failed")
+}
+
+func TestWriteTimeline(t *testing.T) {
+	t.Parallel()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	dir := t.TempDir()
+	require.NoError(t, reporter.WriteTimeline(dir))
+	require.NoFileExists(t, filepath.Join(dir, TimelineFile), "timeline should not be written without requests")
+
+	laneStats := reporter.AddNewLane("source-dest", zerolog.Nop())
+	laneStats.UpdatePhaseStatsForReq(timedRequest(1, time.Now().UTC(), 20*time.Second, Success))
+	require.NoError(t, reporter.WriteTimeline(dir))
+	require.FileExists(t, filepath.Join(dir, TimelineFile))
+}
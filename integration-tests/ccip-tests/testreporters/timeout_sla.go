@@ -0,0 +1,91 @@
+package testreporters
+
+import (
+	"time"
+)
+
+// PhaseTimeoutSLA is the share of the requests of a lane completing a phase within its timeout. It's reported against
+// the fixed phase timeout (raw) and against the timeouts adapted to the latencies observed on the lane (adjusted), so
+// that the requests which passed only thanks to the adaptation stay visible.
+type PhaseTimeoutSLA struct {
+	Total           int64   `json:"total"`
+	RawTimeout      float64 `json:"raw_timeout(s)"`
+	MetRaw          int64   `json:"met_raw"`
+	RawSLA          float64 `json:"raw_sla(%)"`
+	AdjustedTimeout float64 `json:"max_adjusted_timeout(s)"` // the longest timeout the phase was validated with
+	MetAdjusted     int64   `json:"met_adjusted"`
+	AdjustedSLA     float64 `json:"adjusted_sla(%)"`
+}
+
+type phaseTimeout struct {
+	raw      time.Duration
+	adjusted time.Duration
+}
+
+// RecordPhaseTimeout records the fixed timeout of phase along with the adjusted timeout a request of the lane was
+// validated with. The timeout SLA is reported on Finalize only for the phases with recorded timeouts.
+func (testStats *CCIPLaneStats) RecordPhaseTimeout(phase Phase, raw, adjusted time.Duration) {
+	testStats.timeoutsMu.Lock()
+	defer testStats.timeoutsMu.Unlock()
+	if testStats.phaseTimeouts == nil {
+		testStats.phaseTimeouts = make(map[Phase]phaseTimeout)
+	}
+	timeout := testStats.phaseTimeouts[phase]
+	timeout.raw = raw
+	if adjusted > timeout.adjusted {
+		timeout.adjusted = adjusted
+	}
+	testStats.phaseTimeouts[phase] = timeout
+}
+
+// newPhaseTimeoutSLAs returns the timeout SLA of every phase in timeouts. A request completing a phase successfully
+// met the adjusted timeout, as it was validated with it, and met the raw timeout if it completed within it as well.
+func newPhaseTimeoutSLAs(stats []*RequestStat, timeouts map[Phase]phaseTimeout) map[Phase]*PhaseTimeoutSLA {
+	if len(timeouts) == 0 {
+		return nil
+	}
+	slas := make(map[Phase]*PhaseTimeoutSLA)
+	for phase, timeout := range timeouts {
+		sla := &PhaseTimeoutSLA{
+			RawTimeout:      timeout.raw.Seconds(),
+			AdjustedTimeout: timeout.adjusted.Seconds(),
+		}
+		for _, stat := range stats {
+			phaseStat, ok := stat.StatusByPhase[phase]
+			if !ok {
+				continue
+			}
+			sla.Total++
+			if phaseStat.Status != Success {
+				continue
+			}
+			sla.MetAdjusted++
+			if phaseStat.Duration <= sla.RawTimeout {
+				sla.MetRaw++
+			}
+		}
+		if sla.Total > 0 {
+			sla.RawSLA = float64(sla.MetRaw) * 100 / float64(sla.Total)
+			sla.AdjustedSLA = float64(sla.MetAdjusted) * 100 / float64(sla.Total)
+		}
+		slas[phase] = sla
+	}
+	return slas
+}
+
+// finalizeTimeoutSLA sets and logs the timeout SLA of the phases with recorded timeouts
+func (testStats *CCIPLaneStats) finalizeTimeoutSLA(lane string) {
+	testStats.timeoutsMu.Lock()
+	testStats.TimeoutSLA = newPhaseTimeoutSLAs(testStats.RequestStats(), testStats.phaseTimeouts)
+	testStats.timeoutsMu.Unlock()
+	for phase, sla := range testStats.TimeoutSLA {
+		testStats.lggr.Info().
+			Str("Phase", string(phase)).
+			Int64("Total", sla.Total).
+			Float64("Raw Timeout(s)", sla.RawTimeout).
+			Float64("Raw SLA(%)", sla.RawSLA).
+			Float64("Max Adjusted Timeout(s)", sla.AdjustedTimeout).
+			Float64("Adjusted SLA(%)", sla.AdjustedSLA).
+			Msgf("Timeout SLA for Lane %s", lane)
+	}
+}
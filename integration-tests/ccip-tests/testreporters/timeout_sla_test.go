@@ -0,0 +1,50 @@
+package testreporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseTimeoutSLA(t *testing.T) {
+	t.Parallel()
+	lane := &CCIPLaneStats{
+		lggr:                 zerolog.Nop(),
+		FailedCountsByPhase:  make(map[Phase]int64),
+		SuccessCountsByPhase: make(map[Phase]int64),
+		DurationStatByPhase:  make(map[Phase]AggregatorMetrics),
+	}
+	for reqNo, exec := range []struct {
+		duration time.Duration
+		status   Status
+	}{
+		{time.Minute, Success},
+		{3 * time.Minute, Success}, // beyond the raw timeout, within the adjusted one
+		{6 * time.Minute, Failure},
+		{90 * time.Second, Success},
+	} {
+		stat := NewCCIPRequestStats(int64(reqNo+1), "A", "B")
+		stat.UpdateState(zerolog.Nop(), uint64(reqNo+1), ExecStateChanged, exec.duration, exec.status)
+		lane.UpdatePhaseStatsForReq(stat)
+	}
+	lane.RecordPhaseTimeout(ExecStateChanged, 2*time.Minute, 2*time.Minute)
+	lane.RecordPhaseTimeout(ExecStateChanged, 2*time.Minute, 5*time.Minute)
+	lane.RecordPhaseTimeout(ExecStateChanged, 2*time.Minute, 4*time.Minute)
+	lane.Finalize("A To B")
+
+	require.Len(t, lane.TimeoutSLA, 1)
+	require.Equal(t, &PhaseTimeoutSLA{
+		Total:           4,
+		RawTimeout:      120,
+		MetRaw:          2,
+		RawSLA:          50,
+		AdjustedTimeout: 300,
+		MetAdjusted:     3,
+		AdjustedSLA:     75,
+	}, lane.TimeoutSLA[ExecStateChanged])
+
+	// not reported without recorded timeouts
+	require.Nil(t, newPhaseTimeoutSLAs(lane.RequestStats(), nil))
+}
@@ -0,0 +1,196 @@
+package testreporters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// MsgEvent is a transition of a message notified to the webhook
+type MsgEvent string
+
+const (
+	MsgSent      MsgEvent = "sent"      // the CCIPSendRequested event of the message is seen on the source chain
+	MsgCommitted MsgEvent = "committed" // the commit report of the message is accepted on the dest chain
+	MsgBlessed   MsgEvent = "blessed"   // the commit report of the message is blessed by the ARM
+	MsgExecuted  MsgEvent = "executed"  // the message is executed on the dest chain
+	MsgFailed    MsgEvent = "failed"    // any of the phases of the message failed
+
+	// WebhookSignatureHeader is the header carrying the hex encoded HMAC-SHA256 of the body, keyed with the secret
+	WebhookSignatureHeader = "X-CCIP-Signature"
+
+	webhookQueueSize  = 1000
+	webhookMaxRetries = 3
+	webhookRetryDelay = time.Second
+)
+
+// msgEventForPhase returns the event notified for step of a message moving to state, if any
+func msgEventForPhase(step Phase, state Status) (MsgEvent, bool) {
+	if state == Failure || state == Unsure {
+		return MsgFailed, true
+	}
+	switch step {
+	case CCIPSendRe:
+		return MsgSent, true
+	case Commit:
+		return MsgCommitted, true
+	case ReportBlessed:
+		return MsgBlessed, true
+	case ExecStateChanged:
+		return MsgExecuted, true
+	}
+	return "", false
+}
+
+// MsgTransition is the body posted to the webhook on a phase transition of a message
+type MsgTransition struct {
+	Event         MsgEvent  `json:"event"`
+	Phase         Phase     `json:"phase"`
+	Status        Status    `json:"status"`
+	SourceNetwork string    `json:"source_network"`
+	DestNetwork   string    `json:"dest_network"`
+	ReqNo         int64     `json:"req_no"`
+	SeqNum        uint64    `json:"seq_num,omitempty"`
+	MsgID         string    `json:"msg_id,omitempty"`
+	TxHash        string    `json:"tx_hash,omitempty"`
+	Duration      float64   `json:"duration_seconds,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// PhaseNotifier is notified of every phase transition of the requests it is set on
+type PhaseNotifier interface {
+	Notify(transition MsgTransition)
+}
+
+// SignWebhookBody returns the hex encoded HMAC-SHA256 of body keyed with secret, as sent in WebhookSignatureHeader
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookNotifier posts the phase transitions of the messages to a webhook. The transitions are posted in the order
+// they are notified from a single goroutine, so that the test is not held up by a slow webhook. Transitions notified
+// while the queue is full are dropped.
+type WebhookNotifier struct {
+	url    string
+	secret string
+	events map[MsgEvent]bool
+	client *http.Client
+	lggr   zerolog.Logger
+	queue  chan MsgTransition
+	done   chan struct{}
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewWebhookNotifier starts posting the transitions to url, only for events if any are set, signed with secret if set
+func NewWebhookNotifier(lggr zerolog.Logger, url, secret string, events []MsgEvent) *WebhookNotifier {
+	w := &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+		lggr:   lggr.With().Str("Component", "Webhook").Logger(),
+		queue:  make(chan MsgTransition, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	if len(events) > 0 {
+		w.events = make(map[MsgEvent]bool)
+		for _, e := range events {
+			w.events[e] = true
+		}
+	}
+	go w.run()
+	return w
+}
+
+func (w *WebhookNotifier) Notify(transition MsgTransition) {
+	if w.events != nil && !w.events[transition.Event] {
+		return
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.queue <- transition:
+	default:
+		w.lggr.Warn().
+			Str("Event", string(transition.Event)).
+			Int64("ReqNo", transition.ReqNo).
+			Msg("Webhook queue is full, dropping the transition")
+	}
+}
+
+func (w *WebhookNotifier) run() {
+	defer close(w.done)
+	for transition := range w.queue {
+		if err := w.post(transition); err != nil {
+			w.lggr.Warn().Err(err).
+				Str("Event", string(transition.Event)).
+				Int64("ReqNo", transition.ReqNo).
+				Msg("Failed to post transition to webhook")
+		}
+	}
+}
+
+// post posts transition to the webhook, retrying on errors and server errors
+func (w *WebhookNotifier) post(transition MsgTransition) error {
+	body, err := json.Marshal(transition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transition: %w", err)
+	}
+	for attempt := 0; ; attempt++ {
+		err = w.postOnce(body)
+		if err == nil || attempt == webhookMaxRetries {
+			return err
+		}
+		time.Sleep(webhookRetryDelay * time.Duration(attempt+1))
+	}
+}
+
+func (w *WebhookNotifier) postOnce(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookBody(w.secret, body))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		// client errors are not retried, the same body would be rejected again
+		w.lggr.Warn().Int("Status", resp.StatusCode).Msg("Webhook rejected the transition")
+	}
+	return nil
+}
+
+// Close stops accepting transitions and waits for the queued ones to be posted
+func (w *WebhookNotifier) Close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	<-w.done
+}
@@ -0,0 +1,104 @@
+package testreporters
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type transitionRecorder struct {
+	transitions []MsgTransition
+}
+
+func (r *transitionRecorder) Notify(transition MsgTransition) {
+	r.transitions = append(r.transitions, transition)
+}
+
+func TestRequestStatNotify(t *testing.T) {
+	t.Parallel()
+	recorder := &transitionRecorder{}
+	stat := NewCCIPRequestStats(7, "source", "dest")
+	stat.SetNotifier(recorder)
+	stat.UpdateState(zerolog.Nop(), 0, TX, time.Second, Success, TransactionStats{TxHash: "0x1"})
+	stat.UpdateState(zerolog.Nop(), 3, CCIPSendRe, time.Second, Success, TransactionStats{MsgID: "0xabc"})
+	stat.UpdateState(zerolog.Nop(), 3, SourceLogFinalized, time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), 3, Commit, 10*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), 3, ReportBlessed, time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), 3, ExecStateChanged, 5*time.Second, Failure,
+		TransactionStats{FailureReason: "receiver reverted"})
+
+	var events []MsgEvent
+	for _, tr := range recorder.transitions {
+		events = append(events, tr.Event)
+		require.Equal(t, int64(7), tr.ReqNo)
+		require.Equal(t, "source", tr.SourceNetwork)
+		require.Equal(t, "dest", tr.DestNetwork)
+	}
+	// TX and SourceLogFinalized are not notified
+	require.Equal(t, []MsgEvent{MsgSent, MsgCommitted, MsgBlessed, MsgFailed}, events)
+	require.Equal(t, "0xabc", recorder.transitions[0].MsgID)
+	require.Equal(t, uint64(3), recorder.transitions[0].SeqNum)
+	require.Equal(t, ExecStateChanged, recorder.transitions[3].Phase)
+	require.Equal(t, "receiver reverted", recorder.transitions[3].FailureReason)
+
+	// a failure at any phase is notified
+	stat = NewCCIPRequestStats(8, "source", "dest")
+	stat.SetNotifier(recorder)
+	stat.UpdateState(zerolog.Nop(), 0, TX, time.Second, Failure)
+	require.Equal(t, MsgFailed, recorder.transitions[len(recorder.transitions)-1].Event)
+	require.Equal(t, TX, recorder.transitions[len(recorder.transitions)-1].Phase)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+	var (
+		mu       sync.Mutex
+		received []MsgTransition
+		attempts int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Header.Get(WebhookSignatureHeader) != SignWebhookBody("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var tr MsgTransition
+		if err := json.Unmarshal(body, &tr); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		// the first attempt fails with a server error and is retried
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, tr)
+	}))
+	defer srv.Close()
+
+	notifier := NewWebhookNotifier(zerolog.Nop(), srv.URL, "secret", []MsgEvent{MsgExecuted, MsgFailed})
+	notifier.Notify(MsgTransition{Event: MsgSent, ReqNo: 1})
+	notifier.Notify(MsgTransition{Event: MsgExecuted, ReqNo: 1})
+	notifier.Notify(MsgTransition{Event: MsgFailed, ReqNo: 2})
+	notifier.Close()
+	// transitions notified after close are dropped
+	notifier.Notify(MsgTransition{Event: MsgFailed, ReqNo: 3})
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, attempts)
+	require.Len(t, received, 2)
+	require.Equal(t, MsgExecuted, received[0].Event)
+	require.Equal(t, MsgFailed, received[1].Event)
+	require.Equal(t, int64(2), received[1].ReqNo)
+}
@@ -0,0 +1,262 @@
+package testreporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+)
+
+// WhatIfParams are the exec OCR parameters to replay the recorded requests of a run with
+type WhatIfParams struct {
+	BatchGasLimit  uint64        // max gas of the messages executed in one exec report
+	RootSnoozeTime time.Duration // time a root is skipped for once no batch could be built from it
+	InflightExpiry time.Duration // time after which a message in an exec report which is not yet executed is considered for execution again
+	RoundInterval  time.Duration // interval between two exec OCR rounds, only one exec report is built per round
+	ExecTxDelay    time.Duration // time taken by an exec report to be executed on chain once it's built
+	TxOverheadGas  uint64        // gas used by an exec report apart from the messages
+}
+
+// DefaultWhatIfParams returns the params matching the defaults used by the tests for the exec plugin
+func DefaultWhatIfParams() WhatIfParams {
+	return WhatIfParams{
+		BatchGasLimit:  7_000_000,
+		RootSnoozeTime: 3 * time.Minute,
+		InflightExpiry: 3 * time.Minute,
+		RoundInterval:  20 * time.Second,
+		ExecTxDelay:    10 * time.Second,
+		TxOverheadGas:  100_000,
+	}
+}
+
+func (p WhatIfParams) Validate() error {
+	if p.BatchGasLimit <= p.TxOverheadGas {
+		return fmt.Errorf("batch gas limit should be greater than the tx overhead gas")
+	}
+	if p.RoundInterval <= 0 {
+		return fmt.Errorf("round interval should be greater than 0")
+	}
+	return nil
+}
+
+// WhatIfResult is the outcome of replaying the recorded requests of a lane, the recorded values are
+// reported alongside the simulated ones for comparison. Latencies are in seconds and measured from the
+// time the message is committed (and blessed) till it's executed.
+type WhatIfResult struct {
+	Lane                   string  `json:"lane"`
+	Requests               int     `json:"requests"`
+	ExecReports            int     `json:"exec_reports"`
+	ExecGas                uint64  `json:"exec_gas"`
+	AvgExecLatency         float64 `json:"avg_exec_latency"`
+	P90ExecLatency         float64 `json:"p90_exec_latency"`
+	MaxExecLatency         float64 `json:"max_exec_latency"`
+	RecordedExecReports    int     `json:"recorded_exec_reports"`
+	RecordedExecGas        uint64  `json:"recorded_exec_gas"`
+	RecordedAvgExecLatency float64 `json:"recorded_avg_exec_latency"`
+	RecordedP90ExecLatency float64 `json:"recorded_p90_exec_latency"`
+	RecordedMaxExecLatency float64 `json:"recorded_max_exec_latency"`
+}
+
+// LoadRequestStats reads the request stats written by CCIPTestReporter.WriteRequestStats
+func LoadRequestStats(path string) (map[string][]*RequestStat, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request stats %s: %w", path, err)
+	}
+	requestStats := make(map[string][]*RequestStat)
+	if err := json.Unmarshal(content, &requestStats); err != nil {
+		return nil, fmt.Errorf("failed to parse request stats %s: %w", path, err)
+	}
+	return requestStats, nil
+}
+
+// SimulateWhatIfFromFile replays the request stats in the file written by CCIPTestReporter.WriteRequestStats
+// for every lane under params
+func SimulateWhatIfFromFile(path string, params WhatIfParams) ([]WhatIfResult, error) {
+	requestStats, err := LoadRequestStats(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []WhatIfResult
+	for lane, stats := range requestStats {
+		result, err := SimulateWhatIf(lane, stats, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate lane %s: %w", lane, err)
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Lane < results[j].Lane
+	})
+	return results, nil
+}
+
+// simMsg is a successfully executed message of the recorded run
+type simMsg struct {
+	seqNum       uint64
+	readyAt      time.Time // time at which the message is committed and blessed
+	gas          uint64    // gas attributed to the message in the exec report it was executed in
+	inflightTill time.Time
+	executedAt   time.Time
+}
+
+type simRoot struct {
+	root        string
+	readyAt     time.Time
+	msgs        []*simMsg
+	snoozedTill time.Time
+	executed    bool
+}
+
+// SimulateWhatIf replays the successfully executed requests of a lane under params. The commit side of the
+// recorded run is kept as is, i.e. each message becomes executable at the recorded time of its commit (and blessing),
+// and the exec plugin is simulated round by round -
+//  1. the committed roots which are not snoozed are visited in the order of their commit
+//  2. a batch is built from the messages of the first root which are neither executed nor inflight,
+//     packed in sequence number order till the batch gas limit is reached; only one batch is built per round
+//  3. if no batch could be built from a root, it is snoozed for RootSnoozeTime
+//  4. the messages in a batch are inflight till the exec report lands after ExecTxDelay, if it takes longer
+//     than InflightExpiry, the messages are considered for execution again and the repeated exec reports add to the cost
+//
+// The gas of a message is its share of the gas used by the recorded exec report it was executed in.
+func SimulateWhatIf(lane string, stats []*RequestStat, params WhatIfParams) (WhatIfResult, error) {
+	if err := params.Validate(); err != nil {
+		return WhatIfResult{}, err
+	}
+	result := WhatIfResult{Lane: lane}
+	msgsByExecTx := make(map[string]int)
+	gasByExecTx := make(map[string]uint64)
+	for _, stat := range stats {
+		if exec, ok := stat.StatusByPhase[ExecStateChanged]; ok && exec.Status == Success {
+			msgsByExecTx[exec.SendTransactionStats.TxHash]++
+			gasByExecTx[exec.SendTransactionStats.TxHash] = exec.SendTransactionStats.GasUsed
+		}
+	}
+	roots := make(map[string]*simRoot)
+	var recordedLatencies []float64
+	for _, stat := range stats {
+		exec, ok := stat.StatusByPhase[ExecStateChanged]
+		if !ok || exec.Status != Success {
+			continue
+		}
+		commit := stat.StatusByPhase[Commit]
+		readyAt := stat.SentAt
+		for _, phase := range []Phase{CCIPSendRe, SourceLogFinalized, Commit, ReportBlessed} {
+			if phaseStat, ok := stat.StatusByPhase[phase]; ok && phaseStat.Duration > 0 {
+				readyAt = readyAt.Add(time.Duration(phaseStat.Duration * float64(time.Second)))
+			}
+		}
+		txHash := exec.SendTransactionStats.TxHash
+		gas := uint64(1)
+		if txGas := gasByExecTx[txHash]; txGas > params.TxOverheadGas {
+			gas = (txGas - params.TxOverheadGas) / uint64(msgsByExecTx[txHash])
+		}
+		root, ok := roots[commit.SendTransactionStats.CommitRoot]
+		if !ok {
+			root = &simRoot{root: commit.SendTransactionStats.CommitRoot, readyAt: readyAt}
+			roots[root.root] = root
+		}
+		if readyAt.After(root.readyAt) {
+			root.readyAt = readyAt
+		}
+		root.msgs = append(root.msgs, &simMsg{
+			seqNum:  stat.SeqNum,
+			readyAt: readyAt,
+			gas:     gas,
+		})
+		recordedLatencies = append(recordedLatencies, exec.Duration)
+	}
+	result.Requests = len(recordedLatencies)
+	result.RecordedExecReports = len(gasByExecTx)
+	for _, gas := range gasByExecTx {
+		result.RecordedExecGas += gas
+	}
+	result.RecordedAvgExecLatency, result.RecordedP90ExecLatency, result.RecordedMaxExecLatency = latencyStats(recordedLatencies)
+	if result.Requests == 0 {
+		return result, nil
+	}
+
+	ordered := make([]*simRoot, 0, len(roots))
+	for _, root := range roots {
+		sort.Slice(root.msgs, func(i, j int) bool {
+			return root.msgs[i].seqNum < root.msgs[j].seqNum
+		})
+		ordered = append(ordered, root)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].readyAt.Before(ordered[j].readyAt)
+	})
+
+	pending := len(ordered)
+	now := ordered[0].readyAt
+	for pending > 0 {
+		for _, root := range ordered {
+			if root.executed || root.readyAt.After(now) || root.snoozedTill.After(now) {
+				continue
+			}
+			var batch []*simMsg
+			batchGas := params.TxOverheadGas
+			executed := 0
+			for _, msg := range root.msgs {
+				if !msg.executedAt.IsZero() && !msg.executedAt.After(now) {
+					executed++
+					continue
+				}
+				if !msg.inflightTill.IsZero() && msg.inflightTill.After(now) {
+					continue
+				}
+				if len(batch) > 0 && batchGas+msg.gas > params.BatchGasLimit {
+					break
+				}
+				batch = append(batch, msg)
+				batchGas += msg.gas
+			}
+			if executed == len(root.msgs) {
+				root.executed = true
+				pending--
+				continue
+			}
+			if len(batch) == 0 {
+				root.snoozedTill = now.Add(params.RootSnoozeTime)
+				continue
+			}
+			result.ExecReports++
+			result.ExecGas += batchGas
+			landsAt := now.Add(params.ExecTxDelay)
+			for _, msg := range batch {
+				msg.inflightTill = now.Add(params.InflightExpiry)
+				if msg.executedAt.IsZero() || landsAt.Before(msg.executedAt) {
+					msg.executedAt = landsAt
+				}
+			}
+			break
+		}
+		now = now.Add(params.RoundInterval)
+	}
+
+	var latencies []float64
+	for _, root := range ordered {
+		for _, msg := range root.msgs {
+			latencies = append(latencies, msg.executedAt.Sub(msg.readyAt).Seconds())
+		}
+	}
+	result.AvgExecLatency, result.P90ExecLatency, result.MaxExecLatency = latencyStats(latencies)
+	return result, nil
+}
+
+// latencyStats returns the average, 90th percentile and max of latencies
+func latencyStats(latencies []float64) (avg, p90, maxLatency float64) {
+	if len(latencies) == 0 {
+		return 0, 0, 0
+	}
+	sorted := append([]float64{}, latencies...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, l := range sorted {
+		sum += l
+	}
+	p90Index := int(math.Ceil(0.9*float64(len(sorted)))) - 1
+	return sum / float64(len(sorted)), sorted[p90Index], sorted[len(sorted)-1]
+}
@@ -0,0 +1,102 @@
+package testreporters
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func recordedRequest(reqNo int64, sentAt time.Time, root, execTx string, execGas uint64) *RequestStat {
+	stat := NewCCIPRequestStats(reqNo, "source", "dest")
+	stat.SentAt = sentAt
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), CCIPSendRe, 2*time.Second, Success)
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), Commit, 30*time.Second, Success, TransactionStats{CommitRoot: root})
+	stat.UpdateState(zerolog.Nop(), uint64(reqNo), ExecStateChanged, 40*time.Second, Success, TransactionStats{
+		TxHash:  execTx,
+		GasUsed: execGas,
+	})
+	return stat
+}
+
+func TestSimulateWhatIf(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Now().UTC()
+	// three messages committed in the same root and executed in a single report, 1M gas each
+	stats := []*RequestStat{
+		recordedRequest(1, sentAt, "root1", "exec1", 3_100_000),
+		recordedRequest(2, sentAt, "root1", "exec1", 3_100_000),
+		recordedRequest(3, sentAt, "root1", "exec1", 3_100_000),
+	}
+	params := DefaultWhatIfParams()
+
+	t.Run("default params", func(t *testing.T) {
+		t.Parallel()
+		result, err := SimulateWhatIf("lane", stats, params)
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Requests)
+		require.Equal(t, 1, result.RecordedExecReports)
+		require.Equal(t, uint64(3_100_000), result.RecordedExecGas)
+		require.Equal(t, float64(40), result.RecordedAvgExecLatency)
+		require.Equal(t, 1, result.ExecReports)
+		require.Equal(t, uint64(3_100_000), result.ExecGas)
+		require.Equal(t, params.ExecTxDelay.Seconds(), result.MaxExecLatency)
+	})
+
+	t.Run("lower batch gas limit", func(t *testing.T) {
+		t.Parallel()
+		lowGasParams := params
+		lowGasParams.BatchGasLimit = 2_100_000
+		result, err := SimulateWhatIf("lane", stats, lowGasParams)
+		require.NoError(t, err)
+		require.Equal(t, 2, result.ExecReports)
+		require.Equal(t, uint64(3_200_000), result.ExecGas)
+		require.Equal(t, (params.RoundInterval + params.ExecTxDelay).Seconds(), result.MaxExecLatency)
+	})
+
+	t.Run("exec report slower than inflight expiry", func(t *testing.T) {
+		t.Parallel()
+		slowParams := params
+		slowParams.ExecTxDelay = 2 * time.Minute
+		slowParams.InflightExpiry = time.Minute
+		result, err := SimulateWhatIf("lane", stats, slowParams)
+		require.NoError(t, err)
+		// the root is snoozed while the messages are inflight and is not visited again till the report lands
+		require.Equal(t, 1, result.ExecReports)
+		require.Equal(t, slowParams.ExecTxDelay.Seconds(), result.MaxExecLatency)
+
+		// with a shorter snooze the messages are picked again once the inflight report expires
+		slowParams.RootSnoozeTime = 10 * time.Second
+		result, err = SimulateWhatIf("lane", stats, slowParams)
+		require.NoError(t, err)
+		require.Equal(t, 2, result.ExecReports)
+		require.Equal(t, uint64(6_200_000), result.ExecGas)
+		require.Equal(t, slowParams.ExecTxDelay.Seconds(), result.MaxExecLatency)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		t.Parallel()
+		_, err := SimulateWhatIf("lane", stats, WhatIfParams{})
+		require.Error(t, err)
+	})
+}
+
+func TestSimulateWhatIfFromFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	reporter := NewCCIPTestReporter(t, zerolog.Nop())
+	laneStats := reporter.AddNewLane("source To dest", zerolog.Nop())
+	sentAt := time.Now().UTC()
+	laneStats.UpdatePhaseStatsForReq(recordedRequest(1, sentAt, "root1", "exec1", 1_100_000))
+	laneStats.UpdatePhaseStatsForReq(recordedRequest(2, sentAt.Add(time.Minute), "root2", "exec2", 1_100_000))
+	require.NoError(t, reporter.WriteRequestStats(dir))
+
+	results, err := SimulateWhatIfFromFile(filepath.Join(dir, RequestStatsFile), DefaultWhatIfParams())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "source To dest", results[0].Lane)
+	require.Equal(t, 2, results[0].Requests)
+	require.Equal(t, 2, results[0].ExecReports)
+}
@@ -86,6 +86,20 @@ func (c *CCIPTestConfig) useExistingDeployment() bool {
 	return pointer.GetBool(c.TestGroupInput.ExistingDeployment)
 }
 
+// chainIDByNetwork returns the chain ids of all the networks keyed by network name, as the lane configs are
+func (c *CCIPTestConfig) chainIDByNetwork() map[string]uint64 {
+	chainIDs := make(map[string]uint64)
+	for name, network := range c.AllNetworks {
+		chainIDs[name] = uint64(network.ChainID)
+	}
+	return chainIDs
+}
+
+// mockDON returns true if the requests are committed and executed by the in-process mock DON instead of CL nodes
+func (c *CCIPTestConfig) mockDON() bool {
+	return pointer.GetBool(c.TestGroupInput.MockDON)
+}
+
 func (c *CCIPTestConfig) MultiCallEnabled() bool {
 	return pointer.GetBool(c.TestGroupInput.MulticallInOneTx)
 }
@@ -94,6 +108,34 @@ func (c *CCIPTestConfig) localCluster() bool {
 	return pointer.GetBool(c.TestGroupInput.LocalCluster)
 }
 
+func (c *CCIPTestConfig) dockerCompose() bool {
+	return c.TestGroupInput.DockerCompose != nil
+}
+
+// adaptiveTimeout returns the adaptive phase timeouts for a lane, nil if they are not enabled. Every lane gets its own
+// as the latencies are observed per lane.
+func (c *CCIPTestConfig) adaptiveTimeout() *actions.AdaptiveTimeout {
+	cfg := c.TestGroupInput.AdaptiveTimeout
+	if cfg == nil {
+		return nil
+	}
+	maxTimeout := 3 * c.TestGroupInput.PhaseTimeout.Duration()
+	if cfg.MaxTimeout != nil {
+		maxTimeout = cfg.MaxTimeout.Duration()
+	}
+	adaptive := actions.NewAdaptiveTimeout(maxTimeout)
+	if cfg.Alpha != nil {
+		adaptive.Alpha = *cfg.Alpha
+	}
+	if cfg.LatencyFactor != nil {
+		adaptive.LatencyFactor = *cfg.LatencyFactor
+	}
+	if cfg.MinObservations != nil {
+		adaptive.MinObservations = *cfg.MinObservations
+	}
+	return adaptive
+}
+
 func (c *CCIPTestConfig) ExistingCLCluster() bool {
 	return c.EnvInput.ExistingCLCluster != nil
 }
@@ -424,6 +466,7 @@ type CCIPTestSetUpOutputs struct {
 	Balance                *actions.BalanceSheet
 	BootstrapAdded         *atomic.Bool
 	JobAddGrp              *errgroup.Group
+	ResourceLocker         actions.ResourceLocker // if set, guards the resources shared with other test processes
 }
 
 func (o *CCIPTestSetUpOutputs) AddToLanes(lane *BiDirectionalLaneConfig) {
@@ -448,7 +491,7 @@ func (o *CCIPTestSetUpOutputs) DeployChainContracts(
 	var k8Env *environment.Environment
 	ccipEnv := o.Env
 	if ccipEnv != nil {
-		k8Env = ccipEnv.K8Env
+		k8Env = ccipEnv.K8EnvForNetwork(chainClient.GetNetworkConfig().Name)
 	}
 	if k8Env != nil && chainClient.NetworkSimulated() {
 		networkCfg.URLs = k8Env.URLs[chainClient.GetNetworkConfig().Name]
@@ -458,6 +501,10 @@ func (o *CCIPTestSetUpOutputs) DeployChainContracts(
 	if err != nil {
 		return errors.WithStack(fmt.Errorf("failed to create chain client for %s: %w", networkCfg.Name, err))
 	}
+	chain, err = o.wrapChainClient(chain)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	chain.ParallelTransactions(true)
 	defer chain.Close()
@@ -471,6 +518,13 @@ func (o *CCIPTestSetUpOutputs) DeployChainContracts(
 	if err != nil {
 		return errors.WithStack(fmt.Errorf("failed to create ccip common module for %s: %w", networkCfg.Name, err))
 	}
+	if o.Cfg.TestGroupInput.TokenConfig.IsAllowListEnabled() {
+		ccipCommon.PoolAllowList = []common.Address{common.HexToAddress(chain.GetDefaultWallet().Address())}
+	}
+	ccipCommon.InfiniteRouterApproval = pointer.GetBool(o.Cfg.TestGroupInput.InfiniteRouterApproval)
+	ccipCommon.FeeTokenDecimals = o.Cfg.TestGroupInput.TokenConfig.FeeTokenDecimalsOrLink()
+	ccipCommon.PoolTypes = o.Cfg.TestGroupInput.TokenConfig.PoolTypes
+	ccipCommon.ConcurrentDeployment = pointer.GetBool(o.Cfg.TestGroupInput.ConcurrentDeployment)
 
 	cfg := o.LaneConfig.ReadLaneConfig(networkCfg.Name)
 
@@ -491,14 +545,14 @@ func (o *CCIPTestSetUpOutputs) SetupDynamicTokenPriceUpdates() error {
 		lane := lanes.ForwardLane
 		if _, exists := covered[lane.SourceNetworkName]; !exists {
 			covered[lane.SourceNetworkName] = struct{}{}
-			err := lane.Source.Common.UpdateTokenPricesAtRegularInterval(lane.Context, interval, o.LaneConfig.ReadLaneConfig(lane.SourceNetworkName))
+			err := lane.Source.Common.UpdateTokenPricesAtRegularInterval(lane.Routines(), interval, o.LaneConfig.ReadLaneConfig(lane.SourceNetworkName))
 			if err != nil {
 				return err
 			}
 		}
 		if _, exists := covered[lane.DestNetworkName]; !exists {
 			covered[lane.DestNetworkName] = struct{}{}
-			err := lane.Dest.Common.UpdateTokenPricesAtRegularInterval(lane.Context, interval, o.LaneConfig.ReadLaneConfig(lane.SourceNetworkName))
+			err := lane.Dest.Common.UpdateTokenPricesAtRegularInterval(lane.Routines(), interval, o.LaneConfig.ReadLaneConfig(lane.SourceNetworkName))
 			if err != nil {
 				return err
 			}
@@ -514,7 +568,7 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 ) error {
 	var allErrors atomic.Error
 	t := o.Cfg.Test
-	var k8Env *environment.Environment
+	var k8Env, k8EnvA, k8EnvB *environment.Environment
 	ccipEnv := o.Env
 	namespace := ""
 	if o.Cfg.TestGroupInput.LoadProfile != nil {
@@ -526,6 +580,8 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 		if k8Env != nil {
 			namespace = k8Env.Cfg.Namespace
 		}
+		k8EnvA = ccipEnv.K8EnvForNetwork(chainClientA.GetNetworkConfig().Name)
+		k8EnvB = ccipEnv.K8EnvForNetwork(chainClientB.GetNetworkConfig().Name)
 	}
 
 	setUpFuncs, ctx := errgroup.WithContext(testcontext.Get(t))
@@ -535,17 +591,25 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 	// on one lane will keep on waiting for transactions on other lane for the same network)
 	// Currently for simulated network clients(from same network) created with NewEVMClient does not sync nonce
 	// ConcurrentEVMClient is a work-around for that.
-	sourceChainClientA2B, err := blockchain.ConcurrentEVMClient(networkA, k8Env, chainClientA, lggr)
+	sourceChainClientA2B, err := blockchain.ConcurrentEVMClient(networkA, k8EnvA, chainClientA, lggr)
 	if err != nil {
 		return errors.WithStack(fmt.Errorf("failed to create chain client for %s: %w", networkA.Name, err))
 	}
+	sourceChainClientA2B, err = o.wrapChainClient(sourceChainClientA2B)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 
 	sourceChainClientA2B.ParallelTransactions(true)
 
-	destChainClientA2B, err := blockchain.ConcurrentEVMClient(networkB, k8Env, chainClientB, lggr)
+	destChainClientA2B, err := blockchain.ConcurrentEVMClient(networkB, k8EnvB, chainClientB, lggr)
 	if err != nil {
 		return errors.WithStack(fmt.Errorf("failed to create chain client for %s: %w", networkB.Name, err))
 	}
+	destChainClientA2B, err = o.wrapChainClient(destChainClientA2B)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 	destChainClientA2B.ParallelTransactions(true)
 
 	ccipLaneA2B := &actions.CCIPLane{
@@ -555,6 +619,7 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 		SourceNetworkName: actions.NetworkName(networkA.Name),
 		DestNetworkName:   actions.NetworkName(networkB.Name),
 		ValidationTimeout: o.Cfg.TestGroupInput.PhaseTimeout.Duration(),
+		AdaptiveTimeout:   o.Cfg.adaptiveTimeout(),
 		SentReqs:          make(map[common.Hash][]actions.CCIPRequest),
 		TotalFee:          big.NewInt(0),
 		Balance:           o.Balance,
@@ -577,6 +642,12 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 		fmt.Sprintf("%s-->%s", ccipLaneA2B.SourceNetworkName, ccipLaneA2B.DestNetworkName)).Logger()
 	ccipLaneA2B.Reports = o.Reporter.AddNewLane(fmt.Sprintf("%s To %s",
 		networkA.Name, networkB.Name), ccipLaneA2B.Logger)
+	// the routines of a lane are stopped in its CleanUp, this stops them if the set-up fails before the TearDown is set
+	t.Cleanup(func() {
+		if err := ccipLaneA2B.Routines().Stop(actions.DefaultSupervisorStopTimeout); err != nil {
+			lggr.Error().Err(err).Msg("Failed to stop lane routines")
+		}
+	})
 
 	bidirectionalLane := &BiDirectionalLaneConfig{
 		NetworkA:    networkA,
@@ -587,16 +658,24 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 	var ccipLaneB2A *actions.CCIPLane
 
 	if bidirectional {
-		sourceChainClientB2A, err := blockchain.ConcurrentEVMClient(networkB, k8Env, chainClientB, lggr)
+		sourceChainClientB2A, err := blockchain.ConcurrentEVMClient(networkB, k8EnvB, chainClientB, lggr)
 		if err != nil {
 			return errors.WithStack(fmt.Errorf("failed to create chain client for %s: %w", networkB.Name, err))
 		}
+		sourceChainClientB2A, err = o.wrapChainClient(sourceChainClientB2A)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 		sourceChainClientB2A.ParallelTransactions(true)
 
-		destChainClientB2A, err := blockchain.ConcurrentEVMClient(networkA, k8Env, chainClientA, lggr)
+		destChainClientB2A, err := blockchain.ConcurrentEVMClient(networkA, k8EnvA, chainClientA, lggr)
 		if err != nil {
 			return errors.WithStack(fmt.Errorf("failed to create chain client for %s: %w", networkA.Name, err))
 		}
+		destChainClientB2A, err = o.wrapChainClient(destChainClientB2A)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 		destChainClientB2A.ParallelTransactions(true)
 
 		ccipLaneB2A = &actions.CCIPLane{
@@ -606,6 +685,7 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 			SourceChain:       sourceChainClientB2A,
 			DestChain:         destChainClientB2A,
 			ValidationTimeout: o.Cfg.TestGroupInput.PhaseTimeout.Duration(),
+			AdaptiveTimeout:   o.Cfg.adaptiveTimeout(),
 			Balance:           o.Balance,
 			SentReqs:          make(map[common.Hash][]actions.CCIPRequest),
 			TotalFee:          big.NewInt(0),
@@ -617,6 +697,11 @@ func (o *CCIPTestSetUpOutputs) AddLanesForNetworkPair(
 			fmt.Sprintf("%s-->%s", ccipLaneB2A.SourceNetworkName, ccipLaneB2A.DestNetworkName)).Logger()
 		ccipLaneB2A.Reports = o.Reporter.AddNewLane(
 			fmt.Sprintf("%s To %s", networkB.Name, networkA.Name), ccipLaneB2A.Logger)
+		t.Cleanup(func() {
+			if err := ccipLaneB2A.Routines().Stop(actions.DefaultSupervisorStopTimeout); err != nil {
+				lggr.Error().Err(err).Msg("Failed to stop lane routines")
+			}
+		})
 		bidirectionalLane.ReverseLane = ccipLaneB2A
 	}
 	o.AddToLanes(bidirectionalLane)
@@ -811,9 +896,47 @@ func CCIPDefaultTestSetUp(
 		laneMutex:              &sync.Mutex{},
 	}
 
+	if testConfig.TestGroupInput.TimelineRequests != nil {
+		setUpArgs.Reporter.SetTimelineRequests(*testConfig.TestGroupInput.TimelineRequests)
+	}
+	if sloCfg := testConfig.TestGroupInput.BlessLatencySLO; sloCfg != nil {
+		slo := testreporters.BlessLatencySLO{
+			Latency:      sloCfg.Latency.Duration(),
+			Percentile:   pointer.GetFloat64(sloCfg.Percentile),
+			IncludeCurse: pointer.GetBool(sloCfg.IncludeCurse),
+		}
+		if sloCfg.CurseGrace != nil {
+			slo.CurseGrace = sloCfg.CurseGrace.Duration()
+		}
+		setUpArgs.Reporter.SetBlessLatencySLO(slo)
+	}
+	if rules := testConfig.TestGroupInput.FailureTaxonomy; len(rules) > 0 {
+		taxonomy := make(testreporters.FailureTaxonomy, 0, len(rules))
+		for _, rule := range rules {
+			taxonomy = append(taxonomy, testreporters.FailureRule{
+				Class:          testreporters.FailureClass(pointer.GetString(rule.Class)),
+				Category:       pointer.GetString(rule.Category),
+				ReasonContains: rule.ReasonContains,
+			})
+		}
+		setUpArgs.Reporter.SetFailureTaxonomy(taxonomy)
+	}
+
 	contractsData, err := setUpArgs.Cfg.ContractsInput.ContractsData()
 	require.NoError(t, err, "error reading existing lane config")
 
+	if lockCfg := setUpArgs.Cfg.TestGroupInput.ResourceLock; lockCfg != nil {
+		setUpArgs.ResourceLocker, err = actions.NewResourceLocker(lggr, pointer.GetString(lockCfg.Type), lockCfg.Target())
+		require.NoError(t, err, "error creating resource locker")
+		t.Cleanup(func() {
+			require.NoError(t, setUpArgs.ResourceLocker.Close())
+		})
+	}
+
+	if pointer.GetBool(testConfig.TestGroupInput.Doctor) {
+		require.NoError(t, testConfig.Doctor(parent, lggr).Print(lggr), "environment doctor checks should pass")
+	}
+
 	chainByChainID := setUpArgs.CreateEnvironment(lggr, envName, reportPath)
 	// if test is run in remote runner, register a clean-up to copy the laneconfig file
 	if value, set := os.LookupEnv(config.EnvVarJobImage); set && value != "" &&
@@ -837,7 +960,11 @@ func CCIPDefaultTestSetUp(
 		}
 	}
 
-	setUpArgs.LaneConfig, err = laneconfig.ReadLanesFromExistingDeployment(contractsData)
+	if setUpArgs.Cfg.ContractsInput.IsAddressBook() {
+		setUpArgs.LaneConfig, err = laneconfig.ReadLanesFromAddressBook(contractsData, testConfig.chainIDByNetwork())
+	} else {
+		setUpArgs.LaneConfig, err = laneconfig.ReadLanesFromExistingDeployment(contractsData)
+	}
 	require.NoError(t, err)
 
 	if setUpArgs.LaneConfig == nil {
@@ -891,7 +1018,8 @@ func CCIPDefaultTestSetUp(
 	require.NoError(t, chainAddGrp.Wait(), "Deploying common contracts shouldn't fail")
 
 	// set up mock server for price pipeline and usdc attestation if not using existing deployment
-	if !pointer.GetBool(setUpArgs.Cfg.TestGroupInput.ExistingDeployment) {
+	// the mock DON reads neither of them
+	if !pointer.GetBool(setUpArgs.Cfg.TestGroupInput.ExistingDeployment) && !testConfig.mockDON() {
 		var killgrave *ctftestenv.Killgrave
 		if setUpArgs.Env.LocalCluster != nil {
 			killgrave = setUpArgs.Env.LocalCluster.MockAdapter
@@ -901,7 +1029,17 @@ func CCIPDefaultTestSetUp(
 			// regex to match the path for all tokens across all lanes
 			actions.SetMockserverWithTokenPriceValue(killgrave, setUpArgs.Env.MockServer)
 		}
-		if pointer.GetBool(setUpArgs.Cfg.TestGroupInput.USDCMockDeployment) {
+		if attestationCfg := setUpArgs.Cfg.TestGroupInput.USDCAttestation; attestationCfg != nil {
+			// if the attestation service is configured, every USDC message is attested individually by the
+			// in-process attestation service, the service is shared across all the lanes
+			setUpArgs.Env.USDCAttestationService, err = actions.NewUSDCAttestationService(
+				lggr,
+				pointer.GetInt(attestationCfg.NoOfAttesters),
+				pointer.GetString(attestationCfg.ListenAddress),
+				pointer.GetString(attestationCfg.ExternalURL),
+			)
+			require.NoError(t, err, "failed to start USDC attestation service")
+		} else if pointer.GetBool(setUpArgs.Cfg.TestGroupInput.USDCMockDeployment) {
 			// if it's a new USDC deployment, set up mock server for attestation,
 			// we need to set it only once for all the lanes as the attestation path uses regex to match the path for
 			// all messages across all lanes
@@ -935,15 +1073,23 @@ func CCIPDefaultTestSetUp(
 		})
 	}
 	require.NoError(t, laneAddGrp.Wait())
-	err = laneconfig.WriteLanesToJSON(setUpArgs.LaneConfigFile, setUpArgs.LaneConfig)
+	err = actions.WithResourceLock(setUpArgs.SetUpContext, setUpArgs.ResourceLocker, "laneconfig-"+reportFile, func() error {
+		return laneconfig.WriteLanesToJSON(setUpArgs.LaneConfigFile, setUpArgs.LaneConfig)
+	})
 	require.NoError(t, err)
+	if addressBookFile := pointer.GetString(testConfig.TestGroupInput.AddressBookFile); addressBookFile != "" {
+		err = actions.WithResourceLock(setUpArgs.SetUpContext, setUpArgs.ResourceLocker, "addressbook-"+testutils.FileNameFromPath(addressBookFile), func() error {
+			return laneconfig.WriteAddressBookToJSON(addressBookFile, setUpArgs.LaneConfig, testConfig.chainIDByNetwork())
+		})
+		require.NoError(t, err, "writing the address book shouldn't fail")
+	}
 
 	require.Equal(t, len(setUpArgs.Lanes), len(testConfig.NetworkPairs),
 		"Number of bi-directional lanes should be equal to number of network pairs")
 	// only required for env set up
 	setUpArgs.LaneContractsByNetwork = nil
 
-	if configureCLNode {
+	if configureCLNode && !testConfig.mockDON() {
 		// wait for all jobs to get created
 		lggr.Info().Msg("Waiting for jobs to be created")
 		require.NoError(t, setUpArgs.JobAddGrp.Wait(), "Creating jobs shouldn't fail")
@@ -955,6 +1101,23 @@ func CCIPDefaultTestSetUp(
 		}
 	}
 
+	// notify the webhook of the phase transitions of the messages sent on all lanes
+	var webhook *testreporters.WebhookNotifier
+	if webhookCfg := setUpArgs.Cfg.TestGroupInput.Webhook; webhookCfg != nil {
+		var events []testreporters.MsgEvent
+		for _, event := range webhookCfg.Events {
+			events = append(events, testreporters.MsgEvent(event))
+		}
+		webhook = testreporters.NewWebhookNotifier(lggr,
+			os.ExpandEnv(pointer.GetString(webhookCfg.URL)), os.ExpandEnv(pointer.GetString(webhookCfg.Secret)), events)
+		for _, lanes := range setUpArgs.ReadLanes() {
+			lanes.ForwardLane.PhaseNotifier = webhook
+			if lanes.ReverseLane != nil {
+				lanes.ReverseLane.PhaseNotifier = webhook
+			}
+		}
+	}
+
 	// start event watchers for all lanes
 	setUpArgs.StartEventWatchers()
 
@@ -974,6 +1137,13 @@ func CCIPDefaultTestSetUp(
 				}
 			}
 		}
+		if setUpArgs.Env != nil && setUpArgs.Env.USDCAttestationService != nil {
+			errs = multierr.Append(errs, setUpArgs.Env.USDCAttestationService.Stop())
+		}
+		if webhook != nil {
+			// post the transitions of the messages validated in the clean-up before returning
+			webhook.Close()
+		}
 		return errs
 	}
 	lggr.Info().Msg("Test setup completed")
@@ -995,24 +1165,43 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 		err      error
 		chains   []blockchain.EVMClient
 		local    *test_env.CLClusterTestEnv
+		compose  *DockerComposeEnv
 		deployCL func() error
 	)
 
 	envConfig := createEnvironmentConfig(t, envName, testConfig, reportPath)
 
-	configureCLNode := !testConfig.useExistingDeployment() || pointer.GetString(testConfig.EnvInput.EnvToConnect) != ""
+	// in mock DON mode there are no CL nodes to deploy, the chains are connected to as selected networks
+	configureCLNode := (!testConfig.useExistingDeployment() || pointer.GetString(testConfig.EnvInput.EnvToConnect) != "") &&
+		!testConfig.mockDON()
 	namespace := ""
 	if testConfig.TestGroupInput.LoadProfile != nil {
 		namespace = testConfig.TestGroupInput.LoadProfile.TestRunName
 	}
 	require.False(t, testConfig.localCluster() && testConfig.ExistingCLCluster(),
 		"local cluster and existing cluster cannot be true at the same time")
+	require.False(t, testConfig.dockerCompose() && (testConfig.localCluster() || testConfig.ExistingCLCluster()),
+		"docker compose cannot be used along with local cluster or existing cluster")
+	require.False(t, testConfig.EnvInput.Sharding != nil && (testConfig.localCluster() || testConfig.dockerCompose()),
+		"sharding can only be used with a k8s deployment")
 	// if it's a new deployment, deploy the env
 	// Or if EnvToConnect is given connect to that k8 environment
 	if configureCLNode {
 		if !testConfig.ExistingCLCluster() {
-			// if it's a local cluster, deploy the local cluster in docker
-			if testConfig.localCluster() {
+			if testConfig.dockerCompose() {
+				// bring up the rpcs, nodes and mockserver with plain docker compose and connect to them as an existing cluster
+				compose, err = NewDockerComposeEnv(lggr, testConfig)
+				require.NoError(t, err, "Generating docker compose environment shouldn't fail")
+				require.NoError(t, compose.Up(o.SetUpContext), "Bringing up docker compose environment shouldn't fail")
+				testConfig.SelectedNetworks = compose.Networks
+				ccipEnv = &actions.CCIPTestEnv{}
+				ccipEnv.MockServer = ctfClient.NewMockserverClient(&ctfClient.MockserverConfig{
+					LocalURL:   compose.MockserverURL,
+					ClusterURL: compose.MockserverInternalURL,
+				})
+				namespace = "local-docker-compose-deployment"
+			} else if testConfig.localCluster() {
+				// if it's a local cluster, deploy the local cluster in docker
 				local, deployCL = DeployLocalCluster(t, testConfig)
 				ccipEnv = &actions.CCIPTestEnv{
 					LocalCluster: local,
@@ -1022,21 +1211,29 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 				// Otherwise, deploy the k8s env
 				lggr.Info().Msg("Deploying test environment")
 				// deploy the env if configureCLNode is true
-				k8Env = DeployEnvironments(t, envConfig, testConfig)
-				ccipEnv = &actions.CCIPTestEnv{K8Env: k8Env}
+				var shards []*environment.Environment
+				k8Env, shards = DeployEnvironments(t, envConfig, testConfig)
+				ccipEnv = &actions.CCIPTestEnv{K8Env: k8Env, K8Shards: shards}
 				namespace = ccipEnv.K8Env.Cfg.Namespace
 			}
 		} else {
 			// if there is already a cluster, use the existing cluster to connect to the nodes
 			ccipEnv = &actions.CCIPTestEnv{}
-			mockserverURL := pointer.GetString(testConfig.EnvInput.Mockserver)
-			require.NotEmpty(t, mockserverURL, "mockserver URL cannot be nil")
+			mockserverURL, err := testutils.NormalizeURL(pointer.GetString(testConfig.EnvInput.Mockserver))
+			require.NoError(t, err, "invalid mockserver URL")
 			ccipEnv.MockServer = ctfClient.NewMockserverClient(&ctfClient.MockserverConfig{
 				LocalURL:   mockserverURL,
 				ClusterURL: mockserverURL,
 			})
 		}
 		ccipEnv.CLNodeWithKeyReady, _ = errgroup.WithContext(o.SetUpContext)
+		ccipEnv.ResourceLocker = o.ResourceLocker
+		for network, faucetCfg := range testConfig.TestGroupInput.Faucets {
+			if ccipEnv.Faucets == nil {
+				ccipEnv.Faucets = make(map[string]*actions.Faucet)
+			}
+			ccipEnv.Faucets[network] = actions.NewFaucet(lggr, faucetCfg)
+		}
 		o.Env = ccipEnv
 		if ccipEnv.K8Env != nil && ccipEnv.K8Env.WillUseRemoteRunner() {
 			return nil
@@ -1062,6 +1259,8 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 		require.NotNil(t, ccipEnv.LocalCluster, "Local cluster shouldn't be nil")
 		for _, n := range ccipEnv.LocalCluster.EVMNetworks {
 			if evmClient, err := blockchain.NewEVMClientFromNetwork(*n, lggr); err == nil {
+				evmClient, err = o.wrapChainClient(evmClient)
+				require.NoError(t, err)
 				chainByChainID[evmClient.GetChainID().Int64()] = evmClient
 				chains = append(chains, evmClient)
 			} else {
@@ -1077,10 +1276,16 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 			if k8Env == nil {
 				ec, err = blockchain.ConnectEVMClient(n, lggr)
 			} else {
-				log.Info().Interface("urls", k8Env.URLs).Msg("URLs")
-				ec, err = blockchain.NewEVMClient(n, k8Env, lggr)
+				networkEnv := k8Env
+				if ccipEnv != nil {
+					networkEnv = ccipEnv.K8EnvForNetwork(n.Name)
+				}
+				log.Info().Interface("urls", networkEnv.URLs).Msg("URLs")
+				ec, err = blockchain.NewEVMClient(n, networkEnv, lggr)
 			}
 			require.NoError(t, err, "Connecting to blockchain nodes shouldn't fail")
+			ec, err = o.wrapChainClient(ec)
+			require.NoError(t, err)
 			chains = append(chains, ec)
 			chainByChainID[n.ChainID] = ec
 		}
@@ -1088,7 +1293,14 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 	if configureCLNode {
 		ccipEnv.CLNodeWithKeyReady.Go(func() error {
 			var totalNodes int
-			if !o.Cfg.ExistingCLCluster() {
+			if compose != nil {
+				cluster := compose.CLCluster()
+				totalNodes = pointer.GetInt(cluster.NoOfNodes)
+				err = ccipEnv.ConnectToExistingNodes(&testconfig.Common{ExistingCLCluster: cluster})
+				if err != nil {
+					return fmt.Errorf("error connecting to docker compose chainlink nodes: %w", err)
+				}
+			} else if !o.Cfg.ExistingCLCluster() {
 				if ccipEnv.LocalCluster != nil {
 					err = deployCL()
 					if err != nil {
@@ -1139,6 +1351,13 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 
 	t.Cleanup(func() {
 		if configureCLNode {
+			if compose != nil {
+				if !pointer.GetBool(testConfig.TestGroupInput.DockerCompose.KeepUp) {
+					require.NoError(t, compose.Down(context.Background()), "Docker compose environment tear down shouldn't fail")
+				}
+				require.NoError(t, o.Reporter.SendReport(t, namespace, false), "Aggregating and sending report shouldn't fail")
+				return
+			}
 			if ccipEnv.LocalCluster != nil {
 				err := ccipEnv.LocalCluster.Terminate()
 				require.NoError(t, err, "Local cluster termination shouldn't fail")
@@ -1150,6 +1369,9 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 				return
 			}
 			lggr.Info().Msg("Tearing down the environment")
+			for _, shard := range ccipEnv.K8Shards {
+				require.NoError(t, shard.Shutdown(), "Environment shard teardown shouldn't fail")
+			}
 			err = integrationactions.TeardownSuite(t, ccipEnv.K8Env, ccipEnv.CLNodes, o.Reporter,
 				zapcore.DPanicLevel, o.Cfg.EnvInput, chains...)
 			require.NoError(t, err, "Environment teardown shouldn't fail")
@@ -1161,6 +1383,26 @@ func (o *CCIPTestSetUpOutputs) CreateEnvironment(
 	return chainByChainID
 }
 
+// wrapChainClient wraps chain with the tx customization and the archive node set for its network, if any
+func (o *CCIPTestSetUpOutputs) wrapChainClient(chain blockchain.EVMClient) (blockchain.EVMClient, error) {
+	if txCfg, ok := o.Cfg.TestGroupInput.TxCustomization[chain.GetNetworkName()]; ok {
+		customizer, err := actions.NewTxCustomizer(txCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx customization for %s: %w", chain.GetNetworkName(), err)
+		}
+		chain = actions.WithTxCustomizer(chain, customizer)
+	}
+	if archiveCfg, ok := o.Cfg.TestGroupInput.ArchiveNodes[chain.GetNetworkName()]; ok {
+		archived, err := actions.NewArchiveFallbackClient(log.Logger,
+			chain, os.ExpandEnv(pointer.GetString(archiveCfg.URL)), pointer.GetUint64(archiveCfg.PruningWindow))
+		if err != nil {
+			return nil, err
+		}
+		chain = archived
+	}
+	return chain, nil
+}
+
 func createEnvironmentConfig(t *testing.T, envName string, testConfig *CCIPTestConfig, reportPath string) *environment.Config {
 	envConfig := &environment.Config{
 		NamespacePrefix:    envName,
@@ -0,0 +1,286 @@
+package testsetups
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/blockchain"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
+	"github.com/smartcontractkit/chainlink/integration-tests/client"
+	"github.com/smartcontractkit/chainlink/integration-tests/utils/templates"
+)
+
+const (
+	DefaultComposeProjectName   = "ccip-local"
+	DefaultComposeRPCImage      = "ghcr.io/foundry-rs/foundry:latest"
+	DefaultComposeMockserverImg = "mockserver/mockserver:5.15.0"
+	composeRPCHostPortStart     = 18545
+	composeNodeHostPortStart    = 16688
+	composeMockserverHostPort   = 11080
+	composeNodeEmail            = "local@local.com"
+	composeNodePassword         = "localdevpassword"
+	composeDBPassword           = "postgres"
+)
+
+type composeService struct {
+	Image       string            `yaml:"image"`
+	Entrypoint  []string          `yaml:"entrypoint,omitempty"`
+	Command     []string          `yaml:"command,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Volumes     []string          `yaml:"volumes,omitempty"`
+	WorkingDir  string            `yaml:"working_dir,omitempty"`
+	DependsOn   []string          `yaml:"depends_on,omitempty"`
+	Healthcheck *composeHealth    `yaml:"healthcheck,omitempty"`
+}
+
+type composeHealth struct {
+	Test     []string `yaml:"test"`
+	Interval string   `yaml:"interval"`
+	Retries  int      `yaml:"retries"`
+}
+
+type composeFile struct {
+	Name     string                     `yaml:"name"`
+	Services map[string]*composeService `yaml:"services"`
+}
+
+// DockerComposeEnv is a local environment brought up with plain docker compose, see testconfig.DockerComposeConfig.
+// For every selected network an anvil rpc is started with the chain id of the network, so the private keys of the
+// network should be the ones of the anvil dev accounts. Every CL node gets its own postgres.
+type DockerComposeEnv struct {
+	lggr        zerolog.Logger
+	dir         string
+	project     string
+	composeFile string
+	nodes       []*client.ChainlinkConfig
+	// Networks are the selected networks with the rpc urls reachable from the host
+	Networks []blockchain.EVMNetwork
+	// MockserverURL is the mockserver url reachable from the host
+	MockserverURL string
+	// MockserverInternalURL is the mockserver url reachable from the CL nodes
+	MockserverInternalURL string
+}
+
+// NewDockerComposeEnv generates the compose file and the CL node configs for the selected networks
+// and the NewCLCluster config of testInputs in the configured directory
+func NewDockerComposeEnv(lggr zerolog.Logger, testInputs *CCIPTestConfig) (*DockerComposeEnv, error) {
+	cfg := testInputs.TestGroupInput.DockerCompose
+	if cfg == nil {
+		return nil, fmt.Errorf("docker compose config is nil")
+	}
+	if testInputs.EnvInput.NewCLCluster == nil {
+		return nil, fmt.Errorf("new CL cluster config is required to bring up the CL nodes with docker compose")
+	}
+	dir := pointer.GetString(cfg.Dir)
+	if dir == "" {
+		var err error
+		dir, err = os.MkdirTemp("", "ccip-compose-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create docker compose directory: %w", err)
+		}
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create docker compose directory %s: %w", dir, err)
+	}
+	env := &DockerComposeEnv{
+		lggr:                  lggr,
+		dir:                   dir,
+		project:               DefaultComposeProjectName,
+		composeFile:           filepath.Join(dir, "docker-compose.yml"),
+		MockserverURL:         fmt.Sprintf("http://localhost:%d", composeMockserverHostPort),
+		MockserverInternalURL: "http://mockserver:1080",
+	}
+	if cfg.ProjectName != nil {
+		env.project = *cfg.ProjectName
+	}
+	rpcImage := DefaultComposeRPCImage
+	if cfg.RPCImage != nil {
+		rpcImage = *cfg.RPCImage
+	}
+	mockserverImage := DefaultComposeMockserverImg
+	if cfg.MockserverImage != nil {
+		mockserverImage = *cfg.MockserverImage
+	}
+
+	compose := composeFile{
+		Name: env.project,
+		Services: map[string]*composeService{
+			"mockserver": {
+				Image: mockserverImage,
+				Ports: []string{fmt.Sprintf("%d:1080", composeMockserverHostPort)},
+			},
+		},
+	}
+	// the nodes connect to the rpcs with the service names, the tests with the published ports
+	internalNetworks := make([]blockchain.EVMNetwork, len(testInputs.SelectedNetworks))
+	var rpcServices []string
+	for i, network := range testInputs.SelectedNetworks {
+		service := fmt.Sprintf("rpc-%d", network.ChainID)
+		hostPort := composeRPCHostPortStart + i
+		compose.Services[service] = &composeService{
+			Image:      rpcImage,
+			Entrypoint: []string{"anvil"},
+			Command: []string{
+				"--host", "0.0.0.0",
+				"--chain-id", fmt.Sprint(network.ChainID),
+				"--block-time", "1",
+			},
+			Ports: []string{fmt.Sprintf("%d:8545", hostPort)},
+		}
+		rpcServices = append(rpcServices, service)
+		internal := network
+		internal.URLs = []string{fmt.Sprintf("ws://%s:8545", service)}
+		internal.HTTPURLs = []string{fmt.Sprintf("http://%s:8545", service)}
+		internalNetworks[i] = internal
+		public := network
+		public.URLs = []string{fmt.Sprintf("ws://localhost:%d", hostPort)}
+		public.HTTPURLs = []string{fmt.Sprintf("http://localhost:%d", hostPort)}
+		env.Networks = append(env.Networks, public)
+	}
+
+	clCluster := testInputs.EnvInput.NewCLCluster
+	nodes := clCluster.Nodes
+	if len(nodes) == 0 {
+		for i := 0; i < pointer.GetInt(clCluster.NoOfNodes); i++ {
+			nodes = append(nodes, clCluster.Common)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no CL nodes specified in new CL cluster config")
+	}
+	for i, node := range nodes {
+		node.Merge(clCluster.Common)
+		if node.ChainlinkImage == nil {
+			return nil, fmt.Errorf("chainlink image not specified for node %d", i+1)
+		}
+		nodeService := fmt.Sprintf("node-%d", i+1)
+		dbService := fmt.Sprintf("postgres-%d", i+1)
+		nodeDir := filepath.Join(dir, nodeService)
+		if err := os.MkdirAll(nodeDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create config directory for %s: %w", nodeService, err)
+		}
+		_, nodeConfig, err := setNodeConfig(
+			internalNetworks,
+			node.BaseConfigTOML,
+			node.CommonChainConfigTOML,
+			node.ChainConfigTOMLByChain,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build config for %s: %w", nodeService, err)
+		}
+		secrets, err := templates.NodeSecretsTemplate{
+			PgDbName:   "chainlink",
+			PgHost:     dbService,
+			PgPort:     "5432",
+			PgPassword: composeDBPassword,
+		}.String()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build secrets for %s: %w", nodeService, err)
+		}
+		files := map[string]string{
+			"config.toml":  nodeConfig,
+			"secrets.toml": secrets,
+			"creds":        fmt.Sprintf("%s\n%s", composeNodeEmail, composeNodePassword),
+		}
+		// readable by the non-root user the CL node runs as in the container
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(nodeDir, name), []byte(content), 0o644); err != nil {
+				return nil, fmt.Errorf("failed to write %s for %s: %w", name, nodeService, err)
+			}
+		}
+
+		dbImage, dbTag := node.DBImage, node.DBTag
+		if dbImage == "" {
+			dbImage = "postgres"
+		}
+		if dbTag == "" {
+			dbTag = "15.6"
+		}
+		compose.Services[dbService] = &composeService{
+			Image: fmt.Sprintf("%s:%s", dbImage, dbTag),
+			Environment: map[string]string{
+				"POSTGRES_DB":       "chainlink",
+				"POSTGRES_PASSWORD": composeDBPassword,
+			},
+			Healthcheck: &composeHealth{
+				Test:     []string{"CMD-SHELL", "pg_isready -U postgres"},
+				Interval: "2s",
+				Retries:  30,
+			},
+		}
+		hostPort := composeNodeHostPortStart + i
+		compose.Services[nodeService] = &composeService{
+			Image: fmt.Sprintf("%s:%s",
+				pointer.GetString(node.ChainlinkImage.Image), pointer.GetString(node.ChainlinkImage.Version)),
+			Entrypoint: []string{"chainlink"},
+			Command: []string{
+				"-c", "config.toml", "-s", "secrets.toml",
+				"node", "start", "-d", "-p", "creds", "-a", "creds",
+			},
+			Ports:      []string{fmt.Sprintf("%d:6688", hostPort)},
+			Volumes:    []string{fmt.Sprintf("%s:/cl", nodeDir)},
+			WorkingDir: "/cl",
+			DependsOn:  append([]string{dbService}, rpcServices...),
+			Healthcheck: &composeHealth{
+				Test:     []string{"CMD-SHELL", "chainlink health || exit 1"},
+				Interval: "5s",
+				Retries:  60,
+			},
+		}
+		env.nodes = append(env.nodes, &client.ChainlinkConfig{
+			URL:        fmt.Sprintf("http://localhost:%d", hostPort),
+			Email:      composeNodeEmail,
+			Password:   composeNodePassword,
+			InternalIP: nodeService,
+		})
+	}
+
+	content, err := yaml.Marshal(compose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal docker compose file: %w", err)
+	}
+	if err := os.WriteFile(env.composeFile, content, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write docker compose file: %w", err)
+	}
+	lggr.Info().Str("File", env.composeFile).Int("Nodes", len(env.nodes)).Msg("Generated docker compose environment")
+	return env, nil
+}
+
+// Up brings up all the services and waits till they are running and healthy
+func (d *DockerComposeEnv) Up(ctx context.Context) error {
+	return d.compose(ctx, "up", "-d", "--wait")
+}
+
+// Down removes the services along with their volumes
+func (d *DockerComposeEnv) Down(ctx context.Context) error {
+	return d.compose(ctx, "down", "-v")
+}
+
+// CLCluster returns the CL nodes of the environment to connect to as an existing cluster
+func (d *DockerComposeEnv) CLCluster() *testconfig.CLCluster {
+	return &testconfig.CLCluster{
+		Name:        pointer.ToString(d.project),
+		NoOfNodes:   pointer.ToInt(len(d.nodes)),
+		NodeConfigs: d.nodes,
+	}
+}
+
+func (d *DockerComposeEnv) compose(ctx context.Context, args ...string) error {
+	args = append([]string{"compose", "-f", d.composeFile, "-p", d.project}, args...)
+	d.lggr.Info().Str("Command", "docker "+strings.Join(args, " ")).Msg("Running docker compose")
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker %s failed: %w\n%s", strings.Join(args, " "), err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package testsetups
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/utils/conversions"
+
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts/laneconfig"
+)
+
+// noOfCLNodes returns the number of CL nodes the test is going to run with, 0 if the requests are served by the mock DON
+func (c *CCIPTestConfig) noOfCLNodes() int {
+	switch {
+	case c.mockDON():
+		return 0
+	case c.ExistingCLCluster():
+		return pointer.GetInt(c.EnvInput.ExistingCLCluster.NoOfNodes)
+	case c.EnvInput.NewCLCluster != nil:
+		return pointer.GetInt(c.EnvInput.NewCLCluster.NoOfNodes)
+	}
+	return 0
+}
+
+// expectedLanes returns the source and dest network names of all the lanes the test is going to run
+func (c *CCIPTestConfig) expectedLanes() [][2]string {
+	var lanes [][2]string
+	for _, pair := range c.NetworkPairs {
+		lanes = append(lanes, [2]string{pair.NetworkA.Name, pair.NetworkB.Name})
+		if pointer.GetBool(c.TestGroupInput.BiDirectionalLane) {
+			lanes = append(lanes, [2]string{pair.NetworkB.Name, pair.NetworkA.Name})
+		}
+	}
+	return lanes
+}
+
+// Doctor checks the preconditions of the test before anything is deployed - the rpcs of the networks are reachable,
+// serve the expected chain and support subscriptions over ws, the funding keys hold enough to fund the nodes,
+// the chain selectors of the networks are known, the API of the existing CL nodes accepts their credentials,
+// the mockserver is reachable and the lane config is consistent. Simulated networks are not running yet, so only
+// their chain selectors are checked.
+func (c *CCIPTestConfig) Doctor(ctx context.Context, lggr zerolog.Logger) *actions.DoctorReport {
+	report := &actions.DoctorReport{}
+	networks := make([]string, 0, len(c.AllNetworks))
+	for name := range c.AllNetworks {
+		networks = append(networks, name)
+	}
+	sort.Strings(networks)
+
+	minBalance := conversions.EtherToWei(new(big.Float).Mul(
+		big.NewFloat(c.TestGroupInput.NodeFunding), big.NewFloat(float64(c.noOfCLNodes()))))
+	for _, name := range networks {
+		network := c.AllNetworks[name]
+		report.Run(ctx, fmt.Sprintf("%s: chain selector resolvable", name), func(context.Context) error {
+			_, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+			return err
+		})
+		if network.Simulated {
+			continue
+		}
+		for _, url := range network.HTTPURLs {
+			url := url
+			report.Run(ctx, fmt.Sprintf("%s: http rpc %s reachable", name, url), func(ctx context.Context) error {
+				return actions.CheckRPC(ctx, url, network.ChainID)
+			})
+		}
+		for _, url := range network.URLs {
+			url := url
+			report.Run(ctx, fmt.Sprintf("%s: ws rpc %s reachable with subscriptions", name, url), func(ctx context.Context) error {
+				return actions.CheckRPC(ctx, url, network.ChainID)
+			})
+		}
+		rpcURLs := append(append([]string{}, network.HTTPURLs...), network.URLs...)
+		report.Run(ctx, fmt.Sprintf("%s: funding key funded", name), func(ctx context.Context) error {
+			if len(network.PrivateKeys) == 0 {
+				return fmt.Errorf("no private key set")
+			}
+			if len(rpcURLs) == 0 {
+				return fmt.Errorf("no rpc url set")
+			}
+			return actions.CheckFunding(ctx, rpcURLs[0], network.PrivateKeys[0], minBalance)
+		})
+	}
+
+	if c.ExistingCLCluster() {
+		for i, node := range c.EnvInput.ExistingCLCluster.NodeConfigs {
+			node := node
+			report.Run(ctx, fmt.Sprintf("node %d: API auth at %s", i+1, node.URL), func(ctx context.Context) error {
+				return actions.CheckNodeAuth(ctx, node.URL, node.Email, node.Password)
+			})
+		}
+	}
+	if mockserver := pointer.GetString(c.EnvInput.Mockserver); mockserver != "" {
+		report.Run(ctx, fmt.Sprintf("mockserver %s reachable", mockserver), func(ctx context.Context) error {
+			return actions.CheckReachable(ctx, mockserver)
+		})
+	}
+
+	report.Run(ctx, "lane config consistent", func(context.Context) error {
+		contractsData, err := c.ContractsInput.ContractsData()
+		if err != nil {
+			return fmt.Errorf("failed to read lane config: %w", err)
+		}
+		var lanes *laneconfig.Lanes
+		if c.ContractsInput.IsAddressBook() {
+			lanes, err = laneconfig.ReadLanesFromAddressBook(contractsData, c.chainIDByNetwork())
+		} else {
+			lanes, err = laneconfig.ReadLanesFromExistingDeployment(contractsData)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse lane config: %w", err)
+		}
+		if lanes == nil {
+			lanes = &laneconfig.Lanes{LaneConfigs: make(map[string]*laneconfig.LaneConfig)}
+		}
+		var expected [][2]string
+		if c.useExistingDeployment() {
+			expected = c.expectedLanes()
+		}
+		return actions.CheckLaneConfigConsistency(lanes, expected)
+	})
+	return report
+}
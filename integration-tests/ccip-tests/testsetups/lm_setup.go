@@ -51,6 +51,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/k8s/environment"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/contracts"
+	testutils "github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/utils"
 	"github.com/smartcontractkit/chainlink/integration-tests/docker/test_env"
 )
 
@@ -100,15 +101,15 @@ func (o *LMTestSetupOutputs) CreateLMEnvironment(
 				// Otherwise, deploy the k8s env
 				lggr.Info().Msg("Deploying test environment")
 				// deploy the env if configureCLNode is true
-				k8Env = DeployEnvironments(t, envConfig, testConfig)
+				k8Env, _ = DeployEnvironments(t, envConfig, testConfig)
 				ccipEnv = &actions.CCIPTestEnv{K8Env: k8Env}
 				namespace = ccipEnv.K8Env.Cfg.Namespace
 			}
 		} else {
 			// if there is already a cluster, use the existing cluster to connect to the nodes
 			ccipEnv = &actions.CCIPTestEnv{}
-			mockserverURL := pointer.GetString(testConfig.EnvInput.Mockserver)
-			require.NotEmpty(t, mockserverURL, "mockserver URL cannot be nil")
+			mockserverURL, err := testutils.NormalizeURL(pointer.GetString(testConfig.EnvInput.Mockserver))
+			require.NoError(t, err, "invalid mockserver URL")
 			ccipEnv.MockServer = ctfClient.NewMockserverClient(&ctfClient.MockserverConfig{
 				LocalURL:   mockserverURL,
 				ClusterURL: mockserverURL,
@@ -303,7 +304,7 @@ func (o *LMTestSetupOutputs) DeployLMChainContracts(
 
 	// Deploy Lock Release Token contract
 	lggr.Info().Msg("Deploying Lock Release Token contract")
-	lockReleaseTokenPool, err := cd.DeployLockReleaseTokenPoolContract(lmCommon.WrapperNative.String(), *lmCommon.MockArm, lmCommon.CcipRouter.EthAddress)
+	lockReleaseTokenPool, err := cd.DeployLockReleaseTokenPoolContract(lmCommon.WrapperNative.String(), *lmCommon.MockArm, lmCommon.CcipRouter.EthAddress, nil)
 	if err != nil {
 		return errors.WithStack(fmt.Errorf("failed to deploy Lock Release Token contract: %w", err))
 	}
@@ -595,7 +596,7 @@ func (o *LMTestSetupOutputs) AddJobs(chainId int64, lggr zerolog.Logger) error {
 	}
 	lggr.Info().Str("jobId", j.Data.ID).Msg("Bootstrap job added")
 
-	P2Pv2Bootstrapper := fmt.Sprintf("%s@%s:%d", bootstrapNode.KeysBundle.P2PKeys.Data[0].Attributes.PeerID, bootstrapNode.Node.InternalIP(), 6690)
+	P2Pv2Bootstrapper := fmt.Sprintf("%s@%s", bootstrapNode.KeysBundle.P2PKeys.Data[0].Attributes.PeerID, testutils.JoinHostPort(bootstrapNode.Node.InternalIP(), 6690))
 
 	// Add LM jobs
 	donNodes := clNodesWithKeys[1:]
@@ -30,6 +30,7 @@ import (
 	k8config "github.com/smartcontractkit/chainlink-testing-framework/k8s/config"
 
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/actions"
+	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/testconfig"
 	"github.com/smartcontractkit/chainlink/integration-tests/ccip-tests/types/config/node"
 	"github.com/smartcontractkit/chainlink/integration-tests/docker/test_env"
 	integrationnodes "github.com/smartcontractkit/chainlink/integration-tests/types/config/node"
@@ -164,6 +165,17 @@ func ChainlinkChart(
 	t *testing.T,
 	testInputs *CCIPTestConfig,
 	nets []blockchain.EVMNetwork,
+) environment.ConnectedChart {
+	return chainlinkChart(t, testInputs, nets, 0, 0, pointer.GetInt(testInputs.EnvInput.NewCLCluster.NoOfNodes))
+}
+
+// chainlinkChart returns the chart with noOfNodes chainlink nodes starting from firstNode of the cluster,
+// index distinguishes the charts of the same cluster deployed in different namespaces
+func chainlinkChart(
+	t *testing.T,
+	testInputs *CCIPTestConfig,
+	nets []blockchain.EVMNetwork,
+	index, firstNode, noOfNodes int,
 ) environment.ConnectedChart {
 	require.NotNil(t, testInputs.EnvInput.NewCLCluster.Common, "Chainlink Common config is not specified")
 	clProps := make(map[string]interface{})
@@ -199,7 +211,7 @@ func ChainlinkChart(
 
 	if len(testInputs.EnvInput.NewCLCluster.Nodes) > 0 {
 		var nodesMap []map[string]any
-		for _, clNode := range testInputs.EnvInput.NewCLCluster.Nodes {
+		for _, clNode := range testInputs.EnvInput.NewCLCluster.Nodes[firstNode : firstNode+noOfNodes] {
 			nodeConfig := clNode.BaseConfigTOML
 			commonChainConfig := clNode.CommonChainConfigTOML
 			chainConfigByChain := clNode.ChainConfigTOMLByChain
@@ -234,9 +246,9 @@ func ChainlinkChart(
 			})
 		}
 		clProps["nodes"] = nodesMap
-		return chainlink.New(0, clProps)
+		return chainlink.New(index, clProps)
 	}
-	clProps["replicas"] = pointer.GetInt(testInputs.EnvInput.NewCLCluster.NoOfNodes)
+	clProps["replicas"] = noOfNodes
 	_, tomlStr, err := setNodeConfig(
 		nets,
 		testInputs.EnvInput.NewCLCluster.Common.BaseConfigTOML,
@@ -245,7 +257,7 @@ func ChainlinkChart(
 	)
 	require.NoError(t, err)
 	clProps["toml"] = tomlStr
-	return chainlink.New(0, clProps)
+	return chainlink.New(index, clProps)
 }
 
 func DeployLocalCluster(
@@ -446,13 +458,55 @@ func UpgradeNodes(
 // 1. two simulated geth network in non-dev mode
 // 2. mockserver ( to set mock price feed details)
 // 3. chainlink nodes
+// If sharding is set in the env input, the simulated networks and the chainlink nodes are spread across several
+// namespaces. The first namespace, which also has the mockserver, is returned as the main env and the rest as shards.
 func DeployEnvironments(
 	t *testing.T,
 	envconfig *environment.Config,
 	testInputs *CCIPTestConfig,
-) *environment.Environment {
+) (*environment.Environment, []*environment.Environment) {
 	selectedNetworks := testInputs.SelectedNetworks
+	sharding := testInputs.EnvInput.Sharding
 	testEnvironment := environment.New(envconfig)
+	var networksPerNamespace, nodesPerNamespace *int
+	if sharding != nil {
+		require.False(t, testEnvironment.WillUseRemoteRunner(), "sharding is not supported with remote runner")
+		require.Empty(t, os.Getenv(k8config.EnvVarNamespace), "sharding is not supported with a fixed namespace")
+		networksPerNamespace, nodesPerNamespace = sharding.NetworksPerNamespace, sharding.NodesPerNamespace
+	}
+	// envs are the namespaces the deployment is spread across, the shards are created as they are needed
+	envs := []*environment.Environment{testEnvironment}
+	envAt := func(i int) *environment.Environment {
+		for len(envs) <= i {
+			shardConfig := *envconfig
+			shardConfig.NamespacePrefix = fmt.Sprintf("%s-shard-%d", envconfig.NamespacePrefix, len(envs))
+			shardConfig.Namespace = ""
+			shardConfig.ReportPath = ""
+			envs = append(envs, environment.New(&shardConfig))
+		}
+		return envs[i]
+	}
+
+	// assign the networks to be deployed to the namespaces
+	networkEnv := make([]int, len(selectedNetworks))
+	var deployed []int
+	for i, network := range selectedNetworks {
+		_, isAnvil := testInputs.EnvInput.Network.AnvilConfigs[strings.ToUpper(network.Name)]
+		if network.Simulated || isAnvil {
+			deployed = append(deployed, i)
+		}
+	}
+	shard, inShard := 0, 0
+	networkShards := testconfig.Shards(len(deployed), networksPerNamespace)
+	for _, i := range deployed {
+		if inShard == networkShards[shard] {
+			shard++
+			inShard = 0
+		}
+		networkEnv[i] = shard
+		inShard++
+	}
+
 	numOfTxNodes := 1
 	var charts []string
 	for i, network := range selectedNetworks {
@@ -460,7 +514,7 @@ func DeployEnvironments(
 			// if anvilconfig is specified for a network addhelm for anvil
 			if anvilConfig, exists := testInputs.EnvInput.Network.AnvilConfigs[strings.ToUpper(network.Name)]; exists {
 				charts = append(charts, foundry.ChartName)
-				testEnvironment.
+				envAt(networkEnv[i]).
 					AddHelm(foundry.New(&foundry.Props{
 						NetworkName: network.Name,
 						Values: map[string]interface{}{
@@ -488,7 +542,7 @@ func DeployEnvironments(
 			continue
 		}
 		charts = append(charts, strings.ReplaceAll(strings.ToLower(network.Name), " ", "-"))
-		testEnvironment.
+		envAt(networkEnv[i]).
 			AddHelm(reorg.New(&reorg.Props{
 				NetworkName: network.Name,
 				NetworkType: "simulated-geth-non-dev",
@@ -518,13 +572,15 @@ func DeployEnvironments(
 			AddHelm(mockservercfg.New(nil)).
 			AddHelm(mockserver.New(nil))
 	}
-	err := testEnvironment.Run()
-	require.NoError(t, err)
+	for _, env := range envs {
+		err := env.Run()
+		require.NoError(t, err)
+	}
 
 	if testEnvironment.WillUseRemoteRunner() {
-		return testEnvironment
+		return testEnvironment, nil
 	}
-	urlFinder := func(network blockchain.EVMNetwork, chart string) ([]string, []string) {
+	urlFinder := func(network blockchain.EVMNetwork, chart string, env *environment.Environment) ([]string, []string) {
 		if !network.Simulated {
 			return network.URLs, network.HTTPURLs
 		}
@@ -540,18 +596,30 @@ func DeployEnvironments(
 				internalHttpURLs = append(internalHttpURLs, fmt.Sprintf("http://%s-ethereum-geth:8544", networkName))
 			}
 		}
+		if sharding != nil {
+			// nodes in other namespaces reach the network by the cluster wide name of its service
+			for i := range internalWsURLs {
+				internalWsURLs[i] = actions.ClusterServiceURL(internalWsURLs[i], env.Cfg.Namespace)
+				internalHttpURLs[i] = actions.ClusterServiceURL(internalHttpURLs[i], env.Cfg.Namespace)
+			}
+		}
 
 		return internalWsURLs, internalHttpURLs
 	}
 	var nets []blockchain.EVMNetwork
 	for i := range selectedNetworks {
 		nets = append(nets, selectedNetworks[i])
-		nets[i].URLs, nets[i].HTTPURLs = urlFinder(selectedNetworks[i], charts[i])
+		nets[i].URLs, nets[i].HTTPURLs = urlFinder(selectedNetworks[i], charts[i], envs[networkEnv[i]])
 	}
 
-	err = testEnvironment.
-		AddHelm(ChainlinkChart(t, testInputs, nets)).
-		Run()
-	require.NoError(t, err)
-	return testEnvironment
+	firstNode := 0
+	for i, noOfNodes := range testconfig.Shards(pointer.GetInt(testInputs.EnvInput.NewCLCluster.NoOfNodes), nodesPerNamespace) {
+		envAt(i).AddHelm(chainlinkChart(t, testInputs, nets, i, firstNode, noOfNodes))
+		firstNode += noOfNodes
+	}
+	for _, env := range envs {
+		err := env.Run()
+		require.NoError(t, err)
+	}
+	return testEnvironment, envs[1:]
 }
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// IsIPv6 returns true if host is an IPv6 address, with or without brackets
+func IsIPv6(host string) bool {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if i := strings.Index(host, "%"); i >= 0 {
+		// zone of a link-local address
+		host = host[:i]
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.To4() == nil
+}
+
+// HostForURL returns host as it's written in a url or in a host:port address, IPv6 addresses are enclosed in brackets
+func HostForURL(host string) string {
+	if strings.HasPrefix(host, "[") || !IsIPv6(host) {
+		return host
+	}
+	return "[" + host + "]"
+}
+
+// JoinHostPort returns the host:port address of host, which can be an IPv6 address with or without brackets
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), strconv.Itoa(port))
+}
+
+// NormalizeURL validates rawURL and returns it with its IPv6 host in the canonical form. A url with an IPv6 host which
+// is not enclosed in brackets is rejected, as whether it ends in a port can't be told, e.g. in http://fd00::1:8545.
+func NormalizeURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", fmt.Errorf("empty url")
+	}
+	scheme, rest, found := strings.Cut(rawURL, "://")
+	if !found || scheme == "" {
+		return "", fmt.Errorf("url %s has no scheme", rawURL)
+	}
+	authority := rest
+	if i := strings.IndexAny(authority, "/?#"); i >= 0 {
+		authority = authority[:i]
+	}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		authority = authority[i+1:]
+	}
+	if !strings.HasPrefix(authority, "[") && strings.Count(authority, ":") > 1 {
+		return "", fmt.Errorf("url %s has an IPv6 host without brackets, write it as %s://[<address>]:<port>", rawURL, scheme)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %s: %w", rawURL, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("url %s has no host", rawURL)
+	}
+	if IsIPv6(u.Hostname()) {
+		host := u.Hostname()
+		zone := ""
+		if i := strings.Index(host, "%"); i >= 0 {
+			host, zone = host[:i], host[i:]
+		}
+		host = net.ParseIP(host).String() + zone
+		if port := u.Port(); port != "" {
+			u.Host = net.JoinHostPort(host, port)
+		} else {
+			u.Host = "[" + host + "]"
+		}
+	}
+	return u.String(), nil
+}